@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	ballotrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/ballot"
+	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/schedule"
+	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/ticket_category"
+	ballotservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ballot"
+)
+
+// Admin command to run or verify a ballot draw:
+//
+//	go run ./cmd/ballot -id <ballot-id> [-seed <seed>] [-by <operator>]
+//	go run ./cmd/ballot -id <ballot-id> -verify
+func main() {
+	ballotID := flag.String("id", "", "ballot ID")
+	seed := flag.String("seed", "", "draw seed (random when empty; recorded on the ballot)")
+	drawnBy := flag.String("by", "cli", "operator recorded as drawn_by")
+	verify := flag.Bool("verify", false, "re-run the recorded draw instead of drawing")
+	flag.Parse()
+
+	if *ballotID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	if err := config.Load(); err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// Connect to database
+	if err := database.Connect(); err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer database.Close()
+
+	// Claims are not handled from the CLI, so no order service is needed
+	ballotService := ballotservice.NewService(
+		ballotrepo.NewRepository(database.DB),
+		ticketcategoryrepo.NewRepository(database.DB),
+		schedulerepo.NewRepository(database.DB),
+		nil,
+	)
+
+	var result interface{}
+	var err error
+	if *verify {
+		result, err = ballotService.VerifyDraw(*ballotID)
+	} else {
+		result, err = ballotService.Draw(*ballotID, *seed, *drawnBy)
+	}
+	if err != nil {
+		log.Fatal("Ballot command failed:", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatal("Failed to write result:", err)
+	}
+}
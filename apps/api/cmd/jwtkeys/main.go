@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	signingkeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/signing_key"
+	signingkeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/signing_key"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+)
+
+// Admin command to list or rotate the JWT signing keys. Running servers pick up a rotation
+// within a minute.
+//
+//	go run ./cmd/jwtkeys -list
+//	go run ./cmd/jwtkeys -rotate [-alg EdDSA|RS256] [-grace-hours <hours>]
+func main() {
+	list := flag.Bool("list", false, "list signing keys")
+	rotate := flag.Bool("rotate", false, "replace the signing key")
+	algorithm := flag.String("alg", "", "algorithm of the new key (defaults to JWT_SIGNING_ALGORITHM)")
+	graceHours := flag.Int("grace-hours", -1, "hours the old key keeps verifying tokens (defaults to JWT_KEY_ROTATION_GRACE_HOURS; 0 invalidates its tokens now)")
+	flag.Parse()
+
+	if *list == *rotate {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	if err := config.Load(); err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// Connect to database
+	if err := database.Connect(); err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer database.Close()
+
+	// Tokens are not issued from the CLI, the manager only receives the reloaded keys
+	jwtManager := jwt.NewJWTManager(
+		config.AppConfig.JWT.SecretKey,
+		time.Duration(config.AppConfig.JWT.AccessTokenTTL)*time.Hour,
+		time.Duration(config.AppConfig.JWT.RefreshTokenTTL)*24*time.Hour,
+	)
+	signingKeyService := signingkeyservice.NewService(signingkeyrepo.NewRepository(database.DB), jwtManager, nil)
+
+	var result interface{}
+	var err error
+	if *rotate {
+		req := &auth.RotateSigningKeyRequest{Algorithm: *algorithm}
+		if *graceHours >= 0 {
+			req.GraceHours = graceHours
+		}
+		result, err = signingKeyService.Rotate(req)
+	} else {
+		result, err = signingKeyService.List()
+	}
+	if err != nil {
+		log.Fatal("Signing key command failed:", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatal("Failed to write result:", err)
+	}
+}
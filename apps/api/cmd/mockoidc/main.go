@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/integration/oidc"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+// Local OpenID Connect provider for developing and testing social login. Every authorization
+// request is approved right away for the configured user (or the login_hint email), so no
+// browser interaction is needed.
+//
+//	go run ./cmd/mockoidc [-addr :9400] [-email buyer@example.com] [-unverified]
+//
+// Configure the API with OIDC_PROVIDERS=mock, OIDC_MOCK_ISSUER=http://localhost:9400 and any
+// OIDC_MOCK_CLIENT_ID.
+func main() {
+	addr := flag.String("addr", ":9400", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9400", "issuer URL, as configured in OIDC_MOCK_ISSUER")
+	email := flag.String("email", "buyer@example.com", "email of the logged in user (login_hint overrides it)")
+	name := flag.String("name", "Mock Buyer", "name of the logged in user")
+	subject := flag.String("sub", "", "subject of the logged in user (defaults to one derived from the email)")
+	unverified := flag.Bool("unverified", false, "report the email as not verified")
+	flag.Parse()
+
+	privateKey, err := jwt.GenerateKey(jwt.AlgorithmRS256)
+	if err != nil {
+		log.Fatal("Failed to generate key:", err)
+	}
+	publicKey := &jwt.VerificationKey{ID: "mock-1", Algorithm: jwt.AlgorithmRS256, PublicKey: privateKey.Public()}
+	jwk, err := publicKey.ToJWK()
+	if err != nil {
+		log.Fatal("Failed to encode key:", err)
+	}
+
+	provider := &mockProvider{
+		issuer:     strings.TrimRight(*issuer, "/"),
+		name:       *name,
+		email:      *email,
+		subject:    *subject,
+		unverified: *unverified,
+		key:        publicKey.ID,
+		signer:     privateKey,
+		codes:      make(map[string]*authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("/authorize", provider.authorize)
+	mux.HandleFunc("/token", provider.token)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, jwt.JWKS{Keys: []jwt.JWK{jwk}})
+	})
+
+	log.Printf("Mock OIDC provider listening on %s (issuer %s)", *addr, provider.issuer)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatal("Mock OIDC provider stopped:", err)
+	}
+}
+
+// codeTTL is how long an authorization code can be redeemed
+const codeTTL = 5 * time.Minute
+
+type mockProvider struct {
+	issuer     string
+	name       string
+	email      string
+	subject    string
+	unverified bool
+	key        string
+	signer     crypto.Signer
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+// authorization is an issued authorization code
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	email         string
+	expiresAt     time.Time
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwt.AlgorithmRS256},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorize approves the request and redirects back with a code
+func (p *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI := query.Get("redirect_uri")
+	if query.Get("response_type") != "code" || query.Get("client_id") == "" || redirectURI == "" {
+		http.Error(w, "response_type=code, client_id and redirect_uri are required", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE with code_challenge_method=S256 is required", http.StatusBadRequest)
+		return
+	}
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code, err := oidc.RandomToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	email := p.email
+	if hint := strings.TrimSpace(query.Get("login_hint")); hint != "" {
+		email = hint
+	}
+
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		email:         email,
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	p.mu.Unlock()
+
+	params := target.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	target.RawQuery = params.Encode()
+	log.Printf("Approved login of %s for client %s", email, query.Get("client_id"))
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// token redeems a code for an ID token, checking the client, redirect URI and PKCE verifier
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "grant_type must be authorization_code")
+		return
+	}
+
+	clientID := r.PostForm.Get("client_id")
+	if user, _, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(user)
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	auth, ok := p.codes[code]
+	delete(p.codes, code) // codes are single use
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(auth.expiresAt) {
+		tokenError(w, "invalid_grant", "unknown or expired code")
+		return
+	}
+	if auth.clientID != clientID || auth.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, "invalid_grant", "client_id or redirect_uri does not match the authorization request")
+		return
+	}
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.codeChallenge {
+		tokenError(w, "invalid_grant", "code_verifier does not match code_challenge")
+		return
+	}
+
+	subject := p.subject
+	if subject == "" {
+		sum := sha256.Sum256([]byte(strings.ToLower(auth.email)))
+		subject = "mock-" + base64.RawURLEncoding.EncodeToString(sum[:12])
+	}
+	now := time.Now()
+	idToken := gojwt.NewWithClaims(gojwt.SigningMethodRS256, gojwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            subject,
+		"aud":            auth.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.email,
+		"email_verified": !p.unverified,
+		"name":           p.name,
+	})
+	idToken.Header["kid"] = p.key
+	signed, err := idToken.SignedString(p.signer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	accessToken, err := oidc.RandomToken()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -3,27 +3,38 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/grpcserver"
 	attendeehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/attendee"
 	audithandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/audit"
 	authhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/auth"
+	ballothandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/ballot"
 	checkinhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/checkin"
 	dashboardhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/dashboard"
 	eventhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/event"
+	fraudhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/fraud"
 	gatehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/gate"
 	menuhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/menu"
 	merchandisehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/merchandise"
 	orderhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/order"
 	orderitemhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/order_item"
+	quotaallocationhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/quota_allocation"
 	permissionhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/permission"
+	presalehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/presale"
+	resalehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/resale"
 	rolehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/role"
+	scanloghandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/scan_log"
 	schedulehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/schedule"
 	settingshandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/settings"
+	signingkeyhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/signing_key"
+	apikeyhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/api_key"
+	organizationhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/organization"
 	tickethandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/ticket"
 	ticketcategoryhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/ticket_category"
 	userhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/user"
@@ -31,59 +42,100 @@ import (
 	attendeeroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/attendee"
 	auditroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/audit"
 	authroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/auth"
+	ballotroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/ballot"
 	checkinroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/checkin"
 	dashboardroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/dashboard"
 	eventroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/event"
+	fraudroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/fraud"
 	gateroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/gate"
 	menuroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/menu"
 	merchandiseroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/merchandise"
 	orderroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/order"
 	orderitemroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/order_item"
 	permissionroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/permission"
+	presaleroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/presale"
+	quotaallocationroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/quota_allocation"
+	resaleroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/resale"
 	roleroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/role"
+	scanlogroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/scan_log"
 	scheduleroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/schedule"
 	settingsroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/settings"
+	signingkeyroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/signing_key"
+	apikeyroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/api_key"
+	organizationroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/organization"
 	ticketroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/ticket"
 	ticketcategoryroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/ticket_category"
 	userroutes "github.com/gilabs/webapp-ticket-konser/api/internal/api/routes/user"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
-	paymentexpirationjob "github.com/gilabs/webapp-ticket-konser/api/internal/job"
+	redisint "github.com/gilabs/webapp-ticket-konser/api/internal/integration/redis"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/job"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	gatedevice "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
 	attendeerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/attendee"
 	auditrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/audit"
 	authrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/auth"
+	sessionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/session"
+	loginattemptrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/login_attempt"
+	oidcrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/oidc"
+	twofactorrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/two_factor"
+	usertokenrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/user_token"
+	ballotrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/ballot"
 	checkinrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/checkin"
 	dashboardrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/dashboard"
 	eventrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/event"
+	fraudrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/fraud"
 	gaterepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/gate"
 	gatestaffrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/gate_staff"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/gate_device"
+	gateoccupancyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/gate_occupancy"
 	menurepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/menu"
 	merchandiserepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/merchandise"
 	orderrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/order"
 	orderitemrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/order_item"
 	permissionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/permission"
+	presalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/presale"
+	quotaallocationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/quota_allocation"
+	resalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/resale"
+	scanlogrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/scan_log"
 	rolerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/role"
+	rolebindingrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/role_binding"
 	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/schedule"
 	settingsrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/settings"
+	signingkeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/signing_key"
+	apikeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/api_key"
+	organizationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/organization"
 	ticketrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/ticket"
 	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/ticket_category"
 	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/postgres/user"
 	attendeeservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/attendee"
 	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
 	authservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/auth"
+	ballotservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ballot"
 	checkinservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/checkin"
 	dashboardservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/dashboard"
 	eventservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/event"
+	fraudservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/fraud"
 	gateservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/gate"
 	menuservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/menu"
 	merchandiseservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/merchandise"
+	occupancyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/occupancy"
 	orderservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order"
 	orderitemservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order_item"
 	permissionservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/permission"
+	permissioncacheservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/permission_cache"
+	presaleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/presale"
+	quotaallocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/quota_allocation"
+	resaleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/resale"
+	revocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/revocation"
+	scanlogservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/scan_log"
 	roleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/role"
 	scheduleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/schedule"
+	sessioncacheservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/session_cache"
 	settingsservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/settings"
+	signingkeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/signing_key"
+	apikeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/api_key"
+	organizationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/organization"
 	ticketservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ticket"
 	ticketcategoryservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ticket_category"
 	userservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/user"
@@ -92,6 +144,7 @@ import (
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/gilabs/webapp-ticket-konser/api/seeders"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -109,6 +162,12 @@ func main() {
 	}
 	defer database.Close()
 
+	// Connect to Redis (optional; shares gate occupancy counters, rate limits and idempotency keys across replicas)
+	if err := redisint.Connect(context.Background(), redisint.LoadConfigFromEnv()); err != nil {
+		log.Printf("WARNING: %v; continuing without Redis", err)
+	}
+	defer redisint.Close()
+
 	// Run migrations
 	if err := database.AutoMigrate(); err != nil {
 		log.Fatal("Failed to run migrations:", err)
@@ -128,8 +187,17 @@ func main() {
 
 	// Setup repositories
 	authRepo := authrepo.NewRepository(database.DB)
+	sessionRepo := sessionrepo.NewRepository(database.DB)
+	userTokenRepo := usertokenrepo.NewRepository(database.DB)
+	twoFactorRepo := twofactorrepo.NewRepository(database.DB)
+	loginAttemptRepo := loginattemptrepo.NewRepository(database.DB)
+	oidcRepo := oidcrepo.NewRepository(database.DB)
+	signingKeyRepo := signingkeyrepo.NewRepository(database.DB)
+	apiKeyRepo := apikeyrepo.NewRepository(database.DB)
+	organizationRepo := organizationrepo.NewRepository(database.DB)
 	attendeeRepo := attendeerepo.NewRepository(database.DB)
 	roleRepo := rolerepo.NewRepository(database.DB)
+	roleBindingRepo := rolebindingrepo.NewRepository(database.DB)
 	permissionRepo := permissionrepo.NewRepository(database.DB)
 	menuRepo := menurepo.NewRepository(database.DB)
 	eventRepo := eventrepo.NewRepository(database.DB)
@@ -139,33 +207,65 @@ func main() {
 	orderRepo := orderrepo.NewRepository(database.DB)
 	orderItemRepo := orderitemrepo.NewRepository(database.DB)
 	checkInRepo := checkinrepo.NewRepository(database.DB)
+	scanLogRepo := scanlogrepo.NewRepository(database.DB)
+	fraudRepo := fraudrepo.NewRepository(database.DB)
 	gateRepo := gaterepo.NewRepository(database.DB)
 	gateStaffRepo := gatestaffrepo.NewRepository(database.DB)
+	gateDeviceRepo := gatedevicerepo.NewRepository(database.DB)
+	gateOccupancyRepo := gateoccupancyrepo.NewRepository(database.DB)
 	userRepo := userrepo.NewRepository(database.DB)
 	merchandiseRepo := merchandiserepo.NewRepository(database.DB)
 	settingsRepo := settingsrepo.NewRepository(database.DB)
 	dashboardRepo := dashboardrepo.NewRepository(database.DB)
 	auditRepo := auditrepo.NewRepository(database.DB)
+	quotaAllocationRepo := quotaallocationrepo.NewRepository(database.DB)
+	presaleRepo := presalerepo.NewRepository(database.DB)
+	ballotRepo := ballotrepo.NewRepository(database.DB)
+	resaleRepo := resalerepo.NewRepository(database.DB)
 
 	// Setup services
 	menuService := menuservice.NewService(menuRepo, roleRepo)
-	authService := authservice.NewService(authRepo, roleRepo, menuService, jwtManager)
+	sessionCacheService := sessioncacheservice.NewService(sessionRepo, time.Duration(config.AppConfig.Auth.SessionCacheTTLSeconds)*time.Second)
+	authService := authservice.NewService(authRepo, sessionRepo, sessionCacheService, userTokenRepo, twoFactorRepo, loginAttemptRepo, oidcRepo, roleRepo, menuService, jwtManager)
+	jwtManager.SetSessionChecker(sessionCacheService) // Reject access tokens of revoked sessions
 	attendeeService := attendeeservice.NewService(attendeeRepo)
 	permissionService := permissionservice.NewService(permissionRepo)
-	roleService := roleservice.NewService(roleRepo, permissionRepo)
+	permissionCacheService := permissioncacheservice.NewService(roleRepo, time.Duration(config.AppConfig.PermissionCache.TTLSeconds)*time.Second)
+	middleware.SetPermissionResolver(permissionCacheService) // Serve permission checks from the per-role cache
+	if config.AppConfig.PermissionCache.VersionInToken {
+		jwtManager.SetPermissionsVersionSource(permissionCacheService) // Reject access tokens issued before a role's permissions changed
+	}
+	roleService := roleservice.NewService(roleRepo, permissionRepo, roleBindingRepo, userRepo, eventRepo, permissionCacheService)
+	middleware.SetEventPermissionResolver(roleService) // Roles bound to users within single events
 	eventService := eventservice.NewService(eventRepo)
-	ticketCategoryService := ticketcategoryservice.NewService(ticketCategoryRepo)
+	ticketCategoryService := ticketcategoryservice.NewService(ticketCategoryRepo, scheduleRepo)
 	ticketService := ticketservice.NewService(ticketRepo)
 	scheduleService := scheduleservice.NewService(scheduleRepo)
 	orderItemService := orderitemservice.NewService(orderItemRepo, orderRepo, ticketCategoryRepo)
 	orderService := orderservice.NewService(orderRepo, ticketCategoryRepo, scheduleRepo, orderItemRepo, orderItemService)
-	checkInService := checkinservice.NewService(checkInRepo, orderItemRepo)
-	gateService := gateservice.NewService(gateRepo, gateStaffRepo, orderItemRepo, checkInRepo, checkInService)
-	dashboardService := dashboardservice.NewService(dashboardRepo)
 	auditService := auditservice.NewService(auditRepo)
-	userService := userservice.NewService(userRepo, roleRepo, auditService)
+	signingKeyService := signingkeyservice.NewService(signingKeyRepo, jwtManager, auditService)
+	if err := signingKeyService.Init(); err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+	occupancyService := occupancyservice.NewService(gateOccupancyRepo, gateRepo)
+	checkInService := checkinservice.NewService(checkInRepo, orderItemRepo, auditService, occupancyService)
+	gateService := gateservice.NewService(gateRepo, gateStaffRepo, gateDeviceRepo, orderItemRepo, checkInRepo, userRepo, scheduleRepo, checkInService, occupancyService)
+	revocationService := revocationservice.NewService()
+	fraudService := fraudservice.NewService(fraudRepo, scanLogRepo, orderItemRepo, gateStaffRepo, auditService, revocationService)
+	scanLogService := scanlogservice.NewService(scanLogRepo, fraudService)
+	dashboardService := dashboardservice.NewService(dashboardRepo)
+	userService := userservice.NewService(userRepo, loginAttemptRepo, roleRepo, organizationRepo, auditService)
+	apiKeyService := apikeyservice.NewService(apiKeyRepo, userRepo, roleRepo, permissionRepo, auditService)
+	middleware.SetAPIKeyAuthenticator(apiKeyService) // Accept API keys next to JWTs in AuthMiddleware
+	organizationService := organizationservice.NewService(organizationRepo, auditService)
+	middleware.SetOrganizationOwnerResolver(organizationService) // Keep staff within their organization's data
 	merchandiseService := merchandiseservice.NewService(merchandiseRepo)
 	settingsService := settingsservice.NewService(settingsRepo)
+	quotaAllocationService := quotaallocationservice.NewService(quotaAllocationRepo)
+	presaleService := presaleservice.NewService(presaleRepo, ticketCategoryRepo)
+	ballotService := ballotservice.NewService(ballotRepo, ticketCategoryRepo, scheduleRepo, orderService)
+	resaleService := resaleservice.NewService(resaleRepo, orderService)
 
 	// Setup handlers
 	authHandler := authhandler.NewHandler(authService)
@@ -179,13 +279,22 @@ func main() {
 	scheduleHandler := schedulehandler.NewHandler(scheduleService)
 	orderHandler := orderhandler.NewHandler(orderService)
 	orderItemHandler := orderitemhandler.NewHandler(orderItemService, orderRepo)
-	checkInHandler := checkinhandler.NewHandler(checkInService)
-	gateHandler := gatehandler.NewHandler(gateService)
+	checkInHandler := checkinhandler.NewHandler(checkInService, scanLogService)
+	gateHandler := gatehandler.NewHandler(gateService, scanLogService)
+	scanLogHandler := scanloghandler.NewHandler(scanLogService)
+	fraudHandler := fraudhandler.NewHandler(fraudService)
 	userHandler := userhandler.NewHandler(userService)
 	merchandiseHandler := merchandisehandler.NewHandler(merchandiseService)
 	settingsHandler := settingshandler.NewHandler(settingsService)
 	dashboardHandler := dashboardhandler.NewHandler(dashboardService)
 	auditHandler := audithandler.NewHandler(auditService)
+	quotaAllocationHandler := quotaallocationhandler.NewHandler(quotaAllocationService)
+	presaleHandler := presalehandler.NewHandler(presaleService)
+	ballotHandler := ballothandler.NewHandler(ballotService)
+	resaleHandler := resalehandler.NewHandler(resaleService)
+	signingKeyHandler := signingkeyhandler.NewHandler(signingKeyService)
+	apiKeyHandler := apikeyhandler.NewHandler(apiKeyService)
+	organizationHandler := organizationhandler.NewHandler(organizationService)
 
 	// Setup router
 	router := setupRouter(
@@ -203,16 +312,32 @@ func main() {
 		orderItemHandler,
 		checkInHandler,
 		gateHandler,
+		scanLogHandler,
+		fraudHandler,
 		userHandler,
 		merchandiseHandler,
 		settingsHandler,
 		dashboardHandler,
 		auditHandler,
+		quotaAllocationHandler,
+		presaleHandler,
+		ballotHandler,
+		resaleHandler,
+		signingKeyHandler,
+		apiKeyHandler,
+		organizationHandler,
+		gateDeviceRepo,
 		roleRepo,
 	)
 
 	// Start background jobs
-	cronJob := paymentexpirationjob.StartPaymentExpirationJob(orderService)
+	cronJob := job.StartPaymentExpirationJob(orderService)
+	allocationCronJob := job.StartQuotaAllocationExpirationJob(quotaAllocationService)
+	ballotCronJob := job.StartBallotClaimExpirationJob(ballotService)
+	signingKeyCronJob := job.StartSigningKeyRefreshJob(signingKeyService)
+	cacheListenerCtx, stopCacheListeners := context.WithCancel(context.Background())
+	go permissionCacheService.Listen(cacheListenerCtx) // Invalidations published by other replicas
+	go sessionCacheService.Listen(cacheListenerCtx)    // Session revocations published by other replicas
 
 	// Run server with explicit timeouts + graceful shutdown
 	port := config.AppConfig.Server.Port
@@ -234,6 +359,23 @@ func main() {
 		}
 	}()
 
+	// gRPC scanning API for gate devices runs on its own port when configured
+	var grpcSrv *grpc.Server
+	if grpcPort := config.AppConfig.Server.GRPCPort; grpcPort != "" {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatal("Failed to listen for gRPC:", err)
+		}
+		scanServer := grpcserver.NewScanServer(gateService, checkInService, scanLogService, revocationService, roleService, organizationService)
+		grpcSrv = grpcserver.NewServer(scanServer, jwtManager, permissionCacheService, gateDeviceRepo, roleService)
+		go func() {
+			log.Printf("gRPC server starting on :%s", grpcPort)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Fatal("Failed to start gRPC server:", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
@@ -246,7 +388,29 @@ func main() {
 	// Stop cron job first to prevent new processing during shutdown
 	cronCtx := cronJob.Stop()
 	<-cronCtx.Done()
+	allocationCronCtx := allocationCronJob.Stop()
+	<-allocationCronCtx.Done()
+	ballotCronCtx := ballotCronJob.Stop()
+	<-ballotCronCtx.Done()
+	signingKeyCronCtx := signingKeyCronJob.Stop()
+	<-signingKeyCronCtx.Done()
 	log.Println("Cron jobs stopped")
+	stopCacheListeners()
+
+	if grpcSrv != nil {
+		// Device streams stay open until the client leaves, so don't wait on them past the deadline
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcSrv.Stop()
+		}
+		log.Println("gRPC server stopped")
+	}
 
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server shutdown failed:", err)
@@ -269,11 +433,21 @@ func setupRouter(
 	orderItemHandler *orderitemhandler.Handler,
 	checkInHandler *checkinhandler.Handler,
 	gateHandler *gatehandler.Handler,
+	scanLogHandler *scanloghandler.Handler,
+	fraudHandler *fraudhandler.Handler,
 	userHandler *userhandler.Handler,
 	merchandiseHandler *merchandisehandler.Handler,
 	settingsHandler *settingshandler.Handler,
 	dashboardHandler *dashboardhandler.Handler,
 	auditHandler *audithandler.Handler,
+	quotaAllocationHandler *quotaallocationhandler.Handler,
+	presaleHandler *presalehandler.Handler,
+	ballotHandler *ballothandler.Handler,
+	resaleHandler *resalehandler.Handler,
+	signingKeyHandler *signingkeyhandler.Handler,
+	apiKeyHandler *apikeyhandler.Handler,
+	organizationHandler *organizationhandler.Handler,
+	gateDeviceRepo gatedevice.Repository,
 	roleRepo role.Repository,
 ) *gin.Engine {
 	// Set Gin mode
@@ -304,6 +478,9 @@ func setupRouter(
 		})
 	})
 
+	// Public keys for verifying issued tokens
+	router.GET("/.well-known/jwks.json", signingKeyHandler.JWKS)
+
 	// Static file serving for uploads
 	router.Static("/uploads", "./uploads")
 
@@ -345,10 +522,16 @@ func setupRouter(
 		orderroutes.SetupRoutes(v1, orderHandler, orderItemHandler, roleRepo, jwtManager)
 
 		// Check-in routes
-		checkinroutes.SetupRoutes(v1, checkInHandler, roleRepo, jwtManager)
+		checkinroutes.SetupRoutes(v1, checkInHandler, gateDeviceRepo, roleRepo, jwtManager)
 
 		// Gate routes
-		gateroutes.SetupRoutes(v1, gateHandler, roleRepo, jwtManager)
+		gateroutes.SetupRoutes(v1, gateHandler, gateDeviceRepo, roleRepo, jwtManager)
+
+		// Scan log routes
+		scanlogroutes.SetupRoutes(v1, scanLogHandler, roleRepo, jwtManager)
+
+		// Fraud alert routes
+		fraudroutes.SetupRoutes(v1, fraudHandler, roleRepo, jwtManager)
 
 		// User routes
 		userroutes.SetupRoutes(v1, userHandler, roleRepo, jwtManager)
@@ -370,6 +553,25 @@ func setupRouter(
 
 		// Audit Log routes
 		auditroutes.SetupRoutes(v1, auditHandler, roleRepo, jwtManager)
+
+		// Quota allocation routes
+		quotaallocationroutes.SetupRoutes(v1, quotaAllocationHandler, roleRepo, jwtManager)
+
+		// Presale routes
+		presaleroutes.SetupRoutes(v1, presaleHandler, roleRepo, jwtManager)
+
+		// Ballot routes
+		ballotroutes.SetupRoutes(v1, ballotHandler, roleRepo, jwtManager)
+
+		// Resale marketplace routes
+		resaleroutes.SetupRoutes(v1, resaleHandler, roleRepo, jwtManager)
+
+		// JWT signing key routes
+		signingkeyroutes.SetupRoutes(v1, signingKeyHandler, roleRepo, jwtManager)
+
+		// API key routes
+		apikeyroutes.SetupRoutes(v1, apiKeyHandler, roleRepo, jwtManager)
+		organizationroutes.SetupRoutes(v1, organizationHandler, roleRepo, jwtManager)
 	}
 
 	return router
@@ -0,0 +1,194 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// scanPermission is required for every scanning call, same as the REST check-in routes
+const scanPermission = "checkin.create"
+
+// PermissionResolver answers the scan permission of the caller's platform role, typically from
+// the permission cache shared with the REST API
+type PermissionResolver interface {
+	HasPermission(roleID string, permissionCode string) (bool, error)
+}
+
+// EventPermissionResolver answers the scan permission of roles bound to the caller within single
+// events, like the EventContext routes of the REST API
+type EventPermissionResolver interface {
+	EventOf(resource roledomain.ContextResource, id string) (string, error)
+	HasEventPermission(userID, eventID, permissionCode string) (bool, error)
+	HasAnyEventPermission(userID, permissionCode string) (bool, error)
+}
+
+// OrganizationResolver finds the organization owning a gate or ticket, so callers only scan within
+// their organization like on the REST check-in routes
+type OrganizationResolver interface {
+	OwnerOf(resource organization.Resource, id string) (string, error)
+}
+
+// identity is the authenticated caller of a gRPC call
+type identity struct {
+	UserID        string
+	Role          string
+	RoleID        string
+	ExpiresAt     time.Time
+	DeviceID      string
+	DeviceGateID  string
+	BoundToEvents bool // Scan permission only comes from roles bound within single events
+	Scope         organization.Scope
+}
+
+// IsAdmin reports whether the caller may scan at gates they are not assigned to
+func (i *identity) IsAdmin() bool {
+	return i.Role == "admin" || i.Role == "super_admin"
+}
+
+type identityKey struct{}
+
+func identityFromContext(ctx context.Context) *identity {
+	id, _ := ctx.Value(identityKey{}).(*identity)
+	return id
+}
+
+// authenticator checks the JWT, permission and device token of every call, mirroring the
+// AuthMiddleware, RequirePermission and DeviceAuthMiddleware chain of the REST API
+type authenticator struct {
+	jwtManager       *jwt.JWTManager
+	permissions      PermissionResolver
+	deviceRepo       gatedevicerepo.Repository
+	eventPermissions EventPermissionResolver
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, identityKey{}, id), req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{
+		ServerStream: ss,
+		ctx:          context.WithValue(ss.Context(), identityKey{}, id),
+	})
+}
+
+func (a *authenticator) authenticate(ctx context.Context) (*identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	authHeader := firstValue(md, "authorization")
+	if authHeader == "" {
+		return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: token missing")
+	}
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: invalid token format")
+	}
+
+	claims, err := a.jwtManager.ValidateToken(parts[1])
+	if err != nil {
+		if err == jwt.ErrExpiredToken {
+			return nil, status.Error(codes.Unauthenticated, "TOKEN_EXPIRED")
+		}
+		if err == jwt.ErrRevokedToken {
+			return nil, status.Error(codes.Unauthenticated, "SESSION_REVOKED")
+		}
+		if err == jwt.ErrStaleToken {
+			return nil, status.Error(codes.Unauthenticated, "TOKEN_PERMISSIONS_STALE")
+		}
+		return nil, status.Error(codes.Unauthenticated, "TOKEN_INVALID")
+	}
+
+	if claims.RoleID == "" {
+		return nil, status.Error(codes.Unauthenticated, "UNAUTHORIZED: invalid role ID")
+	}
+	hasPermission, err := a.permissions.HasPermission(claims.RoleID, scanPermission)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+	}
+	boundToEvents := false
+	if !hasPermission && a.eventPermissions != nil {
+		// Staff bound within single events may connect; each scan is checked against its schedule
+		hasPermission, err = a.eventPermissions.HasAnyEventPermission(claims.UserID, scanPermission)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+		}
+		boundToEvents = hasPermission
+	}
+	if !hasPermission {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: %s permission required", scanPermission)
+	}
+
+	id := &identity{
+		UserID:        claims.UserID,
+		Role:          claims.Role,
+		RoleID:        claims.RoleID,
+		BoundToEvents: boundToEvents,
+		Scope:         organization.Scope{OrganizationID: claims.OrganizationID},
+	}
+	if claims.Role == organization.SuperAdminRole {
+		// Super admins may select an organization with the same header as on the REST API
+		id.Scope = organization.Scope{
+			OrganizationID: strings.TrimSpace(firstValue(md, strings.ToLower(organization.Header))),
+			SuperAdmin:     true,
+		}
+	}
+	if claims.ExpiresAt != nil {
+		id.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	// The device token is optional, as on the REST check-in routes
+	if token := firstValue(md, strings.ToLower(gate.DeviceTokenHeader)); token != "" {
+		device, err := a.deviceRepo.FindByTokenHash(gate.HashDeviceToken(token))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Error(codes.Unauthenticated, "DEVICE_NOT_REGISTERED")
+			}
+			return nil, status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+		}
+		if device.Status != gate.DeviceStatusActive {
+			return nil, status.Error(codes.PermissionDenied, "DEVICE_REVOKED")
+		}
+		id.DeviceID = device.ID
+		id.DeviceGateID = device.GateID
+	}
+
+	return id, nil
+}
+
+// authenticatedStream carries the caller identity in the stream context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
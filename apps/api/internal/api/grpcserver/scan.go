@@ -0,0 +1,347 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/grpcserver/scanpb"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	checkinservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/checkin"
+	gateservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/gate"
+	revocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/revocation"
+	scanlogservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/scan_log"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Result codes for scans rejected before reaching the services, same as the REST API
+const (
+	resultCodeValidationError = "VALIDATION_ERROR"
+	resultCodeForbidden       = "FORBIDDEN"
+	resultCodeInternalError   = "INTERNAL_SERVER_ERROR"
+)
+
+// ScanServer implements the gRPC scanning API on top of the same services as the REST check-in
+// endpoints. Scan rejections are returned in the result, never as gRPC errors, so a device can
+// keep its stream open across bad scans.
+type ScanServer struct {
+	scanpb.UnimplementedScanServiceServer
+	gateService      *gateservice.Service
+	checkInService   *checkinservice.Service
+	scanLogService   *scanlogservice.Service
+	revocations      *revocationservice.Service
+	eventPermissions EventPermissionResolver
+	organizations    OrganizationResolver
+}
+
+func NewScanServer(
+	gateService *gateservice.Service,
+	checkInService *checkinservice.Service,
+	scanLogService *scanlogservice.Service,
+	revocations *revocationservice.Service,
+	eventPermissions EventPermissionResolver,
+	organizations OrganizationResolver,
+) *ScanServer {
+	return &ScanServer{
+		gateService:      gateService,
+		checkInService:   checkInService,
+		scanLogService:   scanLogService,
+		revocations:      revocations,
+		eventPermissions: eventPermissions,
+		organizations:    organizations,
+	}
+}
+
+// Validate checks a QR code without checking it in
+func (s *ScanServer) Validate(ctx context.Context, req *scanpb.ValidateRequest) (*scanpb.ValidateResponse, error) {
+	id := identityFromContext(ctx)
+	return s.validate(id, sourceFromContext(ctx, id), req), nil
+}
+
+// CheckIn admits a ticket at a gate
+func (s *ScanServer) CheckIn(ctx context.Context, req *scanpb.CheckInRequest) (*scanpb.ScanResult, error) {
+	id := identityFromContext(ctx)
+	return s.checkIn(id, sourceFromContext(ctx, id), req), nil
+}
+
+// Scan handles scans pushed over a stream and pushes ticket revocation updates back. The stream
+// ends when the client closes it or the access token expires.
+func (s *ScanServer) Scan(stream scanpb.ScanService_ScanServer) error {
+	ctx := stream.Context()
+	id := identityFromContext(ctx)
+	source := sourceFromContext(ctx, id)
+
+	revocations, unsubscribe := s.revocations.Subscribe()
+	defer unsubscribe()
+
+	requests := make(chan *scanpb.ScanStreamRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var expired <-chan time.Time
+	if !id.ExpiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(id.ExpiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	// Only this loop sends, as a gRPC stream doesn't allow concurrent sends
+	for {
+		var resp *scanpb.ScanStreamResponse
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-expired:
+			return status.Error(codes.Unauthenticated, "TOKEN_EXPIRED")
+		case event := <-revocations:
+			resp = &scanpb.ScanStreamResponse{
+				Payload: &scanpb.ScanStreamResponse_Revocation{Revocation: toTicketRevocation(event)},
+			}
+		case req := <-requests:
+			resp = s.handleStreamRequest(id, source, req)
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ScanServer) handleStreamRequest(id *identity, source scanlogservice.Source, req *scanpb.ScanStreamRequest) *scanpb.ScanStreamResponse {
+	resp := &scanpb.ScanStreamResponse{RequestId: req.GetRequestId()}
+	switch scan := req.GetScan().(type) {
+	case *scanpb.ScanStreamRequest_Validate:
+		resp.Payload = &scanpb.ScanStreamResponse_Validation{Validation: s.validate(id, source, scan.Validate)}
+	case *scanpb.ScanStreamRequest_CheckIn:
+		resp.Payload = &scanpb.ScanStreamResponse_CheckIn{CheckIn: s.checkIn(id, source, scan.CheckIn)}
+	default:
+		resp.Payload = &scanpb.ScanStreamResponse_CheckIn{CheckIn: &scanpb.ScanResult{
+			ErrorCode: resultCodeValidationError,
+			Message:   "Scan wajib diisi",
+		}}
+	}
+	return resp
+}
+
+func (s *ScanServer) validate(id *identity, source scanlogservice.Source, req *scanpb.ValidateRequest) *scanpb.ValidateResponse {
+	if req.GetQrCode() == "" {
+		return &scanpb.ValidateResponse{ErrorCode: resultCodeValidationError, Message: "QR code wajib diisi"}
+	}
+	if !isOptionalUUID(req.GetScheduleId()) {
+		return &scanpb.ValidateResponse{ErrorCode: resultCodeValidationError, Message: "Format schedule_id tidak valid"}
+	}
+	if code, message := s.authorizeSchedule(id, req.GetScheduleId()); code != "" {
+		return &scanpb.ValidateResponse{ErrorCode: code, Message: message}
+	}
+	if code, message := s.authorizeOrganization(id, organization.ResourceQRCode, req.GetQrCode()); code != "" {
+		return &scanpb.ValidateResponse{ErrorCode: code, Message: message}
+	}
+
+	startedAt := time.Now()
+	result, err := s.checkInService.ValidateQRCode(req.GetQrCode(), req.GetScheduleId())
+	s.scanLogService.RecordValidationFrom(source, req.GetQrCode(), startedAt, result, err)
+	if err != nil || result == nil {
+		log.Printf("[gRPC] Failed to validate QR code for user %s: %v", id.UserID, err)
+		return &scanpb.ValidateResponse{ErrorCode: resultCodeInternalError, Message: "Terjadi kesalahan saat validasi QR code"}
+	}
+
+	return &scanpb.ValidateResponse{
+		Valid:       result.Valid,
+		OrderItemId: result.OrderItemID,
+		ScheduleId:  result.ScheduleID,
+		Status:      result.Status,
+		Message:     result.Message,
+		AlreadyUsed: result.AlreadyUsed,
+		Reentry:     result.Reentry,
+		Inside:      result.Inside,
+		ErrorCode:   result.ErrorCode,
+	}
+}
+
+func (s *ScanServer) checkIn(id *identity, source scanlogservice.Source, req *scanpb.CheckInRequest) *scanpb.ScanResult {
+	// Registered devices default to the gate they are enrolled at
+	gateID := req.GetGateId()
+	if gateID == "" {
+		gateID = id.DeviceGateID
+	}
+	if req.GetQrCode() == "" {
+		return &scanpb.ScanResult{ErrorCode: resultCodeValidationError, Message: "QR code wajib diisi"}
+	}
+	if _, err := uuid.Parse(gateID); err != nil {
+		return &scanpb.ScanResult{ErrorCode: resultCodeValidationError, Message: "Format gate_id tidak valid"}
+	}
+	if !isOptionalUUID(req.GetScheduleId()) {
+		return &scanpb.ScanResult{ErrorCode: resultCodeValidationError, Message: "Format schedule_id tidak valid"}
+	}
+	if code, message := s.authorizeSchedule(id, req.GetScheduleId()); code != "" {
+		return &scanpb.ScanResult{ErrorCode: code, Message: message, GateId: gateID}
+	}
+	if code, message := s.authorizeOrganization(id, organization.ResourceGate, gateID); code != "" {
+		return &scanpb.ScanResult{ErrorCode: code, Message: message, GateId: gateID}
+	}
+	if code, message := s.authorizeOrganization(id, organization.ResourceQRCode, req.GetQrCode()); code != "" {
+		return &scanpb.ScanResult{ErrorCode: code, Message: message, GateId: gateID}
+	}
+
+	gateReq := &gate.GateCheckInRequest{
+		QRCode:     req.GetQrCode(),
+		GateID:     gateID,
+		Location:   req.GetLocation(),
+		ScheduleID: req.GetScheduleId(),
+	}
+	if id.DeviceID != "" {
+		deviceID := id.DeviceID
+		gateReq.DeviceID = &deviceID
+	}
+
+	startedAt := time.Now()
+	result, err := s.gateService.GateCheckIn(gateReq, id.UserID, source.IPAddress, source.UserAgent, id.IsAdmin())
+	s.scanLogService.RecordScanFrom(source, scanlog.ScanActionEntry, req.GetQrCode(), &gateID, startedAt, result, err)
+	if result == nil {
+		log.Printf("[gRPC] Failed to check in at gate %s for user %s: %v", gateID, id.UserID, err)
+		return &scanpb.ScanResult{ErrorCode: resultCodeInternalError, Message: "Terjadi kesalahan saat check-in", GateId: gateID}
+	}
+
+	return toScanResult(result, gateID)
+}
+
+// authorizeSchedule returns the result code rejecting a scan by staff bound within single events,
+// unless the scan is for a schedule of an event where a bound role has the scan permission
+func (s *ScanServer) authorizeSchedule(id *identity, scheduleID string) (string, string) {
+	if !id.BoundToEvents {
+		return "", ""
+	}
+	if scheduleID == "" {
+		return resultCodeValidationError, "schedule_id wajib diisi untuk staff event"
+	}
+
+	eventID, err := s.eventPermissions.EventOf(roledomain.ContextSchedule, scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resultCodeForbidden, "Tidak memiliki akses scan untuk jadwal ini"
+		}
+		log.Printf("[gRPC] Failed to resolve event of schedule %s: %v", scheduleID, err)
+		return resultCodeInternalError, "Terjadi kesalahan saat memeriksa akses scan"
+	}
+	granted, err := s.eventPermissions.HasEventPermission(id.UserID, eventID, scanPermission)
+	if err != nil {
+		log.Printf("[gRPC] Failed to check event permission for user %s: %v", id.UserID, err)
+		return resultCodeInternalError, "Terjadi kesalahan saat memeriksa akses scan"
+	}
+	if !granted {
+		return resultCodeForbidden, "Tidak memiliki akses scan untuk jadwal ini"
+	}
+	return "", ""
+}
+
+// authorizeOrganization returns the result code rejecting a scan of a gate or ticket outside the
+// caller's organization. Resources that don't exist are left for the services to report.
+func (s *ScanServer) authorizeOrganization(id *identity, resource organization.Resource, resourceID string) (string, string) {
+	if id.Scope.Global() {
+		return "", ""
+	}
+	owner, err := s.organizations.OwnerOf(resource, resourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ""
+		}
+		log.Printf("[gRPC] Failed to resolve organization of %s %s: %v", resource, resourceID, err)
+		return resultCodeInternalError, "Terjadi kesalahan saat memeriksa akses scan"
+	}
+	if !id.Scope.Allows(owner) {
+		return resultCodeForbidden, "Tidak memiliki akses scan untuk organisasi ini"
+	}
+	return "", ""
+}
+
+func toScanResult(result *checkin.CheckInResultResponse, gateID string) *scanpb.ScanResult {
+	resp := &scanpb.ScanResult{
+		Success:   result.Success,
+		ErrorCode: result.ErrorCode,
+		Message:   result.Message,
+		GateId:    gateID,
+	}
+	if result.CheckIn != nil {
+		resp.CheckInId = result.CheckIn.ID
+		resp.OrderItemId = result.CheckIn.OrderItemID
+		if result.CheckIn.ScheduleID != nil {
+			resp.ScheduleId = *result.CheckIn.ScheduleID
+		}
+		resp.ScannedAt = result.CheckIn.CheckedInAt.Format(time.RFC3339)
+	}
+	if result.Scan != nil {
+		resp.OrderItemId = result.Scan.OrderItemID
+		resp.ScheduleId = result.Scan.ScheduleID
+		resp.ScannedAt = result.Scan.ScannedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toTicketRevocation(event revocationservice.Event) *scanpb.TicketRevocation {
+	return &scanpb.TicketRevocation{
+		OrderItemId: event.OrderItemID,
+		QrCode:      event.QRCode,
+		Revoked:     event.Revoked,
+		Reason:      event.Reason,
+		OccurredAt:  event.OccurredAt.Format(time.RFC3339),
+	}
+}
+
+// sourceFromContext builds the scan log source of a gRPC call
+func sourceFromContext(ctx context.Context, id *identity) scanlogservice.Source {
+	source := scanlogservice.Source{
+		StaffID:  id.UserID,
+		DeviceID: id.DeviceID,
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		source.UserAgent = firstValue(md, "user-agent")
+		if source.DeviceID == "" {
+			source.DeviceID = firstValue(md, "x-device-id")
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		source.IPAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(source.IPAddress); err == nil {
+			source.IPAddress = host
+		}
+	}
+	return source
+}
+
+func isOptionalUUID(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := uuid.Parse(value)
+	return err == nil
+}
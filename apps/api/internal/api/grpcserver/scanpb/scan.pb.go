@@ -0,0 +1,775 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: scan/v1/scan.proto
+
+package scanpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidateRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	QrCode string                 `protobuf:"bytes,1,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	// Schedule being admitted by the scanner (optional)
+	ScheduleId    string `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_scan_v1_scan_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateRequest) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *ValidateRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type ValidateResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Valid       bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	OrderItemId string                 `protobuf:"bytes,2,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"`
+	ScheduleId  string                 `protobuf:"bytes,3,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Message     string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	AlreadyUsed bool                   `protobuf:"varint,6,opt,name=already_used,json=alreadyUsed,proto3" json:"already_used,omitempty"`
+	Reentry     bool                   `protobuf:"varint,7,opt,name=reentry,proto3" json:"reentry,omitempty"`
+	Inside      bool                   `protobuf:"varint,8,opt,name=inside,proto3" json:"inside,omitempty"`
+	// Error code a check-in would fail with
+	ErrorCode     string `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_scan_v1_scan_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetOrderItemId() string {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetAlreadyUsed() bool {
+	if x != nil {
+		return x.AlreadyUsed
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetReentry() bool {
+	if x != nil {
+		return x.Reentry
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetInside() bool {
+	if x != nil {
+		return x.Inside
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type CheckInRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to the gate the device is enrolled at
+	GateId string `protobuf:"bytes,1,opt,name=gate_id,json=gateId,proto3" json:"gate_id,omitempty"`
+	QrCode string `protobuf:"bytes,2,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	// Schedule being admitted at the gate (optional)
+	ScheduleId    string `protobuf:"bytes,3,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Location      string `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInRequest) Reset() {
+	*x = CheckInRequest{}
+	mi := &file_scan_v1_scan_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInRequest) ProtoMessage() {}
+
+func (x *CheckInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInRequest.ProtoReflect.Descriptor instead.
+func (*CheckInRequest) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckInRequest) GetGateId() string {
+	if x != nil {
+		return x.GateId
+	}
+	return ""
+}
+
+func (x *CheckInRequest) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *CheckInRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *CheckInRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type ScanResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Same error codes as the REST API, e.g. QR_CODE_ALREADY_USED or TICKET_BLOCKED
+	ErrorCode   string `protobuf:"bytes,2,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Message     string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CheckInId   string `protobuf:"bytes,4,opt,name=check_in_id,json=checkInId,proto3" json:"check_in_id,omitempty"`
+	OrderItemId string `protobuf:"bytes,5,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"`
+	ScheduleId  string `protobuf:"bytes,6,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	GateId      string `protobuf:"bytes,7,opt,name=gate_id,json=gateId,proto3" json:"gate_id,omitempty"`
+	// RFC 3339 timestamp of the recorded entry scan
+	ScannedAt     string `protobuf:"bytes,8,opt,name=scanned_at,json=scannedAt,proto3" json:"scanned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResult) Reset() {
+	*x = ScanResult{}
+	mi := &file_scan_v1_scan_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResult) ProtoMessage() {}
+
+func (x *ScanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResult.ProtoReflect.Descriptor instead.
+func (*ScanResult) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScanResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScanResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ScanResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScanResult) GetCheckInId() string {
+	if x != nil {
+		return x.CheckInId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetOrderItemId() string {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetGateId() string {
+	if x != nil {
+		return x.GateId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetScannedAt() string {
+	if x != nil {
+		return x.ScannedAt
+	}
+	return ""
+}
+
+type ScanStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Echoed back on the result so the device can match it to the scan
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are valid to be assigned to Scan:
+	//
+	//	*ScanStreamRequest_Validate
+	//	*ScanStreamRequest_CheckIn
+	Scan          isScanStreamRequest_Scan `protobuf_oneof:"scan"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanStreamRequest) Reset() {
+	*x = ScanStreamRequest{}
+	mi := &file_scan_v1_scan_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanStreamRequest) ProtoMessage() {}
+
+func (x *ScanStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanStreamRequest.ProtoReflect.Descriptor instead.
+func (*ScanStreamRequest) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScanStreamRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ScanStreamRequest) GetScan() isScanStreamRequest_Scan {
+	if x != nil {
+		return x.Scan
+	}
+	return nil
+}
+
+func (x *ScanStreamRequest) GetValidate() *ValidateRequest {
+	if x != nil {
+		if x, ok := x.Scan.(*ScanStreamRequest_Validate); ok {
+			return x.Validate
+		}
+	}
+	return nil
+}
+
+func (x *ScanStreamRequest) GetCheckIn() *CheckInRequest {
+	if x != nil {
+		if x, ok := x.Scan.(*ScanStreamRequest_CheckIn); ok {
+			return x.CheckIn
+		}
+	}
+	return nil
+}
+
+type isScanStreamRequest_Scan interface {
+	isScanStreamRequest_Scan()
+}
+
+type ScanStreamRequest_Validate struct {
+	Validate *ValidateRequest `protobuf:"bytes,2,opt,name=validate,proto3,oneof"`
+}
+
+type ScanStreamRequest_CheckIn struct {
+	CheckIn *CheckInRequest `protobuf:"bytes,3,opt,name=check_in,json=checkIn,proto3,oneof"`
+}
+
+func (*ScanStreamRequest_Validate) isScanStreamRequest_Scan() {}
+
+func (*ScanStreamRequest_CheckIn) isScanStreamRequest_Scan() {}
+
+type ScanStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Request ID of the scan this result belongs to; empty for revocation updates
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ScanStreamResponse_Validation
+	//	*ScanStreamResponse_CheckIn
+	//	*ScanStreamResponse_Revocation
+	Payload       isScanStreamResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanStreamResponse) Reset() {
+	*x = ScanStreamResponse{}
+	mi := &file_scan_v1_scan_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanStreamResponse) ProtoMessage() {}
+
+func (x *ScanStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanStreamResponse.ProtoReflect.Descriptor instead.
+func (*ScanStreamResponse) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScanStreamResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ScanStreamResponse) GetPayload() isScanStreamResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ScanStreamResponse) GetValidation() *ValidateResponse {
+	if x != nil {
+		if x, ok := x.Payload.(*ScanStreamResponse_Validation); ok {
+			return x.Validation
+		}
+	}
+	return nil
+}
+
+func (x *ScanStreamResponse) GetCheckIn() *ScanResult {
+	if x != nil {
+		if x, ok := x.Payload.(*ScanStreamResponse_CheckIn); ok {
+			return x.CheckIn
+		}
+	}
+	return nil
+}
+
+func (x *ScanStreamResponse) GetRevocation() *TicketRevocation {
+	if x != nil {
+		if x, ok := x.Payload.(*ScanStreamResponse_Revocation); ok {
+			return x.Revocation
+		}
+	}
+	return nil
+}
+
+type isScanStreamResponse_Payload interface {
+	isScanStreamResponse_Payload()
+}
+
+type ScanStreamResponse_Validation struct {
+	Validation *ValidateResponse `protobuf:"bytes,2,opt,name=validation,proto3,oneof"`
+}
+
+type ScanStreamResponse_CheckIn struct {
+	CheckIn *ScanResult `protobuf:"bytes,3,opt,name=check_in,json=checkIn,proto3,oneof"`
+}
+
+type ScanStreamResponse_Revocation struct {
+	Revocation *TicketRevocation `protobuf:"bytes,4,opt,name=revocation,proto3,oneof"`
+}
+
+func (*ScanStreamResponse_Validation) isScanStreamResponse_Payload() {}
+
+func (*ScanStreamResponse_CheckIn) isScanStreamResponse_Payload() {}
+
+func (*ScanStreamResponse_Revocation) isScanStreamResponse_Payload() {}
+
+type TicketRevocation struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OrderItemId string                 `protobuf:"bytes,1,opt,name=order_item_id,json=orderItemId,proto3" json:"order_item_id,omitempty"`
+	QrCode      string                 `protobuf:"bytes,2,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	// True when the ticket may no longer enter, false when a hold was released
+	Revoked bool   `protobuf:"varint,3,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	Reason  string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// RFC 3339
+	OccurredAt    string `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketRevocation) Reset() {
+	*x = TicketRevocation{}
+	mi := &file_scan_v1_scan_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketRevocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketRevocation) ProtoMessage() {}
+
+func (x *TicketRevocation) ProtoReflect() protoreflect.Message {
+	mi := &file_scan_v1_scan_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketRevocation.ProtoReflect.Descriptor instead.
+func (*TicketRevocation) Descriptor() ([]byte, []int) {
+	return file_scan_v1_scan_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TicketRevocation) GetOrderItemId() string {
+	if x != nil {
+		return x.OrderItemId
+	}
+	return ""
+}
+
+func (x *TicketRevocation) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *TicketRevocation) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *TicketRevocation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TicketRevocation) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+var File_scan_v1_scan_proto protoreflect.FileDescriptor
+
+const file_scan_v1_scan_proto_rawDesc = "" +
+	"\n" +
+	"\x12scan/v1/scan.proto\x12\x11ticketing.scan.v1\"K\n" +
+	"\x0fValidateRequest\x12\x17\n" +
+	"\aqr_code\x18\x01 \x01(\tR\x06qrCode\x12\x1f\n" +
+	"\vschedule_id\x18\x02 \x01(\tR\n" +
+	"scheduleId\"\x93\x02\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\"\n" +
+	"\rorder_item_id\x18\x02 \x01(\tR\vorderItemId\x12\x1f\n" +
+	"\vschedule_id\x18\x03 \x01(\tR\n" +
+	"scheduleId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12!\n" +
+	"\falready_used\x18\x06 \x01(\bR\valreadyUsed\x12\x18\n" +
+	"\areentry\x18\a \x01(\bR\areentry\x12\x16\n" +
+	"\x06inside\x18\b \x01(\bR\x06inside\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tR\terrorCode\"\x7f\n" +
+	"\x0eCheckInRequest\x12\x17\n" +
+	"\agate_id\x18\x01 \x01(\tR\x06gateId\x12\x17\n" +
+	"\aqr_code\x18\x02 \x01(\tR\x06qrCode\x12\x1f\n" +
+	"\vschedule_id\x18\x03 \x01(\tR\n" +
+	"scheduleId\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\"\xfc\x01\n" +
+	"\n" +
+	"ScanResult\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x02 \x01(\tR\terrorCode\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1e\n" +
+	"\vcheck_in_id\x18\x04 \x01(\tR\tcheckInId\x12\"\n" +
+	"\rorder_item_id\x18\x05 \x01(\tR\vorderItemId\x12\x1f\n" +
+	"\vschedule_id\x18\x06 \x01(\tR\n" +
+	"scheduleId\x12\x17\n" +
+	"\agate_id\x18\a \x01(\tR\x06gateId\x12\x1d\n" +
+	"\n" +
+	"scanned_at\x18\b \x01(\tR\tscannedAt\"\xbc\x01\n" +
+	"\x11ScanStreamRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12@\n" +
+	"\bvalidate\x18\x02 \x01(\v2\".ticketing.scan.v1.ValidateRequestH\x00R\bvalidate\x12>\n" +
+	"\bcheck_in\x18\x03 \x01(\v2!.ticketing.scan.v1.CheckInRequestH\x00R\acheckInB\x06\n" +
+	"\x04scan\"\x88\x02\n" +
+	"\x12ScanStreamResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12E\n" +
+	"\n" +
+	"validation\x18\x02 \x01(\v2#.ticketing.scan.v1.ValidateResponseH\x00R\n" +
+	"validation\x12:\n" +
+	"\bcheck_in\x18\x03 \x01(\v2\x1d.ticketing.scan.v1.ScanResultH\x00R\acheckIn\x12E\n" +
+	"\n" +
+	"revocation\x18\x04 \x01(\v2#.ticketing.scan.v1.TicketRevocationH\x00R\n" +
+	"revocationB\t\n" +
+	"\apayload\"\xa2\x01\n" +
+	"\x10TicketRevocation\x12\"\n" +
+	"\rorder_item_id\x18\x01 \x01(\tR\vorderItemId\x12\x17\n" +
+	"\aqr_code\x18\x02 \x01(\tR\x06qrCode\x12\x18\n" +
+	"\arevoked\x18\x03 \x01(\bR\arevoked\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1f\n" +
+	"\voccurred_at\x18\x05 \x01(\tR\n" +
+	"occurredAt2\x88\x02\n" +
+	"\vScanService\x12S\n" +
+	"\bValidate\x12\".ticketing.scan.v1.ValidateRequest\x1a#.ticketing.scan.v1.ValidateResponse\x12K\n" +
+	"\aCheckIn\x12!.ticketing.scan.v1.CheckInRequest\x1a\x1d.ticketing.scan.v1.ScanResult\x12W\n" +
+	"\x04Scan\x12$.ticketing.scan.v1.ScanStreamRequest\x1a%.ticketing.scan.v1.ScanStreamResponse(\x010\x01BRZPgithub.com/gilabs/webapp-ticket-konser/api/internal/api/grpcserver/scanpb;scanpbb\x06proto3"
+
+var (
+	file_scan_v1_scan_proto_rawDescOnce sync.Once
+	file_scan_v1_scan_proto_rawDescData []byte
+)
+
+func file_scan_v1_scan_proto_rawDescGZIP() []byte {
+	file_scan_v1_scan_proto_rawDescOnce.Do(func() {
+		file_scan_v1_scan_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scan_v1_scan_proto_rawDesc), len(file_scan_v1_scan_proto_rawDesc)))
+	})
+	return file_scan_v1_scan_proto_rawDescData
+}
+
+var file_scan_v1_scan_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_scan_v1_scan_proto_goTypes = []any{
+	(*ValidateRequest)(nil),    // 0: ticketing.scan.v1.ValidateRequest
+	(*ValidateResponse)(nil),   // 1: ticketing.scan.v1.ValidateResponse
+	(*CheckInRequest)(nil),     // 2: ticketing.scan.v1.CheckInRequest
+	(*ScanResult)(nil),         // 3: ticketing.scan.v1.ScanResult
+	(*ScanStreamRequest)(nil),  // 4: ticketing.scan.v1.ScanStreamRequest
+	(*ScanStreamResponse)(nil), // 5: ticketing.scan.v1.ScanStreamResponse
+	(*TicketRevocation)(nil),   // 6: ticketing.scan.v1.TicketRevocation
+}
+var file_scan_v1_scan_proto_depIdxs = []int32{
+	0, // 0: ticketing.scan.v1.ScanStreamRequest.validate:type_name -> ticketing.scan.v1.ValidateRequest
+	2, // 1: ticketing.scan.v1.ScanStreamRequest.check_in:type_name -> ticketing.scan.v1.CheckInRequest
+	1, // 2: ticketing.scan.v1.ScanStreamResponse.validation:type_name -> ticketing.scan.v1.ValidateResponse
+	3, // 3: ticketing.scan.v1.ScanStreamResponse.check_in:type_name -> ticketing.scan.v1.ScanResult
+	6, // 4: ticketing.scan.v1.ScanStreamResponse.revocation:type_name -> ticketing.scan.v1.TicketRevocation
+	0, // 5: ticketing.scan.v1.ScanService.Validate:input_type -> ticketing.scan.v1.ValidateRequest
+	2, // 6: ticketing.scan.v1.ScanService.CheckIn:input_type -> ticketing.scan.v1.CheckInRequest
+	4, // 7: ticketing.scan.v1.ScanService.Scan:input_type -> ticketing.scan.v1.ScanStreamRequest
+	1, // 8: ticketing.scan.v1.ScanService.Validate:output_type -> ticketing.scan.v1.ValidateResponse
+	3, // 9: ticketing.scan.v1.ScanService.CheckIn:output_type -> ticketing.scan.v1.ScanResult
+	5, // 10: ticketing.scan.v1.ScanService.Scan:output_type -> ticketing.scan.v1.ScanStreamResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_scan_v1_scan_proto_init() }
+func file_scan_v1_scan_proto_init() {
+	if File_scan_v1_scan_proto != nil {
+		return
+	}
+	file_scan_v1_scan_proto_msgTypes[4].OneofWrappers = []any{
+		(*ScanStreamRequest_Validate)(nil),
+		(*ScanStreamRequest_CheckIn)(nil),
+	}
+	file_scan_v1_scan_proto_msgTypes[5].OneofWrappers = []any{
+		(*ScanStreamResponse_Validation)(nil),
+		(*ScanStreamResponse_CheckIn)(nil),
+		(*ScanStreamResponse_Revocation)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scan_v1_scan_proto_rawDesc), len(file_scan_v1_scan_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scan_v1_scan_proto_goTypes,
+		DependencyIndexes: file_scan_v1_scan_proto_depIdxs,
+		MessageInfos:      file_scan_v1_scan_proto_msgTypes,
+	}.Build()
+	File_scan_v1_scan_proto = out.File
+	file_scan_v1_scan_proto_goTypes = nil
+	file_scan_v1_scan_proto_depIdxs = nil
+}
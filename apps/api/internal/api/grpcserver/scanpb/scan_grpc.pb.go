@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: scan/v1/scan.proto
+
+package scanpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScanService_Validate_FullMethodName = "/ticketing.scan.v1.ScanService/Validate"
+	ScanService_CheckIn_FullMethodName  = "/ticketing.scan.v1.ScanService/CheckIn"
+	ScanService_Scan_FullMethodName     = "/ticketing.scan.v1.ScanService/Scan"
+)
+
+// ScanServiceClient is the client API for ScanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScanService is the high-throughput scanning API for gate devices.
+//
+// Calls are authenticated with the same JWT as the REST API ("authorization: Bearer <token>"
+// metadata) and require the checkin.create permission. Registered devices also send their
+// device token as "x-device-token" metadata.
+type ScanServiceClient interface {
+	// Validate checks a QR code without checking it in.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// CheckIn admits a ticket at a gate.
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*ScanResult, error)
+	// Scan keeps a stream open for a device: it pushes scans and receives their results,
+	// plus ticket revocation updates as tickets are put on hold or released.
+	Scan(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ScanStreamRequest, ScanStreamResponse], error)
+}
+
+type scanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScanServiceClient(cc grpc.ClientConnInterface) ScanServiceClient {
+	return &scanServiceClient{cc}
+}
+
+func (c *scanServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, ScanService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scanServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*ScanResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResult)
+	err := c.cc.Invoke(ctx, ScanService_CheckIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scanServiceClient) Scan(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ScanStreamRequest, ScanStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScanService_ServiceDesc.Streams[0], ScanService_Scan_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ScanStreamRequest, ScanStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScanService_ScanClient = grpc.BidiStreamingClient[ScanStreamRequest, ScanStreamResponse]
+
+// ScanServiceServer is the server API for ScanService service.
+// All implementations must embed UnimplementedScanServiceServer
+// for forward compatibility.
+//
+// ScanService is the high-throughput scanning API for gate devices.
+//
+// Calls are authenticated with the same JWT as the REST API ("authorization: Bearer <token>"
+// metadata) and require the checkin.create permission. Registered devices also send their
+// device token as "x-device-token" metadata.
+type ScanServiceServer interface {
+	// Validate checks a QR code without checking it in.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// CheckIn admits a ticket at a gate.
+	CheckIn(context.Context, *CheckInRequest) (*ScanResult, error)
+	// Scan keeps a stream open for a device: it pushes scans and receives their results,
+	// plus ticket revocation updates as tickets are put on hold or released.
+	Scan(grpc.BidiStreamingServer[ScanStreamRequest, ScanStreamResponse]) error
+	mustEmbedUnimplementedScanServiceServer()
+}
+
+// UnimplementedScanServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScanServiceServer struct{}
+
+func (UnimplementedScanServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedScanServiceServer) CheckIn(context.Context, *CheckInRequest) (*ScanResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedScanServiceServer) Scan(grpc.BidiStreamingServer[ScanStreamRequest, ScanStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (UnimplementedScanServiceServer) mustEmbedUnimplementedScanServiceServer() {}
+func (UnimplementedScanServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeScanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScanServiceServer will
+// result in compilation errors.
+type UnsafeScanServiceServer interface {
+	mustEmbedUnimplementedScanServiceServer()
+}
+
+func RegisterScanServiceServer(s grpc.ServiceRegistrar, srv ScanServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScanServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScanService_ServiceDesc, srv)
+}
+
+func _ScanService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScanServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScanService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScanServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScanService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScanServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScanService_CheckIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScanServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScanService_Scan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ScanServiceServer).Scan(&grpc.GenericServerStream[ScanStreamRequest, ScanStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScanService_ScanServer = grpc.BidiStreamingServer[ScanStreamRequest, ScanStreamResponse]
+
+// ScanService_ServiceDesc is the grpc.ServiceDesc for ScanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ticketing.scan.v1.ScanService",
+	HandlerType: (*ScanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _ScanService_Validate_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _ScanService_CheckIn_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Scan",
+			Handler:       _ScanService_Scan_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "scan/v1/scan.proto",
+}
@@ -0,0 +1,46 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/grpcserver/scanpb"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// NewServer builds the gRPC server for gate devices; every call is authenticated like the REST
+// check-in routes (JWT, checkin.create permission, also from roles bound within events, and the
+// optional device token)
+func NewServer(
+	scanServer *ScanServer,
+	jwtManager *jwt.JWTManager,
+	permissions PermissionResolver,
+	deviceRepo gatedevicerepo.Repository,
+	eventPermissions EventPermissionResolver,
+) *grpc.Server {
+	auth := &authenticator{
+		jwtManager:       jwtManager,
+		permissions:      permissions,
+		deviceRepo:       deviceRepo,
+		eventPermissions: eventPermissions,
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+		// Gate devices on venue Wi-Fi drop silently; ping idle streams so dead ones are cleaned up
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+	scanpb.RegisterScanServiceServer(server, scanServer)
+
+	return server
+}
@@ -0,0 +1,189 @@
+package apikey
+
+import (
+	stderrors "errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	apikeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	apiKeyService *apikeyservice.Service
+}
+
+func NewHandler(apiKeyService *apikeyservice.Service) *Handler {
+	return &Handler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// List returns a paginated list of API keys
+// GET /api/v1/admin/api-keys
+func (h *Handler) List(c *gin.Context) {
+	var req apikey.ListAPIKeysRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	keys, pagination, err := h.apiKeyService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, keys, meta)
+}
+
+// Create creates an API key; the key is only returned in this response
+// POST /api/v1/admin/api-keys
+func (h *Handler) Create(c *gin.Context) {
+	var req apikey.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	if req.UserID != "" && !middleware.AuthorizeOrganizationResource(c, organization.ResourceUser, req.UserID) {
+		return
+	}
+
+	created, err := h.apiKeyService.CreateWithAudit(c, &req, userIDStr)
+	if err != nil {
+		var permissionErr *apikeyservice.PermissionError
+		switch {
+		case stderrors.As(err, &permissionErr) && permissionErr.Err == apikeyservice.ErrPermissionNotFound:
+			errors.NotFoundResponse(c, "permission", permissionErr.Code)
+		case stderrors.As(err, &permissionErr):
+			errors.ErrorResponse(c, "API_KEY_PERMISSION_NOT_GRANTED", map[string]interface{}{
+				"permission": permissionErr.Code,
+			}, nil)
+		case err == apikeyservice.ErrOwnerNotFound:
+			errors.ErrorResponse(c, "USER_NOT_FOUND", map[string]interface{}{
+				"user_id": req.UserID,
+			}, nil)
+		case err == apikeyservice.ErrOwnerInactive:
+			errors.ErrorResponse(c, "API_KEY_OWNER_INACTIVE", nil, nil)
+		case err == apikeyservice.ErrInvalidExpiry:
+			errors.ErrorResponse(c, "API_KEY_EXPIRY_INVALID", nil, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
+	meta := &response.Meta{
+		CreatedBy: userIDStr,
+	}
+	response.SuccessResponseCreated(c, created, meta)
+}
+
+// GetByID returns an API key
+// GET /api/v1/admin/api-keys/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	key, err := h.apiKeyService.GetByID(id)
+	if err != nil {
+		if err == apikeyservice.ErrAPIKeyNotFound {
+			errors.NotFoundResponse(c, "api key", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, key, meta)
+}
+
+// Revoke revokes an API key
+// POST /api/v1/admin/api-keys/:id/revoke
+func (h *Handler) Revoke(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	key, err := h.apiKeyService.RevokeWithAudit(c, id, userIDStr)
+	if err != nil {
+		if err == apikeyservice.ErrAPIKeyNotFound {
+			errors.NotFoundResponse(c, "api key", id)
+			return
+		}
+		if err == apikeyservice.ErrAPIKeyAlreadyRevoked {
+			errors.ErrorResponse(c, "API_KEY_ALREADY_REVOKED", map[string]interface{}{
+				"api_key_id": id,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, key, meta)
+}
+
+// ListCalls returns the audit trail of requests made with an API key
+// GET /api/v1/admin/api-keys/:id/calls
+func (h *Handler) ListCalls(c *gin.Context) {
+	id := c.Param("id")
+
+	var req apikey.ListAPIKeyCallsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	calls, pagination, err := h.apiKeyService.ListCalls(id, &req)
+	if err != nil {
+		if err == apikeyservice.ErrAPIKeyNotFound {
+			errors.NotFoundResponse(c, "api key", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, calls, meta)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"net/http"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/attendee"
 	attendeeservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/attendee"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
@@ -35,7 +36,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	attendees, paginationMeta, err := h.attendeeService.List(&req)
+	attendees, paginationMeta, err := h.attendeeService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -66,7 +67,7 @@ func (h *Handler) GetStatistics(c *gin.Context) {
 		return
 	}
 
-	statistics, err := h.attendeeService.GetStatistics(&req)
+	statistics, err := h.attendeeService.GetStatistics(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -89,7 +90,7 @@ func (h *Handler) Export(c *gin.Context) {
 		return
 	}
 
-	csvData, err := h.attendeeService.Export(&req)
+	csvData, err := h.attendeeService.Export(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -1,12 +1,17 @@
 package auth
 
 import (
+	stderrors "errors"
+	"math"
+	"strconv"
+
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
 	authservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/auth"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 type Handler struct {
@@ -21,7 +26,7 @@ func NewHandler(authService *authservice.Service) *Handler {
 
 // Login handles login request
 // @Summary Login user
-// @Description Authenticate user and return JWT tokens
+// @Description Authenticate user and return JWT tokens, or a two-factor challenge (two_factor) to complete via /auth/2fa/verify
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -29,6 +34,7 @@ func NewHandler(authService *authservice.Service) *Handler {
 // @Success 200 {object} response.APIResponse
 // @Failure 400 {object} response.APIResponse
 // @Failure 401 {object} response.APIResponse
+// @Failure 429 {object} response.APIResponse
 // @Router /api/v1/auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var req auth.LoginRequest
@@ -42,8 +48,11 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	loginResponse, err := h.authService.Login(&req)
+	loginResponse, err := h.authService.Login(&req, clientInfo(c))
 	if err != nil {
+		if handleLoginBlockedError(c, err) {
+			return
+		}
 		if err == authservice.ErrInvalidCredentials {
 			errors.ErrorResponse(c, "INVALID_CREDENTIALS", nil, nil)
 			return
@@ -91,7 +100,7 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	registerResponse, err := h.authService.Register(&req)
+	registerResponse, err := h.authService.Register(&req, clientInfo(c))
 	if err != nil {
 		switch err {
 		case authservice.ErrPasswordMismatch:
@@ -115,18 +124,16 @@ func (h *Handler) Register(c *gin.Context) {
 
 // RefreshToken handles refresh token request
 // @Summary Refresh access token
-// @Description Refresh access token using refresh token
+// @Description Exchange a refresh token for new tokens. The refresh token is rotated; reusing an old one revokes the session
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body map[string]string true "Refresh token"
+// @Param request body auth.RefreshTokenRequest true "Refresh token"
 // @Success 200 {object} response.APIResponse
 // @Failure 401 {object} response.APIResponse
 // @Router /api/v1/auth/refresh [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
-	var req struct {
-		RefreshToken string `json:"refresh_token" binding:"required"`
-	}
+	var req auth.RefreshTokenRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
@@ -137,13 +144,24 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	loginResponse, err := h.authService.RefreshToken(req.RefreshToken)
+	loginResponse, err := h.authService.RefreshToken(req.RefreshToken, clientInfo(c))
 	if err != nil {
-		if err == authservice.ErrUserNotFound {
+		switch err {
+		case authservice.ErrUserNotFound:
 			errors.ErrorResponse(c, "USER_NOT_FOUND", nil, nil)
-			return
+		case authservice.ErrUserInactive:
+			errors.ErrorResponse(c, "ACCOUNT_DISABLED", map[string]interface{}{
+				"reason": "User account is inactive",
+			}, nil)
+		case authservice.ErrRefreshTokenExpired:
+			errors.ErrorResponse(c, "REFRESH_TOKEN_EXPIRED", nil, nil)
+		case authservice.ErrRefreshTokenReused:
+			errors.ErrorResponse(c, "REFRESH_TOKEN_REUSED", nil, nil)
+		case authservice.ErrRefreshTokenInvalid:
+			errors.ErrorResponse(c, "REFRESH_TOKEN_INVALID", nil, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
 		}
-		errors.ErrorResponse(c, "REFRESH_TOKEN_INVALID", nil, nil)
 		return
 	}
 
@@ -152,18 +170,277 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 
 // Logout handles logout request
 // @Summary Logout user
-// @Description Logout user (invalidate token on client side)
+// @Description Revoke the current session; its refresh token and access tokens stop working
 // @Tags auth
 // @Security BearerAuth
 // @Produce json
 // @Success 204
 // @Router /api/v1/auth/logout [post]
 func (h *Handler) Logout(c *gin.Context) {
-	// In a stateless JWT system, logout is handled client-side
-	// Server can maintain a blacklist if needed
+	if err := h.authService.Logout(c.GetString("user_id"), c.GetString("session_id")); err != nil {
+		if err == authservice.ErrSessionNotFound {
+			response.SuccessResponseNoContent(c)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// LogoutAll handles log out of all devices
+// @Summary Logout all devices
+// @Description Revoke every session of the current user, including the current one
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.APIResponse
+// @Router /api/v1/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	revoked, err := h.authService.LogoutAll(c.GetString("user_id"))
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponse(c, map[string]interface{}{
+		"revoked_sessions": revoked,
+	}, &response.Meta{})
+}
+
+// ListSessions lists the current user's active sessions
+// @Summary List active sessions
+// @Description List active login sessions (device, IP, created and last used time) of the current user
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.APIResponse
+// @Router /api/v1/auth/sessions [get]
+func (h *Handler) ListSessions(c *gin.Context) {
+	sessions, err := h.authService.ListSessions(c.GetString("user_id"), c.GetString("session_id"))
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, sessions, meta)
+}
+
+// RevokeSession revokes one of the current user's sessions
+// @Summary Revoke session
+// @Description Revoke one active session of the current user, e.g. a lost device
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	sessionID := c.Param("id")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.GetString("user_id"), sessionID); err != nil {
+		if err == authservice.ErrSessionNotFound {
+			errors.NotFoundResponse(c, "session", sessionID)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// ListLoginHistory lists the current user's login attempts
+// @Summary List login history
+// @Description List the current user's successful and failed login attempts with IP address and user agent, newest first
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page"
+// @Param result query string false "success or failure"
+// @Success 200 {object} response.APIResponse
+// @Router /api/v1/auth/login-history [get]
+func (h *Handler) ListLoginHistory(c *gin.Context) {
+	var req auth.ListLoginHistoryRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	attempts, pagination, err := h.authService.ListLoginHistory(c.GetString("user_id"), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, attempts, meta)
+}
+
+// ForgotPassword handles forgot password request
+// @Summary Forgot password
+// @Description Email a password reset link. The response is the same whether or not the email is registered
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body auth.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} response.APIResponse
+// @Failure 429 {object} response.APIResponse
+// @Router /api/v1/auth/forgot-password [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var req auth.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	if err := h.authService.ForgotPassword(&req); err != nil {
+		if err == authservice.ErrTooManyEmailRequests {
+			errors.ErrorResponse(c, "RATE_LIMIT_EXCEEDED", map[string]interface{}{
+				"email": req.Email,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponse(c, map[string]interface{}{
+		"message": "If the email is registered, a password reset link has been sent",
+	}, &response.Meta{})
+}
+
+// ResetPassword handles reset password request
+// @Summary Reset password
+// @Description Set a new password with a reset token. All sessions of the user are revoked
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body auth.ResetPasswordRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/auth/reset-password [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req auth.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	if err := h.authService.ResetPassword(&req); err != nil {
+		switch err {
+		case authservice.ErrPasswordMismatch:
+			errors.ErrorResponse(c, "VALIDATION_ERROR", map[string]interface{}{
+				"confirm_password": "Passwords do not match",
+			}, nil)
+		case authservice.ErrUserTokenInvalid:
+			errors.ErrorResponse(c, "USER_TOKEN_INVALID", nil, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
 	response.SuccessResponseNoContent(c)
 }
 
+// VerifyEmail handles verify email request
+// @Summary Verify email
+// @Description Mark the user's email as verified with the token from the verification email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body auth.VerifyEmailRequest true "Verification token"
+// @Success 204
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/auth/verify-email [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req auth.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	if err := h.authService.VerifyEmail(&req); err != nil {
+		if err == authservice.ErrUserTokenInvalid {
+			errors.ErrorResponse(c, "USER_TOKEN_INVALID", nil, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// ResendVerification handles resend verification email request
+// @Summary Resend verification email
+// @Description Email a new verification link to the current user; earlier links stop working
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 204
+// @Failure 409 {object} response.APIResponse
+// @Failure 429 {object} response.APIResponse
+// @Router /api/v1/auth/verify-email/resend [post]
+func (h *Handler) ResendVerification(c *gin.Context) {
+	if err := h.authService.ResendVerification(c.GetString("user_id")); err != nil {
+		switch err {
+		case authservice.ErrUserNotFound:
+			errors.ErrorResponse(c, "USER_NOT_FOUND", nil, nil)
+		case authservice.ErrEmailAlreadyVerified:
+			errors.ErrorResponse(c, "EMAIL_ALREADY_VERIFIED", nil, nil)
+		case authservice.ErrTooManyEmailRequests:
+			errors.ErrorResponse(c, "RATE_LIMIT_EXCEEDED", nil, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// clientInfo describes the client making the request for session bookkeeping
+func clientInfo(c *gin.Context) *auth.ClientInfo {
+	return &auth.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 // GetUserMenusAndPermissions returns menus and permissions for the current user
 // @Summary Get user menus and permissions
 // @Description Get menus and permissions based on the logged-in user's role
@@ -205,3 +482,23 @@ func (h *Handler) GetUserMenusAndPermissions(c *gin.Context) {
 
 
 
+
+// handleLoginBlockedError responds to a login refused by the lockout or throttle, telling the
+// client how long to wait. It reports whether err was such a refusal.
+func handleLoginBlockedError(c *gin.Context, err error) bool {
+	var blocked *authservice.LoginBlockedError
+	if !stderrors.As(err, &blocked) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(blocked.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	code := "LOGIN_THROTTLED"
+	if stderrors.Is(err, authservice.ErrAccountLocked) {
+		code = "ACCOUNT_LOCKED"
+	}
+	errors.ErrorResponse(c, code, map[string]interface{}{
+		"retry_after_seconds": retryAfter,
+	}, nil)
+	return true
+}
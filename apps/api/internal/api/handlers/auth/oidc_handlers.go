@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	authservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ListOIDCProviders lists the social login providers
+// @Summary List social login providers
+// @Description List the OpenID Connect providers buyers can log in with
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.APIResponse
+// @Router /api/v1/auth/oidc/providers [get]
+func (h *Handler) ListOIDCProviders(c *gin.Context) {
+	response.SuccessResponse(c, h.authService.ListOIDCProviders(), &response.Meta{})
+}
+
+// StartOIDCLogin starts a social login
+// @Summary Start social login
+// @Description Start an authorization code login with PKCE. Send the browser to authorization_url; the provider redirects back to the frontend callback with code and state
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Failure 502 {object} response.APIResponse
+// @Router /api/v1/auth/oidc/{provider}/start [post]
+func (h *Handler) StartOIDCLogin(c *gin.Context) {
+	startResponse, err := h.authService.StartOIDCLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		handleOIDCError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, startResponse, &response.Meta{})
+}
+
+// OIDCCallback completes a social login
+// @Summary Complete social login
+// @Description Exchange the code and state the provider redirected back with for tokens. Accounts are linked by verified email; unknown emails get a new buyer account. When 2FA is enabled the response carries a challenge like /auth/login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param request body auth.OIDCCallbackRequest true "Code and state from the provider redirect"
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Failure 401 {object} response.APIResponse
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/auth/oidc/{provider}/callback [post]
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	var req auth.OIDCCallbackRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	loginResponse, err := h.authService.CompleteOIDCLogin(c.Request.Context(), c.Param("provider"), &req, clientInfo(c))
+	if err != nil {
+		handleOIDCError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, loginResponse, &response.Meta{})
+}
+
+func handleOIDCError(c *gin.Context, err error) {
+	if handleLoginBlockedError(c, err) {
+		return
+	}
+	switch err {
+	case authservice.ErrOIDCProviderNotFound:
+		errors.ErrorResponse(c, "OIDC_PROVIDER_NOT_FOUND", map[string]interface{}{
+			"provider": c.Param("provider"),
+		}, nil)
+	case authservice.ErrOIDCProviderUnavailable:
+		errors.ErrorResponse(c, "OIDC_PROVIDER_UNAVAILABLE", nil, nil)
+	case authservice.ErrOIDCStateInvalid:
+		errors.ErrorResponse(c, "OIDC_STATE_INVALID", nil, nil)
+	case authservice.ErrOIDCLoginFailed:
+		errors.ErrorResponse(c, "OIDC_LOGIN_FAILED", nil, nil)
+	case authservice.ErrOIDCEmailNotVerified:
+		errors.ErrorResponse(c, "OIDC_EMAIL_NOT_VERIFIED", nil, nil)
+	case authservice.ErrOIDCRoleNotAllowed:
+		errors.ErrorResponse(c, "OIDC_ROLE_NOT_ALLOWED", nil, nil)
+	case authservice.ErrUserInactive:
+		errors.ErrorResponse(c, "ACCOUNT_DISABLED", map[string]interface{}{
+			"reason": "User account is inactive",
+		}, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
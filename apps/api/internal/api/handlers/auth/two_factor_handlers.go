@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	authservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// VerifyTwoFactor handles the second step of a two-factor login
+// @Summary Complete two-factor login
+// @Description Exchange a login challenge and a TOTP or recovery code for tokens. When enrolling during login, the response also contains the recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body auth.VerifyTwoFactorRequest true "Challenge token and code"
+// @Success 200 {object} response.APIResponse
+// @Failure 401 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/verify [post]
+func (h *Handler) VerifyTwoFactor(c *gin.Context) {
+	var req auth.VerifyTwoFactorRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	loginResponse, err := h.authService.VerifyTwoFactor(&req, clientInfo(c))
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, loginResponse, &response.Meta{})
+}
+
+// EnrollTwoFactorChallenge handles 2FA enrollment during a login that requires it
+// @Summary Enroll two-factor during login
+// @Description Provision a TOTP secret for a login challenge with method ENROLL; confirm it with /auth/2fa/verify
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body auth.TwoFactorChallengeRequest true "Challenge token"
+// @Success 200 {object} response.APIResponse
+// @Failure 401 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/enroll [post]
+func (h *Handler) EnrollTwoFactorChallenge(c *gin.Context) {
+	var req auth.TwoFactorChallengeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	setup, err := h.authService.EnrollTwoFactorChallenge(&req)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, setup, &response.Meta{})
+}
+
+// GetTwoFactorStatus returns the current user's two-factor status
+// @Summary Two-factor status
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.APIResponse
+// @Router /api/v1/auth/2fa [get]
+func (h *Handler) GetTwoFactorStatus(c *gin.Context) {
+	status, err := h.authService.GetTwoFactorStatus(c.GetString("user_id"))
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, status, &response.Meta{})
+}
+
+// SetupTwoFactor starts TOTP enrollment for the current user
+// @Summary Set up two-factor
+// @Description Provision a new TOTP secret and provisioning URI; the enrollment is enabled by /auth/2fa/confirm
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.APIResponse
+// @Failure 409 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/setup [post]
+func (h *Handler) SetupTwoFactor(c *gin.Context) {
+	setup, err := h.authService.SetupTwoFactor(c.GetString("user_id"))
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, setup, &response.Meta{})
+}
+
+// ConfirmTwoFactor enables the current user's pending TOTP enrollment
+// @Summary Confirm two-factor
+// @Description Enable two-factor with a code from the authenticator app; returns recovery codes, shown only once
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body auth.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} response.APIResponse
+// @Failure 401 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/confirm [post]
+func (h *Handler) ConfirmTwoFactor(c *gin.Context) {
+	var req auth.TwoFactorCodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	codes, err := h.authService.ConfirmTwoFactor(c.GetString("user_id"), &req)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, codes, &response.Meta{})
+}
+
+// DisableTwoFactor disables two-factor for the current user
+// @Summary Disable two-factor
+// @Description Remove the TOTP enrollment and recovery codes. Not allowed when the user's role requires two-factor
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body auth.DisableTwoFactorRequest true "Password and TOTP or recovery code"
+// @Success 204
+// @Failure 403 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/disable [post]
+func (h *Handler) DisableTwoFactor(c *gin.Context) {
+	var req auth.DisableTwoFactorRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	if err := h.authService.DisableTwoFactor(c.GetString("user_id"), &req); err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// RegenerateRecoveryCodes replaces the current user's recovery codes
+// @Summary Regenerate recovery codes
+// @Description Generate new recovery codes; the previous ones stop working
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body auth.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} response.APIResponse
+// @Failure 401 {object} response.APIResponse
+// @Router /api/v1/auth/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req auth.TwoFactorCodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	codes, err := h.authService.RegenerateRecoveryCodes(c.GetString("user_id"), &req)
+	if err != nil {
+		handleTwoFactorError(c, err)
+		return
+	}
+
+	response.SuccessResponse(c, codes, &response.Meta{})
+}
+
+// handleTwoFactorError maps two-factor service errors to API errors
+func handleTwoFactorError(c *gin.Context, err error) {
+	if handleLoginBlockedError(c, err) {
+		return
+	}
+	switch err {
+	case authservice.ErrTwoFactorChallengeInvalid:
+		errors.ErrorResponse(c, "TWO_FACTOR_CHALLENGE_INVALID", nil, nil)
+	case authservice.ErrTwoFactorInvalidCode:
+		errors.ErrorResponse(c, "TWO_FACTOR_INVALID_CODE", nil, nil)
+	case authservice.ErrTwoFactorAlreadyEnabled:
+		errors.ErrorResponse(c, "TWO_FACTOR_ALREADY_ENABLED", nil, nil)
+	case authservice.ErrTwoFactorNotEnabled:
+		errors.ErrorResponse(c, "TWO_FACTOR_NOT_ENABLED", nil, nil)
+	case authservice.ErrTwoFactorNotSetUp:
+		errors.ErrorResponse(c, "TWO_FACTOR_NOT_SET_UP", nil, nil)
+	case authservice.ErrTwoFactorRequired:
+		errors.ErrorResponse(c, "TWO_FACTOR_REQUIRED", map[string]interface{}{
+			"reason": "Two-factor authentication is required for your role",
+		}, nil)
+	case authservice.ErrInvalidCredentials:
+		errors.ErrorResponse(c, "INVALID_CREDENTIALS", nil, nil)
+	case authservice.ErrUserInactive:
+		errors.ErrorResponse(c, "ACCOUNT_DISABLED", map[string]interface{}{
+			"reason": "User account is inactive",
+		}, nil)
+	case authservice.ErrUserNotFound:
+		errors.ErrorResponse(c, "USER_NOT_FOUND", nil, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
@@ -0,0 +1,413 @@
+package ballot
+
+import (
+	stderrors "errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	ballotservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ballot"
+	orderservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	ballotService *ballotservice.Service
+}
+
+func NewHandler(ballotService *ballotservice.Service) *Handler {
+	return &Handler{
+		ballotService: ballotService,
+	}
+}
+
+// List lists ballots with pagination and filters
+// GET /api/v1/admin/ballots
+func (h *Handler) List(c *gin.Context) {
+	var req ballot.ListBallotsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	ballots, pagination, err := h.ballotService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, ballots, meta)
+}
+
+// GetByID gets a ballot by ID
+// GET /api/v1/ballots/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	b, err := h.ballotService.GetByID(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, b, meta)
+}
+
+// Create creates a ballot for a ticket category
+// POST /api/v1/admin/ballots
+func (h *Handler) Create(c *gin.Context) {
+	var req ballot.CreateBallotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceTicketCategory, req.TicketCategoryID) {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	b, err := h.ballotService.Create(&req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, b, meta)
+}
+
+// Update updates a ballot before its draw
+// PUT /api/v1/admin/ballots/:id
+func (h *Handler) Update(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req ballot.UpdateBallotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	b, err := h.ballotService.Update(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, b, meta)
+}
+
+// Draw runs the seeded draw for a ballot
+// POST /api/v1/admin/ballots/:id/draw
+func (h *Handler) Draw(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	// Body is optional: without a seed one is generated and recorded
+	var req ballot.DrawBallotRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				errors.HandleValidationError(c, validationErrors)
+			} else {
+				errors.InvalidRequestBodyResponse(c)
+			}
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	result, err := h.ballotService.Draw(id, req.Seed, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, result, meta)
+}
+
+// VerifyDraw re-runs a ballot's draw from its recorded seed
+// GET /api/v1/admin/ballots/:id/verify
+func (h *Handler) VerifyDraw(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	verification, err := h.ballotService.VerifyDraw(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, verification, meta)
+}
+
+// Close closes a ballot and returns its category to public sale
+// POST /api/v1/admin/ballots/:id/close
+func (h *Handler) Close(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	b, err := h.ballotService.Close(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, b, meta)
+}
+
+// ListEntries lists entries of a ballot
+// GET /api/v1/admin/ballots/:id/entries
+func (h *Handler) ListEntries(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req ballot.ListBallotEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	entries, pagination, err := h.ballotService.ListEntries(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, entries, meta)
+}
+
+// Enter registers the current user's entry in a ballot
+// POST /api/v1/ballots/:id/entries
+func (h *Handler) Enter(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ballot.CreateBallotEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	entry, err := h.ballotService.Enter(id, &req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, entry, meta)
+}
+
+// Withdraw removes the current user's entry from a ballot
+// DELETE /api/v1/ballots/:id/entries/me
+func (h *Handler) Withdraw(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ballotService.Withdraw(id, userIDStr); err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// GetMyEntries lists the current user's ballot entries (with active claim tokens)
+// GET /api/v1/ballot-entries
+func (h *Handler) GetMyEntries(c *gin.Context) {
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.ballotService.GetMyEntries(userIDStr)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, entries, meta)
+}
+
+// Claim creates an order from a winning entry's claim token; pay it via the order payment endpoint
+// POST /api/v1/ballot-claims/:token
+func (h *Handler) Claim(c *gin.Context) {
+	token := c.Param("token")
+	if token == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "token",
+		}, nil)
+		return
+	}
+
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	createdOrder, err := h.ballotService.Claim(token, userIDStr, c.GetHeader("X-Idempotency-Key"))
+	if err != nil {
+		h.handleServiceError(c, err, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, createdOrder, meta)
+}
+
+// currentUserID reads the authenticated user ID, writing an error response when missing
+func currentUserID(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		errors.UnauthorizedResponse(c, "user not authenticated")
+		return "", false
+	}
+	userIDStr, ok := userID.(string)
+	if !ok {
+		errors.UnauthorizedResponse(c, "invalid user id")
+		return "", false
+	}
+	return userIDStr, true
+}
+
+// handleServiceError maps ballot (and claim order) service errors to API errors
+func (h *Handler) handleServiceError(c *gin.Context, err error, id string) {
+	switch {
+	case stderrors.Is(err, ballotservice.ErrBallotNotFound):
+		errors.NotFoundResponse(c, "ballot", id)
+	case stderrors.Is(err, ballotservice.ErrEntryNotFound):
+		errors.NotFoundResponse(c, "ballot_entry", "")
+	case stderrors.Is(err, ballotservice.ErrClaimNotFound):
+		errors.NotFoundResponse(c, "ballot_claim", "")
+	case stderrors.Is(err, ballotservice.ErrTicketCategoryNotFound):
+		errors.ErrorResponse(c, "TICKET_CATEGORY_NOT_FOUND", nil, nil)
+	case stderrors.Is(err, ballotservice.ErrScheduleNotFound),
+		stderrors.Is(err, orderservice.ErrScheduleNotFound):
+		errors.ErrorResponse(c, "SCHEDULE_NOT_FOUND", nil, nil)
+	case stderrors.Is(err, ballotservice.ErrBallotExists),
+		stderrors.Is(err, ballotservice.ErrEntryExists):
+		errors.ErrorResponse(c, "CONFLICT", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	case stderrors.Is(err, ballotservice.ErrBallotNotOpen),
+		stderrors.Is(err, ballotservice.ErrBallotNotDrawn),
+		stderrors.Is(err, ballotservice.ErrEntryWindowStillOpen):
+		errors.ErrorResponse(c, "BALLOT_NOT_OPEN", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	case stderrors.Is(err, ballotservice.ErrEntryWindowClosed):
+		errors.ErrorResponse(c, "BALLOT_ENTRY_CLOSED", nil, nil)
+	case stderrors.Is(err, ballotservice.ErrClaimExpired),
+		stderrors.Is(err, orderservice.ErrBallotClaimExpired):
+		errors.ErrorResponse(c, "BALLOT_CLAIM_EXPIRED", nil, nil)
+	case stderrors.Is(err, orderservice.ErrBallotClaimInvalid):
+		errors.ErrorResponse(c, "BALLOT_CLAIM_INVALID", nil, nil)
+	case stderrors.Is(err, orderservice.ErrInsufficientQuota):
+		errors.ErrorResponse(c, "INSUFFICIENT_QUOTA", nil, nil)
+	case stderrors.Is(err, orderservice.ErrInsufficientSeats):
+		errors.ErrorResponse(c, "INSUFFICIENT_SEATS", nil, nil)
+	case stderrors.Is(err, orderservice.ErrEventNotAvailable):
+		errors.ErrorResponse(c, "EVENT_NOT_AVAILABLE", nil, nil)
+	case stderrors.Is(err, orderservice.ErrSchedulePassed):
+		errors.ErrorResponse(c, "SCHEDULE_PASSED", nil, nil)
+	case stderrors.Is(err, ballotservice.ErrInvalidEntryWindow),
+		stderrors.Is(err, ballotservice.ErrQuantityExceedsLimit):
+		errors.ErrorResponse(c, "VALIDATION_ERROR", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
@@ -1,8 +1,13 @@
 package checkin
 
 import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
 	checkinservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/checkin"
+	scanlogservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/scan_log"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -11,11 +16,13 @@ import (
 
 type Handler struct {
 	checkInService *checkinservice.Service
+	scanLogService *scanlogservice.Service
 }
 
-func NewHandler(checkInService *checkinservice.Service) *Handler {
+func NewHandler(checkInService *checkinservice.Service, scanLogService *scanlogservice.Service) *Handler {
 	return &Handler{
 		checkInService: checkInService,
+		scanLogService: scanLogService,
 	}
 }
 
@@ -32,7 +39,13 @@ func (h *Handler) ValidateQRCode(c *gin.Context) {
 		return
 	}
 
-	result, err := h.checkInService.ValidateQRCode(req.QRCode)
+	if !authorizeScan(c, req.QRCode, nil) {
+		return
+	}
+
+	startedAt := time.Now()
+	result, err := h.checkInService.ValidateQRCode(req.QRCode, req.ScheduleID)
+	h.scanLogService.RecordValidation(c, req.QRCode, startedAt, result, err)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -85,11 +98,27 @@ func (h *Handler) CheckIn(c *gin.Context) {
 		return
 	}
 
+	// Scans from a registered device are attributed to it and default to its gate
+	if deviceID := c.GetString("device_id"); deviceID != "" {
+		req.DeviceID = &deviceID
+		if req.GateID == nil {
+			if gateID := c.GetString("device_gate_id"); gateID != "" {
+				req.GateID = &gateID
+			}
+		}
+	}
+
+	if !authorizeScan(c, req.QRCode, req.GateID) {
+		return
+	}
+
 	// Get IP address and user agent
 	ipAddress := c.ClientIP()
 	userAgent := c.GetHeader("User-Agent")
 
+	startedAt := time.Now()
 	result, err := h.checkInService.CheckIn(&req, userIDStr, ipAddress, userAgent)
+	h.scanLogService.RecordScan(c, scanlog.ScanActionEntry, req.QRCode, req.GateID, startedAt, result, err)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -115,6 +144,10 @@ func (h *Handler) CheckIn(c *gin.Context) {
 				"message":  result.Message,
 				"check_in": result.CheckIn,
 			}, nil)
+		case "ALREADY_INSIDE", "REENTRY_NOT_ALLOWED", "WRONG_SCHEDULE", "TOO_EARLY", "SCHEDULE_ENDED", "TICKET_BLOCKED", "GATE_CAPACITY_EXCEEDED":
+			errors.ErrorResponse(c, result.ErrorCode, map[string]interface{}{
+				"message": result.Message,
+			}, nil)
 		default:
 			errors.ErrorResponse(c, "CHECK_IN_ERROR", map[string]interface{}{
 				"message": result.Message,
@@ -198,7 +231,7 @@ func (h *Handler) List(c *gin.Context) {
 		req.StaffID = userIDStr
 	}
 
-	checkIns, pagination, err := h.checkInService.List(&req)
+	checkIns, pagination, err := h.checkInService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -377,3 +410,53 @@ func (h *Handler) GetByGateID(c *gin.Context) {
 	meta := &response.Meta{}
 	response.SuccessResponse(c, checkIns, meta)
 }
+
+// Void voids a mistaken check-in and reverts the ticket to PAID (supervisor only)
+// POST /api/v1/check-ins/:id/void
+func (h *Handler) Void(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req checkin.VoidCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	checkIn, err := h.checkInService.VoidWithAudit(c, id, &req, userIDStr)
+	if err != nil {
+		if err == checkinservice.ErrCheckInNotFound {
+			errors.NotFoundResponse(c, "check-in", id)
+			return
+		}
+		if err == checkinservice.ErrCheckInAlreadyVoided {
+			errors.ErrorResponse(c, "CHECK_IN_ALREADY_VOIDED", map[string]interface{}{
+				"check_in_id": id,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, checkIn, meta)
+}
@@ -0,0 +1,145 @@
+package checkin
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// authorizeScan responds with not found when the ticket or gate of a scan belongs to another
+// organization than the caller's
+func authorizeScan(c *gin.Context, qrCode string, gateID *string) bool {
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceQRCode, qrCode) {
+		return false
+	}
+	return gateID == nil || middleware.AuthorizeOrganizationResource(c, organization.ResourceGate, *gateID)
+}
+
+// Exit records an exit scan so the ticket can re-enter later
+// POST /api/v1/check-in/exit
+func (h *Handler) Exit(c *gin.Context) {
+	// Same as check-in: staff members must use the gate-specific endpoint POST /api/v1/gates/:id/exit
+	userRole, _ := c.Get("user_role")
+	userRoleStr, _ := userRole.(string)
+	isAdmin := userRoleStr == "admin" || userRoleStr == "super_admin"
+	if !isAdmin {
+		errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+			"reason":  "Gate exit required",
+			"message": "Gunakan endpoint gate exit: /api/v1/gates/:id/exit",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req checkin.ExitScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if deviceID := c.GetString("device_id"); deviceID != "" {
+		req.DeviceID = &deviceID
+		if req.GateID == nil {
+			if gateID := c.GetString("device_gate_id"); gateID != "" {
+				req.GateID = &gateID
+			}
+		}
+	}
+
+	if !authorizeScan(c, req.QRCode, req.GateID) {
+		return
+	}
+
+	startedAt := time.Now()
+	result, err := h.checkInService.Exit(&req, userIDStr)
+	h.scanLogService.RecordScan(c, scanlog.ScanActionExit, req.QRCode, req.GateID, startedAt, result, err)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	if !result.Success {
+		scanErrorResponse(c, result)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, result, meta)
+}
+
+// scanErrorResponse writes the error response for a failed exit scan
+func scanErrorResponse(c *gin.Context, result *checkin.CheckInResultResponse) {
+	switch result.ErrorCode {
+	case "INVALID_QR_CODE", "NOT_INSIDE":
+		errors.ErrorResponse(c, result.ErrorCode, map[string]interface{}{
+			"message": result.Message,
+		}, nil)
+	default:
+		errors.ErrorResponse(c, "CHECK_IN_ERROR", map[string]interface{}{
+			"message": result.Message,
+		}, nil)
+	}
+}
+
+// GetScansByOrderItemID gets the entry/exit scan history of a ticket
+// GET /api/v1/check-ins/order-item/:order_item_id/scans
+func (h *Handler) GetScansByOrderItemID(c *gin.Context) {
+	orderItemID := c.Param("order_item_id")
+	if orderItemID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "order_item_id",
+		}, nil)
+		return
+	}
+
+	scans, err := h.checkInService.GetScansByOrderItemID(orderItemID)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, scans, meta)
+}
+
+// GetOccupancy gets how many tickets are currently inside, per schedule and per gate
+// GET /api/v1/check-ins/occupancy
+func (h *Handler) GetOccupancy(c *gin.Context) {
+	var req checkin.GetOccupancyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	occupancy, err := h.checkInService.GetOccupancy(&req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, occupancy, meta)
+}
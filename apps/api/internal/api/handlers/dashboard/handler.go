@@ -1,6 +1,7 @@
 package dashboard
 
 import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/dashboard"
 	dashboardservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/dashboard"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
@@ -32,7 +33,7 @@ func (h *Handler) GetDashboardOverview(c *gin.Context) {
 		return
 	}
 
-	overview, err := h.dashboardService.GetDashboardOverview(&filters)
+	overview, err := h.dashboardService.GetDashboardOverview(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -55,7 +56,7 @@ func (h *Handler) GetSalesOverview(c *gin.Context) {
 		return
 	}
 
-	sales, err := h.dashboardService.GetSalesOverview(&filters)
+	sales, err := h.dashboardService.GetSalesOverview(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -78,7 +79,7 @@ func (h *Handler) GetCheckInOverview(c *gin.Context) {
 		return
 	}
 
-	checkIns, err := h.dashboardService.GetCheckInOverview(&filters)
+	checkIns, err := h.dashboardService.GetCheckInOverview(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -101,7 +102,7 @@ func (h *Handler) GetQuotaOverview(c *gin.Context) {
 		return
 	}
 
-	quota, err := h.dashboardService.GetQuotaOverview(&filters)
+	quota, err := h.dashboardService.GetQuotaOverview(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -124,7 +125,7 @@ func (h *Handler) GetGateActivity(c *gin.Context) {
 		return
 	}
 
-	gates, err := h.dashboardService.GetGateActivity(&filters)
+	gates, err := h.dashboardService.GetGateActivity(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -147,7 +148,7 @@ func (h *Handler) GetBuyerList(c *gin.Context) {
 		return
 	}
 
-	buyers, err := h.dashboardService.GetBuyerList(&filters)
+	buyers, err := h.dashboardService.GetBuyerList(middleware.OrganizationScope(c), &filters)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
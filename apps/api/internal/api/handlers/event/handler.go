@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	eventservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/event"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
@@ -64,7 +66,12 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	event, err := h.eventService.Create(&req)
+	organizationID, ok := middleware.OrganizationOwner(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventService.Create(organizationID, &req)
 	if err != nil {
 		if err == eventservice.ErrEventAlreadyExists {
 			errors.ErrorResponse(c, "CONFLICT", map[string]interface{}{
@@ -167,7 +174,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	events, _, paginationMeta, err := h.eventService.List(&req)
+	events, _, paginationMeta, err := h.eventService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -348,7 +355,7 @@ func (h *Handler) ListPublic(c *gin.Context) {
 	// Force status to published for public routes
 	req.Status = event.EventStatusPublished
 
-	events, _, paginationMeta, err := h.eventService.List(&req)
+	events, _, paginationMeta, err := h.eventService.List(organization.AllOrganizations, &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -0,0 +1,150 @@
+package fraud
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
+	fraudservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/fraud"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	fraudService *fraudservice.Service
+}
+
+func NewHandler(fraudService *fraudservice.Service) *Handler {
+	return &Handler{
+		fraudService: fraudService,
+	}
+}
+
+// List lists fraud alerts with pagination and filters
+// GET /api/v1/fraud-alerts
+func (h *Handler) List(c *gin.Context) {
+	var req fraud.ListAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	alerts, pagination, err := h.fraudService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, alerts, meta)
+}
+
+// GetByID gets a fraud alert by ID
+// GET /api/v1/fraud-alerts/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	alert, err := h.fraudService.GetByID(id)
+	if err != nil {
+		if err == fraudservice.ErrAlertNotFound {
+			errors.NotFoundResponse(c, "fraud alert", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, alert, meta)
+}
+
+// BlockTicket blocks the alert's ticket from entry pending review
+// POST /api/v1/fraud-alerts/:id/block
+func (h *Handler) BlockTicket(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	alert, err := h.fraudService.BlockTicketWithAudit(c, id)
+	if err != nil {
+		h.handleReviewError(c, id, err)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, alert, meta)
+}
+
+// Review confirms or dismisses an open fraud alert
+// POST /api/v1/fraud-alerts/:id/review
+func (h *Handler) Review(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req fraud.ReviewAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	alert, err := h.fraudService.ReviewWithAudit(c, id, &req, userIDStr)
+	if err != nil {
+		h.handleReviewError(c, id, err)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, alert, meta)
+}
+
+// handleReviewError maps block/review errors to error responses
+func (h *Handler) handleReviewError(c *gin.Context, id string, err error) {
+	switch err {
+	case fraudservice.ErrAlertNotFound:
+		errors.NotFoundResponse(c, "fraud alert", id)
+	case fraudservice.ErrAlertAlreadyReviewed:
+		errors.ErrorResponse(c, "FRAUD_ALERT_ALREADY_REVIEWED", map[string]interface{}{
+			"fraud_alert_id": id,
+		}, nil)
+	case fraudservice.ErrAlertHasNoTicket:
+		errors.ErrorResponse(c, "FRAUD_ALERT_NO_TICKET", map[string]interface{}{
+			"fraud_alert_id": id,
+		}, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
@@ -0,0 +1,158 @@
+package gate
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	gateservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// EnrollDevice registers a scanner device for a gate and returns its device token (shown once)
+// POST /api/v1/gates/:id/devices
+func (h *Handler) EnrollDevice(c *gin.Context) {
+	gateID := c.Param("id")
+	if gateID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req gate.EnrollDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	enrollment, err := h.gateService.EnrollDevice(gateID, &req, userIDStr)
+	if err != nil {
+		if err == gateservice.ErrGateNotFound {
+			errors.NotFoundResponse(c, "gate", gateID)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, enrollment, meta)
+}
+
+// ListDevices lists scanner devices, e.g. ?online=false for scanners that stopped sending heartbeats
+// GET /api/v1/gates/devices
+func (h *Handler) ListDevices(c *gin.Context) {
+	var req gate.ListDevicesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	// Gate-scoped listing
+	if gateID := c.Param("id"); gateID != "" {
+		req.GateID = gateID
+	}
+
+	devices, pagination, err := h.gateService.ListDevices(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, devices, meta)
+}
+
+// RevokeDevice revokes a scanner device's token
+// POST /api/v1/gates/devices/:device_id/revoke
+func (h *Handler) RevokeDevice(c *gin.Context) {
+	deviceID := c.Param("device_id")
+	if deviceID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "device_id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	device, err := h.gateService.RevokeDevice(deviceID, userIDStr)
+	if err != nil {
+		if err == gateservice.ErrDeviceNotFound {
+			errors.NotFoundResponse(c, "gate device", deviceID)
+			return
+		}
+		if err == gateservice.ErrDeviceAlreadyRevoked {
+			errors.ErrorResponse(c, "DEVICE_REVOKED", map[string]interface{}{
+				"device_id": deviceID,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, device, meta)
+}
+
+// Heartbeat records a scanner device heartbeat (authenticated by the X-Device-Token header)
+// POST /api/v1/gates/devices/heartbeat
+func (h *Handler) Heartbeat(c *gin.Context) {
+	deviceID := c.GetString("device_id")
+	if deviceID == "" {
+		errors.ErrorResponse(c, "DEVICE_NOT_REGISTERED", nil, nil)
+		return
+	}
+
+	var req gate.DeviceHeartbeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	device, err := h.gateService.Heartbeat(deviceID, &req, c.ClientIP())
+	if err != nil {
+		if err == gateservice.ErrDeviceNotFound {
+			errors.NotFoundResponse(c, "gate device", deviceID)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, device, meta)
+}
@@ -1,8 +1,14 @@
 package gate
 
 import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
 	gateservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/gate"
+	scanlogservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/scan_log"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -10,12 +16,14 @@ import (
 )
 
 type Handler struct {
-	gateService *gateservice.Service
+	gateService    *gateservice.Service
+	scanLogService *scanlogservice.Service
 }
 
-func NewHandler(gateService *gateservice.Service) *Handler {
+func NewHandler(gateService *gateservice.Service, scanLogService *scanlogservice.Service) *Handler {
 	return &Handler{
-		gateService: gateService,
+		gateService:    gateService,
+		scanLogService: scanLogService,
 	}
 }
 
@@ -57,7 +65,12 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	createdGate, err := h.gateService.Create(&req)
+	organizationID, ok := middleware.OrganizationOwner(c)
+	if !ok {
+		return
+	}
+
+	createdGate, err := h.gateService.Create(organizationID, &req)
 	if err != nil {
 		if err == gateservice.ErrGateCodeExists {
 			errors.ErrorResponse(c, "CONFLICT", map[string]interface{}{
@@ -152,7 +165,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	gates, pagination, err := h.gateService.List(&req)
+	gates, pagination, err := h.gateService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -254,8 +267,16 @@ func (h *Handler) GateCheckIn(c *gin.Context) {
 		return
 	}
 
-	// Set gate ID from path parameter
+	// The gate is checked by the route; tickets of other organizations are not admitted either
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceQRCode, req.QRCode) {
+		return
+	}
+
+	// Set gate ID from path parameter and the scanning device from the device token
 	req.GateID = gateID
+	if deviceID := c.GetString("device_id"); deviceID != "" {
+		req.DeviceID = &deviceID
+	}
 
 	// Get IP address and user agent
 	ipAddress := c.ClientIP()
@@ -266,7 +287,9 @@ func (h *Handler) GateCheckIn(c *gin.Context) {
 	userRoleStr, _ := userRole.(string)
 	isAdmin := userRoleStr == "admin" || userRoleStr == "super_admin"
 
+	startedAt := time.Now()
 	result, err := h.gateService.GateCheckIn(&req, userIDStr, ipAddress, userAgent, isAdmin)
+	h.scanLogService.RecordScan(c, scanlog.ScanActionEntry, req.QRCode, &gateID, startedAt, result, err)
 	if err != nil {
 		// Handle specific errors
 		if err == gateservice.ErrGateNotFound {
@@ -329,10 +352,108 @@ func (h *Handler) GateCheckIn(c *gin.Context) {
 				"message":  result.Message,
 				"check_in": result.CheckIn,
 			}, nil)
+		case "ALREADY_INSIDE", "REENTRY_NOT_ALLOWED", "WRONG_SCHEDULE", "TOO_EARLY", "SCHEDULE_ENDED", "TICKET_BLOCKED":
+			errors.ErrorResponse(c, result.ErrorCode, map[string]interface{}{
+				"message": result.Message,
+			}, nil)
 		case "GATE_STAFF_NOT_ASSIGNED":
 			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
 				"message": result.Message,
 			}, nil)
+		case "GATE_STAFF_OFF_SHIFT":
+			errors.ErrorResponse(c, "GATE_STAFF_OFF_SHIFT", map[string]interface{}{
+				"message": result.Message,
+			}, nil)
+		case "DEVICE_GATE_MISMATCH":
+			errors.ErrorResponse(c, "DEVICE_GATE_MISMATCH", map[string]interface{}{
+				"message": result.Message,
+			}, nil)
+		default:
+			errors.ErrorResponse(c, "CHECK_IN_ERROR", map[string]interface{}{
+				"message": result.Message,
+			}, nil)
+		}
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, result, meta)
+}
+
+// GateExit records an exit scan at a specific gate
+// POST /api/v1/gates/:id/exit
+func (h *Handler) GateExit(c *gin.Context) {
+	gateID := c.Param("id")
+	if gateID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req gate.GateExitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceQRCode, req.QRCode) {
+		return
+	}
+
+	if deviceID := c.GetString("device_id"); deviceID != "" {
+		req.DeviceID = &deviceID
+	}
+
+	userRole, _ := c.Get("user_role")
+	userRoleStr, _ := userRole.(string)
+	isAdmin := userRoleStr == "admin" || userRoleStr == "super_admin"
+
+	startedAt := time.Now()
+	result, err := h.gateService.GateExit(gateID, &req, userIDStr, isAdmin)
+	h.scanLogService.RecordScan(c, scanlog.ScanActionExit, req.QRCode, &gateID, startedAt, result, err)
+	if err != nil {
+		if err == gateservice.ErrGateNotFound {
+			errors.NotFoundResponse(c, "gate", gateID)
+			return
+		}
+		if err == gateservice.ErrGateInactive {
+			errors.ErrorResponse(c, "GATE_INACTIVE", map[string]interface{}{
+				"message": "Gate is inactive",
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	if !result.Success {
+		switch result.ErrorCode {
+		case "GATE_STAFF_NOT_ASSIGNED":
+			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+				"message": result.Message,
+			}, nil)
+		case "GATE_STAFF_OFF_SHIFT":
+			errors.ErrorResponse(c, "GATE_STAFF_OFF_SHIFT", map[string]interface{}{
+				"message": result.Message,
+			}, nil)
+		case "INVALID_QR_CODE", "NOT_INSIDE", "DEVICE_GATE_MISMATCH":
+			errors.ErrorResponse(c, result.ErrorCode, map[string]interface{}{
+				"message": result.Message,
+			}, nil)
 		default:
 			errors.ErrorResponse(c, "CHECK_IN_ERROR", map[string]interface{}{
 				"message": result.Message,
@@ -395,6 +516,10 @@ func (h *Handler) AssignStaffToGate(c *gin.Context) {
 		return
 	}
 
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceUser, req.StaffID) {
+		return
+	}
+
 	if err := h.gateService.AssignStaffToGate(gateID, req.StaffID); err != nil {
 		if err == gateservice.ErrGateNotFound {
 			errors.NotFoundResponse(c, "gate", gateID)
@@ -466,6 +591,39 @@ func (h *Handler) GetStatistics(c *gin.Context) {
 	response.SuccessResponse(c, statistics, meta)
 }
 
+// GetOccupancy gets the live admission counters per gate and schedule (today's schedules by default)
+// GET /api/v1/gates/occupancy
+// GET /api/v1/gates/:id/occupancy
+func (h *Handler) GetOccupancy(c *gin.Context) {
+	var req gate.GetGateOccupancyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	// Gate-scoped counters
+	if gateID := c.Param("id"); gateID != "" {
+		req.GateID = gateID
+	}
+
+	occupancy, err := h.gateService.GetOccupancy(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		if err == gateservice.ErrGateNotFound {
+			errors.NotFoundResponse(c, "gate", req.GateID)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, occupancy, meta)
+}
+
 // GetAssignedStaff gets staff members assigned to a gate
 // GET /api/v1/gates/:id/staff
 func (h *Handler) GetAssignedStaff(c *gin.Context) {
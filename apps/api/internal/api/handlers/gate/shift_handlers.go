@@ -0,0 +1,305 @@
+package gate
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	gateservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateShift schedules a staff shift at a gate
+// POST /api/v1/gates/:id/shifts
+func (h *Handler) CreateShift(c *gin.Context) {
+	gateID := c.Param("id")
+	if gateID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req gate.CreateShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceUser, req.StaffID) {
+		return
+	}
+
+	shift, err := h.gateService.CreateShift(gateID, &req, userIDStr)
+	if err != nil {
+		if err == gateservice.ErrGateNotFound {
+			errors.NotFoundResponse(c, "gate", gateID)
+			return
+		}
+		if err == gateservice.ErrShiftOverlap {
+			errors.ErrorResponse(c, "GATE_SHIFT_OVERLAP", map[string]interface{}{
+				"staff_id": req.StaffID,
+			}, nil)
+			return
+		}
+		if err == gateservice.ErrShiftStaffInvalid {
+			errors.ErrorResponse(c, "GATE_SHIFT_INVALID_STAFF", map[string]interface{}{
+				"staff_id": req.StaffID,
+			}, nil)
+			return
+		}
+		if err == gateservice.ErrShiftScheduleInvalid {
+			errors.ErrorResponse(c, "GATE_SHIFT_INVALID_SCHEDULE", map[string]interface{}{
+				"schedule_id": req.ScheduleID,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, shift, meta)
+}
+
+// ListShifts lists staff shifts, e.g. ?date=2026-08-01 or ?active_only=true
+// GET /api/v1/gates/shifts
+func (h *Handler) ListShifts(c *gin.Context) {
+	var req gate.ListShiftsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	// Gate-scoped listing
+	if gateID := c.Param("id"); gateID != "" {
+		req.GateID = gateID
+	}
+
+	shifts, pagination, err := h.gateService.ListShifts(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, shifts, meta)
+}
+
+// ListMyShifts lists the authenticated staff user's shifts
+// GET /api/v1/gates/my/shifts
+func (h *Handler) ListMyShifts(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req gate.ListShiftsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+	req.StaffID = userIDStr
+
+	shifts, pagination, err := h.gateService.ListShifts(organization.AllOrganizations, &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, shifts, meta)
+}
+
+// GetShiftReport reports scans per staff member per shift, with the same filters as ListShifts
+// GET /api/v1/gates/shifts/report
+func (h *Handler) GetShiftReport(c *gin.Context) {
+	var req gate.ListShiftsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	reports, pagination, err := h.gateService.GetShiftReport(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, reports, meta)
+}
+
+// DeleteShift cancels a shift that has not started yet
+// DELETE /api/v1/gates/shifts/:shift_id
+func (h *Handler) DeleteShift(c *gin.Context) {
+	shiftID := c.Param("shift_id")
+	if shiftID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "shift_id",
+		}, nil)
+		return
+	}
+
+	if err := h.gateService.DeleteShift(shiftID); err != nil {
+		if err == gateservice.ErrShiftNotFound {
+			errors.NotFoundResponse(c, "gate shift", shiftID)
+			return
+		}
+		if err == gateservice.ErrShiftStarted {
+			errors.ErrorResponse(c, "GATE_SHIFT_STARTED", map[string]interface{}{
+				"shift_id": shiftID,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
+
+// EndShift ends an active shift early without a replacement
+// POST /api/v1/gates/shifts/:shift_id/end
+func (h *Handler) EndShift(c *gin.Context) {
+	shiftID := c.Param("shift_id")
+	if shiftID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "shift_id",
+		}, nil)
+		return
+	}
+
+	shift, err := h.gateService.EndShift(shiftID)
+	if err != nil {
+		if err == gateservice.ErrShiftNotFound {
+			errors.NotFoundResponse(c, "gate shift", shiftID)
+			return
+		}
+		if err == gateservice.ErrShiftNotActive {
+			errors.ErrorResponse(c, "GATE_SHIFT_NOT_ACTIVE", map[string]interface{}{
+				"shift_id": shiftID,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, shift, meta)
+}
+
+// HandoverShift hands the rest of a shift over to another staff member
+// POST /api/v1/gates/shifts/:shift_id/handover
+func (h *Handler) HandoverShift(c *gin.Context) {
+	shiftID := c.Param("shift_id")
+	if shiftID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "shift_id",
+		}, nil)
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, ok := userID.(string)
+	if !ok || userIDStr == "" {
+		errors.ErrorResponse(c, "UNAUTHORIZED", map[string]interface{}{
+			"reason": "Invalid user ID",
+		}, nil)
+		return
+	}
+
+	var req gate.HandoverShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	userRole, _ := c.Get("user_role")
+	userRoleStr, _ := userRole.(string)
+	isAdmin := userRoleStr == "admin" || userRoleStr == "super_admin"
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceUser, req.ToStaffID) {
+		return
+	}
+
+	handover, err := h.gateService.HandoverShift(shiftID, &req, userIDStr, isAdmin)
+	if err != nil {
+		switch err {
+		case gateservice.ErrShiftNotFound:
+			errors.NotFoundResponse(c, "gate shift", shiftID)
+		case gateservice.ErrShiftHandoverForbidden:
+			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+				"reason": "Only the shift owner, a supervisor on duty at the gate or an admin can hand over a shift",
+			}, nil)
+		case gateservice.ErrShiftAlreadyHandedOver:
+			errors.ErrorResponse(c, "GATE_SHIFT_ALREADY_HANDED_OVER", map[string]interface{}{
+				"shift_id": shiftID,
+			}, nil)
+		case gateservice.ErrShiftNotActive:
+			errors.ErrorResponse(c, "GATE_SHIFT_NOT_ACTIVE", map[string]interface{}{
+				"shift_id": shiftID,
+			}, nil)
+		case gateservice.ErrShiftHandoverSameStaff:
+			errors.ErrorResponse(c, "GATE_SHIFT_INVALID_HANDOVER", map[string]interface{}{
+				"to_staff_id": req.ToStaffID,
+			}, nil)
+		case gateservice.ErrShiftOverlap:
+			errors.ErrorResponse(c, "GATE_SHIFT_OVERLAP", map[string]interface{}{
+				"staff_id": req.ToStaffID,
+			}, nil)
+		case gateservice.ErrShiftStaffInvalid:
+			errors.ErrorResponse(c, "GATE_SHIFT_INVALID_STAFF", map[string]interface{}{
+				"staff_id": req.ToStaffID,
+			}, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, handover, meta)
+}
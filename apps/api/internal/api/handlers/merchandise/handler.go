@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/merchandise"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	merchandiseservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/merchandise"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
@@ -39,7 +41,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	merchandises, pagination, err := h.merchandiseService.List(&req)
+	merchandises, pagination, err := h.merchandiseService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -89,6 +91,10 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceEvent, req.EventID) {
+		return
+	}
+
 	merchandise, err := h.merchandiseService.Create(&req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
@@ -163,7 +169,7 @@ func (h *Handler) Delete(c *gin.Context) {
 func (h *Handler) GetInventory(c *gin.Context) {
 	eventID := c.Query("event_id")
 
-	inventory, err := h.merchandiseService.GetInventory(eventID)
+	inventory, err := h.merchandiseService.GetInventory(middleware.OrganizationScope(c), eventID)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -298,7 +304,7 @@ func (h *Handler) ListPublic(c *gin.Context) {
 	// Force active status for public routes
 	req.Status = "active"
 
-	merchandises, pagination, err := h.merchandiseService.List(&req)
+	merchandises, pagination, err := h.merchandiseService.List(organization.AllOrganizations, &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
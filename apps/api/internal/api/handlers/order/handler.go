@@ -5,7 +5,9 @@ import (
 	"log"
 	"strconv"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	orderservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
@@ -86,7 +88,7 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	orders, pagination, err := h.orderService.List(&req)
+	orders, pagination, err := h.orderService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -166,7 +168,7 @@ func (h *Handler) GetRecentOrders(c *gin.Context) {
 		limit = l
 	}
 
-	orders, err := h.orderService.GetRecentOrders(limit)
+	orders, err := h.orderService.GetRecentOrders(middleware.OrganizationScope(c), limit)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -214,6 +216,12 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 			}, nil)
 			return
 		}
+		if stderrors.Is(err, orderservice.ErrEmailNotVerified) {
+			errors.ErrorResponse(c, "EMAIL_NOT_VERIFIED", map[string]interface{}{
+				"message": "Please verify your email before ordering tickets.",
+			}, nil)
+			return
+		}
 		if stderrors.Is(err, orderservice.ErrScheduleNotFound) {
 			errors.ErrorResponse(c, "SCHEDULE_NOT_FOUND", map[string]interface{}{
 				"schedule_id": req.ScheduleID,
@@ -232,6 +240,12 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 			}, nil)
 			return
 		}
+		if stderrors.Is(err, orderservice.ErrScheduleNotInPass) {
+			errors.ErrorResponse(c, "INVALID_PASS_SCHEDULE", map[string]interface{}{
+				"schedule_id": req.ScheduleID,
+			}, nil)
+			return
+		}
 		if stderrors.Is(err, orderservice.ErrInsufficientSeats) {
 			errors.ErrorResponse(c, "INSUFFICIENT_SEATS", map[string]interface{}{
 				"requested": req.Quantity,
@@ -250,6 +264,52 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 			}, nil)
 			return
 		}
+		if stderrors.Is(err, orderservice.ErrBallotOnly) {
+			errors.ErrorResponse(c, "BALLOT_ONLY", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrBallotClaimInvalid) {
+			errors.ErrorResponse(c, "BALLOT_CLAIM_INVALID", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrBallotClaimExpired) {
+			errors.ErrorResponse(c, "BALLOT_CLAIM_EXPIRED", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrPresaleCodeRequired) {
+			errors.ErrorResponse(c, "PRESALE_CODE_REQUIRED", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrPresaleCodeInvalid) {
+			errors.ErrorResponse(c, "PRESALE_CODE_INVALID", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrPresaleCodeForbidden) {
+			errors.ErrorResponse(c, "PRESALE_CODE_FORBIDDEN", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrPresaleCodeExhausted) {
+			errors.ErrorResponse(c, "PRESALE_CODE_EXHAUSTED", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrAllocationNotFound) {
+			errors.ErrorResponse(c, "QUOTA_ALLOCATION_NOT_FOUND", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrAllocationForbidden) {
+			errors.ErrorResponse(c, "QUOTA_ALLOCATION_FORBIDDEN", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrAllocationNotActive) {
+			errors.ErrorResponse(c, "QUOTA_ALLOCATION_NOT_ACTIVE", nil, nil)
+			return
+		}
+		if stderrors.Is(err, orderservice.ErrInsufficientAllocation) {
+			errors.ErrorResponse(c, "INSUFFICIENT_ALLOCATION", map[string]interface{}{
+				"requested": req.Quantity,
+			}, nil)
+			return
+		}
 		log.Printf("[CreateOrder] Internal Server Error: %v", err)
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -334,7 +394,7 @@ func (h *Handler) GetMyOrders(c *gin.Context) {
 	// Force filter by current user ID
 	req.UserID = userIDStr
 
-	orders, pagination, err := h.orderService.List(&req)
+	orders, pagination, err := h.orderService.List(organization.AllOrganizations, &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
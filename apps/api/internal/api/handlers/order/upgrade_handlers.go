@@ -0,0 +1,92 @@
+package order
+
+import (
+	stderrors "errors"
+	"log"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	orderservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// UpgradeTicket creates a supplementary order for upgrading an issued ticket to another category (Guest API)
+// POST /api/v1/orders/:id/items/:item_id/upgrade
+// The ticket switches category and gets a new QR code once the returned order is paid
+func (h *Handler) UpgradeTicket(c *gin.Context) {
+	orderID := c.Param("id")
+	itemID := c.Param("item_id")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		errors.UnauthorizedResponse(c, "user not authenticated")
+		return
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		errors.UnauthorizedResponse(c, "invalid user id")
+		return
+	}
+
+	idempotencyKey := c.GetHeader("X-Idempotency-Key")
+
+	var req order.UpgradeTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	upgradeOrder, err := h.orderService.CreateUpgradeOrder(orderID, itemID, &req, userIDStr, idempotencyKey)
+	if err != nil {
+		switch {
+		case stderrors.Is(err, orderservice.ErrOrderItemNotFound):
+			errors.NotFoundResponse(c, "ticket", itemID)
+		case stderrors.Is(err, orderservice.ErrOrderNotFound):
+			errors.NotFoundResponse(c, "order", orderID)
+		case stderrors.Is(err, orderservice.ErrEmailNotVerified):
+			errors.ErrorResponse(c, "EMAIL_NOT_VERIFIED", map[string]interface{}{
+				"message": "Please verify your email before ordering tickets.",
+			}, nil)
+		case stderrors.Is(err, orderservice.ErrUpgradeForbidden):
+			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+				"message": "You do not have permission to upgrade this ticket",
+			}, nil)
+		case stderrors.Is(err, orderservice.ErrTicketCategoryNotFound):
+			errors.ErrorResponse(c, "TICKET_CATEGORY_NOT_FOUND", map[string]interface{}{
+				"ticket_category_id": req.TargetCategoryID,
+			}, nil)
+		case stderrors.Is(err, orderservice.ErrScheduleNotFound):
+			errors.ErrorResponse(c, "SCHEDULE_NOT_FOUND", nil, nil)
+		case stderrors.Is(err, orderservice.ErrSchedulePassed):
+			errors.ErrorResponse(c, "SCHEDULE_PASSED", map[string]interface{}{
+				"message": "This event schedule has already passed.",
+			}, nil)
+		case stderrors.Is(err, orderservice.ErrTicketNotUpgradable):
+			errors.ErrorResponse(c, "TICKET_NOT_UPGRADABLE", nil, nil)
+		case stderrors.Is(err, orderservice.ErrUpgradeInvalidTarget):
+			errors.ErrorResponse(c, "UPGRADE_INVALID_TARGET", map[string]interface{}{
+				"ticket_category_id": req.TargetCategoryID,
+			}, nil)
+		case stderrors.Is(err, orderservice.ErrUpgradePending):
+			errors.ErrorResponse(c, "UPGRADE_PENDING", nil, nil)
+		case stderrors.Is(err, orderservice.ErrInsufficientQuota):
+			errors.ErrorResponse(c, "INSUFFICIENT_QUOTA", map[string]interface{}{
+				"requested": 1,
+			}, nil)
+		default:
+			log.Printf("[UpgradeTicket] Internal Server Error: %v", err)
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, upgradeOrder, meta)
+}
@@ -0,0 +1,130 @@
+package organization
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	organizationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	organizationService *organizationservice.Service
+}
+
+func NewHandler(organizationService *organizationservice.Service) *Handler {
+	return &Handler{
+		organizationService: organizationService,
+	}
+}
+
+// List returns the organizations the caller may access; only super admins see more than their own
+// GET /api/v1/admin/organizations
+func (h *Handler) List(c *gin.Context) {
+	var req organization.ListOrganizationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	organizations, pagination, err := h.organizationService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, organizations, meta)
+}
+
+// GetByID returns an organization
+// GET /api/v1/admin/organizations/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	if !middleware.OrganizationScope(c).Allows(id) {
+		errors.NotFoundResponse(c, "organization", id)
+		return
+	}
+
+	o, err := h.organizationService.GetByID(id)
+	if err != nil {
+		if err == organizationservice.ErrOrganizationNotFound {
+			errors.NotFoundResponse(c, "organization", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, o, meta)
+}
+
+// Create creates an organization (super admin)
+// POST /api/v1/admin/organizations
+func (h *Handler) Create(c *gin.Context) {
+	var req organization.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	o, err := h.organizationService.CreateWithAudit(c, &req)
+	if err != nil {
+		if err == organizationservice.ErrOrganizationCodeExists {
+			errors.ErrorResponse(c, "ORGANIZATION_CODE_EXISTS", map[string]interface{}{
+				"code": req.Code,
+			}, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		CreatedBy: c.GetString("user_id"),
+	}
+	response.SuccessResponseCreated(c, o, meta)
+}
+
+// Update updates an organization (super admin)
+// PUT /api/v1/admin/organizations/:id
+func (h *Handler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var req organization.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+			return
+		}
+		errors.InvalidRequestBodyResponse(c)
+		return
+	}
+
+	o, err := h.organizationService.UpdateWithAudit(c, id, &req)
+	if err != nil {
+		if err == organizationservice.ErrOrganizationNotFound {
+			errors.NotFoundResponse(c, "organization", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, o, meta)
+}
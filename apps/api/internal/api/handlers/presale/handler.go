@@ -0,0 +1,268 @@
+package presale
+
+import (
+	"encoding/csv"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+	presaleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/presale"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	presaleService *presaleservice.Service
+}
+
+func NewHandler(presaleService *presaleservice.Service) *Handler {
+	return &Handler{
+		presaleService: presaleService,
+	}
+}
+
+// ListBatches lists presale batches with pagination and filters
+// GET /api/v1/admin/presale-batches
+func (h *Handler) ListBatches(c *gin.Context) {
+	var req presale.ListPresaleBatchesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	batches, pagination, err := h.presaleService.ListBatches(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, batches, meta)
+}
+
+// GetBatchByID gets a presale batch by ID
+// GET /api/v1/admin/presale-batches/:id
+func (h *Handler) GetBatchByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	batch, err := h.presaleService.GetBatchByID(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, batch, meta)
+}
+
+// CreateBatch creates a presale batch and generates its codes
+// POST /api/v1/admin/presale-batches
+func (h *Handler) CreateBatch(c *gin.Context) {
+	var req presale.CreatePresaleBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceTicketCategory, req.TicketCategoryID) {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	batch, err := h.presaleService.CreateBatch(&req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, batch, meta)
+}
+
+// UpdateBatch updates a presale batch
+// PUT /api/v1/admin/presale-batches/:id
+func (h *Handler) UpdateBatch(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req presale.UpdatePresaleBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	batch, err := h.presaleService.UpdateBatch(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, batch, meta)
+}
+
+// GenerateCodes generates additional codes for a batch
+// POST /api/v1/admin/presale-batches/:id/codes
+func (h *Handler) GenerateCodes(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req presale.GeneratePresaleCodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	batch, err := h.presaleService.GenerateCodes(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, batch, meta)
+}
+
+// ListCodes lists codes of a batch
+// GET /api/v1/admin/presale-batches/:id/codes
+func (h *Handler) ListCodes(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req presale.ListPresaleCodesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	codes, pagination, err := h.presaleService.ListCodes(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, codes, meta)
+}
+
+// ExportCodes exports all codes of a batch to CSV
+// GET /api/v1/admin/presale-batches/:id/codes/export
+func (h *Handler) ExportCodes(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	filename, csvData, err := h.presaleService.ExportCodes(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	// Set response headers for CSV download
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+
+	// Write CSV to response
+	writer := csv.NewWriter(c.Writer)
+	defer writer.Flush()
+
+	for _, row := range csvData {
+		if err := writer.Write(row); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// GetBatchReport gets redemption statistics for a batch
+// GET /api/v1/admin/presale-batches/:id/report
+func (h *Handler) GetBatchReport(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	report, err := h.presaleService.GetBatchReport(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, report, meta)
+}
+
+// handleServiceError maps presale service errors to API errors
+func (h *Handler) handleServiceError(c *gin.Context, err error, id string) {
+	switch {
+	case stderrors.Is(err, presaleservice.ErrPresaleBatchNotFound):
+		errors.NotFoundResponse(c, "presale_batch", id)
+	case stderrors.Is(err, presaleservice.ErrTicketCategoryNotFound):
+		errors.ErrorResponse(c, "TICKET_CATEGORY_NOT_FOUND", nil, nil)
+	case stderrors.Is(err, presaleservice.ErrInvalidSaleWindow),
+		stderrors.Is(err, presaleservice.ErrNoAccessMethod):
+		errors.ErrorResponse(c, "VALIDATION_ERROR", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
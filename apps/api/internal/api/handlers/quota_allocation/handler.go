@@ -0,0 +1,183 @@
+package quotaallocation
+
+import (
+	stderrors "errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
+	quotaallocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/quota_allocation"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	quotaAllocationService *quotaallocationservice.Service
+}
+
+func NewHandler(quotaAllocationService *quotaallocationservice.Service) *Handler {
+	return &Handler{
+		quotaAllocationService: quotaAllocationService,
+	}
+}
+
+// List lists quota allocations with pagination and filters
+// GET /api/v1/admin/quota-allocations
+func (h *Handler) List(c *gin.Context) {
+	var req quotaallocation.ListQuotaAllocationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	allocations, pagination, err := h.quotaAllocationService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, allocations, meta)
+}
+
+// GetByID gets a quota allocation by ID
+// GET /api/v1/admin/quota-allocations/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	allocation, err := h.quotaAllocationService.GetByID(id)
+	if err != nil {
+		if err == quotaallocationservice.ErrQuotaAllocationNotFound {
+			errors.NotFoundResponse(c, "quota_allocation", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, allocation, meta)
+}
+
+// Create carves a new allocation out of a ticket category
+// POST /api/v1/admin/quota-allocations
+func (h *Handler) Create(c *gin.Context) {
+	var req quotaallocation.CreateQuotaAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceTicketCategory, req.TicketCategoryID) {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	allocation, err := h.quotaAllocationService.Create(&req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, allocation, meta)
+}
+
+// Update updates an active quota allocation
+// PUT /api/v1/admin/quota-allocations/:id
+func (h *Handler) Update(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req quotaallocation.UpdateQuotaAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	allocation, err := h.quotaAllocationService.Update(id, &req)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, allocation, meta)
+}
+
+// Release releases an allocation and returns unused seats to the public quota
+// POST /api/v1/admin/quota-allocations/:id/release
+func (h *Handler) Release(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	allocation, err := h.quotaAllocationService.Release(id)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, allocation, meta)
+}
+
+// handleServiceError maps quota allocation service errors to API errors
+func (h *Handler) handleServiceError(c *gin.Context, err error, id string) {
+	switch {
+	case stderrors.Is(err, quotaallocationservice.ErrQuotaAllocationNotFound):
+		errors.NotFoundResponse(c, "quota_allocation", id)
+	case stderrors.Is(err, quotaallocationservice.ErrTicketCategoryNotFound):
+		errors.ErrorResponse(c, "TICKET_CATEGORY_NOT_FOUND", nil, nil)
+	case stderrors.Is(err, quotaallocationservice.ErrInsufficientQuota):
+		errors.ErrorResponse(c, "INSUFFICIENT_QUOTA", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	case stderrors.Is(err, quotaallocationservice.ErrAllocationNotActive):
+		errors.ErrorResponse(c, "QUOTA_ALLOCATION_NOT_ACTIVE", nil, nil)
+	case stderrors.Is(err, quotaallocationservice.ErrAccessCodeExists):
+		errors.ErrorResponse(c, "CONFLICT", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	case stderrors.Is(err, quotaallocationservice.ErrSizeBelowUsed),
+		stderrors.Is(err, quotaallocationservice.ErrExpiryInPast):
+		errors.ErrorResponse(c, "VALIDATION_ERROR", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	default:
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
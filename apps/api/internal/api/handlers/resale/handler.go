@@ -0,0 +1,390 @@
+package resale
+
+import (
+	stderrors "errors"
+	"log"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
+	orderservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/order"
+	resaleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/resale"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	resaleService *resaleservice.Service
+}
+
+func NewHandler(resaleService *resaleservice.Service) *Handler {
+	return &Handler{
+		resaleService: resaleService,
+	}
+}
+
+// Browse lists active resale listings
+// GET /api/v1/resale-listings
+func (h *Handler) Browse(c *gin.Context) {
+	var req resale.ListListingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	listings, pagination, err := h.resaleService.Browse(&req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, listings, meta)
+}
+
+// GetListing gets a resale listing by ID (public view)
+// GET /api/v1/resale-listings/:id
+func (h *Handler) GetListing(c *gin.Context) {
+	h.getListing(c, false)
+}
+
+// CreateListing lists one of my tickets for resale
+// POST /api/v1/resale-listings
+func (h *Handler) CreateListing(c *gin.Context) {
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req resale.CreateListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	listing, err := h.resaleService.CreateListing(&req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, listing, meta)
+}
+
+// CancelMyListing withdraws one of my active listings
+// DELETE /api/v1/resale-listings/:id
+func (h *Handler) CancelMyListing(c *gin.Context) {
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	h.cancelListing(c, userIDStr)
+}
+
+// GetMyListings lists my resale listings
+// GET /api/v1/resale-listings/me
+func (h *Handler) GetMyListings(c *gin.Context) {
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	listings, err := h.resaleService.GetMyListings(userIDStr)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, listings, meta)
+}
+
+// Purchase buys a resale listing; pay the returned order through the normal payment flow
+// POST /api/v1/resale-listings/:id/purchase
+func (h *Handler) Purchase(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req resale.PurchaseListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	createdOrder, err := h.resaleService.Purchase(id, &req, userIDStr, c.GetHeader("X-Idempotency-Key"))
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponseCreated(c, createdOrder, meta)
+}
+
+// GetMyPayouts lists payouts credited to me for sold listings
+// GET /api/v1/resale-payouts
+func (h *Handler) GetMyPayouts(c *gin.Context) {
+	userIDStr, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	payouts, err := h.resaleService.GetMyPayouts(userIDStr)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, payouts, meta)
+}
+
+// List lists resale listings with any status
+// GET /api/v1/admin/resale-listings
+func (h *Handler) List(c *gin.Context) {
+	var req resale.ListListingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	listings, pagination, err := h.resaleService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, listings, meta)
+}
+
+// GetByID gets a resale listing by ID with seller and payout details
+// GET /api/v1/admin/resale-listings/:id
+func (h *Handler) GetByID(c *gin.Context) {
+	h.getListing(c, true)
+}
+
+// Cancel withdraws an active listing on behalf of the seller
+// POST /api/v1/admin/resale-listings/:id/cancel
+func (h *Handler) Cancel(c *gin.Context) {
+	h.cancelListing(c, "")
+}
+
+// ListPayouts lists seller payouts
+// GET /api/v1/admin/resale-payouts
+func (h *Handler) ListPayouts(c *gin.Context) {
+	var req resale.ListPayoutsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	payouts, pagination, err := h.resaleService.ListPayouts(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, payouts, meta)
+}
+
+// GetPayoutSummary gets resale totals for payout reconciliation
+// GET /api/v1/admin/resale-payouts/summary
+func (h *Handler) GetPayoutSummary(c *gin.Context) {
+	var req resale.ListPayoutsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	summary, err := h.resaleService.GetPayoutSummary(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, summary, meta)
+}
+
+// MarkPayoutPaid records the transfer of a seller payout
+// POST /api/v1/admin/resale-payouts/:id/mark-paid
+func (h *Handler) MarkPayoutPaid(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req resale.MarkPayoutPaidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	payout, err := h.resaleService.MarkPayoutPaid(id, &req, userIDStr)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, payout, meta)
+}
+
+// getListing writes a listing, with seller details only for admins
+func (h *Handler) getListing(c *gin.Context, admin bool) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	listing, err := h.resaleService.GetListing(id, admin)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, listing, meta)
+}
+
+// cancelListing cancels a listing; an empty sellerID cancels as admin
+func (h *Handler) cancelListing(c *gin.Context, sellerID string) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	listing, err := h.resaleService.CancelListing(id, sellerID)
+	if err != nil {
+		h.handleServiceError(c, err, id)
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, listing, meta)
+}
+
+// currentUserID reads the authenticated user ID, writing an error response when missing
+func currentUserID(c *gin.Context) (string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		errors.UnauthorizedResponse(c, "user not authenticated")
+		return "", false
+	}
+	userIDStr, ok := userID.(string)
+	if !ok {
+		errors.UnauthorizedResponse(c, "invalid user id")
+		return "", false
+	}
+	return userIDStr, true
+}
+
+// handleServiceError maps resale (and resale order) service errors to API errors
+func (h *Handler) handleServiceError(c *gin.Context, err error, id string) {
+	var capErr *resaleservice.PriceCapError
+	switch {
+	case stderrors.Is(err, resaleservice.ErrListingNotFound),
+		stderrors.Is(err, orderservice.ErrListingNotFound):
+		errors.NotFoundResponse(c, "resale_listing", id)
+	case stderrors.Is(err, orderservice.ErrEmailNotVerified):
+		errors.ErrorResponse(c, "EMAIL_NOT_VERIFIED", map[string]interface{}{
+			"message": "Please verify your email before ordering tickets.",
+		}, nil)
+	case stderrors.Is(err, resaleservice.ErrPayoutNotFound):
+		errors.NotFoundResponse(c, "resale_payout", id)
+	case stderrors.Is(err, resaleservice.ErrTicketNotFound):
+		errors.NotFoundResponse(c, "ticket", "")
+	case stderrors.Is(err, resaleservice.ErrNotTicketHolder),
+		stderrors.Is(err, resaleservice.ErrNotListingSeller):
+		errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+			"message": err.Error(),
+		}, nil)
+	case stderrors.As(err, &capErr):
+		errors.ErrorResponse(c, "RESALE_PRICE_ABOVE_CAP", map[string]interface{}{
+			"face_value": capErr.FaceValue,
+			"max_price":  capErr.MaxPrice,
+		}, nil)
+	case stderrors.Is(err, resaleservice.ErrTicketNotResellable):
+		errors.ErrorResponse(c, "TICKET_NOT_RESELLABLE", nil, nil)
+	case stderrors.Is(err, resaleservice.ErrTicketAlreadyListed):
+		errors.ErrorResponse(c, "TICKET_ALREADY_LISTED", nil, nil)
+	case stderrors.Is(err, resaleservice.ErrListingNotActive),
+		stderrors.Is(err, orderservice.ErrListingNotAvailable):
+		errors.ErrorResponse(c, "RESALE_LISTING_NOT_AVAILABLE", nil, nil)
+	case stderrors.Is(err, orderservice.ErrOwnListing):
+		errors.ErrorResponse(c, "RESALE_OWN_LISTING", nil, nil)
+	case stderrors.Is(err, resaleservice.ErrPayoutAlreadyPaid):
+		errors.ErrorResponse(c, "PAYOUT_ALREADY_PAID", nil, nil)
+	case stderrors.Is(err, resaleservice.ErrScheduleNotFound),
+		stderrors.Is(err, orderservice.ErrScheduleNotFound):
+		errors.ErrorResponse(c, "SCHEDULE_NOT_FOUND", nil, nil)
+	case stderrors.Is(err, resaleservice.ErrSchedulePassed),
+		stderrors.Is(err, orderservice.ErrSchedulePassed):
+		errors.ErrorResponse(c, "SCHEDULE_PASSED", nil, nil)
+	case stderrors.Is(err, orderservice.ErrTicketCategoryNotFound):
+		errors.ErrorResponse(c, "TICKET_CATEGORY_NOT_FOUND", nil, nil)
+	default:
+		log.Printf("[Resale] Internal Server Error: %v", err)
+		errors.InternalServerErrorResponse(c, "")
+	}
+}
@@ -0,0 +1,156 @@
+package role
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	roleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ListBindings returns the users the role is bound to within single events
+// GET /api/v1/admin/roles/:id/bindings
+func (h *Handler) ListBindings(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req role.ListRoleBindingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	bindings, pagination, err := h.roleService.ListBindings(middleware.OrganizationScope(c), id, &req)
+	if err != nil {
+		if err == roleservice.ErrRoleNotFound {
+			errors.NotFoundResponse(c, "role", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: &response.PaginationMeta{
+			Page:       pagination.Page,
+			PerPage:    pagination.PerPage,
+			Total:      pagination.Total,
+			TotalPages: pagination.TotalPages,
+			HasNext:    pagination.Page < pagination.TotalPages,
+			HasPrev:    pagination.Page > 1,
+		},
+		Filters: map[string]interface{}{},
+	}
+	if req.UserID != "" {
+		meta.Filters["user_id"] = req.UserID
+	}
+	if req.EventID != "" {
+		meta.Filters["event_id"] = req.EventID
+	}
+	if meta.Pagination.HasNext {
+		nextPage := pagination.Page + 1
+		meta.Pagination.NextPage = &nextPage
+	}
+	if meta.Pagination.HasPrev {
+		prevPage := pagination.Page - 1
+		meta.Pagination.PrevPage = &prevPage
+	}
+
+	response.SuccessResponse(c, bindings, meta)
+}
+
+// CreateBinding binds the role to a user within a single event
+// POST /api/v1/admin/roles/:id/bindings
+func (h *Handler) CreateBinding(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req role.CreateRoleBindingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceUser, req.UserID) ||
+		!middleware.AuthorizeOrganizationResource(c, organization.ResourceEvent, req.EventID) {
+		return
+	}
+
+	createdBy := c.GetString("user_id")
+	binding, err := h.roleService.CreateBinding(id, &req, createdBy)
+	if err != nil {
+		switch err {
+		case roleservice.ErrRoleNotFound:
+			errors.NotFoundResponse(c, "role", id)
+		case roleservice.ErrRoleBindingUserNotFound:
+			errors.NotFoundResponse(c, "user", req.UserID)
+		case roleservice.ErrRoleBindingEventNotFound:
+			errors.NotFoundResponse(c, "event", req.EventID)
+		case roleservice.ErrRoleBindingExists:
+			errors.ErrorResponse(c, "ROLE_BINDING_EXISTS", map[string]interface{}{
+				"user_id":  req.UserID,
+				"event_id": req.EventID,
+			}, nil)
+		case roleservice.ErrRoleBindingOrganization:
+			errors.ErrorResponse(c, "ROLE_BINDING_ORGANIZATION_MISMATCH", map[string]interface{}{
+				"user_id":  req.UserID,
+				"event_id": req.EventID,
+			}, nil)
+		case roleservice.ErrSuperAdminRoleNotBindable:
+			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+				"reason": "The super admin role cannot be bound to an event",
+			}, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		return
+	}
+
+	meta := &response.Meta{CreatedBy: createdBy}
+	response.SuccessResponseCreated(c, binding, meta)
+}
+
+// DeleteBinding removes a binding of the role
+// DELETE /api/v1/admin/roles/:id/bindings/:binding_id
+func (h *Handler) DeleteBinding(c *gin.Context) {
+	id := c.Param("id")
+	bindingID := c.Param("binding_id")
+	if id == "" || bindingID == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "binding_id",
+		}, nil)
+		return
+	}
+
+	if err := h.roleService.DeleteBinding(id, bindingID); err != nil {
+		if err == roleservice.ErrRoleBindingNotFound {
+			errors.NotFoundResponse(c, "role binding", bindingID)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	response.SuccessResponseNoContent(c)
+}
@@ -0,0 +1,84 @@
+package scanlog
+
+import (
+	"encoding/csv"
+	"net/http"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	scanlogservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/scan_log"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	scanLogService *scanlogservice.Service
+}
+
+func NewHandler(scanLogService *scanlogservice.Service) *Handler {
+	return &Handler{
+		scanLogService: scanLogService,
+	}
+}
+
+// List lists scan attempts with pagination and filters
+// GET /api/v1/admin/scan-logs
+func (h *Handler) List(c *gin.Context) {
+	var req scanlog.ListScanLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	logs, pagination, err := h.scanLogService.List(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+	}
+	response.SuccessResponse(c, logs, meta)
+}
+
+// Export exports scan attempts to CSV
+// GET /api/v1/admin/scan-logs/export
+func (h *Handler) Export(c *gin.Context) {
+	var req scanlog.ListScanLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidQueryParamResponse(c)
+		}
+		return
+	}
+
+	csvData, err := h.scanLogService.Export(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	// Set response headers for CSV download
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=scan-logs.csv")
+
+	// Write CSV to response
+	writer := csv.NewWriter(c.Writer)
+	defer writer.Flush()
+
+	for _, row := range csvData {
+		if err := writer.Write(row); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -3,6 +3,8 @@ package schedule
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	scheduleservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/schedule"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
@@ -78,6 +80,10 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceEvent, req.EventID) {
+		return
+	}
+
 	schedule, err := h.scheduleService.Create(&req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
@@ -150,7 +156,7 @@ func (h *Handler) Delete(c *gin.Context) {
 // List lists all schedules
 // GET /api/v1/admin/schedules
 func (h *Handler) List(c *gin.Context) {
-	schedules, err := h.scheduleService.List()
+	schedules, err := h.scheduleService.List(middleware.OrganizationScope(c))
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -0,0 +1,79 @@
+package signingkey
+
+import (
+	"net/http"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	signingkeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/signing_key"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Handler struct {
+	signingKeyService *signingkeyservice.Service
+}
+
+func NewHandler(signingKeyService *signingkeyservice.Service) *Handler {
+	return &Handler{
+		signingKeyService: signingKeyService,
+	}
+}
+
+// JWKS returns the public keys tokens are verified with, as a plain JSON Web Key Set
+// GET /.well-known/jwks.json
+func (h *Handler) JWKS(c *gin.Context) {
+	// Short enough for verifiers to pick up a rotated key well within its grace period
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.signingKeyService.JWKS())
+}
+
+// List returns all signing keys with their status
+// GET /api/v1/admin/signing-keys
+func (h *Handler) List(c *gin.Context) {
+	keys, err := h.signingKeyService.List()
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, keys, meta)
+}
+
+// Rotate replaces the signing key; the previous key keeps verifying tokens for the grace period
+// POST /api/v1/admin/signing-keys/rotate
+func (h *Handler) Rotate(c *gin.Context) {
+	// Body is optional: the configured algorithm and grace period are used by default
+	var req auth.RotateSigningKeyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				errors.HandleValidationError(c, validationErrors)
+			} else {
+				errors.InvalidRequestBodyResponse(c)
+			}
+			return
+		}
+	}
+
+	key, err := h.signingKeyService.RotateWithAudit(c, &req)
+	if err != nil {
+		if err == signingkeyservice.ErrSigningKeysDisabled {
+			errors.ErrorResponse(c, "SIGNING_KEYS_DISABLED", nil, nil)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(string); ok {
+			meta.CreatedBy = id
+		}
+	}
+
+	response.SuccessResponseCreated(c, key, meta)
+}
@@ -1,6 +1,8 @@
 package ticketcategory
 
 import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
 	ticketcategoryservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ticket_category"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
@@ -22,7 +24,7 @@ func NewHandler(ticketCategoryService *ticketcategoryservice.Service) *Handler {
 // List lists all ticket categories
 // GET /api/v1/admin/ticket-categories
 func (h *Handler) List(c *gin.Context) {
-	categories, err := h.ticketCategoryService.List()
+	categories, err := h.ticketCategoryService.List(middleware.OrganizationScope(c))
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -112,8 +114,18 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
+	if !middleware.AuthorizeOrganizationResource(c, organization.ResourceEvent, req.EventID) {
+		return
+	}
+
 	createdCategory, err := h.ticketCategoryService.Create(&req)
 	if err != nil {
+		if err == ticketcategoryservice.ErrInvalidPassSchedule {
+			errors.ErrorResponse(c, "INVALID_PASS_SCHEDULE", map[string]interface{}{
+				"field": "schedule_ids",
+			}, nil)
+			return
+		}
 		errors.InternalServerErrorResponse(c, "")
 		return
 	}
@@ -149,6 +161,12 @@ func (h *Handler) Update(c *gin.Context) {
 			errors.NotFoundResponse(c, "ticket_category", id)
 			return
 		}
+		if err == ticketcategoryservice.ErrInvalidPassSchedule {
+			errors.ErrorResponse(c, "INVALID_PASS_SCHEDULE", map[string]interface{}{
+				"field": "schedule_ids",
+			}, nil)
+			return
+		}
 		errors.InternalServerErrorResponse(c, "")
 		return
 	}
@@ -3,6 +3,9 @@ package user
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 	userservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/user"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
@@ -44,7 +47,7 @@ func (h *Handler) List(c *gin.Context) {
 		req.PerPage = 100
 	}
 
-	users, pagination, err := h.userService.List(&req)
+	users, pagination, err := h.userService.List(middleware.OrganizationScope(c), &req)
 	if err != nil {
 		errors.InternalServerErrorResponse(c, "")
 		return
@@ -125,8 +128,11 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	userResponse, err := h.userService.Create(&req)
+	userResponse, err := h.userService.Create(middleware.OrganizationScope(c), &req)
 	if err != nil {
+		if handleOrganizationError(c, err, req.OrganizationID) {
+			return
+		}
 		if err == userservice.ErrUserAlreadyExists {
 			errors.ErrorResponse(c, "CONFLICT", map[string]interface{}{
 				"reason":         "User already exists",
@@ -174,8 +180,15 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	userResponse, err := h.userService.Update(id, &req)
+	userResponse, err := h.userService.Update(middleware.OrganizationScope(c), id, &req)
 	if err != nil {
+		organizationID := ""
+		if req.OrganizationID != nil {
+			organizationID = *req.OrganizationID
+		}
+		if handleOrganizationError(c, err, organizationID) {
+			return
+		}
 		if err == userservice.ErrUserNotFound {
 			errors.NotFoundResponse(c, "user", id)
 			return
@@ -230,3 +243,128 @@ func (h *Handler) Delete(c *gin.Context) {
 	response.SuccessResponseNoContent(c)
 }
 
+
+// Unlock lifts a user's login lockout
+// POST /api/v1/admin/users/:id/unlock
+func (h *Handler) Unlock(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	userResponse, err := h.userService.UnlockWithAudit(c, id)
+	if err != nil {
+		if err == userservice.ErrUserNotFound {
+			errors.NotFoundResponse(c, "user", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(string); ok {
+			meta.UpdatedBy = id
+		}
+	}
+
+	response.SuccessResponse(c, userResponse, meta)
+}
+
+// ListLoginHistory returns a user's login attempts with pagination
+// GET /api/v1/admin/users/:id/login-history
+func (h *Handler) ListLoginHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		errors.ErrorResponse(c, "INVALID_PATH_PARAM", map[string]interface{}{
+			"param": "id",
+		}, nil)
+		return
+	}
+
+	var req auth.ListLoginHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	attempts, pagination, err := h.userService.ListLoginHistory(id, &req)
+	if err != nil {
+		if err == userservice.ErrUserNotFound {
+			errors.NotFoundResponse(c, "user", id)
+			return
+		}
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{
+		Pagination: pagination,
+		Filters:    map[string]interface{}{},
+	}
+	if req.Result != "" {
+		meta.Filters["result"] = req.Result
+	}
+
+	response.SuccessResponse(c, attempts, meta)
+}
+
+// GetSuspiciousLoginActivity returns IP addresses and accounts with many recent failed logins
+// GET /api/v1/admin/users/login-activity
+func (h *Handler) GetSuspiciousLoginActivity(c *gin.Context) {
+	var req auth.SuspiciousLoginActivityRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			errors.HandleValidationError(c, validationErrors)
+		} else {
+			errors.InvalidRequestBodyResponse(c)
+		}
+		return
+	}
+
+	activity, err := h.userService.GetSuspiciousLoginActivity(middleware.OrganizationScope(c), &req)
+	if err != nil {
+		errors.InternalServerErrorResponse(c, "")
+		return
+	}
+
+	meta := &response.Meta{}
+	response.SuccessResponse(c, activity, meta)
+}
+
+// handleOrganizationError responds to the errors of assigning a user to an organization, and
+// reports whether err was one of them
+func handleOrganizationError(c *gin.Context, err error, organizationID string) bool {
+	switch err {
+	case organization.ErrOrganizationRequired:
+		errors.ErrorResponse(c, "ORGANIZATION_REQUIRED", map[string]interface{}{
+			"field": "organization_id",
+		}, nil)
+	case userservice.ErrOrganizationNotFound:
+		errors.NotFoundResponse(c, "organization", organizationID)
+	case userservice.ErrOrganizationInactive:
+		errors.ErrorResponse(c, "ORGANIZATION_INACTIVE", map[string]interface{}{
+			"organization_id": organizationID,
+		}, nil)
+	case userservice.ErrOrganizationForbidden:
+		errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+			"reason": "Only super admins can choose the organization of a user",
+		}, nil)
+	case userservice.ErrSuperAdminRoleForbidden:
+		errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+			"reason": "Only super admins can assign the super admin role",
+		}, nil)
+	default:
+		return false
+	}
+	return true
+}
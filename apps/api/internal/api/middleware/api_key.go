@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// APIKeyAuthenticator resolves the API keys AuthMiddleware accepts next to JWTs and keeps the
+// audit trail of the calls made with them
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(key, ipAddress string) (*apikey.Principal, error)
+	RecordAPIKeyCall(call *apikey.APIKeyCall)
+}
+
+var apiKeyAuthenticator APIKeyAuthenticator
+
+// SetAPIKeyAuthenticator makes AuthMiddleware accept API keys; without it they are rejected
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
+// apiKeyLimiter is the rate limiter of one API key
+type apiKeyLimiter struct {
+	limiter   *rate.Limiter
+	perMinute int
+	lastSeen  time.Time
+}
+
+// apiKeyLimiters is shared by every AuthMiddleware, so a key's limit covers all routes
+var apiKeyLimiters = struct {
+	mu          sync.Mutex
+	limiters    map[string]*apiKeyLimiter
+	lastCleanup time.Time
+}{limiters: make(map[string]*apiKeyLimiter)}
+
+// allowAPIKeyRequest takes a token from the key's bucket, which holds a minute worth of requests
+func allowAPIKeyRequest(principal *apikey.Principal, now time.Time) (*apiKeyLimiter, bool) {
+	apiKeyLimiters.mu.Lock()
+	defer apiKeyLimiters.mu.Unlock()
+
+	if now.Sub(apiKeyLimiters.lastCleanup) > time.Minute {
+		for id, l := range apiKeyLimiters.limiters {
+			if now.Sub(l.lastSeen) > 5*time.Minute {
+				delete(apiKeyLimiters.limiters, id)
+			}
+		}
+		apiKeyLimiters.lastCleanup = now
+	}
+
+	l, exists := apiKeyLimiters.limiters[principal.KeyID]
+	// A changed limit starts a fresh bucket
+	if !exists || l.perMinute != principal.RateLimitPerMinute {
+		l = &apiKeyLimiter{
+			limiter:   rate.NewLimiter(rate.Every(time.Minute/time.Duration(principal.RateLimitPerMinute)), principal.RateLimitPerMinute),
+			perMinute: principal.RateLimitPerMinute,
+		}
+		apiKeyLimiters.limiters[principal.KeyID] = l
+	}
+	l.lastSeen = now
+	return l, l.limiter.AllowN(now, 1)
+}
+
+// authenticateAPIKey handles a request made with an API key: it sets the owner's user info and
+// the key's permissions in context, applies the key's rate limit and records the call
+func authenticateAPIKey(c *gin.Context, key string) {
+	if apiKeyAuthenticator == nil {
+		errors.ErrorResponse(c, "API_KEY_INVALID", nil, nil)
+		c.Abort()
+		return
+	}
+
+	principal, err := apiKeyAuthenticator.AuthenticateAPIKey(key, c.ClientIP())
+	if err != nil {
+		switch {
+		case stderrors.Is(err, apikey.ErrKeyExpired):
+			errors.ErrorResponse(c, "API_KEY_EXPIRED", nil, nil)
+		case stderrors.Is(err, apikey.ErrKeyRevoked):
+			errors.ErrorResponse(c, "API_KEY_REVOKED", nil, nil)
+		case stderrors.Is(err, apikey.ErrKeyInvalid):
+			errors.ErrorResponse(c, "API_KEY_INVALID", nil, nil)
+		default:
+			errors.InternalServerErrorResponse(c, "")
+		}
+		c.Abort()
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		requestID, _ := c.Get("request_id")
+		requestIDStr, _ := requestID.(string)
+		apiKeyAuthenticator.RecordAPIKeyCall(&apikey.APIKeyCall{
+			APIKeyID:   principal.KeyID,
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			Route:      c.FullPath(),
+			StatusCode: c.Writer.Status(),
+			DurationMs: time.Since(start).Milliseconds(),
+			IPAddress:  c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+			RequestID:  requestIDStr,
+		})
+	}()
+
+	l, allowed := allowAPIKeyRequest(principal, start)
+	c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", principal.RateLimitPerMinute))
+	if !allowed {
+		resetTime := start.Add(time.Minute / time.Duration(principal.RateLimitPerMinute))
+		c.Header("X-RateLimit-Remaining", "0")
+		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", resetTime.Unix()))
+		errors.ErrorResponse(c, "RATE_LIMIT_EXCEEDED", map[string]interface{}{
+			"limit":     principal.RateLimitPerMinute,
+			"remaining": 0,
+			"reset_at":  resetTime.Format(time.RFC3339),
+		}, nil)
+		c.Abort()
+		return
+	}
+	c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", int(l.limiter.TokensAt(start))))
+
+	// The key acts as its owner; RequirePermission additionally limits it to its own permissions
+	c.Set("user_id", principal.UserID)
+	c.Set("user_email", principal.Email)
+	c.Set("user_role", principal.Role)
+	c.Set("role_id", principal.RoleID)
+	c.Set("organization_id", principal.OrganizationID)
+	c.Set("api_key_id", principal.KeyID)
+	c.Set("api_key_permissions", principal.Permissions)
+
+	c.Next()
+}
+
+// apiKeyHasPermission reports whether the request was made with an API key, and if so whether the
+// key was granted the permission
+func apiKeyHasPermission(c *gin.Context, permissionCode string) (usesKey, granted bool) {
+	value, exists := c.Get("api_key_permissions")
+	if !exists {
+		return false, false
+	}
+	codes, _ := value.([]string)
+	for _, code := range codes {
+		if code == permissionCode {
+			return true, true
+		}
+	}
+	return true, false
+}
@@ -3,14 +3,31 @@ package middleware
 import (
 	"strings"
 
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates JWT token and sets user info in context
+// AuthMiddleware validates JWT token and sets user info in context. API keys (X-API-Key header or
+// a Bearer token starting with tkk_) are accepted too, see SetAPIKeyAuthenticator.
 func AuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
+	return authMiddleware(jwtManager, true)
+}
+
+// UserAuthMiddleware is AuthMiddleware for routes only a logged in user may call, such as managing
+// the account, its sessions or API keys; API keys are rejected
+func UserAuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
+	return authMiddleware(jwtManager, false)
+}
+
+func authMiddleware(jwtManager *jwt.JWTManager, allowAPIKeys bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := c.GetHeader(apikey.Header); key != "" {
+			handleAPIKey(c, key, allowAPIKeys)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			errors.UnauthorizedResponse(c, "token missing")
@@ -28,11 +45,20 @@ func AuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
+		if apikey.IsKey(tokenString) {
+			handleAPIKey(c, tokenString, allowAPIKeys)
+			return
+		}
+
 		// Validate token
 		claims, err := jwtManager.ValidateToken(tokenString)
 		if err != nil {
 			if err == jwt.ErrExpiredToken {
 				errors.ErrorResponse(c, "TOKEN_EXPIRED", nil, nil)
+			} else if err == jwt.ErrRevokedToken {
+				errors.ErrorResponse(c, "SESSION_REVOKED", nil, nil)
+			} else if err == jwt.ErrStaleToken {
+				errors.ErrorResponse(c, "TOKEN_PERMISSIONS_STALE", nil, nil)
 			} else {
 				errors.ErrorResponse(c, "TOKEN_INVALID", nil, nil)
 			}
@@ -45,7 +71,18 @@ func AuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
 		c.Set("role_id", claims.RoleID)
+		c.Set("organization_id", claims.OrganizationID)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
 }
+
+func handleAPIKey(c *gin.Context, key string, allowAPIKeys bool) {
+	if !allowAPIKeys {
+		errors.ErrorResponse(c, "API_KEY_NOT_ALLOWED", nil, nil)
+		c.Abort()
+		return
+	}
+	authenticateAPIKey(c, key)
+}
@@ -53,6 +53,8 @@ func CORSMiddleware() gin.HandlerFunc {
 		"Accept",
 		"X-Requested-With",
 		"X-Request-ID",
+		"X-Device-ID",
+		"X-Device-Token",
 	}
 	corsCfg.AllowCredentials = true
 	corsCfg.ExposeHeaders = []string{"X-Request-ID"}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	stderrors "errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DeviceAuthMiddleware identifies the registered scanner device from the X-Device-Token header and
+// sets device_id and device_gate_id in context. Unknown or revoked tokens are rejected; a missing
+// token is only rejected when required is true.
+func DeviceAuthMiddleware(deviceRepo gatedevicerepo.Repository, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(gate.DeviceTokenHeader)
+		if token == "" {
+			if required {
+				errors.ErrorResponse(c, "DEVICE_NOT_REGISTERED", map[string]interface{}{
+					"reason": "Device token missing",
+				}, nil)
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		device, err := deviceRepo.FindByTokenHash(gate.HashDeviceToken(token))
+		if err != nil {
+			if stderrors.Is(err, gorm.ErrRecordNotFound) {
+				errors.ErrorResponse(c, "DEVICE_NOT_REGISTERED", nil, nil)
+			} else {
+				errors.InternalServerErrorResponse(c, "")
+			}
+			c.Abort()
+			return
+		}
+
+		if device.Status != gate.DeviceStatusActive {
+			errors.ErrorResponse(c, "DEVICE_REVOKED", map[string]interface{}{
+				"device_id": device.ID,
+			}, nil)
+			c.Abort()
+			return
+		}
+
+		c.Set("device_id", device.ID)
+		c.Set("device_gate_id", device.GateID)
+
+		c.Next()
+	}
+}
@@ -6,7 +6,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// RequirePermission creates a middleware that checks if the user's role has the required permission
+// PermissionResolver answers RequirePermission's permission checks, typically from a cache
+type PermissionResolver interface {
+	HasPermission(roleID string, permissionCode string) (bool, error)
+}
+
+var permissionResolver PermissionResolver
+
+// SetPermissionResolver makes RequirePermission check permissions with the resolver instead of
+// querying the role repository on every request
+func SetPermissionResolver(resolver PermissionResolver) {
+	permissionResolver = resolver
+}
+
+// RequirePermission creates a middleware that checks if the user's role has the required permission.
+// On routes declaring an EventContext, a role bound to the user within that event also counts.
 func RequirePermission(permissionCode string, roleRepo role.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		roleID, exists := c.Get("role_id")
@@ -27,7 +41,25 @@ func RequirePermission(permissionCode string, roleRepo role.Repository) gin.Hand
 			return
 		}
 
-		hasPermission, err := roleRepo.HasPermission(roleIDStr, permissionCode)
+		// API keys only get the permissions granted to them, on top of their owner's role
+		if usesKey, granted := apiKeyHasPermission(c, permissionCode); usesKey && !granted {
+			errors.ErrorResponse(c, "FORBIDDEN", map[string]interface{}{
+				"required_permission": permissionCode,
+				"reason":              "Permission not granted to the API key",
+			}, nil)
+			c.Abort()
+			return
+		}
+
+		var resolver PermissionResolver = roleRepo
+		if permissionResolver != nil {
+			resolver = permissionResolver
+		}
+		hasPermission, err := resolver.HasPermission(roleIDStr, permissionCode)
+		if err == nil && !hasPermission {
+			// Roles bound to the user within the event of the request, see EventContext
+			hasPermission, err = hasEventPermission(c, permissionCode)
+		}
 		if err != nil {
 			errors.InternalServerErrorResponse(c, "")
 			c.Abort()
@@ -0,0 +1,30 @@
+package apikey
+
+import (
+	apikeyhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	apiKeyHandler *apikeyhandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// API key management (admin only); keys cannot manage keys themselves
+	keyRoutes := router.Group("/admin/api-keys")
+	keyRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	keyRoutes.Use(middleware.RequirePermission("api_key.read", roleRepo))
+	keyRoutes.Use(middleware.OrganizationResource(organization.ResourceAPIKey, "id"))
+	{
+		keyRoutes.GET("", apiKeyHandler.List)                                                                         // List API keys
+		keyRoutes.POST("", middleware.RequirePermission("api_key.create", roleRepo), apiKeyHandler.Create)            // Create API key
+		keyRoutes.GET("/:id", apiKeyHandler.GetByID)                                                                  // Get API key
+		keyRoutes.POST("/:id/revoke", middleware.RequirePermission("api_key.revoke", roleRepo), apiKeyHandler.Revoke) // Revoke API key
+		keyRoutes.GET("/:id/calls", apiKeyHandler.ListCalls)                                                          // Calls made with the key
+	}
+}
@@ -3,6 +3,7 @@ package attendee
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/attendee"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -17,6 +18,7 @@ func SetupRoutes(
 	// Admin only routes
 	adminRoutes := router.Group("/admin/attendees")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.EventContextQuery(roledomain.ContextSchedule, "schedule_id")) // Attendance of one schedule
 	adminRoutes.Use(middleware.RequirePermission("attendee.read", roleRepo))
 	{
 		adminRoutes.GET("", attendeeHandler.List)                    // List all attendees
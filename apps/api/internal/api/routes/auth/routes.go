@@ -13,10 +13,32 @@ func SetupRoutes(router *gin.RouterGroup, authHandler *auth.Handler, jwtManager
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/register", authHandler.Register) // Public buyer self-registration
 		auth.POST("/refresh", authHandler.RefreshToken)
-		auth.POST("/logout", middleware.AuthMiddleware(jwtManager), authHandler.Logout)
+		auth.POST("/logout", middleware.UserAuthMiddleware(jwtManager), authHandler.Logout)
+		auth.POST("/logout-all", middleware.UserAuthMiddleware(jwtManager), authHandler.LogoutAll) // Revoke all sessions of the user
+		auth.POST("/forgot-password", authHandler.ForgotPassword)
+		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/verify-email/resend", middleware.UserAuthMiddleware(jwtManager), authHandler.ResendVerification)
+
+		// Two-factor login step (authenticated by the challenge token from /login)
+		auth.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+		auth.POST("/2fa/enroll", authHandler.EnrollTwoFactorChallenge) // Enrollment required by the role, during login
+
+		// Social login (OpenID Connect, buyers only)
+		auth.GET("/oidc/providers", authHandler.ListOIDCProviders)
+		auth.POST("/oidc/:provider/start", authHandler.StartOIDCLogin)
+		auth.POST("/oidc/:provider/callback", authHandler.OIDCCallback)
 		
 		// Protected routes
-		auth.GET("/me/menus-permissions", middleware.AuthMiddleware(jwtManager), authHandler.GetUserMenusAndPermissions)
+		auth.GET("/me/menus-permissions", middleware.UserAuthMiddleware(jwtManager), authHandler.GetUserMenusAndPermissions)
+		auth.GET("/sessions", middleware.UserAuthMiddleware(jwtManager), authHandler.ListSessions)          // Active sessions of the user
+		auth.DELETE("/sessions/:id", middleware.UserAuthMiddleware(jwtManager), authHandler.RevokeSession)  // Revoke one session
+		auth.GET("/login-history", middleware.UserAuthMiddleware(jwtManager), authHandler.ListLoginHistory) // Login attempts of the user
+		auth.GET("/2fa", middleware.UserAuthMiddleware(jwtManager), authHandler.GetTwoFactorStatus)
+		auth.POST("/2fa/setup", middleware.UserAuthMiddleware(jwtManager), authHandler.SetupTwoFactor)
+		auth.POST("/2fa/confirm", middleware.UserAuthMiddleware(jwtManager), authHandler.ConfirmTwoFactor)
+		auth.POST("/2fa/disable", middleware.UserAuthMiddleware(jwtManager), authHandler.DisableTwoFactor)
+		auth.POST("/2fa/recovery-codes", middleware.UserAuthMiddleware(jwtManager), authHandler.RegenerateRecoveryCodes)
 	}
 }
 
@@ -0,0 +1,58 @@
+package ballot
+
+import (
+	"time"
+
+	ballothandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/ballot"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	ballotHandler *ballothandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Guest/User routes (logged in users; API keys are rejected since these routes check no permission)
+	guestRoutes := router.Group("/ballots")
+	guestRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	{
+		guestRoutes.GET("/:id", ballotHandler.GetByID)                // Get ballot details
+		guestRoutes.POST("/:id/entries", ballotHandler.Enter)         // Register entry
+		guestRoutes.DELETE("/:id/entries/me", ballotHandler.Withdraw) // Withdraw my entry
+	}
+
+	entryRoutes := router.Group("/ballot-entries")
+	entryRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	{
+		entryRoutes.GET("", ballotHandler.GetMyEntries) // List my entries (with active claim tokens)
+	}
+
+	// Claims create orders, so they share the order rate limit and idempotency handling
+	claimRoutes := router.Group("/ballot-claims")
+	claimRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	claimRoutes.Use(middleware.OrderRateLimitMiddleware())
+	{
+		claimRoutes.POST("/:token", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), ballotHandler.Claim) // Claim winning entry as order
+	}
+
+	// Admin only routes
+	adminRoutes := router.Group("/admin/ballots")
+	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.RequirePermission("ballot.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceBallot, "id"))
+	{
+		adminRoutes.GET("", ballotHandler.List)                                                                      // List ballots
+		adminRoutes.GET("/:id", ballotHandler.GetByID)                                                               // Get ballot by ID
+		adminRoutes.GET("/:id/entries", ballotHandler.ListEntries)                                                   // List entries
+		adminRoutes.GET("/:id/verify", ballotHandler.VerifyDraw)                                                     // Re-run draw from recorded seed
+		adminRoutes.POST("", middleware.RequirePermission("ballot.create", roleRepo), ballotHandler.Create)          // Create ballot
+		adminRoutes.PUT("/:id", middleware.RequirePermission("ballot.update", roleRepo), ballotHandler.Update)       // Update ballot (before draw)
+		adminRoutes.POST("/:id/draw", middleware.RequirePermission("ballot.draw", roleRepo), ballotHandler.Draw)     // Run seeded draw
+		adminRoutes.POST("/:id/close", middleware.RequirePermission("ballot.update", roleRepo), ballotHandler.Close) // Close ballot, back to public sale
+	}
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/checkin"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -13,28 +16,57 @@ import (
 func SetupRoutes(
 	router *gin.RouterGroup,
 	checkInHandler *checkin.Handler,
+	deviceRepo gatedevicerepo.Repository,
 	roleRepo role.Repository,
 	jwtManager *jwt.JWTManager,
 ) {
 	// Check-in routes (authenticated users)
 	checkInRoutes := router.Group("/check-in")
 	checkInRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	checkInRoutes.Use(middleware.EventContextBody(roledomain.ContextSchedule, "schedule_id")) // Staff bound to the schedule's event
 	checkInRoutes.Use(middleware.RequirePermission("checkin.create", roleRepo))
-	checkInRoutes.Use(middleware.CheckInRateLimitMiddleware()) // Rate limiting for check-in endpoints
+	checkInRoutes.Use(middleware.CheckInRateLimitMiddleware())            // Rate limiting for check-in endpoints
+	checkInRoutes.Use(middleware.DeviceAuthMiddleware(deviceRepo, false)) // Attribute scans to a registered device when a token is sent
 	{
 		checkInRoutes.POST("/validate", checkInHandler.ValidateQRCode)                                                                        // Validate QR code
 		checkInRoutes.POST("", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), checkInHandler.CheckIn) // Perform check-in
 	}
 
+	// Exit scans are not tied to a schedule, so staff bound to single events cannot record them
+	exitRoutes := router.Group("/check-in")
+	exitRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	exitRoutes.Use(middleware.RequirePermission("checkin.create", roleRepo))
+	exitRoutes.Use(middleware.CheckInRateLimitMiddleware())
+	exitRoutes.Use(middleware.DeviceAuthMiddleware(deviceRepo, false))
+	{
+		exitRoutes.POST("/exit", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), checkInHandler.Exit) // Record exit scan
+	}
+
 	// Check-in history routes (admin only)
 	adminRoutes := router.Group("/check-ins")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	adminRoutes.Use(middleware.RequirePermission("checkin.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceCheckIn, "id"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceQRCode, "qr_code"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceOrderItem, "order_item_id"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "gate_id"))
 	{
 		adminRoutes.GET("", checkInHandler.List)                                       // List all check-ins
+		adminRoutes.GET("/occupancy", checkInHandler.GetOccupancy)                     // Currently inside per schedule and gate
 		adminRoutes.GET("/:id", checkInHandler.GetByID)                                // Get check-in by ID
 		adminRoutes.GET("/qr/:qr_code", checkInHandler.GetByQRCode)                    // Get check-ins by QR code
 		adminRoutes.GET("/order-item/:order_item_id", checkInHandler.GetByOrderItemID) // Get check-ins by order item ID
+		adminRoutes.GET("/order-item/:order_item_id/scans", checkInHandler.GetScansByOrderItemID) // Entry/exit history of a ticket
 		adminRoutes.GET("/gate/:gate_id", checkInHandler.GetByGateID)                  // Get check-ins by gate ID
 	}
+
+	// Check-in correction routes (supervisor only)
+	supervisorRoutes := router.Group("/check-ins")
+	supervisorRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	supervisorRoutes.Use(middleware.EventContext(roledomain.ContextCheckIn, "id"))
+	supervisorRoutes.Use(middleware.RequirePermission("checkin.void", roleRepo))
+	supervisorRoutes.Use(middleware.OrganizationResource(organization.ResourceCheckIn, "id"))
+	{
+		supervisorRoutes.POST("/:id/void", checkInHandler.Void) // Void mistaken check-in
+	}
 }
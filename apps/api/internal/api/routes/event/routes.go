@@ -3,6 +3,8 @@ package event
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/event"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -17,7 +19,9 @@ func SetupRoutes(
 	// Admin only routes
 	adminRoutes := router.Group("/admin/events")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.EventContext(roledomain.ContextEvent, "id"))
 	adminRoutes.Use(middleware.RequirePermission("event.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceEvent, "id"))
 	{
 		adminRoutes.GET("", eventHandler.List)                    // List all events
 		adminRoutes.GET("/:id", eventHandler.GetByID)            // Get event by ID
@@ -0,0 +1,36 @@
+package fraud
+
+import (
+	fraudhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/fraud"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	fraudHandler *fraudhandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Fraud alert routes (supervisors)
+	readRoutes := router.Group("/fraud-alerts")
+	readRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	readRoutes.Use(middleware.RequirePermission("fraud.read", roleRepo))
+	readRoutes.Use(middleware.OrganizationResource(organization.ResourceFraudAlert, "id"))
+	{
+		readRoutes.GET("", fraudHandler.List)        // List alerts
+		readRoutes.GET("/:id", fraudHandler.GetByID) // Get alert by ID
+	}
+
+	reviewRoutes := router.Group("/fraud-alerts")
+	reviewRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	reviewRoutes.Use(middleware.RequirePermission("fraud.review", roleRepo))
+	reviewRoutes.Use(middleware.OrganizationResource(organization.ResourceFraudAlert, "id"))
+	{
+		reviewRoutes.POST("/:id/block", fraudHandler.BlockTicket) // Block ticket pending review
+		reviewRoutes.POST("/:id/review", fraudHandler.Review)     // Confirm or dismiss alert
+	}
+}
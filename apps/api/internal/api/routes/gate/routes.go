@@ -5,6 +5,9 @@ import (
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/gate"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -13,6 +16,7 @@ import (
 func SetupRoutes(
 	router *gin.RouterGroup,
 	gateHandler *gate.Handler,
+	deviceRepo gatedevicerepo.Repository,
 	roleRepo role.Repository,
 	jwtManager *jwt.JWTManager,
 ) {
@@ -20,11 +24,19 @@ func SetupRoutes(
 	gateRoutes := router.Group("/gates")
 	gateRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	gateRoutes.Use(middleware.RequirePermission("gate.read", roleRepo))
+	gateRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
 	{
 		gateRoutes.GET("", gateHandler.List)                         // List all gates
+		gateRoutes.GET("/devices", gateHandler.ListDevices)          // List scanner devices
+		gateRoutes.GET("/occupancy", gateHandler.GetOccupancy)       // Live admission counters of all gates
+		gateRoutes.GET("/shifts", gateHandler.ListShifts)            // List staff shifts
+		gateRoutes.GET("/shifts/report", gateHandler.GetShiftReport) // Scans per staff per shift
 		gateRoutes.GET("/:id", gateHandler.GetByID)                  // Get gate by ID
 		gateRoutes.GET("/:id/statistics", gateHandler.GetStatistics) // Get gate statistics
 		gateRoutes.GET("/:id/staff", gateHandler.GetAssignedStaff)   // Get staff assigned to gate
+		gateRoutes.GET("/:id/devices", gateHandler.ListDevices)      // List devices enrolled at gate
+		gateRoutes.GET("/:id/occupancy", gateHandler.GetOccupancy)   // Live admission counters of gate
+		gateRoutes.GET("/:id/shifts", gateHandler.ListShifts)        // List staff shifts at gate
 	}
 
 	// Gate management routes (admin only for create/update/delete)
@@ -38,6 +50,7 @@ func SetupRoutes(
 	adminUpdateRoutes := router.Group("/gates")
 	adminUpdateRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	adminUpdateRoutes.Use(middleware.RequirePermission("gate.update", roleRepo))
+	adminUpdateRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
 	{
 		adminUpdateRoutes.PUT("/:id", gateHandler.Update) // Update gate
 	}
@@ -45,6 +58,7 @@ func SetupRoutes(
 	adminDeleteRoutes := router.Group("/gates")
 	adminDeleteRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	adminDeleteRoutes.Use(middleware.RequirePermission("gate.delete", roleRepo))
+	adminDeleteRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
 	{
 		adminDeleteRoutes.DELETE("/:id", gateHandler.Delete) // Delete gate
 	}
@@ -53,26 +67,69 @@ func SetupRoutes(
 	assignmentRoutes := router.Group("/gates")
 	assignmentRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	assignmentRoutes.Use(middleware.RequirePermission("gate.update", roleRepo))
+	assignmentRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
+	assignmentRoutes.Use(middleware.OrganizationResource(organization.ResourceGateDevice, "device_id"))
 	{
 		assignmentRoutes.POST("/:id/assign-ticket", gateHandler.AssignTicketToGate)               // Assign ticket to gate
 		assignmentRoutes.POST("/:id/assign-staff", gateHandler.AssignStaffToGate)                 // Assign staff to gate
 		assignmentRoutes.DELETE("/:id/assign-staff/:staff_id", gateHandler.UnassignStaffFromGate) // Unassign staff from gate
+		assignmentRoutes.POST("/:id/devices", gateHandler.EnrollDevice)                           // Enroll scanner device at gate
+		assignmentRoutes.POST("/devices/:device_id/revoke", gateHandler.RevokeDevice)             // Revoke scanner device
+	}
+
+	// Shift routes act within the event of the shift's schedule, so event-scoped roles apply
+	shiftRoutes := router.Group("/gates")
+	shiftRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	shiftRoutes.Use(middleware.EventContext(roledomain.ContextGateShift, "shift_id"))
+	shiftRoutes.Use(middleware.EventContextBody(roledomain.ContextSchedule, "schedule_id"))
+	shiftRoutes.Use(middleware.RequirePermission("gate.update", roleRepo))
+	shiftRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
+	shiftRoutes.Use(middleware.OrganizationResource(organization.ResourceGateShift, "shift_id"))
+	{
+		shiftRoutes.POST("/:id/shifts", gateHandler.CreateShift)         // Schedule staff shift at gate
+		shiftRoutes.DELETE("/shifts/:shift_id", gateHandler.DeleteShift) // Cancel upcoming shift
+		shiftRoutes.POST("/shifts/:shift_id/end", gateHandler.EndShift)  // End active shift early
+	}
+
+	// Device heartbeat (authenticated by device token, no user session)
+	deviceRoutes := router.Group("/gates/devices")
+	deviceRoutes.Use(middleware.DeviceAuthMiddleware(deviceRepo, true))
+	{
+		deviceRoutes.POST("/heartbeat", gateHandler.Heartbeat)
 	}
 
 	// My gates (staff)
 	myGateRoutes := router.Group("/gates")
 	myGateRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	myGateRoutes.Use(middleware.EventContext(roledomain.ContextGateShift, "shift_id"))
 	myGateRoutes.Use(middleware.RequirePermission("checkin.create", roleRepo))
+	myGateRoutes.Use(middleware.OrganizationResource(organization.ResourceGateShift, "shift_id"))
 	{
 		myGateRoutes.GET("/my", gateHandler.ListMyGates)
+		myGateRoutes.GET("/my/shifts", gateHandler.ListMyShifts)
+		myGateRoutes.POST("/shifts/:shift_id/handover", gateHandler.HandoverShift) // Hand own shift over (admins: any shift)
 	}
 
 	// Gate check-in routes (authenticated users - staff can check-in at their assigned gate)
 	checkInRoutes := router.Group("/gates")
 	checkInRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	checkInRoutes.Use(middleware.EventContextBody(roledomain.ContextSchedule, "schedule_id")) // Staff bound to the schedule's event
 	checkInRoutes.Use(middleware.RequirePermission("checkin.create", roleRepo))
-	checkInRoutes.Use(middleware.CheckInRateLimitMiddleware()) // Rate limiting for check-in endpoints
+	checkInRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
+	checkInRoutes.Use(middleware.CheckInRateLimitMiddleware())            // Rate limiting for check-in endpoints
+	checkInRoutes.Use(middleware.DeviceAuthMiddleware(deviceRepo, false)) // Attribute scans to a registered device when a token is sent
 	{
 		checkInRoutes.POST("/:id/check-in", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), gateHandler.GateCheckIn) // Perform check-in at gate
 	}
+
+	// Gate exit routes (exit scans are not tied to a schedule, so no event-scoped roles)
+	exitRoutes := router.Group("/gates")
+	exitRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	exitRoutes.Use(middleware.RequirePermission("checkin.create", roleRepo))
+	exitRoutes.Use(middleware.OrganizationResource(organization.ResourceGate, "id"))
+	exitRoutes.Use(middleware.CheckInRateLimitMiddleware())
+	exitRoutes.Use(middleware.DeviceAuthMiddleware(deviceRepo, false))
+	{
+		exitRoutes.POST("/:id/exit", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), gateHandler.GateExit) // Record exit scan at gate
+	}
 }
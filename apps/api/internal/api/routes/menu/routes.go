@@ -14,9 +14,9 @@ func SetupRoutes(
 	roleRepo role.Repository,
 	jwtManager *jwt.JWTManager,
 ) {
-	// Public menu routes (for authenticated users)
+	// Public menu routes (for logged in users, not API keys)
 	menuRoutes := router.Group("/menus")
-	menuRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	menuRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
 	{
 		menuRoutes.GET("", menuHandler.GetMenusByRole) // Get menus for current user
 	}
@@ -3,6 +3,7 @@ package merchandise
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/merchandise"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,7 @@ func SetupRoutes(
 	merchandiseRoutes := router.Group("/merchandise")
 	merchandiseRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	merchandiseRoutes.Use(middleware.RequirePermission("merchandise.read", roleRepo))
+	merchandiseRoutes.Use(middleware.OrganizationResource(organization.ResourceMerchandise, "id"))
 	{
 		merchandiseRoutes.GET("", merchandiseHandler.List)                    // Get all merchandises
 		merchandiseRoutes.GET("/inventory", merchandiseHandler.GetInventory)  // Get inventory summary
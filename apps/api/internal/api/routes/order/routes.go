@@ -6,6 +6,7 @@ import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/order"
 	orderitemhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/order_item"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -26,9 +27,10 @@ func SetupRoutes(
 		webhookGroup.POST("/webhook", orderHandler.HandlePaymentWebhook)
 	}
 
-	// Guest/User routes (authenticated users, rate limited for anti-overselling)
+	// Guest/User routes (logged in users, rate limited for anti-overselling; API keys are rejected
+	// since these routes check no permission)
 	guestRoutes := router.Group("/orders")
-	guestRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	guestRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
 	guestRoutes.Use(middleware.OrderRateLimitMiddleware())
 	{
 		guestRoutes.POST("", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), orderHandler.CreateOrder) // Create order
@@ -37,6 +39,7 @@ func SetupRoutes(
 		guestRoutes.GET("/:id/tickets", orderItemHandler.GetMyOrderTickets)                                                                                   // Get my order tickets
 		guestRoutes.POST("/:id/payment", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), orderHandler.InitiatePayment) // Initiate payment
 		guestRoutes.GET("/:id/payment-status", orderHandler.CheckPaymentStatus)                                                                               // Check payment status
+		guestRoutes.POST("/:id/items/:item_id/upgrade", middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), orderHandler.UpgradeTicket) // Upgrade ticket (creates price-difference order)
 		guestRoutes.GET("/:id", orderHandler.GetMyOrder)                                                                                                      // Get my order by ID (must be last)
 	}
 
@@ -44,6 +47,8 @@ func SetupRoutes(
 	adminRoutes := router.Group("/admin/orders")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	adminRoutes.Use(middleware.RequirePermission("order.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceOrder, "id"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceOrderCode, "order_code"))
 	{
 		adminRoutes.GET("", orderHandler.List)                            // List all orders with filters
 		adminRoutes.GET("/recent", orderHandler.GetRecentOrders)          // Get recent orders
@@ -0,0 +1,27 @@
+package organization
+
+import (
+	organizationhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	organizationHandler *organizationhandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Organizations (tenants); staff only see their own, super admins manage them all
+	organizationRoutes := router.Group("/admin/organizations")
+	organizationRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	organizationRoutes.Use(middleware.RequirePermission("organization.read", roleRepo))
+	{
+		organizationRoutes.GET("", organizationHandler.List)                                                                                                      // List organizations
+		organizationRoutes.GET("/:id", organizationHandler.GetByID)                                                                                               // Get organization
+		organizationRoutes.POST("", middleware.RequireSuperAdmin(), middleware.RequirePermission("organization.create", roleRepo), organizationHandler.Create)    // Create organization
+		organizationRoutes.PUT("/:id", middleware.RequireSuperAdmin(), middleware.RequirePermission("organization.update", roleRepo), organizationHandler.Update) // Update organization
+	}
+}
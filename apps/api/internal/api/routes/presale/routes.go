@@ -0,0 +1,33 @@
+package presale
+
+import (
+	presalehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/presale"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	presaleHandler *presalehandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Admin only routes
+	adminRoutes := router.Group("/admin/presale-batches")
+	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.RequirePermission("presale.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourcePresaleBatch, "id"))
+	{
+		adminRoutes.GET("", presaleHandler.ListBatches)                                                                        // List presale batches
+		adminRoutes.GET("/:id", presaleHandler.GetBatchByID)                                                                   // Get presale batch by ID
+		adminRoutes.GET("/:id/report", presaleHandler.GetBatchReport)                                                          // Redemption report
+		adminRoutes.GET("/:id/codes", presaleHandler.ListCodes)                                                                // List codes
+		adminRoutes.GET("/:id/codes/export", presaleHandler.ExportCodes)                                                       // Export codes to CSV
+		adminRoutes.POST("", middleware.RequirePermission("presale.create", roleRepo), presaleHandler.CreateBatch)             // Create batch and generate codes
+		adminRoutes.PUT("/:id", middleware.RequirePermission("presale.update", roleRepo), presaleHandler.UpdateBatch)          // Update batch (window, role, activation)
+		adminRoutes.POST("/:id/codes", middleware.RequirePermission("presale.create", roleRepo), presaleHandler.GenerateCodes) // Generate additional codes
+	}
+}
@@ -0,0 +1,30 @@
+package quotaallocation
+
+import (
+	quotaallocationhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/quota_allocation"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	quotaAllocationHandler *quotaallocationhandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Admin only routes
+	adminRoutes := router.Group("/admin/quota-allocations")
+	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.RequirePermission("quota_allocation.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceQuotaAllocation, "id"))
+	{
+		adminRoutes.GET("", quotaAllocationHandler.List)                                                                                    // List quota allocations
+		adminRoutes.GET("/:id", quotaAllocationHandler.GetByID)                                                                             // Get quota allocation by ID
+		adminRoutes.POST("", middleware.RequirePermission("quota_allocation.create", roleRepo), quotaAllocationHandler.Create)              // Carve allocation out of a category
+		adminRoutes.PUT("/:id", middleware.RequirePermission("quota_allocation.update", roleRepo), quotaAllocationHandler.Update)           // Update/resize allocation
+		adminRoutes.POST("/:id/release", middleware.RequirePermission("quota_allocation.update", roleRepo), quotaAllocationHandler.Release) // Release unused seats to public quota
+	}
+}
@@ -0,0 +1,59 @@
+package resale
+
+import (
+	"time"
+
+	resalehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/resale"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	resaleHandler *resalehandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Guest/User routes (logged in users; API keys are rejected since these routes check no permission)
+	guestRoutes := router.Group("/resale-listings")
+	guestRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	{
+		guestRoutes.GET("", resaleHandler.Browse)                 // Browse active listings
+		guestRoutes.POST("", resaleHandler.CreateListing)         // List my ticket for resale
+		guestRoutes.GET("/me", resaleHandler.GetMyListings)       // List my listings
+		guestRoutes.GET("/:id", resaleHandler.GetListing)         // Get listing
+		guestRoutes.DELETE("/:id", resaleHandler.CancelMyListing) // Cancel my listing
+		// Purchases create orders, so they share the order rate limit and idempotency handling
+		guestRoutes.POST("/:id/purchase", middleware.OrderRateLimitMiddleware(), middleware.IdempotencyMiddleware(middleware.IdempotencyConfig{TTL: 10 * time.Minute}), resaleHandler.Purchase) // Buy listing
+	}
+
+	payoutRoutes := router.Group("/resale-payouts")
+	payoutRoutes.Use(middleware.UserAuthMiddleware(jwtManager))
+	{
+		payoutRoutes.GET("", resaleHandler.GetMyPayouts) // List my seller payouts
+	}
+
+	// Admin only routes
+	adminRoutes := router.Group("/admin/resale-listings")
+	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.RequirePermission("resale.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceResaleListing, "id"))
+	{
+		adminRoutes.GET("", resaleHandler.List)                                                                        // List listings
+		adminRoutes.GET("/:id", resaleHandler.GetByID)                                                                 // Get listing by ID
+		adminRoutes.POST("/:id/cancel", middleware.RequirePermission("resale.update", roleRepo), resaleHandler.Cancel) // Cancel active listing
+	}
+
+	adminPayoutRoutes := router.Group("/admin/resale-payouts")
+	adminPayoutRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminPayoutRoutes.Use(middleware.RequirePermission("resale.read", roleRepo))
+	adminPayoutRoutes.Use(middleware.OrganizationResource(organization.ResourceResalePayout, "id"))
+	{
+		adminPayoutRoutes.GET("", resaleHandler.ListPayouts)                                                                            // List seller payouts
+		adminPayoutRoutes.GET("/summary", resaleHandler.GetPayoutSummary)                                                               // Reconciliation totals
+		adminPayoutRoutes.POST("/:id/mark-paid", middleware.RequirePermission("resale.payout", roleRepo), resaleHandler.MarkPayoutPaid) // Record payout transfer
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	rolehandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 )
@@ -21,10 +22,28 @@ func SetupRoutes(
 	{
 		adminRoutes.GET("", roleHandler.List)                                    // Get all roles (admin)
 		adminRoutes.GET("/:id", roleHandler.GetByID)                             // Get role by ID (admin)
-		adminRoutes.POST("", middleware.RequirePermission("role.create", roleRepo), roleHandler.Create)                    // Create role (admin)
-		adminRoutes.PUT("/:id", middleware.RequirePermission("role.update", roleRepo), roleHandler.Update)                // Update role (admin)
-		adminRoutes.DELETE("/:id", middleware.RequirePermission("role.delete", roleRepo), roleHandler.Delete)            // Delete role (admin)
-		adminRoutes.PUT("/:id/permissions", middleware.RequirePermission("role.assign_permissions", roleRepo), roleHandler.AssignPermissions) // Assign permissions to role (admin)
+	}
+
+	// Roles are shared by every organization, so only super admins change them
+	manageRoutes := router.Group("/admin/roles")
+	manageRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	manageRoutes.Use(middleware.RequireSuperAdmin())
+	{
+		manageRoutes.POST("", middleware.RequirePermission("role.create", roleRepo), roleHandler.Create)                    // Create role (super admin)
+		manageRoutes.PUT("/:id", middleware.RequirePermission("role.update", roleRepo), roleHandler.Update)                // Update role (super admin)
+		manageRoutes.DELETE("/:id", middleware.RequirePermission("role.delete", roleRepo), roleHandler.Delete)            // Delete role (super admin)
+		manageRoutes.PUT("/:id/permissions", middleware.RequirePermission("role.assign_permissions", roleRepo), roleHandler.AssignPermissions) // Assign permissions to role (super admin)
+	}
+
+	// Event-scoped role bindings (user has the role only within one event)
+	bindingRoutes := router.Group("/admin/roles/:id/bindings")
+	bindingRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	bindingRoutes.Use(middleware.RequirePermission("role.read", roleRepo))
+	bindingRoutes.Use(middleware.OrganizationResource(organization.ResourceRoleBinding, "binding_id"))
+	{
+		bindingRoutes.GET("", roleHandler.ListBindings)                                                                      // List users bound to role per event
+		bindingRoutes.POST("", middleware.RequirePermission("role.bind", roleRepo), roleHandler.CreateBinding)               // Bind role to user within event
+		bindingRoutes.DELETE("/:binding_id", middleware.RequirePermission("role.bind", roleRepo), roleHandler.DeleteBinding) // Remove binding
 	}
 }
 
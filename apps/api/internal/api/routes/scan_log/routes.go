@@ -0,0 +1,25 @@
+package scanlog
+
+import (
+	scanloghandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/scan_log"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	scanLogHandler *scanloghandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// Scan log routes (admin and supervisors)
+	adminRoutes := router.Group("/admin/scan-logs")
+	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.RequirePermission("scan_log.read", roleRepo))
+	{
+		adminRoutes.GET("", scanLogHandler.List)                                                                     // List scan attempts
+		adminRoutes.GET("/export", middleware.RequirePermission("scan_log.export", roleRepo), scanLogHandler.Export) // Export to CSV
+	}
+}
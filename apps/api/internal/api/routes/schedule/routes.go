@@ -5,6 +5,8 @@ import (
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/schedule"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -26,7 +28,12 @@ func SetupRoutes(
 	// Admin only routes
 	adminRoutes := router.Group("/admin/schedules")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.EventContext(roledomain.ContextSchedule, "id"))
+	adminRoutes.Use(middleware.EventContext(roledomain.ContextEvent, "event_id"))
+	adminRoutes.Use(middleware.EventContextBody(roledomain.ContextEvent, "event_id"))
 	adminRoutes.Use(middleware.RequirePermission("schedule.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceSchedule, "id"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceEvent, "event_id"))
 	{
 		adminRoutes.GET("", scheduleHandler.List)                         // List all schedules
 		adminRoutes.GET("/:id", scheduleHandler.GetByID)                  // Get schedule by ID
@@ -0,0 +1,25 @@
+package signingkey
+
+import (
+	signingkeyhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/signing_key"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutes(
+	router *gin.RouterGroup,
+	signingKeyHandler *signingkeyhandler.Handler,
+	roleRepo role.Repository,
+	jwtManager *jwt.JWTManager,
+) {
+	// JWT signing key routes (admin only); the JWKS itself is served at /.well-known/jwks.json
+	keyRoutes := router.Group("/admin/signing-keys")
+	keyRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	keyRoutes.Use(middleware.RequirePermission("signing_key.read", roleRepo))
+	{
+		keyRoutes.GET("", signingKeyHandler.List)                                                                         // List signing keys
+		keyRoutes.POST("/rotate", middleware.RequirePermission("signing_key.rotate", roleRepo), signingKeyHandler.Rotate) // Rotate signing key
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	ticketcategoryhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/ticket_category"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	roledomain "github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
@@ -26,7 +28,12 @@ func SetupRoutes(
 	// Admin only routes
 	adminRoutes := router.Group("/admin/ticket-categories")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
+	adminRoutes.Use(middleware.EventContext(roledomain.ContextTicketCategory, "id"))
+	adminRoutes.Use(middleware.EventContext(roledomain.ContextEvent, "event_id"))
+	adminRoutes.Use(middleware.EventContextBody(roledomain.ContextEvent, "event_id"))
 	adminRoutes.Use(middleware.RequirePermission("ticket_category.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceTicketCategory, "id"))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceEvent, "event_id"))
 	{
 		adminRoutes.GET("", ticketCategoryHandler.List)                         // List all ticket categories
 		adminRoutes.GET("/:id", ticketCategoryHandler.GetByID)                  // Get ticket category by ID
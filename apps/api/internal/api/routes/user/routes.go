@@ -5,6 +5,7 @@ import (
 	userhandler "github.com/gilabs/webapp-ticket-konser/api/internal/api/handlers/user"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/api/middleware"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 )
 
@@ -18,12 +19,16 @@ func SetupRoutes(
 	adminRoutes := router.Group("/admin/users")
 	adminRoutes.Use(middleware.AuthMiddleware(jwtManager))
 	adminRoutes.Use(middleware.RequirePermission("user.read", roleRepo))
+	adminRoutes.Use(middleware.OrganizationResource(organization.ResourceUser, "id"))
 	{
 		adminRoutes.GET("", userHandler.List)          // Get all users (admin)
+		adminRoutes.GET("/login-activity", userHandler.GetSuspiciousLoginActivity) // Suspicious login activity review (admin)
 		adminRoutes.GET("/:id", userHandler.GetByID)   // Get user by ID (admin)
 		adminRoutes.POST("", middleware.RequirePermission("user.create", roleRepo), userHandler.Create)       // Create user (admin)
 		adminRoutes.PUT("/:id", middleware.RequirePermission("user.update", roleRepo), userHandler.Update)  // Update user (admin)
 		adminRoutes.DELETE("/:id", middleware.RequirePermission("user.delete", roleRepo), userHandler.Delete) // Delete user (admin)
+		adminRoutes.GET("/:id/login-history", userHandler.ListLoginHistory)                                      // Login history of a user (admin)
+		adminRoutes.POST("/:id/unlock", middleware.RequirePermission("user.update", roleRepo), userHandler.Unlock) // Lift login lockout (admin)
 	}
 }
 
@@ -18,12 +18,23 @@ type Config struct {
 	Obs      ObservabilityConfig
 	Cerebras CerebrasConfig
 	Midtrans MidtransConfig
+	Resale   ResaleConfig
+	CheckIn  CheckInConfig
+	Fraud    FraudConfig
+	Auth     AuthConfig
+	Mail     MailConfig
+	OIDC     OIDCConfig
+	APIKey   APIKeyConfig
+	PermissionCache PermissionCacheConfig
 }
 
 type ServerConfig struct {
 	Port string
 	Env  string
 
+	// GRPCPort serves the gRPC scanning API for gate devices; empty disables it.
+	GRPCPort string
+
 	// Request/transport hardening
 	// MaxBodyBytes limits the size of incoming request bodies.
 	// MaxHeaderBytes limits the size of request headers at the HTTP server level.
@@ -48,9 +59,13 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	SecretKey       string
-	AccessTokenTTL  int // in hours
-	RefreshTokenTTL int // in days
+	SecretKey          string
+	AccessTokenTTL     int    // in hours
+	RefreshTokenTTL    int    // in days
+	SigningAlgorithm   string // EdDSA or RS256 for rotating asymmetric keys; HS256 signs with SecretKey only
+	KeyEncryptionKey   string // Key signing keys are encrypted with at rest; defaults to one derived from SecretKey
+	RotationGraceHours int    // How long a rotated-out key still verifies tokens
+	AcceptLegacyHS256  bool   // Accept HS256 tokens issued before switching to asymmetric keys
 }
 
 type CerebrasConfig struct {
@@ -67,6 +82,87 @@ type MidtransConfig struct {
 	APIBaseURL   string // https://api.midtrans.com (production) atau https://api.sandbox.midtrans.com (sandbox)
 }
 
+// ResaleConfig controls the secondary resale marketplace
+type ResaleConfig struct {
+	MaxMarkupPercent   float64 // Maximum listing price over face value, in percent
+	PlatformFeePercent float64 // Fee kept by the platform from each sale, in percent of the sale price
+}
+
+// CheckInConfig controls when tickets may be scanned relative to their schedule
+type CheckInConfig struct {
+	EarlyOpenMinutes     int // Gates open this many minutes before the schedule's start time
+	LateCloseMinutes     int // Check-in stays open this many minutes after the schedule's end time
+	DeviceOfflineSeconds int // Scanner devices without a heartbeat for this long are reported offline
+}
+
+// FraudConfig controls the suspicious scan pattern rules
+type FraudConfig struct {
+	MultiGateWindowMinutes    int  // A ticket admitted at another gate within this window raises an alert
+	InvalidBurstThreshold     int  // Invalid codes from one device within the burst window that raise an alert
+	InvalidBurstWindowMinutes int  // Sliding window for counting invalid codes per device
+	AutoBlock                 bool // Block the ticket pending review when a high severity alert is raised
+}
+
+// AuthConfig controls account verification and password reset
+type AuthConfig struct {
+	AppURL                        string // Base URL of the web app, used for links in emails
+	RequireVerifiedEmailForOrders bool   // Users must verify their email before they can create orders
+	PasswordResetTTLMinutes       int    // Password reset links expire after this many minutes
+	EmailVerificationTTLHours     int    // Email verification links expire after this many hours
+	EmailsPerHour                 int    // Reset/verification emails that may be requested per address per hour
+	TOTPIssuer                    string // Issuer shown in authenticator apps
+	TOTPEncryptionKey             string // Key TOTP secrets are encrypted with at rest; defaults to one derived from JWT_SECRET
+	TwoFactorChallengeTTLMinutes  int    // Time to complete the two-factor step of a login
+	TwoFactorMaxAttempts          int    // Wrong codes allowed per login challenge before it is invalidated
+	LoginDelayAfterFailures       int    // Failed logins before an account has to wait between attempts
+	LoginMaxDelaySeconds          int    // Cap of the wait, which doubles with every further failure
+	LockoutThreshold              int    // Consecutive failed logins that lock an account
+	LockoutMinutes                int    // How long a locked account stays locked (admins can unlock earlier)
+	IPMaxFailures                 int    // Failed logins from one IP within IPWindowMinutes before the IP is throttled
+	IPWindowMinutes               int
+	SessionCacheTTLSeconds        int    // Longest a replica accepts access tokens of a session revoked on another replica without Redis
+}
+
+// MailConfig holds the SMTP server used for transactional emails; an empty host logs emails instead
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+}
+
+// OIDCConfig holds the OpenID Connect providers buyers can log in with. Providers are listed in
+// OIDC_PROVIDERS and configured with OIDC_<NAME>_* variables.
+type OIDCConfig struct {
+	Providers       []OIDCProviderConfig
+	StateTTLMinutes int // How long a started login can be completed
+}
+
+type OIDCProviderConfig struct {
+	Name         string // Lowercase identifier used in the API paths, e.g. google
+	DisplayName  string
+	Issuer       string // Discovery is read from <Issuer>/.well-known/openid-configuration
+	ClientID     string
+	ClientSecret string // Empty for public clients, which rely on PKCE alone
+	Scopes       []string
+	RedirectURL  string // Frontend callback the provider redirects to with the code
+}
+
+// APIKeyConfig controls the API keys partner systems authenticate with
+type APIKeyConfig struct {
+	DefaultTTLDays            int // Validity of a key created without expires_at
+	MaxTTLDays                int // Longest validity a key can be created with
+	DefaultRateLimitPerMinute int // Requests per minute of a key created without rate_limit_per_minute
+	LastUsedIntervalSeconds   int // last_used_at is written at most this often per key
+}
+
+// PermissionCacheConfig controls the per-role permission cache used by RequirePermission
+type PermissionCacheConfig struct {
+	TTLSeconds     int  // Longest a replica serves cached permissions without reloading them
+	VersionInToken bool // Embed the role's permissions version in access tokens and reject stale ones
+}
+
 type RedisConfig struct {
 	Enabled  bool
 	URL      string
@@ -159,6 +255,7 @@ func Load() error {
 		Server: ServerConfig{
 			Port: getEnv("PORT", "8083"),
 			Env:  env,
+			GRPCPort: getEnv("GRPC_PORT", ""),
 			MaxBodyBytes:           getEnvAsInt64("MAX_BODY_BYTES", 10*1024*1024),
 			MaxHeaderBytes:         getEnvAsInt("MAX_HEADER_BYTES", 1*1024*1024),
 			MaxMultipartMemoryBytes: getEnvAsInt64("MAX_MULTIPART_MEMORY_BYTES", 10*1024*1024),
@@ -173,9 +270,13 @@ func Load() error {
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
 		JWT: JWTConfig{
-			SecretKey:       jwtSecret,
-			AccessTokenTTL:  getEnvAsInt("JWT_ACCESS_TTL", 24), // 24 hours
-			RefreshTokenTTL: getEnvAsInt("JWT_REFRESH_TTL", 7), // 7 days
+			SecretKey:          jwtSecret,
+			AccessTokenTTL:     getEnvAsInt("JWT_ACCESS_TTL", 24), // 24 hours
+			RefreshTokenTTL:    getEnvAsInt("JWT_REFRESH_TTL", 7), // 7 days
+			SigningAlgorithm:   getEnv("JWT_SIGNING_ALGORITHM", "EdDSA"),
+			KeyEncryptionKey:   getEnv("JWT_KEY_ENCRYPTION_KEY", ""),
+			RotationGraceHours: getEnvAsInt("JWT_KEY_ROTATION_GRACE_HOURS", getEnvAsInt("JWT_REFRESH_TTL", 7)*24), // Refresh tokens are signed too
+			AcceptLegacyHS256:  getEnv("JWT_ACCEPT_LEGACY_HS256", "true") == "true",
 		},
 		Redis: RedisConfig{
 			Enabled:  getEnv("REDIS_ENABLED", "false") == "true",
@@ -206,6 +307,66 @@ func Load() error {
 			MerchantID:   getEnv("MIDTRANS_MERCHANT_ID", ""),
 			IsProduction: getEnv("MIDTRANS_IS_PRODUCTION", "false") == "true",
 		},
+		Resale: ResaleConfig{
+			MaxMarkupPercent:   getEnvAsFloat("RESALE_MAX_MARKUP_PERCENT", 10),
+			PlatformFeePercent: getEnvAsFloat("RESALE_PLATFORM_FEE_PERCENT", 5),
+		},
+		CheckIn: CheckInConfig{
+			EarlyOpenMinutes:     getEnvAsInt("CHECKIN_EARLY_OPEN_MINUTES", 120),
+			LateCloseMinutes:     getEnvAsInt("CHECKIN_LATE_CLOSE_MINUTES", 60),
+			DeviceOfflineSeconds: getEnvAsInt("CHECKIN_DEVICE_OFFLINE_SECONDS", 120),
+		},
+		Fraud: FraudConfig{
+			MultiGateWindowMinutes:    getEnvAsInt("FRAUD_MULTI_GATE_WINDOW_MINUTES", 10),
+			InvalidBurstThreshold:     getEnvAsInt("FRAUD_INVALID_BURST_THRESHOLD", 5),
+			InvalidBurstWindowMinutes: getEnvAsInt("FRAUD_INVALID_BURST_WINDOW_MINUTES", 2),
+			AutoBlock:                 getEnv("FRAUD_AUTO_BLOCK", "false") == "true",
+		},
+		Auth: AuthConfig{
+			AppURL:                        getEnv("APP_URL", "http://localhost:3000"),
+			RequireVerifiedEmailForOrders: getEnv("AUTH_REQUIRE_VERIFIED_EMAIL_FOR_ORDERS", "true") == "true",
+			PasswordResetTTLMinutes:       getEnvAsInt("AUTH_PASSWORD_RESET_TTL_MINUTES", 60),
+			EmailVerificationTTLHours:     getEnvAsInt("AUTH_EMAIL_VERIFICATION_TTL_HOURS", 48),
+			EmailsPerHour:                 getEnvAsInt("AUTH_EMAILS_PER_HOUR", 3),
+			TOTPIssuer:                    getEnv("AUTH_TOTP_ISSUER", "Ticketing"),
+			TOTPEncryptionKey:             getEnv("AUTH_TOTP_ENCRYPTION_KEY", ""),
+			TwoFactorChallengeTTLMinutes:  getEnvAsInt("AUTH_2FA_CHALLENGE_TTL_MINUTES", 5),
+			TwoFactorMaxAttempts:          getEnvAsInt("AUTH_2FA_MAX_ATTEMPTS", 5),
+			LoginDelayAfterFailures:       getEnvAsInt("AUTH_LOGIN_DELAY_AFTER_FAILURES", 3),
+			LoginMaxDelaySeconds:          getEnvAsInt("AUTH_LOGIN_MAX_DELAY_SECONDS", 60),
+			LockoutThreshold:              getEnvAsInt("AUTH_LOCKOUT_THRESHOLD", 10),
+			LockoutMinutes:                getEnvAsInt("AUTH_LOCKOUT_MINUTES", 15),
+			IPMaxFailures:                 getEnvAsInt("AUTH_IP_MAX_FAILURES", 30),
+			IPWindowMinutes:               getEnvAsInt("AUTH_IP_WINDOW_MINUTES", 15),
+			SessionCacheTTLSeconds:        getEnvAsInt("AUTH_SESSION_CACHE_TTL_SECONDS", 30),
+		},
+		Mail: MailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", "Ticketing <no-reply@example.com>"),
+		},
+		OIDC: OIDCConfig{
+			Providers:       loadOIDCProviders(getEnv("APP_URL", "http://localhost:3000")),
+			StateTTLMinutes: getEnvAsInt("OIDC_STATE_TTL_MINUTES", 10),
+		},
+		APIKey: APIKeyConfig{
+			DefaultTTLDays:            getEnvAsInt("API_KEY_DEFAULT_TTL_DAYS", 90),
+			MaxTTLDays:                getEnvAsInt("API_KEY_MAX_TTL_DAYS", 365),
+			DefaultRateLimitPerMinute: getEnvAsInt("API_KEY_DEFAULT_RATE_LIMIT_PER_MINUTE", 120),
+			LastUsedIntervalSeconds:   getEnvAsInt("API_KEY_LAST_USED_INTERVAL_SECONDS", 60),
+		},
+		PermissionCache: PermissionCacheConfig{
+			TTLSeconds:     getEnvAsInt("PERMISSION_CACHE_TTL_SECONDS", 300),
+			VersionInToken: getEnv("PERMISSION_VERSION_IN_TOKEN", "false") == "true",
+		},
+	}
+
+	switch AppConfig.JWT.SigningAlgorithm {
+	case "EdDSA", "RS256", "HS256":
+	default:
+		return fmt.Errorf("JWT_SIGNING_ALGORITHM must be EdDSA, RS256 or HS256")
 	}
 
 	// Set APIBaseURL berdasarkan IsProduction
@@ -229,6 +390,35 @@ func Load() error {
 	return nil
 }
 
+// loadOIDCProviders reads the providers named in OIDC_PROVIDERS; providers without an issuer or
+// client ID are skipped with a warning
+func loadOIDCProviders(appURL string) []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		provider := OIDCProviderConfig{
+			Name:         name,
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:       strings.TrimRight(getEnv(prefix+"ISSUER", ""), "/"),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", strings.TrimRight(appURL, "/")+"/auth/callback/"+name),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			log.Printf("WARNING: OIDC provider %s has no %sISSUER or %sCLIENT_ID, skipping", name, prefix, prefix)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -262,6 +452,19 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	var value float64
+	_, err := fmt.Sscanf(valueStr, "%g", &value)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := strings.TrimSpace(os.Getenv(key))
 	if valueStr == "" {
@@ -9,16 +9,25 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/audit"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/menu"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/merchandise"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
 	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/permission"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/settings"
 	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
@@ -133,30 +142,136 @@ func AutoMigrate() error {
 		log.Printf("Warning: Could not handle constraint issues (this may be expected): %v", err)
 	}
 
+	// Accounts created before email verification existed are treated as verified (backfilled below)
+	backfillEmailVerified := DB.Migrator().HasTable(&user.User{}) && !DB.Migrator().HasColumn(&user.User{}, "email_verified_at")
+	// Staff who had shifts at a gate were restricted to them before the flag existed (backfilled below)
+	backfillShiftRestricted := DB.Migrator().HasTable(&gate.GateStaffAssignment{}) && !DB.Migrator().HasColumn(&gate.GateStaffAssignment{}, "shift_restricted")
+
 	// Use a custom migration approach that handles constraint errors gracefully
 	err := migrateWithErrorHandling(
+		&organization.Organization{},
 		&user.User{},
+		&auth.Session{},
+		&auth.UserToken{},
+		&auth.TwoFactor{},
+		&auth.RecoveryCode{},
+		&auth.LoginAttempt{},
+		&auth.SigningKey{},
+		&auth.OIDCLoginState{},
+		&auth.UserIdentity{},
+		&apikey.APIKey{},
+		&apikey.APIKeyPermission{},
+		&apikey.APIKeyCall{},
 		&role.Role{},
 		&role.RolePermission{},
+		&role.RoleBinding{},
 		&permission.Permission{},
 		&menu.Menu{},
 		&event.Event{},
 		&ticketcategory.TicketCategory{},
 		&schedule.Schedule{},
+		&ticketcategory.PassSchedule{},
 		&order.Order{},
 		&orderitem.OrderItem{},
 		&checkin.CheckIn{},
+		&checkin.TicketScan{},
+		&scanlog.ScanLog{},
+		&fraud.Alert{},
 		&gate.Gate{},
 		&gate.GateStaffAssignment{},
+		&gate.GateStaffShift{},
+		&gate.GateDevice{},
+		&gate.GateOccupancy{},
 		&merchandise.Merchandise{},
 		&merchandise.StockLog{},
 		&settings.Settings{},
 		&audit.AuditLog{},
+		&quotaallocation.QuotaAllocation{},
+		&presale.PresaleBatch{},
+		&presale.PresaleCode{},
+		&ballot.Ballot{},
+		&ballot.BallotEntry{},
+		&resale.ResaleListing{},
+		&resale.SellerPayout{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if backfillEmailVerified {
+		if err := DB.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error; err != nil {
+			log.Printf("Warning: failed to backfill users.email_verified_at: %v", err)
+		}
+	}
+
+	// Check-ins are unique per ticket and schedule (multi-day passes): backfill the schedule of older
+	// check-ins from their order and drop the previous one-check-in-per-ticket index
+	if err := DB.Exec(`
+		UPDATE check_ins SET schedule_id = orders.schedule_id
+		FROM order_items JOIN orders ON orders.id = order_items.order_id
+		WHERE check_ins.order_item_id = order_items.id AND check_ins.schedule_id IS NULL
+	`).Error; err != nil {
+		log.Printf("Warning: failed to backfill check_ins.schedule_id: %v", err)
+	}
+	if err := DB.Exec("DROP INDEX IF EXISTS idx_check_ins_order_item_id").Error; err != nil {
+		log.Printf("Warning: failed to drop index idx_check_ins_order_item_id: %v", err)
+	}
+	// Voided check-ins don't count towards uniqueness (replaced by idx_check_ins_item_schedule_active)
+	if err := DB.Exec("DROP INDEX IF EXISTS idx_check_ins_item_schedule").Error; err != nil {
+		log.Printf("Warning: failed to drop index idx_check_ins_item_schedule: %v", err)
+	}
+
+	if backfillShiftRestricted {
+		if err := DB.Exec(`
+			UPDATE gate_staff_assignments a SET shift_restricted = true
+			WHERE EXISTS (SELECT 1 FROM gate_staff_shifts s WHERE s.gate_id = a.gate_id AND s.staff_id = a.staff_id)
+		`).Error; err != nil {
+			log.Printf("Warning: failed to backfill gate_staff_assignments.shift_restricted: %v", err)
+		}
+	}
+
+	// Seed the gate occupancy counters from the existing check-ins and scans on first run
+	if err := DB.Exec(`
+		INSERT INTO gate_occupancies (id, gate_id, schedule_id, admitted, entries, exits, updated_at)
+		SELECT gen_random_uuid(), counts.gate_id, counts.schedule_id,
+			SUM(counts.admitted), SUM(counts.entries), SUM(counts.exits), NOW()
+		FROM (
+			SELECT gate_id, schedule_id, COUNT(*) AS admitted, 0 AS entries, 0 AS exits
+			FROM check_ins
+			WHERE gate_id IS NOT NULL AND schedule_id IS NOT NULL AND status <> 'VOIDED'
+			GROUP BY gate_id, schedule_id
+			UNION ALL
+			SELECT gate_id, schedule_id, 0,
+				COUNT(*) FILTER (WHERE direction = 'ENTRY'),
+				COUNT(*) FILTER (WHERE direction = 'EXIT')
+			FROM ticket_scans
+			WHERE gate_id IS NOT NULL AND voided = false
+			GROUP BY gate_id, schedule_id
+		) AS counts
+		WHERE NOT EXISTS (SELECT 1 FROM gate_occupancies)
+		GROUP BY counts.gate_id, counts.schedule_id
+	`).Error; err != nil {
+		log.Printf("Warning: failed to seed gate_occupancies: %v", err)
+	}
+
+	// Scan logs are append-only: reject updates and deletes at the database level
+	if err := DB.Exec(`
+		CREATE OR REPLACE FUNCTION scan_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'scan_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql
+	`).Error; err != nil {
+		log.Printf("Warning: failed to create scan_logs_append_only function: %v", err)
+	} else {
+		if err := DB.Exec("DROP TRIGGER IF EXISTS trg_scan_logs_append_only ON scan_logs").Error; err != nil {
+			log.Printf("Warning: failed to drop trigger trg_scan_logs_append_only: %v", err)
+		}
+		if err := DB.Exec("CREATE TRIGGER trg_scan_logs_append_only BEFORE UPDATE OR DELETE ON scan_logs FOR EACH ROW EXECUTE FUNCTION scan_logs_append_only()").Error; err != nil {
+			log.Printf("Warning: failed to create trigger trg_scan_logs_append_only: %v", err)
+		}
+	}
+
 	// Step 6: Create pg_trgm extension and index for search optimization
 	if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
 		log.Printf("Warning: failed to create pg_trgm extension: %v", err)
@@ -0,0 +1,285 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Header is the request header API keys can be sent in; Authorization: Bearer <key> works too
+const Header = "X-API-Key"
+
+// KeyPrefix starts every API key, so keys are recognisable in configs and secret scanners
+const KeyPrefix = "tkk_"
+
+// prefixLength is the length of the public part of a key (KeyPrefix plus 8 hex characters)
+const prefixLength = len(KeyPrefix) + 8
+
+// Errors returned when authenticating with an API key
+var (
+	ErrKeyInvalid = errors.New("api key is invalid")
+	ErrKeyExpired = errors.New("api key has expired")
+	ErrKeyRevoked = errors.New("api key has been revoked")
+)
+
+// Status represents API key status enum
+type Status string
+
+const (
+	StatusActive  Status = "ACTIVE"
+	StatusExpired Status = "EXPIRED"
+	StatusRevoked Status = "REVOKED"
+)
+
+// APIKey is a credential for machine-to-machine integrations. It acts as its owner, limited to
+// its own permission codes. Only the sha256 hash of the key is stored; the key itself is shown
+// once on creation, its prefix identifies it afterwards.
+type APIKey struct {
+	ID                 string             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name               string             `gorm:"type:varchar(100);not null" json:"name"`
+	Description        string             `gorm:"type:text" json:"description"`
+	Prefix             string             `gorm:"type:varchar(20);uniqueIndex;not null" json:"prefix"`
+	KeyHash            string             `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	UserID             string             `gorm:"type:uuid;not null;index" json:"user_id"` // Owner the key acts as
+	Permissions        []APIKeyPermission `gorm:"foreignKey:APIKeyID" json:"permissions,omitempty"`
+	RateLimitPerMinute int                `gorm:"not null" json:"rate_limit_per_minute"`
+	ExpiresAt          time.Time          `gorm:"type:timestamp;not null;index" json:"expires_at"`
+	LastUsedAt         *time.Time         `gorm:"type:timestamp" json:"last_used_at,omitempty"`
+	LastUsedIP         string             `gorm:"type:varchar(45)" json:"last_used_ip"`
+	CreatedBy          string             `gorm:"type:uuid;not null" json:"created_by"`
+	RevokedAt          *time.Time         `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+	RevokedBy          *string            `gorm:"type:uuid" json:"revoked_by,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// TableName specifies the table name for APIKey
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// BeforeCreate hook to generate UUID
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Status returns the status of the key at the given time
+func (k *APIKey) Status(now time.Time) Status {
+	switch {
+	case k.RevokedAt != nil:
+		return StatusRevoked
+	case !now.Before(k.ExpiresAt):
+		return StatusExpired
+	default:
+		return StatusActive
+	}
+}
+
+// PermissionCodes returns the permission codes granted to the key
+func (k *APIKey) PermissionCodes() []string {
+	codes := make([]string, len(k.Permissions))
+	for i, p := range k.Permissions {
+		codes[i] = p.PermissionCode
+	}
+	return codes
+}
+
+// Principal is who a request authenticated with an API key acts as: the owner, limited to the
+// key's permissions
+type Principal struct {
+	KeyID              string
+	UserID             string
+	Email              string
+	Role               string // Role code of the owner
+	RoleID             string
+	OrganizationID     string // Organization of the owner
+	Permissions        []string
+	RateLimitPerMinute int
+}
+
+// APIKeyPermission grants a permission code to an API key
+type APIKeyPermission struct {
+	ID             string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	APIKeyID       string    `gorm:"type:uuid;not null;uniqueIndex:idx_api_key_permissions_key_code" json:"api_key_id"`
+	PermissionCode string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_api_key_permissions_key_code" json:"permission_code"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for APIKeyPermission
+func (APIKeyPermission) TableName() string {
+	return "api_key_permissions"
+}
+
+// BeforeCreate hook to generate UUID
+func (p *APIKeyPermission) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// APIKeyCall is the audit trail entry of a request authenticated with an API key
+type APIKeyCall struct {
+	ID         string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	APIKeyID   string    `gorm:"type:uuid;not null;index:idx_api_key_calls_key_created" json:"api_key_id"`
+	Method     string    `gorm:"type:varchar(10);not null" json:"method"`
+	Path       string    `gorm:"type:text;not null" json:"path"`
+	Route      string    `gorm:"type:varchar(255)" json:"route"` // Matched route pattern, e.g. /api/v1/orders/:id
+	StatusCode int       `gorm:"not null" json:"status_code"`
+	DurationMs int64     `gorm:"not null" json:"duration_ms"`
+	IPAddress  string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent  string    `gorm:"type:text" json:"user_agent"`
+	RequestID  string    `gorm:"type:varchar(100)" json:"request_id"`
+	CreatedAt  time.Time `gorm:"index:idx_api_key_calls_key_created" json:"created_at"`
+}
+
+// TableName specifies the table name for APIKeyCall
+func (APIKeyCall) TableName() string {
+	return "api_key_calls"
+}
+
+// BeforeCreate hook to generate UUID
+func (c *APIKeyCall) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// GenerateKey generates a new API key and returns it with its public prefix
+func GenerateKey() (key, prefix string, err error) {
+	buf := make([]byte, 28)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key = KeyPrefix + hex.EncodeToString(buf)
+	return key, key[:prefixLength], nil
+}
+
+// IsKey reports whether a credential looks like an API key rather than a JWT
+func IsKey(credential string) bool {
+	return strings.HasPrefix(credential, KeyPrefix)
+}
+
+// HashKey returns the sha256 hex digest stored for an API key
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyResponse represents API key response DTO
+type APIKeyResponse struct {
+	ID                 string     `json:"id"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	Prefix             string     `json:"prefix"`
+	UserID             string     `json:"user_id"`
+	Permissions        []string   `json:"permissions"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	Status             Status     `json:"status"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP         string     `json:"last_used_ip"`
+	CreatedBy          string     `json:"created_by"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy          *string    `json:"revoked_by,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// ToAPIKeyResponse converts APIKey to APIKeyResponse
+func (k *APIKey) ToAPIKeyResponse(now time.Time) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:                 k.ID,
+		Name:               k.Name,
+		Description:        k.Description,
+		Prefix:             k.Prefix,
+		UserID:             k.UserID,
+		Permissions:        k.PermissionCodes(),
+		RateLimitPerMinute: k.RateLimitPerMinute,
+		Status:             k.Status(now),
+		ExpiresAt:          k.ExpiresAt,
+		LastUsedAt:         k.LastUsedAt,
+		LastUsedIP:         k.LastUsedIP,
+		CreatedBy:          k.CreatedBy,
+		RevokedAt:          k.RevokedAt,
+		RevokedBy:          k.RevokedBy,
+		CreatedAt:          k.CreatedAt,
+		UpdatedAt:          k.UpdatedAt,
+	}
+}
+
+// CreateAPIKeyResponse returns the key, which is only ever shown here
+type CreateAPIKeyResponse struct {
+	APIKey *APIKeyResponse `json:"api_key"`
+	Key    string          `json:"key"`
+}
+
+// CreateAPIKeyRequest represents create API key request DTO. The key acts as user_id (the caller
+// by default) and can only be granted permissions that user's role has.
+type CreateAPIKeyRequest struct {
+	Name               string     `json:"name" binding:"required,min=1,max=100"`
+	Description        string     `json:"description" binding:"omitempty,max=500"`
+	UserID             string     `json:"user_id" binding:"omitempty,uuid"`
+	Permissions        []string   `json:"permissions" binding:"required,min=1,dive,required,max=100"`
+	RateLimitPerMinute *int       `json:"rate_limit_per_minute" binding:"omitempty,min=1,max=6000"`
+	ExpiresAt          *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// ListAPIKeysRequest represents list API keys query parameters
+type ListAPIKeysRequest struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	UserID  string `form:"user_id" binding:"omitempty,uuid"`
+	Status  Status `form:"status" binding:"omitempty,oneof=ACTIVE EXPIRED REVOKED"`
+	Search  string `form:"search" binding:"omitempty,max=100"` // Name or prefix
+}
+
+// APIKeyCallResponse represents API key call response DTO
+type APIKeyCallResponse struct {
+	ID         string    `json:"id"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Route      string    `json:"route"`
+	StatusCode int       `json:"status_code"`
+	DurationMs int64     `json:"duration_ms"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToAPIKeyCallResponse converts APIKeyCall to APIKeyCallResponse
+func (c *APIKeyCall) ToAPIKeyCallResponse() *APIKeyCallResponse {
+	return &APIKeyCallResponse{
+		ID:         c.ID,
+		Method:     c.Method,
+		Path:       c.Path,
+		Route:      c.Route,
+		StatusCode: c.StatusCode,
+		DurationMs: c.DurationMs,
+		IPAddress:  c.IPAddress,
+		UserAgent:  c.UserAgent,
+		RequestID:  c.RequestID,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+// ListAPIKeyCallsRequest represents list API key calls query parameters
+type ListAPIKeyCallsRequest struct {
+	Page      int        `form:"page" binding:"omitempty,min=1"`
+	PerPage   int        `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Method    string     `form:"method" binding:"omitempty,oneof=GET POST PUT PATCH DELETE"`
+	MinStatus int        `form:"min_status" binding:"omitempty,min=100,max=599"` // e.g. 400 for failed calls only
+	StartDate *time.Time `form:"start_date" binding:"omitempty"`
+	EndDate   *time.Time `form:"end_date" binding:"omitempty"`
+}
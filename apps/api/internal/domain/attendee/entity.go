@@ -40,6 +40,7 @@ type Attendee struct {
 	OrderItemID       string         `json:"order_item_id"`     // order_item.id
 	QRCode            string         `json:"qr_code"`           // order_item.qr_code
 	CategoryID        string         `json:"category_id"`       // category.id
+	DaysAttended      int            `json:"days_attended"`     // check-ins across schedules (multi-day passes)
 }
 
 // AttendeeResponse represents attendee response DTO
@@ -53,6 +54,7 @@ type AttendeeResponse struct {
 	RegistrationDate time.Time      `json:"registration_date"`
 	Status           AttendeeStatus `json:"status"`
 	CheckedInAt      *time.Time     `json:"checked_in_at,omitempty"`
+	DaysAttended     int            `json:"days_attended"`
 	AvatarURL        string         `json:"avatar_url,omitempty"`
 }
 
@@ -68,6 +70,7 @@ func (a *Attendee) ToAttendeeResponse() *AttendeeResponse {
 		RegistrationDate: a.RegistrationDate,
 		Status:           a.Status,
 		CheckedInAt:      a.CheckedInAt,
+		DaysAttended:     a.DaysAttended,
 		AvatarURL:        a.AvatarURL,
 	}
 }
@@ -81,6 +84,7 @@ type ListAttendeesRequest struct {
 	Status     AttendeeStatus `form:"status" binding:"omitempty,oneof=registered checked_in cancelled"`
 	StartDate  *time.Time    `form:"start_date" binding:"omitempty"`
 	EndDate    *time.Time    `form:"end_date" binding:"omitempty"`
+	ScheduleID string        `form:"schedule_id" binding:"omitempty,uuid"` // Attendance for one schedule/day
 }
 
 // AttendeeStatistics represents attendee statistics
@@ -90,6 +94,7 @@ type AttendeeStatistics struct {
 	RegisteredCount     int64 `json:"registered_count"`
 	CancelledCount      int64 `json:"cancelled_count"`
 	ByTicketTier        map[string]int64 `json:"by_ticket_tier"`
+	BySchedule          map[string]int64 `json:"by_schedule"` // Check-ins per schedule ID (per-day attendance)
 }
 
 // AttendeeStatisticsResponse represents attendee statistics response
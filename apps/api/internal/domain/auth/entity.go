@@ -35,8 +35,9 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 
 // LoginRequest represents login request DTO
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,min=6"`
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"` // Shown in the session list
 }
 
 // RegisterRequest represents buyer self-registration request DTO
@@ -45,14 +46,23 @@ type RegisterRequest struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required,min=6,max=72"`
 	ConfirmPassword string `json:"confirm_password" binding:"required"`
+	DeviceName      string `json:"device_name" binding:"omitempty,max=100"`
+}
+
+// RefreshTokenRequest represents refresh token request DTO
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 // LoginResponse represents login response DTO
+// When two-factor authentication is needed only TwoFactor is set and no tokens are issued yet.
 type LoginResponse struct {
-	User         *UserResponse `json:"user"`
-	Token        string        `json:"token"`
-	RefreshToken string        `json:"refresh_token"`
-	ExpiresIn    int           `json:"expires_in"` // in seconds
+	User          *UserResponse       `json:"user,omitempty"`
+	Token         string              `json:"token,omitempty"`
+	RefreshToken  string              `json:"refresh_token,omitempty"`
+	ExpiresIn     int                 `json:"expires_in,omitempty"` // in seconds
+	TwoFactor     *TwoFactorChallenge `json:"two_factor,omitempty"`
+	RecoveryCodes []string            `json:"recovery_codes,omitempty"` // Only when 2FA was enrolled during this login
 }
 
 // UserResponse represents user response DTO (without sensitive data)
@@ -63,6 +73,7 @@ type UserResponse struct {
 	AvatarURL  string    `json:"avatar_url"`
 	Role       string    `json:"role"`
 	Status     string    `json:"status"`
+	EmailVerified bool   `json:"email_verified"`
 	Permissions []string `json:"permissions"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Login failure reasons
+const (
+	LoginFailureUnknownEmail     = "UNKNOWN_EMAIL"
+	LoginFailureInvalidPassword  = "INVALID_PASSWORD"
+	LoginFailureInvalidTwoFactor = "INVALID_2FA"
+	LoginFailureAccountLocked    = "ACCOUNT_LOCKED"
+	LoginFailureAccountThrottled = "ACCOUNT_THROTTLED" // Attempt made before the progressive delay passed
+	LoginFailureIPThrottled      = "IP_THROTTLED"
+	LoginFailureAccountDisabled  = "ACCOUNT_DISABLED"
+)
+
+// LoginAttempt records one login attempt. It is the per-user login history and the source of
+// the per-IP failure counters; attempts for unknown emails have no UserID.
+type LoginAttempt struct {
+	ID            string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID        *string   `gorm:"type:uuid;index:idx_login_attempts_user_created" json:"user_id,omitempty"`
+	Email         string    `gorm:"type:varchar(255);not null" json:"email"`
+	IPAddress     string    `gorm:"type:varchar(45);index:idx_login_attempts_ip_created" json:"ip_address"`
+	UserAgent     string    `gorm:"type:text" json:"user_agent"`
+	Success       bool      `gorm:"not null" json:"success"`
+	FailureReason string    `gorm:"type:varchar(30)" json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `gorm:"index:idx_login_attempts_user_created;index:idx_login_attempts_ip_created;index" json:"created_at"`
+}
+
+// TableName specifies the table name for LoginAttempt
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// BeforeCreate hook to generate UUID
+func (a *LoginAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// LoginAttemptResponse represents login history entry response DTO
+type LoginAttemptResponse struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ToLoginAttemptResponse converts LoginAttempt to LoginAttemptResponse
+func (a *LoginAttempt) ToLoginAttemptResponse() *LoginAttemptResponse {
+	return &LoginAttemptResponse{
+		ID:            a.ID,
+		Email:         a.Email,
+		IPAddress:     a.IPAddress,
+		UserAgent:     a.UserAgent,
+		Success:       a.Success,
+		FailureReason: a.FailureReason,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+// SuspiciousIP summarizes failed logins from one IP address
+type SuspiciousIP struct {
+	IPAddress      string    `json:"ip_address"`
+	Failures       int64     `json:"failures"`
+	DistinctEmails int64     `json:"distinct_emails"` // Many emails from one IP suggests password spraying
+	LastAttemptAt  time.Time `json:"last_attempt_at"`
+}
+
+// SuspiciousAccount summarizes failed logins against one account
+type SuspiciousAccount struct {
+	UserID        string     `json:"user_id"`
+	Email         string     `json:"email"`
+	Failures      int64      `json:"failures"`
+	DistinctIPs   int64      `json:"distinct_ips"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	LastAttemptAt time.Time  `json:"last_attempt_at"`
+}
+
+// SuspiciousLoginActivityResponse represents the suspicious login activity review
+type SuspiciousLoginActivityResponse struct {
+	Since    time.Time            `json:"since"`
+	IPs      []*SuspiciousIP      `json:"ips"`
+	Accounts []*SuspiciousAccount `json:"accounts"`
+}
+
+// ListLoginHistoryRequest represents login history query parameters
+type ListLoginHistoryRequest struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Result  string `form:"result" binding:"omitempty,oneof=success failure"`
+}
+
+// SuspiciousLoginActivityRequest represents suspicious login activity query parameters
+type SuspiciousLoginActivityRequest struct {
+	Hours       int `form:"hours" binding:"omitempty,min=1,max=720"`          // Look-back window, default 24
+	MinFailures int `form:"min_failures" binding:"omitempty,min=1,max=10000"` // Default 5
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session revoke reasons
+const (
+	SessionRevokedLogout     = "LOGOUT"
+	SessionRevokedLogoutAll  = "LOGOUT_ALL"
+	SessionRevokedByUser     = "REVOKED"
+	SessionRevokedTokenReuse = "TOKEN_REUSE" // A rotated refresh token was presented again
+	SessionRevokedPassword   = "PASSWORD_RESET"
+)
+
+// Session is a server-side login session. Each session is one refresh token family: only the
+// most recently issued refresh token (RefreshTokenID, the jti) may be exchanged, and presenting
+// an older one revokes the whole session.
+type Session struct {
+	ID             string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID         string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	RefreshTokenID string     `gorm:"type:varchar(36);not null" json:"-"`
+	DeviceName     string     `gorm:"type:varchar(100)" json:"device_name"`
+	UserAgent      string     `gorm:"type:text" json:"user_agent"`
+	IPAddress      string     `gorm:"type:varchar(45)" json:"ip_address"` // Address of the latest login or refresh
+	LastUsedAt     time.Time  `gorm:"type:timestamp;not null" json:"last_used_at"`
+	ExpiresAt      time.Time  `gorm:"type:timestamp;not null;index" json:"expires_at"`
+	RevokedAt      *time.Time `gorm:"type:timestamp;index" json:"revoked_at,omitempty"`
+	RevokedReason  string     `gorm:"type:varchar(50)" json:"revoked_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Session
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsActive reports whether the session can still be used
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SessionResponse represents session response DTO
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"` // Session of the token making the request
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToSessionResponse converts Session to SessionResponse
+func (s *Session) ToSessionResponse(currentSessionID string) *SessionResponse {
+	return &SessionResponse{
+		ID:         s.ID,
+		DeviceName: s.DeviceName,
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		Current:    s.ID == currentSessionID,
+		LastUsedAt: s.LastUsedAt,
+		ExpiresAt:  s.ExpiresAt,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+// ClientInfo describes the client a session is created or refreshed from
+type ClientInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
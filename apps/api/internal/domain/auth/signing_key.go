@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SigningKey is an asymmetric JWT signing key; its ID is the kid header of the tokens it signs.
+// The key without RetiredAt signs new tokens. A retired key only verifies, until ExpiresAt, so
+// tokens signed before a rotation stay valid for the grace period.
+type SigningKey struct {
+	ID                  string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Algorithm           string     `gorm:"type:varchar(10);not null" json:"algorithm"`
+	PrivateKeyEncrypted string     `gorm:"type:text;not null" json:"-"`
+	PublicKey           string     `gorm:"type:text;not null" json:"public_key"` // PKIX PEM
+	ActivatedAt         time.Time  `gorm:"type:timestamp;not null" json:"activated_at"`
+	RetiredAt           *time.Time `gorm:"type:timestamp;index" json:"retired_at,omitempty"`
+	ExpiresAt           *time.Time `gorm:"type:timestamp;index" json:"expires_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for SigningKey
+func (SigningKey) TableName() string {
+	return "jwt_signing_keys"
+}
+
+// BeforeCreate hook to generate UUID
+func (k *SigningKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// Signing key statuses
+const (
+	SigningKeyStatusActive   = "ACTIVE"   // Signs new tokens
+	SigningKeyStatusRetiring = "RETIRING" // Verifies tokens until the grace period ends
+	SigningKeyStatusExpired  = "EXPIRED"
+)
+
+// Status returns the key's status at the given time
+func (k *SigningKey) Status(now time.Time) string {
+	if k.RetiredAt == nil {
+		return SigningKeyStatusActive
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return SigningKeyStatusExpired
+	}
+	return SigningKeyStatusRetiring
+}
+
+// SigningKeyResponse represents signing key response DTO
+type SigningKeyResponse struct {
+	ID          string     `json:"id"`
+	Algorithm   string     `json:"algorithm"`
+	Status      string     `json:"status"`
+	PublicKey   string     `json:"public_key"`
+	ActivatedAt time.Time  `json:"activated_at"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// ToSigningKeyResponse converts SigningKey to SigningKeyResponse
+func (k *SigningKey) ToSigningKeyResponse(now time.Time) *SigningKeyResponse {
+	return &SigningKeyResponse{
+		ID:          k.ID,
+		Algorithm:   k.Algorithm,
+		Status:      k.Status(now),
+		PublicKey:   k.PublicKey,
+		ActivatedAt: k.ActivatedAt,
+		RetiredAt:   k.RetiredAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
+
+// RotateSigningKeyRequest represents rotate signing key request DTO
+type RotateSigningKeyRequest struct {
+	Algorithm  string `json:"algorithm" binding:"omitempty,oneof=EdDSA RS256"` // Defaults to JWT_SIGNING_ALGORITHM
+	GraceHours *int   `json:"grace_hours" binding:"omitempty,min=0,max=8760"`  // How long the old key still verifies; 0 invalidates its tokens now
+}
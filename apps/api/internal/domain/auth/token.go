@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenPurpose represents what a user token can be used for
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "PASSWORD_RESET"
+	TokenPurposeEmailVerification TokenPurpose = "EMAIL_VERIFICATION"
+	TokenPurposeLoginChallenge    TokenPurpose = "LOGIN_CHALLENGE" // Second step of a two-factor login
+)
+
+// UserToken is a single-use, expiring token emailed to a user. Only the sha256 hash of the
+// token is stored; the token itself only appears in the emailed link.
+type UserToken struct {
+	ID        string       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string       `gorm:"type:uuid;not null;index" json:"user_id"`
+	Purpose   TokenPurpose `gorm:"type:varchar(30);not null;index" json:"purpose"`
+	TokenHash string       `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Email     string       `gorm:"type:varchar(255);not null" json:"email"` // Address the token was sent to
+	ExpiresAt time.Time    `gorm:"type:timestamp;not null" json:"expires_at"`
+	UsedAt    *time.Time   `gorm:"type:timestamp" json:"used_at,omitempty"` // Also set when superseded by a newer token
+	Attempts  int          `gorm:"not null;default:0" json:"attempts"`      // Failed uses, for tokens that allow retries
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// TableName specifies the table name for UserToken
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// BeforeCreate hook to generate UUID
+func (t *UserToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// GenerateUserToken generates a random user token
+func GenerateUserToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// HashUserToken returns the sha256 hex digest stored for a user token
+func HashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ForgotPasswordRequest represents forgot password request DTO
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents reset password request DTO
+type ResetPasswordRequest struct {
+	Token           string `json:"token" binding:"required"`
+	Password        string `json:"password" binding:"required,min=6,max=72"`
+	ConfirmPassword string `json:"confirm_password" binding:"required"`
+}
+
+// VerifyEmailRequest represents verify email request DTO
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Two-factor login challenge methods
+const (
+	TwoFactorMethodTOTP   = "TOTP"   // Enter a code from the authenticator app or a recovery code
+	TwoFactorMethodEnroll = "ENROLL" // The role requires 2FA: set up an authenticator app before logging in
+)
+
+// TwoFactor is a user's TOTP enrollment. It is pending until the first code is confirmed
+// (EnabledAt set); only enabled enrollments are enforced at login.
+type TwoFactor struct {
+	ID              string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID          string     `gorm:"type:uuid;uniqueIndex;not null" json:"user_id"`
+	SecretEncrypted string     `gorm:"type:text;not null" json:"-"`
+	EnabledAt       *time.Time `gorm:"type:timestamp" json:"enabled_at,omitempty"`
+	LastUsedStep    int64      `gorm:"not null;default:0" json:"-"` // Time step of the last accepted code, to reject replays
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for TwoFactor
+func (TwoFactor) TableName() string {
+	return "user_two_factors"
+}
+
+// BeforeCreate hook to generate UUID
+func (t *TwoFactor) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsEnabled reports whether the enrollment has been confirmed
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the authenticator is lost.
+// Only the sha256 hash is stored.
+type RecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null;index" json:"-"`
+	UsedAt    *time.Time `gorm:"type:timestamp" json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for RecoveryCode
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// BeforeCreate hook to generate UUID
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TwoFactorChallenge is returned by login instead of tokens when a second factor is needed
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	Method         string `json:"method"`     // TOTP or ENROLL
+	ExpiresIn      int    `json:"expires_in"` // in seconds
+}
+
+// TwoFactorStatusResponse represents the current user's two-factor status
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	Required               bool       `json:"required"` // Required by the user's role
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorSetupResponse carries a new TOTP secret to add to an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI, usually shown as a QR code
+}
+
+// RecoveryCodesResponse carries newly generated recovery codes; they are shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorCodeRequest represents a request confirmed with a TOTP code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// DisableTwoFactorRequest represents disable two-factor request DTO
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP or recovery code
+}
+
+// TwoFactorChallengeRequest identifies a pending two-factor login
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+// VerifyTwoFactorRequest completes a two-factor login with a TOTP code or a recovery code
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	DeviceName     string `json:"device_name" binding:"omitempty,max=100"`
+}
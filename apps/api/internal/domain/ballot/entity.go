@@ -0,0 +1,313 @@
+package ballot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BallotStatus represents ballot status enum
+type BallotStatus string
+
+const (
+	BallotStatusOpen   BallotStatus = "OPEN"   // Accepting entries / waiting for draw
+	BallotStatusDrawn  BallotStatus = "DRAWN"  // Draw executed, winners claiming
+	BallotStatusClosed BallotStatus = "CLOSED" // Ballot finished, category back on public sale
+)
+
+// EntryStatus represents ballot entry status enum
+type EntryStatus string
+
+const (
+	EntryStatusPending    EntryStatus = "PENDING"    // Registered, waiting for draw
+	EntryStatusWon        EntryStatus = "WON"        // Won, claim link active
+	EntryStatusLost       EntryStatus = "LOST"       // Not selected
+	EntryStatusWaitlisted EntryStatus = "WAITLISTED" // Not selected, may be promoted when seats free up
+	EntryStatusClaimed    EntryStatus = "CLAIMED"    // Order created from the claim link
+	EntryStatusExpired    EntryStatus = "EXPIRED"    // Claim window passed without a paid order
+)
+
+// Ballot represents a lottery sale for a ticket category.
+// While a ballot is OPEN or DRAWN the category can only be purchased through a winning entry's claim token.
+type Ballot struct {
+	ID                  string                         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketCategoryID    string                         `gorm:"type:uuid;not null;index" json:"ticket_category_id"`
+	TicketCategory      *ticketcategory.TicketCategory `gorm:"foreignKey:TicketCategoryID" json:"ticket_category,omitempty"`
+	Name                string                         `gorm:"type:varchar(255);not null" json:"name"`
+	EntryOpensAt        time.Time                      `gorm:"type:timestamp;not null" json:"entry_opens_at"`
+	EntryClosesAt       time.Time                      `gorm:"type:timestamp;not null" json:"entry_closes_at"`
+	Seats               int                            `gorm:"not null;default:0" json:"seats"` // Seats drawn across all winners
+	MaxQuantityPerEntry int                            `gorm:"not null;default:1" json:"max_quantity_per_entry"`
+	ClaimWindowMinutes  int                            `gorm:"not null;default:60" json:"claim_window_minutes"`
+	WaitlistLosers      bool                           `gorm:"not null;default:false" json:"waitlist_losers"`
+	Status              BallotStatus                   `gorm:"type:varchar(20);not null;default:'OPEN';index" json:"status"`
+	Seed                *string                        `gorm:"type:varchar(128)" json:"seed,omitempty"`       // Draw seed (recorded for audit)
+	DrawDigest          *string                        `gorm:"type:varchar(64)" json:"draw_digest,omitempty"` // sha256 of ranked entry IDs
+	DrawnAt             *time.Time                     `gorm:"type:timestamp" json:"drawn_at,omitempty"`
+	DrawnBy             *string                        `gorm:"type:varchar(100)" json:"drawn_by,omitempty"`
+	CreatedBy           string                         `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt           time.Time                      `json:"created_at"`
+	UpdatedAt           time.Time                      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt                 `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for Ballot
+func (Ballot) TableName() string {
+	return "ballots"
+}
+
+// BeforeCreate hook to generate UUID
+func (b *Ballot) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	if b.Status == "" {
+		b.Status = BallotStatusOpen
+	}
+	return nil
+}
+
+// IsAcceptingEntries reports whether users can currently register entries
+func (b *Ballot) IsAcceptingEntries(now time.Time) bool {
+	return b.Status == BallotStatusOpen && !now.Before(b.EntryOpensAt) && now.Before(b.EntryClosesAt)
+}
+
+// BallotEntry represents a user's registered intent to buy in a ballot
+type BallotEntry struct {
+	ID             string      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	BallotID       string      `gorm:"type:uuid;not null;uniqueIndex:idx_ballot_entries_ballot_user" json:"ballot_id"`
+	Ballot         *Ballot     `gorm:"foreignKey:BallotID" json:"ballot,omitempty"`
+	UserID         string      `gorm:"type:uuid;not null;uniqueIndex:idx_ballot_entries_ballot_user;index" json:"user_id"`
+	ScheduleID     string      `gorm:"type:uuid;not null" json:"schedule_id"`
+	Quantity       int         `gorm:"not null" json:"quantity"`
+	BuyerName      string      `gorm:"type:varchar(100);not null" json:"buyer_name"`
+	BuyerEmail     string      `gorm:"type:varchar(255);not null" json:"buyer_email"`
+	BuyerPhone     string      `gorm:"type:varchar(20);not null" json:"buyer_phone"`
+	Status         EntryStatus `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"status"`
+	DrawRank       int         `gorm:"not null;default:0" json:"draw_rank"` // 1-based position in the draw, 0 before draw
+	ClaimToken     *string     `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	ClaimExpiresAt *time.Time  `gorm:"type:timestamp;index" json:"claim_expires_at,omitempty"`
+	OrderID        *string     `gorm:"type:uuid;index" json:"order_id,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for BallotEntry
+func (BallotEntry) TableName() string {
+	return "ballot_entries"
+}
+
+// BeforeCreate hook to generate UUID
+func (e *BallotEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	if e.Status == "" {
+		e.Status = EntryStatusPending
+	}
+	return nil
+}
+
+// GenerateClaimToken generates a random claim token for a winning entry
+func GenerateClaimToken() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "") + strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// RankEntries orders entry IDs deterministically for a seed.
+// Each entry is ranked by sha256(seed + ":" + entryID), ties broken by ID, so the same seed
+// and entry set always produce the same order.
+func RankEntries(seed string, entryIDs []string) []string {
+	keys := make(map[string]string, len(entryIDs))
+	ranked := make([]string, len(entryIDs))
+	copy(ranked, entryIDs)
+	for _, id := range ranked {
+		sum := sha256.Sum256([]byte(seed + ":" + id))
+		keys[id] = hex.EncodeToString(sum[:])
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if keys[ranked[i]] == keys[ranked[j]] {
+			return ranked[i] < ranked[j]
+		}
+		return keys[ranked[i]] < keys[ranked[j]]
+	})
+	return ranked
+}
+
+// DrawDigest returns the sha256 digest of ranked entry IDs, used to audit a draw
+func DrawDigest(rankedIDs []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(rankedIDs, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// BallotResponse represents ballot response DTO
+type BallotResponse struct {
+	ID                  string                                 `json:"id"`
+	TicketCategoryID    string                                 `json:"ticket_category_id"`
+	TicketCategory      *ticketcategory.TicketCategoryResponse `json:"ticket_category,omitempty"`
+	Name                string                                 `json:"name"`
+	EntryOpensAt        time.Time                              `json:"entry_opens_at"`
+	EntryClosesAt       time.Time                              `json:"entry_closes_at"`
+	Seats               int                                    `json:"seats"`
+	MaxQuantityPerEntry int                                    `json:"max_quantity_per_entry"`
+	ClaimWindowMinutes  int                                    `json:"claim_window_minutes"`
+	WaitlistLosers      bool                                   `json:"waitlist_losers"`
+	Status              BallotStatus                           `json:"status"`
+	AcceptingEntries    bool                                   `json:"accepting_entries"`
+	Seed                *string                                `json:"seed,omitempty"`
+	DrawDigest          *string                                `json:"draw_digest,omitempty"`
+	DrawnAt             *time.Time                             `json:"drawn_at,omitempty"`
+	DrawnBy             *string                                `json:"drawn_by,omitempty"`
+	CreatedAt           time.Time                              `json:"created_at"`
+	UpdatedAt           time.Time                              `json:"updated_at"`
+}
+
+// ToBallotResponse converts Ballot to BallotResponse
+func (b *Ballot) ToBallotResponse() *BallotResponse {
+	resp := &BallotResponse{
+		ID:                  b.ID,
+		TicketCategoryID:    b.TicketCategoryID,
+		Name:                b.Name,
+		EntryOpensAt:        b.EntryOpensAt,
+		EntryClosesAt:       b.EntryClosesAt,
+		Seats:               b.Seats,
+		MaxQuantityPerEntry: b.MaxQuantityPerEntry,
+		ClaimWindowMinutes:  b.ClaimWindowMinutes,
+		WaitlistLosers:      b.WaitlistLosers,
+		Status:              b.Status,
+		AcceptingEntries:    b.IsAcceptingEntries(time.Now()),
+		Seed:                b.Seed,
+		DrawDigest:          b.DrawDigest,
+		DrawnAt:             b.DrawnAt,
+		DrawnBy:             b.DrawnBy,
+		CreatedAt:           b.CreatedAt,
+		UpdatedAt:           b.UpdatedAt,
+	}
+	if b.TicketCategory != nil {
+		resp.TicketCategory = b.TicketCategory.ToTicketCategoryResponse()
+	}
+	return resp
+}
+
+// BallotEntryResponse represents ballot entry response DTO
+type BallotEntryResponse struct {
+	ID             string          `json:"id"`
+	BallotID       string          `json:"ballot_id"`
+	Ballot         *BallotResponse `json:"ballot,omitempty"`
+	UserID         string          `json:"user_id"`
+	ScheduleID     string          `json:"schedule_id"`
+	Quantity       int             `json:"quantity"`
+	BuyerName      string          `json:"buyer_name"`
+	BuyerEmail     string          `json:"buyer_email"`
+	BuyerPhone     string          `json:"buyer_phone"`
+	Status         EntryStatus     `json:"status"`
+	DrawRank       int             `json:"draw_rank"`
+	ClaimToken     *string         `json:"claim_token,omitempty"` // Only exposed to the entry owner while the claim is active
+	ClaimExpiresAt *time.Time      `json:"claim_expires_at,omitempty"`
+	OrderID        *string         `json:"order_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// ToBallotEntryResponse converts BallotEntry to BallotEntryResponse
+func (e *BallotEntry) ToBallotEntryResponse() *BallotEntryResponse {
+	resp := &BallotEntryResponse{
+		ID:             e.ID,
+		BallotID:       e.BallotID,
+		UserID:         e.UserID,
+		ScheduleID:     e.ScheduleID,
+		Quantity:       e.Quantity,
+		BuyerName:      e.BuyerName,
+		BuyerEmail:     e.BuyerEmail,
+		BuyerPhone:     e.BuyerPhone,
+		Status:         e.Status,
+		DrawRank:       e.DrawRank,
+		ClaimExpiresAt: e.ClaimExpiresAt,
+		OrderID:        e.OrderID,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+	if e.Ballot != nil {
+		resp.Ballot = e.Ballot.ToBallotResponse()
+	}
+	return resp
+}
+
+// DrawVerification represents the result of re-running a draw from its recorded seed
+type DrawVerification struct {
+	BallotID          string `json:"ballot_id"`
+	Seed              string `json:"seed"`
+	DrawDigest        string `json:"draw_digest"`
+	RecomputedDigest  string `json:"recomputed_digest"`
+	RankedEntries     int    `json:"ranked_entries"`
+	MismatchedEntries int    `json:"mismatched_entries"`
+	Verified          bool   `json:"verified"`
+}
+
+// DrawResult represents the outcome of a draw
+type DrawResult struct {
+	Ballot     *BallotResponse `json:"ballot"`
+	Entries    int             `json:"entries"`
+	Winners    int             `json:"winners"`
+	Waitlisted int             `json:"waitlisted"`
+	Losers     int             `json:"losers"`
+	SeatsWon   int             `json:"seats_won"`
+}
+
+// CreateBallotRequest represents create ballot request DTO
+type CreateBallotRequest struct {
+	TicketCategoryID    string    `json:"ticket_category_id" binding:"required,uuid"`
+	Name                string    `json:"name" binding:"required,min=1,max=255"`
+	EntryOpensAt        time.Time `json:"entry_opens_at" binding:"required"`
+	EntryClosesAt       time.Time `json:"entry_closes_at" binding:"required"`
+	Seats               int       `json:"seats" binding:"required,min=1"`
+	MaxQuantityPerEntry int       `json:"max_quantity_per_entry" binding:"omitempty,min=1,max=10"`
+	ClaimWindowMinutes  int       `json:"claim_window_minutes" binding:"omitempty,min=5,max=10080"`
+	WaitlistLosers      bool      `json:"waitlist_losers"`
+}
+
+// UpdateBallotRequest represents update ballot request DTO (only before the draw)
+type UpdateBallotRequest struct {
+	Name                *string    `json:"name" binding:"omitempty,min=1,max=255"`
+	EntryOpensAt        *time.Time `json:"entry_opens_at" binding:"omitempty"`
+	EntryClosesAt       *time.Time `json:"entry_closes_at" binding:"omitempty"`
+	Seats               *int       `json:"seats" binding:"omitempty,min=1"`
+	MaxQuantityPerEntry *int       `json:"max_quantity_per_entry" binding:"omitempty,min=1,max=10"`
+	ClaimWindowMinutes  *int       `json:"claim_window_minutes" binding:"omitempty,min=5,max=10080"`
+	WaitlistLosers      *bool      `json:"waitlist_losers"`
+}
+
+// DrawBallotRequest represents draw request DTO; an empty seed is generated randomly and recorded
+type DrawBallotRequest struct {
+	Seed string `json:"seed" binding:"omitempty,min=8,max=128"`
+}
+
+// CreateBallotEntryRequest represents register ballot entry request DTO
+type CreateBallotEntryRequest struct {
+	ScheduleID string `json:"schedule_id" binding:"required,uuid"`
+	Quantity   int    `json:"quantity" binding:"required,min=1,max=10"`
+	BuyerName  string `json:"buyer_name" binding:"required,min=3,max=100"`
+	BuyerEmail string `json:"buyer_email" binding:"required,email"`
+	BuyerPhone string `json:"buyer_phone" binding:"required,min=10,max=20"`
+}
+
+// ListBallotsRequest represents list ballots query parameters
+type ListBallotsRequest struct {
+	Page             int          `form:"page" binding:"omitempty,min=1"`
+	PerPage          int          `form:"per_page" binding:"omitempty,min=1,max=100"`
+	TicketCategoryID string       `form:"ticket_category_id" binding:"omitempty,uuid"`
+	EventID          string       `form:"event_id" binding:"omitempty,uuid"`
+	Status           BallotStatus `form:"status" binding:"omitempty,oneof=OPEN DRAWN CLOSED"`
+}
+
+// ListBallotEntriesRequest represents list ballot entries query parameters
+type ListBallotEntriesRequest struct {
+	Page    int         `form:"page" binding:"omitempty,min=1"`
+	PerPage int         `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Status  EntryStatus `form:"status" binding:"omitempty,oneof=PENDING WON LOST WAITLISTED CLAIMED EXPIRED"`
+}
@@ -16,15 +16,26 @@ const (
 	CheckInStatusSuccess CheckInStatus = "SUCCESS"
 	CheckInStatusFailed  CheckInStatus = "FAILED"
 	CheckInStatusDuplicate CheckInStatus = "DUPLICATE"
+	CheckInStatusVoided    CheckInStatus = "VOIDED" // Reverted by a supervisor (wrong ticket scanned)
 )
 
-// CheckIn represents a check-in entity
+// ScanDirection represents the direction of a gate scan
+type ScanDirection string
+
+const (
+	ScanDirectionEntry ScanDirection = "ENTRY"
+	ScanDirectionExit  ScanDirection = "EXIT"
+)
+
+// CheckIn represents a check-in entity (first admission of a ticket; later passes are TicketScans)
 type CheckIn struct {
 	ID           string            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	OrderItemID  string            `gorm:"type:uuid;not null;uniqueIndex" json:"order_item_id"`
+	OrderItemID  string            `gorm:"type:uuid;not null;uniqueIndex:idx_check_ins_item_schedule_active,where:status <> 'VOIDED'" json:"order_item_id"`
 	OrderItem    *orderitem.OrderItem `gorm:"foreignKey:OrderItemID" json:"order_item,omitempty"`
+	ScheduleID   *string           `gorm:"type:uuid;uniqueIndex:idx_check_ins_item_schedule_active,where:status <> 'VOIDED';index" json:"schedule_id,omitempty"` // One active check-in per schedule (multi-day passes)
 	QRCode       string            `gorm:"type:varchar(255);not null;index" json:"qr_code"`
 	GateID       *string           `gorm:"type:uuid;index" json:"gate_id,omitempty"`
+	DeviceID     *string           `gorm:"type:uuid;index" json:"device_id,omitempty"` // Registered scanner device, if the scan came from one
 	StaffID      string            `gorm:"type:uuid;not null;index" json:"staff_id"`
 	Staff        *user.User        `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
 	Status       CheckInStatus     `gorm:"type:varchar(20);not null;default:'SUCCESS'" json:"status"`
@@ -32,6 +43,9 @@ type CheckIn struct {
 	IPAddress    string            `gorm:"type:varchar(45)" json:"ip_address"`
 	UserAgent    string            `gorm:"type:text" json:"user_agent"`
 	CheckedInAt  time.Time         `gorm:"type:timestamp;not null" json:"checked_in_at"`
+	VoidedAt     *time.Time        `gorm:"type:timestamp" json:"voided_at,omitempty"`
+	VoidedBy     *string           `gorm:"type:uuid" json:"voided_by,omitempty"`
+	VoidReason   string            `gorm:"type:text" json:"void_reason,omitempty"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt    `gorm:"index" json:"-"`
@@ -53,13 +67,76 @@ func (c *CheckIn) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// TicketScan records every entry and exit scan of a ticket, used for re-entry and occupancy
+type TicketScan struct {
+	ID          string        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderItemID string        `gorm:"type:uuid;not null;index:idx_ticket_scans_item_time" json:"order_item_id"`
+	CheckInID   string        `gorm:"type:uuid;not null;index" json:"check_in_id"`
+	ScheduleID  string        `gorm:"type:uuid;not null;index" json:"schedule_id"`
+	GateID      *string       `gorm:"type:uuid;index" json:"gate_id,omitempty"`
+	DeviceID    *string       `gorm:"type:uuid" json:"device_id,omitempty"`
+	StaffID     string        `gorm:"type:uuid;not null" json:"staff_id"`
+	Direction   ScanDirection `gorm:"type:varchar(10);not null" json:"direction"`
+	Voided      bool          `gorm:"not null;default:false" json:"voided"` // Check-in was voided; ignored for re-entry and occupancy
+	ScannedAt   time.Time     `gorm:"type:timestamp;not null;index:idx_ticket_scans_item_time" json:"scanned_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// TableName specifies the table name for TicketScan
+func (TicketScan) TableName() string {
+	return "ticket_scans"
+}
+
+// BeforeCreate hook to generate UUID
+func (t *TicketScan) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.ScannedAt.IsZero() {
+		t.ScannedAt = time.Now()
+	}
+	return nil
+}
+
+// TicketScanResponse represents ticket scan response DTO
+type TicketScanResponse struct {
+	ID          string        `json:"id"`
+	OrderItemID string        `json:"order_item_id"`
+	CheckInID   string        `json:"check_in_id"`
+	ScheduleID  string        `json:"schedule_id"`
+	GateID      *string       `json:"gate_id,omitempty"`
+	DeviceID    *string       `json:"device_id,omitempty"`
+	StaffID     string        `json:"staff_id"`
+	Direction   ScanDirection `json:"direction"`
+	Voided      bool          `json:"voided,omitempty"`
+	ScannedAt   time.Time     `json:"scanned_at"`
+}
+
+// ToTicketScanResponse converts TicketScan to TicketScanResponse
+func (t *TicketScan) ToTicketScanResponse() *TicketScanResponse {
+	return &TicketScanResponse{
+		ID:          t.ID,
+		OrderItemID: t.OrderItemID,
+		CheckInID:   t.CheckInID,
+		ScheduleID:  t.ScheduleID,
+		GateID:      t.GateID,
+		DeviceID:    t.DeviceID,
+		StaffID:     t.StaffID,
+		Direction:   t.Direction,
+		Voided:      t.Voided,
+		ScannedAt:   t.ScannedAt,
+	}
+}
+
 // CheckInResponse represents check-in response DTO
 type CheckInResponse struct {
 	ID           string                      `json:"id"`
 	OrderItemID  string                      `json:"order_item_id"`
 	OrderItem    *orderitem.OrderItemResponse `json:"order_item,omitempty"`
+	ScheduleID   *string                     `json:"schedule_id,omitempty"`
 	QRCode       string                      `json:"qr_code"`
 	GateID       *string                     `json:"gate_id,omitempty"`
+	DeviceID     *string                     `json:"device_id,omitempty"`
 	StaffID      string                      `json:"staff_id"`
 	Staff        *user.UserResponse          `json:"staff,omitempty"`
 	Status       CheckInStatus               `json:"status"`
@@ -67,6 +144,9 @@ type CheckInResponse struct {
 	IPAddress    string                      `json:"ip_address"`
 	UserAgent    string                      `json:"user_agent"`
 	CheckedInAt  time.Time                   `json:"checked_in_at"`
+	VoidedAt     *time.Time                  `json:"voided_at,omitempty"`
+	VoidedBy     *string                     `json:"voided_by,omitempty"`
+	VoidReason   string                      `json:"void_reason,omitempty"`
 	CreatedAt    time.Time                   `json:"created_at"`
 	UpdatedAt    time.Time                   `json:"updated_at"`
 }
@@ -76,14 +156,19 @@ func (c *CheckIn) ToCheckInResponse() *CheckInResponse {
 	resp := &CheckInResponse{
 		ID:          c.ID,
 		OrderItemID: c.OrderItemID,
+		ScheduleID:  c.ScheduleID,
 		QRCode:      c.QRCode,
 		GateID:      c.GateID,
+		DeviceID:    c.DeviceID,
 		StaffID:     c.StaffID,
 		Status:      c.Status,
 		Location:    c.Location,
 		IPAddress:   c.IPAddress,
 		UserAgent:   c.UserAgent,
 		CheckedInAt: c.CheckedInAt,
+		VoidedAt:    c.VoidedAt,
+		VoidedBy:    c.VoidedBy,
+		VoidReason:  c.VoidReason,
 		CreatedAt:   c.CreatedAt,
 		UpdatedAt:   c.UpdatedAt,
 	}
@@ -98,29 +183,49 @@ func (c *CheckIn) ToCheckInResponse() *CheckInResponse {
 
 // ValidateQRCodeRequest represents QR code validation request
 type ValidateQRCodeRequest struct {
-	QRCode string `json:"qr_code" binding:"required"`
+	QRCode     string `json:"qr_code" binding:"required"`
+	ScheduleID string `json:"schedule_id,omitempty" binding:"omitempty,uuid"` // Schedule being admitted by the scanner
 }
 
 // ValidateQRCodeResponse represents QR code validation response
 type ValidateQRCodeResponse struct {
 	Valid       bool   `json:"valid"`
 	OrderItemID string `json:"order_item_id,omitempty"`
+	ScheduleID  string `json:"schedule_id,omitempty"` // Schedule the scan admits to (the current day for passes)
 	Status      string `json:"status,omitempty"`
 	Message     string `json:"message,omitempty"`
 	AlreadyUsed bool   `json:"already_used,omitempty"`
+	Reentry     bool   `json:"reentry,omitempty"` // Valid as a re-entry of a ticket that scanned out
+	Inside      bool   `json:"inside,omitempty"`  // Ticket is currently inside the venue
+	ErrorCode   string `json:"error_code,omitempty"` // Error code a check-in would fail with
 }
 
 // CheckInRequest represents check-in request
 type CheckInRequest struct {
+	QRCode     string  `json:"qr_code" binding:"required"`
+	GateID     *string `json:"gate_id,omitempty"`
+	Location   string  `json:"location,omitempty"`
+	ScheduleID string  `json:"schedule_id,omitempty" binding:"omitempty,uuid"` // Schedule being admitted by the scanner
+	DeviceID   *string `json:"-"`                                              // Registered device, set from the device token
+}
+
+// VoidCheckInRequest represents supervisor void check-in request
+type VoidCheckInRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// ExitScanRequest represents exit scan request
+type ExitScanRequest struct {
 	QRCode   string  `json:"qr_code" binding:"required"`
 	GateID   *string `json:"gate_id,omitempty"`
-	Location string  `json:"location,omitempty"`
+	DeviceID *string `json:"-"` // Registered device, set from the device token
 }
 
 // CheckInResponse represents check-in result response
 type CheckInResultResponse struct {
 	Success   bool              `json:"success"`
 	CheckIn   *CheckInResponse  `json:"check_in,omitempty"`
+	Scan      *TicketScanResponse `json:"scan,omitempty"` // The entry or exit scan recorded by this request
 	Message   string            `json:"message"`
 	ErrorCode string            `json:"error_code,omitempty"`
 }
@@ -132,8 +237,32 @@ type ListCheckInsRequest struct {
 	OrderItemID   string     `form:"order_item_id" binding:"omitempty,uuid"`
 	GateID        string     `form:"gate_id" binding:"omitempty,uuid"`
 	StaffID       string     `form:"staff_id" binding:"omitempty,uuid"`
-	Status        CheckInStatus `form:"status" binding:"omitempty,oneof=SUCCESS FAILED DUPLICATE"`
+	Status        CheckInStatus `form:"status" binding:"omitempty,oneof=SUCCESS FAILED DUPLICATE VOIDED"`
 	StartDate     *time.Time `form:"start_date" binding:"omitempty"`
 	EndDate       *time.Time `form:"end_date" binding:"omitempty"`
 }
 
+// GetOccupancyRequest represents occupancy query parameters
+type GetOccupancyRequest struct {
+	ScheduleID string `form:"schedule_id" binding:"omitempty,uuid"`
+	GateID     string `form:"gate_id" binding:"omitempty,uuid"`
+}
+
+// GateOccupancy represents tickets currently inside that last entered through a gate
+type GateOccupancy struct {
+	GateID *string `json:"gate_id"` // Nil for scans without a gate
+	Inside int64   `json:"inside"`
+}
+
+// ScheduleOccupancy represents tickets currently inside for a schedule
+type ScheduleOccupancy struct {
+	ScheduleID string `json:"schedule_id"`
+	Inside     int64  `json:"inside"`
+}
+
+// OccupancyResponse represents live "currently inside" counts derived from the scan history
+type OccupancyResponse struct {
+	TotalInside int64                `json:"total_inside"`
+	Schedules   []*ScheduleOccupancy `json:"schedules"`
+	Gates       []*GateOccupancy     `json:"gates"`
+}
@@ -44,6 +44,7 @@ type QuotaOverview struct {
 	Remaining         int          `json:"remaining"`
 	UtilizationRate   float64      `json:"utilization_rate"`
 	ByTier            []QuotaByTier `json:"by_tier"`
+	Allocations       []QuotaAllocationUtilization `json:"allocations"`
 }
 
 // QuotaByTier represents quota by tier
@@ -54,6 +55,24 @@ type QuotaByTier struct {
 	Sold             int     `json:"sold"`
 	Remaining        int     `json:"remaining"`
 	UtilizationRate  float64 `json:"utilization_rate"`
+	Allocated        int     `json:"allocated"`       // Seats held by active allocations
+	AllocationUsed   int     `json:"allocation_used"` // Seats consumed from active allocations
+}
+
+// QuotaAllocationUtilization represents utilization of a single quota allocation
+type QuotaAllocationUtilization struct {
+	AllocationID     string  `json:"allocation_id"`
+	Name             string  `json:"name"`
+	OwnerType        string  `json:"owner_type"`
+	OwnerName        string  `json:"owner_name"`
+	TierID           string  `json:"tier_id"`
+	TierName         string  `json:"tier_name"`
+	Status           string  `json:"status"`
+	Size             int     `json:"size"`
+	Used             int     `json:"used"`
+	Remaining        int     `json:"remaining"`
+	UtilizationRate  float64 `json:"utilization_rate"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
 }
 
 // GateActivity represents gate activity
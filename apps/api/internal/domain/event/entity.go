@@ -19,6 +19,7 @@ const (
 // Event represents an event entity
 type Event struct {
 	ID          string      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrganizationID *string  `gorm:"type:uuid;index" json:"organization_id,omitempty"` // Promoter owning the event
 	EventName   string      `gorm:"type:varchar(255);not null" json:"event_name"`
 	Description string      `gorm:"type:text" json:"description"`
 	BannerImage string      `gorm:"type:text" json:"banner_image"`
@@ -46,6 +47,7 @@ func (e *Event) BeforeCreate(tx *gorm.DB) error {
 // EventResponse represents event response DTO
 type EventResponse struct {
 	ID          string      `json:"id"`
+	OrganizationID *string  `json:"organization_id,omitempty"`
 	EventName   string      `json:"event_name"`
 	Description string      `json:"description"`
 	BannerImage string      `json:"banner_image"`
@@ -66,6 +68,7 @@ func (e *Event) ToEventResponse() *EventResponse {
 	
 	return &EventResponse{
 		ID:          e.ID,
+		OrganizationID: e.OrganizationID,
 		EventName:   e.EventName,
 		Description: e.Description,
 		BannerImage: e.BannerImage,
@@ -0,0 +1,133 @@
+package fraud
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Rule identifies the suspicious scan pattern that raised an alert
+type Rule string
+
+const (
+	RuleMultiGate       Rule = "MULTI_GATE"       // Ticket admitted at one gate is scanned at another within minutes
+	RuleInvalidBurst    Rule = "INVALID_BURST"    // Burst of invalid codes from one device
+	RuleUnassignedStaff Rule = "UNASSIGNED_STAFF" // Scan at a gate by staff not assigned to it
+	RuleRefundedTicket  Rule = "REFUNDED_TICKET"  // Refunded or canceled ticket presented (e.g. a screenshot)
+)
+
+// Severity represents how urgently an alert needs review
+type Severity string
+
+const (
+	SeverityLow    Severity = "LOW"
+	SeverityMedium Severity = "MEDIUM"
+	SeverityHigh   Severity = "HIGH"
+)
+
+// AlertStatus represents the review state of an alert
+type AlertStatus string
+
+const (
+	AlertStatusOpen      AlertStatus = "OPEN"
+	AlertStatusConfirmed AlertStatus = "CONFIRMED" // Fraud confirmed; the ticket stays blocked
+	AlertStatusDismissed AlertStatus = "DISMISSED" // False positive; the ticket is released
+)
+
+// Alert is a suspicious scan pattern flagged by the fraud rules
+type Alert struct {
+	ID            string      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Rule          Rule        `gorm:"type:varchar(30);not null;index:idx_fraud_alerts_rule_subject" json:"rule"`
+	Subject       string      `gorm:"type:varchar(255);not null;index:idx_fraud_alerts_rule_subject" json:"subject"` // QR code, device or staff/gate the alert is about
+	Severity      Severity    `gorm:"type:varchar(10);not null" json:"severity"`
+	Status        AlertStatus `gorm:"type:varchar(20);not null;default:'OPEN';index" json:"status"`
+	Message       string      `gorm:"type:text" json:"message"`
+	ScanLogID     *string     `gorm:"type:uuid" json:"scan_log_id,omitempty"` // Scan attempt that triggered the alert
+	OrderItemID   *string     `gorm:"type:uuid;index" json:"order_item_id,omitempty"`
+	GateID        *string     `gorm:"type:uuid;index" json:"gate_id,omitempty"`
+	DeviceID      string      `gorm:"type:varchar(100)" json:"device_id"`
+	StaffID       *string     `gorm:"type:uuid" json:"staff_id,omitempty"`
+	TicketBlocked bool        `gorm:"not null;default:false" json:"ticket_blocked"` // Alert put the ticket on hold
+	ReviewedBy    *string     `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time  `gorm:"type:timestamp" json:"reviewed_at,omitempty"`
+	ReviewNote    string      `gorm:"type:text" json:"review_note,omitempty"`
+	CreatedAt     time.Time   `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for Alert
+func (Alert) TableName() string {
+	return "fraud_alerts"
+}
+
+// BeforeCreate hook to generate UUID
+func (a *Alert) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// AlertResponse represents fraud alert response DTO
+type AlertResponse struct {
+	ID            string      `json:"id"`
+	Rule          Rule        `json:"rule"`
+	Subject       string      `json:"subject"`
+	Severity      Severity    `json:"severity"`
+	Status        AlertStatus `json:"status"`
+	Message       string      `json:"message"`
+	ScanLogID     *string     `json:"scan_log_id,omitempty"`
+	OrderItemID   *string     `json:"order_item_id,omitempty"`
+	GateID        *string     `json:"gate_id,omitempty"`
+	DeviceID      string      `json:"device_id"`
+	StaffID       *string     `json:"staff_id,omitempty"`
+	TicketBlocked bool        `json:"ticket_blocked"`
+	ReviewedBy    *string     `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time  `json:"reviewed_at,omitempty"`
+	ReviewNote    string      `json:"review_note,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// ToAlertResponse converts Alert to AlertResponse
+func (a *Alert) ToAlertResponse() *AlertResponse {
+	return &AlertResponse{
+		ID:            a.ID,
+		Rule:          a.Rule,
+		Subject:       a.Subject,
+		Severity:      a.Severity,
+		Status:        a.Status,
+		Message:       a.Message,
+		ScanLogID:     a.ScanLogID,
+		OrderItemID:   a.OrderItemID,
+		GateID:        a.GateID,
+		DeviceID:      a.DeviceID,
+		StaffID:       a.StaffID,
+		TicketBlocked: a.TicketBlocked,
+		ReviewedBy:    a.ReviewedBy,
+		ReviewedAt:    a.ReviewedAt,
+		ReviewNote:    a.ReviewNote,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// ListAlertsRequest represents list fraud alerts query parameters
+type ListAlertsRequest struct {
+	Page        int         `form:"page" binding:"omitempty,min=1"`
+	PerPage     int         `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Rule        Rule        `form:"rule" binding:"omitempty,oneof=MULTI_GATE INVALID_BURST UNASSIGNED_STAFF REFUNDED_TICKET"`
+	Severity    Severity    `form:"severity" binding:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Status      AlertStatus `form:"status" binding:"omitempty,oneof=OPEN CONFIRMED DISMISSED"`
+	OrderItemID string      `form:"order_item_id" binding:"omitempty,uuid"`
+	GateID      string      `form:"gate_id" binding:"omitempty,uuid"`
+	StartDate   *time.Time  `form:"start_date" binding:"omitempty"`
+	EndDate     *time.Time  `form:"end_date" binding:"omitempty"`
+}
+
+// ReviewAlertRequest represents a supervisor's decision on an open alert
+type ReviewAlertRequest struct {
+	Status AlertStatus `json:"status" binding:"required,oneof=CONFIRMED DISMISSED"`
+	Note   string      `json:"note" binding:"omitempty,max=500"`
+}
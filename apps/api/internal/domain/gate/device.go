@@ -0,0 +1,151 @@
+package gate
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeviceTokenHeader is the request header scanner devices authenticate with
+const DeviceTokenHeader = "X-Device-Token"
+
+// DeviceStatus represents gate device status enum
+type DeviceStatus string
+
+const (
+	DeviceStatusActive  DeviceStatus = "ACTIVE"
+	DeviceStatusRevoked DeviceStatus = "REVOKED"
+)
+
+// GateDevice represents a registered scanner device bound to a gate.
+// Only the sha256 hash of the device token is stored; the token itself is shown once on enrollment.
+type GateDevice struct {
+	ID           string       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	GateID       string       `gorm:"type:uuid;not null;index" json:"gate_id"`
+	Gate         *Gate        `gorm:"foreignKey:GateID" json:"gate,omitempty"`
+	Name         string       `gorm:"type:varchar(100);not null" json:"name"`
+	TokenHash    string       `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Status       DeviceStatus `gorm:"type:varchar(20);not null;default:'ACTIVE';index" json:"status"`
+	EnrolledBy   string       `gorm:"type:uuid;not null" json:"enrolled_by"`
+	LastSeenAt   *time.Time   `gorm:"type:timestamp;index" json:"last_seen_at,omitempty"`
+	LastIP       string       `gorm:"type:varchar(45)" json:"last_ip"`
+	BatteryLevel *int         `json:"battery_level,omitempty"` // Percent, as reported by the last heartbeat
+	AppVersion   string       `gorm:"type:varchar(50)" json:"app_version"`
+	QueueDepth   int          `gorm:"not null;default:0" json:"queue_depth"` // Scans waiting to be uploaded (offline mode)
+	RevokedAt    *time.Time   `gorm:"type:timestamp" json:"revoked_at,omitempty"`
+	RevokedBy    *string      `gorm:"type:uuid" json:"revoked_by,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// TableName specifies the table name for GateDevice
+func (GateDevice) TableName() string {
+	return "gate_devices"
+}
+
+// BeforeCreate hook to generate UUID
+func (d *GateDevice) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.Status == "" {
+		d.Status = DeviceStatusActive
+	}
+	return nil
+}
+
+// IsOnline reports whether the device sent a heartbeat within the given window
+func (d *GateDevice) IsOnline(now time.Time, offlineAfter time.Duration) bool {
+	return d.Status == DeviceStatusActive && d.LastSeenAt != nil && now.Sub(*d.LastSeenAt) <= offlineAfter
+}
+
+// GenerateDeviceToken generates a random device token
+func GenerateDeviceToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "gdt_" + hex.EncodeToString(buf), nil
+}
+
+// HashDeviceToken returns the sha256 hex digest stored for a device token
+func HashDeviceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GateDeviceResponse represents gate device response DTO
+type GateDeviceResponse struct {
+	ID           string        `json:"id"`
+	GateID       string        `json:"gate_id"`
+	Gate         *GateResponse `json:"gate,omitempty"`
+	Name         string        `json:"name"`
+	Status       DeviceStatus  `json:"status"`
+	Online       bool          `json:"online"`
+	EnrolledBy   string        `json:"enrolled_by"`
+	LastSeenAt   *time.Time    `json:"last_seen_at,omitempty"`
+	LastIP       string        `json:"last_ip"`
+	BatteryLevel *int          `json:"battery_level,omitempty"`
+	AppVersion   string        `json:"app_version"`
+	QueueDepth   int           `json:"queue_depth"`
+	RevokedAt    *time.Time    `json:"revoked_at,omitempty"`
+	RevokedBy    *string       `json:"revoked_by,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// ToGateDeviceResponse converts GateDevice to GateDeviceResponse
+func (d *GateDevice) ToGateDeviceResponse(now time.Time, offlineAfter time.Duration) *GateDeviceResponse {
+	resp := &GateDeviceResponse{
+		ID:           d.ID,
+		GateID:       d.GateID,
+		Name:         d.Name,
+		Status:       d.Status,
+		Online:       d.IsOnline(now, offlineAfter),
+		EnrolledBy:   d.EnrolledBy,
+		LastSeenAt:   d.LastSeenAt,
+		LastIP:       d.LastIP,
+		BatteryLevel: d.BatteryLevel,
+		AppVersion:   d.AppVersion,
+		QueueDepth:   d.QueueDepth,
+		RevokedAt:    d.RevokedAt,
+		RevokedBy:    d.RevokedBy,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+	if d.Gate != nil {
+		resp.Gate = d.Gate.ToGateResponse()
+	}
+	return resp
+}
+
+// EnrollDeviceResponse returns the device with its token, which is only ever shown here
+type EnrollDeviceResponse struct {
+	Device      *GateDeviceResponse `json:"device"`
+	DeviceToken string              `json:"device_token"`
+}
+
+// EnrollDeviceRequest represents enroll gate device request DTO
+type EnrollDeviceRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// DeviceHeartbeatRequest represents the status a device reports on each heartbeat
+type DeviceHeartbeatRequest struct {
+	BatteryLevel *int   `json:"battery_level" binding:"omitempty,min=0,max=100"`
+	AppVersion   string `json:"app_version" binding:"omitempty,max=50"`
+	QueueDepth   int    `json:"queue_depth" binding:"omitempty,min=0"`
+}
+
+// ListDevicesRequest represents list gate devices query parameters
+type ListDevicesRequest struct {
+	Page    int          `form:"page" binding:"omitempty,min=1"`
+	PerPage int          `form:"per_page" binding:"omitempty,min=1,max=100"`
+	GateID  string       `form:"gate_id" binding:"omitempty,uuid"`
+	Status  DeviceStatus `form:"status" binding:"omitempty,oneof=ACTIVE REVOKED"`
+	Online  *bool        `form:"online" binding:"omitempty"` // Filter active devices by heartbeat freshness
+}
@@ -18,13 +18,14 @@ const (
 // Gate represents a gate entity
 type Gate struct {
 	ID          string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrganizationID *string `gorm:"type:uuid;index" json:"organization_id,omitempty"` // Organization operating the gate
 	Code        string     `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"`
 	Name        string     `gorm:"type:varchar(255);not null" json:"name"`
 	Location    string     `gorm:"type:varchar(255)" json:"location"`
 	Description string     `gorm:"type:text" json:"description"`
 	IsVIP       bool       `gorm:"default:false" json:"is_vip"`
 	Status      GateStatus `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
-	Capacity    int        `gorm:"default:0" json:"capacity"` // Maximum capacity per gate (0 = unlimited)
+	Capacity    int        `gorm:"default:0" json:"capacity"` // Maximum first check-ins per gate per schedule (0 = unlimited)
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
@@ -46,9 +47,18 @@ func (g *Gate) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// OrganizationIDValue returns the organization operating the gate, empty when there is none
+func (g *Gate) OrganizationIDValue() string {
+	if g.OrganizationID == nil {
+		return ""
+	}
+	return *g.OrganizationID
+}
+
 // GateResponse represents gate response DTO
 type GateResponse struct {
 	ID          string     `json:"id"`
+	OrganizationID *string `json:"organization_id,omitempty"`
 	Code        string     `json:"code"`
 	Name        string     `json:"name"`
 	Location    string     `json:"location"`
@@ -64,6 +74,7 @@ type GateResponse struct {
 func (g *Gate) ToGateResponse() *GateResponse {
 	return &GateResponse{
 		ID:          g.ID,
+		OrganizationID: g.OrganizationID,
 		Code:        g.Code,
 		Name:        g.Name,
 		Location:    g.Location,
@@ -118,6 +129,14 @@ type GateCheckInRequest struct {
 	QRCode   string  `json:"qr_code" binding:"required"`
 	GateID   string  `json:"gate_id" binding:"required,uuid"`
 	Location string  `json:"location" binding:"omitempty"`
+	ScheduleID string `json:"schedule_id" binding:"omitempty,uuid"` // Schedule being admitted at the gate
+	DeviceID   *string `json:"-"`                                    // Registered device, set from the device token
+}
+
+// GateExitRequest represents gate exit scan request DTO
+type GateExitRequest struct {
+	QRCode   string  `json:"qr_code" binding:"required"`
+	DeviceID *string `json:"-"` // Registered device, set from the device token
 }
 
 // GateStatisticsResponse represents gate statistics response
@@ -129,4 +148,5 @@ type GateStatisticsResponse struct {
 	TodayCheckIns  int64  `json:"today_check_ins"`
 	VIPCheckIns    int64  `json:"vip_check_ins"`
 	RegularCheckIns int64 `json:"regular_check_ins"`
+	CurrentlyInside int64 `json:"currently_inside"` // Tickets whose latest scan was an entry through this gate
 }
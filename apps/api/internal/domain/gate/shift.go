@@ -0,0 +1,164 @@
+package gate
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShiftRole represents what a staff member does during a gate shift. Scanners and supervisors both
+// scan; supervisors may also hand over the shifts of other staff at their gate.
+type ShiftRole string
+
+const (
+	ShiftRoleScanner    ShiftRole = "SCANNER"
+	ShiftRoleSupervisor ShiftRole = "SUPERVISOR"
+)
+
+// GateStaffShift is a time-bounded duty of a staff member at a gate.
+// A staff member may only scan at a gate while one of their shifts there is active, also after
+// their shifts there were cancelled; assignments never given a shift keep the legacy always-on behaviour.
+// A shift for a schedule only admits tickets for that schedule.
+// A handover ends the shift early and starts a follow-up shift for the incoming staff member.
+type GateStaffShift struct {
+	ID               string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	GateID           string     `gorm:"type:uuid;not null;index:idx_gate_staff_shifts_gate_staff" json:"gate_id"`
+	Gate             *Gate      `gorm:"foreignKey:GateID" json:"gate,omitempty"`
+	StaffID          string     `gorm:"type:uuid;not null;index:idx_gate_staff_shifts_gate_staff;index" json:"staff_id"`
+	Staff            *user.User `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+	ScheduleID       *string    `gorm:"type:uuid;index" json:"schedule_id,omitempty"`
+	Role             ShiftRole  `gorm:"type:varchar(20);not null;default:'SCANNER'" json:"role"`
+	StartsAt         time.Time  `gorm:"type:timestamp;not null;index" json:"starts_at"`
+	EndsAt           time.Time  `gorm:"type:timestamp;not null;index" json:"ends_at"`
+	HandedOverFromID *string    `gorm:"type:uuid" json:"handed_over_from_id,omitempty"` // Shift this one took over from
+	HandedOverToID   *string    `gorm:"type:uuid" json:"handed_over_to_id,omitempty"`   // Shift that took over this one
+	HandedOverAt     *time.Time `gorm:"type:timestamp" json:"handed_over_at,omitempty"`
+	Notes            string     `gorm:"type:text" json:"notes"`
+	CreatedBy        string     `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GateStaffShift
+func (GateStaffShift) TableName() string {
+	return "gate_staff_shifts"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *GateStaffShift) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.Role == "" {
+		s.Role = ShiftRoleScanner
+	}
+	return nil
+}
+
+// IsActiveAt reports whether the shift covers the given instant (start inclusive, end exclusive)
+func (s *GateStaffShift) IsActiveAt(at time.Time) bool {
+	return !at.Before(s.StartsAt) && at.Before(s.EndsAt)
+}
+
+// GateStaffShiftResponse represents gate staff shift response DTO
+type GateStaffShiftResponse struct {
+	ID               string             `json:"id"`
+	GateID           string             `json:"gate_id"`
+	Gate             *GateResponse      `json:"gate,omitempty"`
+	StaffID          string             `json:"staff_id"`
+	Staff            *user.UserResponse `json:"staff,omitempty"`
+	ScheduleID       *string            `json:"schedule_id,omitempty"`
+	Role             ShiftRole          `json:"role"`
+	StartsAt         time.Time          `json:"starts_at"`
+	EndsAt           time.Time          `json:"ends_at"`
+	Active           bool               `json:"active"`
+	HandedOverFromID *string            `json:"handed_over_from_id,omitempty"`
+	HandedOverToID   *string            `json:"handed_over_to_id,omitempty"`
+	HandedOverAt     *time.Time         `json:"handed_over_at,omitempty"`
+	Notes            string             `json:"notes"`
+	CreatedBy        string             `json:"created_by"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// ToGateStaffShiftResponse converts GateStaffShift to GateStaffShiftResponse
+func (s *GateStaffShift) ToGateStaffShiftResponse(now time.Time) *GateStaffShiftResponse {
+	resp := &GateStaffShiftResponse{
+		ID:               s.ID,
+		GateID:           s.GateID,
+		StaffID:          s.StaffID,
+		ScheduleID:       s.ScheduleID,
+		Role:             s.Role,
+		StartsAt:         s.StartsAt,
+		EndsAt:           s.EndsAt,
+		Active:           s.IsActiveAt(now),
+		HandedOverFromID: s.HandedOverFromID,
+		HandedOverToID:   s.HandedOverToID,
+		HandedOverAt:     s.HandedOverAt,
+		Notes:            s.Notes,
+		CreatedBy:        s.CreatedBy,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+	if s.Gate != nil {
+		resp.Gate = s.Gate.ToGateResponse()
+	}
+	if s.Staff != nil {
+		resp.Staff = s.Staff.ToUserResponse()
+	}
+	return resp
+}
+
+// ShiftScanCount holds scan_logs counts attributed to one shift
+type ShiftScanCount struct {
+	ShiftID  string `json:"shift_id"`
+	Entries  int64  `json:"entries"`
+	Exits    int64  `json:"exits"`
+	Rejected int64  `json:"rejected"`
+}
+
+// ShiftReportResponse represents the scans a staff member performed during one shift
+type ShiftReportResponse struct {
+	Shift    *GateStaffShiftResponse `json:"shift"`
+	Entries  int64                   `json:"entries"`  // Accepted entry scans
+	Exits    int64                   `json:"exits"`    // Accepted exit scans
+	Rejected int64                   `json:"rejected"` // Rejected entry/exit attempts
+	Total    int64                   `json:"total"`
+}
+
+// ShiftHandoverResponse returns the ended shift together with the shift that took over
+type ShiftHandoverResponse struct {
+	Previous *GateStaffShiftResponse `json:"previous"`
+	Next     *GateStaffShiftResponse `json:"next"`
+}
+
+// CreateShiftRequest represents create gate staff shift request DTO
+type CreateShiftRequest struct {
+	StaffID    string    `json:"staff_id" binding:"required,uuid"`
+	ScheduleID *string   `json:"schedule_id" binding:"omitempty,uuid"`
+	Role       ShiftRole `json:"role" binding:"omitempty,oneof=SCANNER SUPERVISOR"`
+	StartsAt   time.Time `json:"starts_at" binding:"required"`
+	EndsAt     time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Notes      string    `json:"notes" binding:"omitempty,max=500"`
+}
+
+// HandoverShiftRequest represents a shift handover to another staff member.
+// At defaults to now and must fall within the shift being handed over.
+type HandoverShiftRequest struct {
+	ToStaffID string     `json:"to_staff_id" binding:"required,uuid"`
+	At        *time.Time `json:"at" binding:"omitempty"`
+	Notes     string     `json:"notes" binding:"omitempty,max=500"`
+}
+
+// ListShiftsRequest represents list gate staff shifts query parameters
+type ListShiftsRequest struct {
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	PerPage    int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	GateID     string `form:"gate_id" binding:"omitempty,uuid"`
+	StaffID    string `form:"staff_id" binding:"omitempty,uuid"`
+	ScheduleID string `form:"schedule_id" binding:"omitempty,uuid"`
+	Date       string `form:"date" binding:"omitempty,datetime=2006-01-02"` // Shifts overlapping this day (WIB)
+	ActiveOnly bool   `form:"active_only" binding:"omitempty"`
+}
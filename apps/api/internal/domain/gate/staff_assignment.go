@@ -12,13 +12,14 @@ import (
 // This enables multi-gate deployments where different staff are assigned to different gates.
 // Rows are hard-deleted on unassign.
 type GateStaffAssignment struct {
-	ID        string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	GateID    string     `gorm:"type:uuid;not null;index;uniqueIndex:ux_gate_staff" json:"gate_id"`
-	Gate      *Gate      `gorm:"foreignKey:GateID" json:"gate,omitempty"`
-	StaffID   string     `gorm:"type:uuid;not null;index;uniqueIndex:ux_gate_staff" json:"staff_id"`
-	Staff     *user.User `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID              string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	GateID          string     `gorm:"type:uuid;not null;index;uniqueIndex:ux_gate_staff" json:"gate_id"`
+	Gate            *Gate      `gorm:"foreignKey:GateID" json:"gate,omitempty"`
+	StaffID         string     `gorm:"type:uuid;not null;index;uniqueIndex:ux_gate_staff" json:"staff_id"`
+	Staff           *user.User `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+	ShiftRestricted bool       `gorm:"not null;default:false" json:"shift_restricted"` // Set once the staff member got a shift at the gate
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 func (GateStaffAssignment) TableName() string {
@@ -32,6 +33,47 @@ func (a *GateStaffAssignment) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// OnDutyAt reports whether the assigned staff member may scan at the gate at the given instant for
+// scheduleID (empty when the scan names no schedule), given their shifts there. A shift for a
+// schedule only covers scans for that schedule; the returned schedule is the one the scan is
+// limited to, the shift's when the scan names none. Assignments restricted to shifts stay
+// restricted when every shift is cancelled, so scheduling a shift never grants round-the-clock access.
+func (a *GateStaffAssignment) OnDutyAt(shifts []*GateStaffShift, at time.Time, scheduleID string) (string, bool) {
+	for _, shift := range a.activeShifts(shifts, at) {
+		if shift.ScheduleID == nil {
+			return scheduleID, true
+		}
+		if scheduleID == "" || scheduleID == *shift.ScheduleID {
+			return *shift.ScheduleID, true
+		}
+	}
+	if a.ShiftRestricted {
+		return "", false
+	}
+	return scheduleID, true
+}
+
+// SupervisingAt reports whether the assigned staff member is on a supervisor shift at the gate at
+// the given instant
+func (a *GateStaffAssignment) SupervisingAt(shifts []*GateStaffShift, at time.Time) bool {
+	for _, shift := range a.activeShifts(shifts, at) {
+		if shift.Role == ShiftRoleSupervisor {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *GateStaffAssignment) activeShifts(shifts []*GateStaffShift, at time.Time) []*GateStaffShift {
+	var active []*GateStaffShift
+	for _, shift := range shifts {
+		if shift.GateID == a.GateID && shift.StaffID == a.StaffID && shift.IsActiveAt(at) {
+			active = append(active, shift)
+		}
+	}
+	return active
+}
+
 type AssignStaffToGateRequest struct {
 	StaffID string `json:"staff_id" binding:"required,uuid"`
 }
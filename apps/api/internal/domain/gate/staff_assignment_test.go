@@ -0,0 +1,91 @@
+package gate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnDutyAfterCancelledShift(t *testing.T) {
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	shift := &GateStaffShift{
+		GateID:   "gate",
+		StaffID:  "staff",
+		StartsAt: now.Add(24 * time.Hour),
+		EndsAt:   now.Add(32 * time.Hour),
+	}
+
+	// CreateShift assigns the staff member restricted to their shifts
+	assignment := &GateStaffAssignment{GateID: "gate", StaffID: "staff", ShiftRestricted: true}
+	if onDuty(assignment, []*GateStaffShift{shift}, now) {
+		t.Fatal("staff is on duty before their shift starts")
+	}
+	if !onDuty(assignment, []*GateStaffShift{shift}, shift.StartsAt) {
+		t.Fatal("staff is off duty during their shift")
+	}
+
+	// DeleteShift removes the only shift; the assignment stays restricted
+	if onDuty(assignment, nil, now) {
+		t.Fatal("staff is on duty after their only shift was cancelled")
+	}
+	if onDuty(assignment, nil, shift.StartsAt) {
+		t.Fatal("staff is on duty in the cancelled shift's period")
+	}
+}
+
+func TestOnDutyWithoutShifts(t *testing.T) {
+	assignment := &GateStaffAssignment{GateID: "gate", StaffID: "staff"}
+	if !onDuty(assignment, nil, time.Now()) {
+		t.Fatal("staff assigned without shifts is off duty")
+	}
+}
+
+func TestOnDutyForShiftSchedule(t *testing.T) {
+	now := time.Now()
+	scheduleA, scheduleB := "schedule-a", "schedule-b"
+	assignment := &GateStaffAssignment{GateID: "gate", StaffID: "staff", ShiftRestricted: true}
+	shifts := []*GateStaffShift{{
+		GateID:     "gate",
+		StaffID:    "staff",
+		ScheduleID: &scheduleA,
+		StartsAt:   now.Add(-time.Hour),
+		EndsAt:     now.Add(time.Hour),
+	}}
+
+	if scheduleID, ok := assignment.OnDutyAt(shifts, now, scheduleA); !ok || scheduleID != scheduleA {
+		t.Fatalf("scan for the shift's schedule = (%q, %v), want (%q, true)", scheduleID, ok, scheduleA)
+	}
+	if _, ok := assignment.OnDutyAt(shifts, now, scheduleB); ok {
+		t.Fatal("staff is on duty for another schedule than their shift's")
+	}
+	if scheduleID, ok := assignment.OnDutyAt(shifts, now, ""); !ok || scheduleID != scheduleA {
+		t.Fatalf("scan without schedule = (%q, %v), want it limited to %q", scheduleID, ok, scheduleA)
+	}
+}
+
+func TestSupervisingAt(t *testing.T) {
+	now := time.Now()
+	assignment := &GateStaffAssignment{GateID: "gate", StaffID: "staff", ShiftRestricted: true}
+	shift := &GateStaffShift{
+		GateID:   "gate",
+		StaffID:  "staff",
+		Role:     ShiftRoleScanner,
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}
+
+	if assignment.SupervisingAt([]*GateStaffShift{shift}, now) {
+		t.Fatal("scanner shift counts as supervising")
+	}
+	shift.Role = ShiftRoleSupervisor
+	if !assignment.SupervisingAt([]*GateStaffShift{shift}, now) {
+		t.Fatal("supervisor shift does not count as supervising")
+	}
+	if assignment.SupervisingAt([]*GateStaffShift{shift}, shift.EndsAt) {
+		t.Fatal("ended supervisor shift counts as supervising")
+	}
+}
+
+func onDuty(assignment *GateStaffAssignment, shifts []*GateStaffShift, at time.Time) bool {
+	_, ok := assignment.OnDutyAt(shifts, at, "")
+	return ok
+}
@@ -29,6 +29,11 @@ type Order struct {
 	ScheduleID            string             `gorm:"type:uuid;not null;index" json:"schedule_id"`
 	Schedule              *schedule.Schedule `gorm:"foreignKey:ScheduleID" json:"schedule,omitempty"`
 	TicketCategoryID      string             `gorm:"type:uuid;not null;index" json:"ticket_category_id"`
+	QuotaAllocationID     *string            `gorm:"type:uuid;index" json:"quota_allocation_id,omitempty"` // Set when seats were taken from a quota allocation
+	PresaleCodeID         *string            `gorm:"type:uuid;index" json:"presale_code_id,omitempty"`     // Set when the purchase redeemed a presale access code
+	BallotEntryID         *string            `gorm:"type:uuid;index" json:"ballot_entry_id,omitempty"`     // Set when the order claims a winning ballot entry
+	UpgradeOrderItemID    *string            `gorm:"type:uuid;index" json:"upgrade_order_item_id,omitempty"` // Set on supplementary orders paying for a ticket upgrade
+	ResaleListingID       *string            `gorm:"type:uuid;index" json:"resale_listing_id,omitempty"`     // Set when the order buys a resale listing
 	Quantity              int                `gorm:"not null;default:1" json:"quantity"`
 	TotalAmount           float64            `gorm:"type:decimal(15,2);not null" json:"total_amount"`
 	PaymentStatus         PaymentStatus      `gorm:"type:varchar(20);not null;default:'UNPAID';index" json:"payment_status"`
@@ -79,6 +84,11 @@ type OrderResponse struct {
 	OrderCode            string                     `json:"order_code"`
 	ScheduleID           string                     `json:"schedule_id"`
 	Schedule             *schedule.ScheduleResponse `json:"schedule,omitempty"`
+	QuotaAllocationID    *string                    `json:"quota_allocation_id,omitempty"`
+	PresaleCodeID        *string                    `json:"presale_code_id,omitempty"`
+	BallotEntryID        *string                    `json:"ballot_entry_id,omitempty"`
+	UpgradeOrderItemID   *string                    `json:"upgrade_order_item_id,omitempty"`
+	ResaleListingID      *string                    `json:"resale_listing_id,omitempty"`
 	TotalAmount          float64                    `json:"total_amount"`
 	UnitPrice            float64                    `json:"unit_price"`
 	CategoryNameSnapshot string                     `json:"category_name_snapshot"`
@@ -102,6 +112,11 @@ func (o *Order) ToOrderResponse() *OrderResponse {
 		UserID:               o.UserID,
 		OrderCode:            o.OrderCode,
 		ScheduleID:           o.ScheduleID,
+		QuotaAllocationID:    o.QuotaAllocationID,
+		PresaleCodeID:        o.PresaleCodeID,
+		BallotEntryID:        o.BallotEntryID,
+		UpgradeOrderItemID:   o.UpgradeOrderItemID,
+		ResaleListingID:      o.ResaleListingID,
 		TotalAmount:          o.TotalAmount,
 		UnitPrice:            o.UnitPrice,
 		CategoryNameSnapshot: o.CategoryNameSnapshot,
@@ -134,6 +149,23 @@ type CreateOrderRequest struct {
 	BuyerName        string `json:"buyer_name" binding:"required,min=3,max=100"`
 	BuyerEmail       string `json:"buyer_email" binding:"required,email"`
 	BuyerPhone       string `json:"buyer_phone" binding:"required,min=10,max=20"`
+
+	// Optional: purchase/redeem against a reserved quota allocation instead of the public quota.
+	// QuotaAllocationID requires the caller to be the allocation's owner; AllocationCode is a shared access code.
+	QuotaAllocationID string `json:"quota_allocation_id" binding:"omitempty,uuid"`
+	AllocationCode    string `json:"allocation_code" binding:"omitempty,max=64"`
+
+	// Optional: PresaleCode is required while the category is restricted by an open presale batch,
+	// unless the buyer's role is allowed by that batch.
+	PresaleCode string `json:"presale_code" binding:"omitempty,max=64"`
+
+	// Optional: BallotClaimToken is required while the category is sold by ballot.
+	BallotClaimToken string `json:"ballot_claim_token" binding:"omitempty,max=64"`
+}
+
+// UpgradeTicketRequest represents upgrade ticket request DTO
+type UpgradeTicketRequest struct {
+	TargetCategoryID string `json:"target_category_id" binding:"required,uuid"`
 }
 
 // UpdateOrderRequest represents update order request DTO
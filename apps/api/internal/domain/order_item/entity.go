@@ -30,6 +30,9 @@ type OrderItem struct {
 	QRCode       string                `gorm:"type:varchar(255);uniqueIndex;not null" json:"qr_code"`
 	Status       TicketStatus          `gorm:"type:varchar(20);not null;default:'UNPAID'" json:"status"`
 	CheckInTime  *time.Time            `gorm:"type:timestamp" json:"check_in_time"`
+	UpgradedFromCategoryID *string     `gorm:"type:uuid" json:"upgraded_from_category_id,omitempty"` // Category held before the last upgrade
+	ListedForResale bool               `gorm:"not null;default:false" json:"listed_for_resale"` // Locked for check-in while on the resale marketplace
+	FraudHold    bool                  `gorm:"not null;default:false" json:"fraud_hold"` // Blocked from entry pending review of a fraud alert
 	CreatedAt    time.Time             `json:"created_at"`
 	UpdatedAt    time.Time             `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt       `gorm:"index" json:"-"`
@@ -51,6 +54,11 @@ func (oi *OrderItem) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// ReissueQRCode replaces the QR code so the previously issued one can no longer be scanned
+func (oi *OrderItem) ReissueQRCode() {
+	oi.QRCode = generateQRCode()
+}
+
 // generateQRCode generates a unique QR code
 func generateQRCode() string {
 	return "QR-" + uuid.New().String()
@@ -66,6 +74,9 @@ type OrderItemResponse struct {
 	QRCode       string                          `json:"qr_code"`
 	Status       TicketStatus                    `json:"status"`
 	CheckInTime  *time.Time                      `json:"check_in_time"`
+	UpgradedFromCategoryID *string               `json:"upgraded_from_category_id,omitempty"`
+	ListedForResale bool                         `json:"listed_for_resale"`
+	FraudHold    bool                            `json:"fraud_hold"`
 	CreatedAt    time.Time                       `json:"created_at"`
 	UpdatedAt    time.Time                       `json:"updated_at"`
 }
@@ -79,6 +90,9 @@ func (oi *OrderItem) ToOrderItemResponse() *OrderItemResponse {
 		QRCode:      oi.QRCode,
 		Status:      oi.Status,
 		CheckInTime: oi.CheckInTime,
+		UpgradedFromCategoryID: oi.UpgradedFromCategoryID,
+		ListedForResale: oi.ListedForResale,
+		FraudHold:   oi.FraudHold,
 		CreatedAt:   oi.CreatedAt,
 		UpdatedAt:   oi.UpdatedAt,
 	}
@@ -0,0 +1,242 @@
+package organization
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// SuperAdminRole is the role that is not bound to an organization and can cross tenants
+	SuperAdminRole = "super_admin"
+
+	// Header lets a super admin act within one organization, e.g. to create events for it
+	Header = "X-Organization-ID"
+
+	// DefaultCode is the organization existing data is assigned to when multi-tenancy is introduced
+	DefaultCode = "default"
+)
+
+// ErrOrganizationRequired is returned when creating a resource without an owning organization,
+// i.e. by a super admin that did not select one with the X-Organization-ID header
+var ErrOrganizationRequired = errors.New("organization required")
+
+// OrganizationStatus represents organization status enum
+type OrganizationStatus string
+
+const (
+	OrganizationStatusActive   OrganizationStatus = "ACTIVE"
+	OrganizationStatusInactive OrganizationStatus = "INACTIVE"
+)
+
+// Resource is a kind of resource owned by an organization, directly or through its event or gate
+type Resource string
+
+const (
+	ResourceEvent           Resource = "event"
+	ResourceTicketCategory  Resource = "ticket_category"
+	ResourceSchedule        Resource = "schedule"
+	ResourceMerchandise     Resource = "merchandise"
+	ResourceGate            Resource = "gate"
+	ResourceGateDevice      Resource = "gate_device"
+	ResourceGateShift       Resource = "gate_shift"
+	ResourceOrder           Resource = "order"
+	ResourceOrderCode       Resource = "order_code"
+	ResourceUser            Resource = "user"
+	ResourceAPIKey          Resource = "api_key"
+	ResourceRoleBinding     Resource = "role_binding"
+	ResourceQuotaAllocation Resource = "quota_allocation"
+	ResourcePresaleBatch    Resource = "presale_batch"
+	ResourceBallot          Resource = "ballot"
+	ResourceResaleListing   Resource = "resale_listing"
+	ResourceResalePayout    Resource = "resale_payout"
+	ResourceFraudAlert      Resource = "fraud_alert"
+	ResourceOrderItem       Resource = "order_item"
+	ResourceQRCode          Resource = "qr_code"
+	ResourceCheckIn         Resource = "check_in"
+)
+
+// Organization is a promoter hosting events; it owns events (and through them ticket categories,
+// schedules, merchandise and orders), gates and staff
+type Organization struct {
+	ID          string             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code        string             `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"`
+	Name        string             `gorm:"type:varchar(255);not null" json:"name"`
+	Description string             `gorm:"type:text" json:"description"`
+	Status      OrganizationStatus `gorm:"type:varchar(20);not null;default:'ACTIVE'" json:"status"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for Organization
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// BeforeCreate hook to generate UUID
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	if o.Status == "" {
+		o.Status = OrganizationStatusActive
+	}
+	return nil
+}
+
+// Scope is what a caller may access: the rows of their own organization, or of every
+// organization for a super admin that did not select one
+type Scope struct {
+	OrganizationID string // Organization of the caller, or the one a super admin selected
+	SuperAdmin     bool
+}
+
+// AllOrganizations covers every organization, for public endpoints such as the published events
+// and for the caller's own records
+var AllOrganizations = Scope{SuperAdmin: true}
+
+// Global reports whether the scope covers every organization
+func (s Scope) Global() bool {
+	return s.SuperAdmin && s.OrganizationID == ""
+}
+
+// Allows reports whether a resource owned by the organization is within the scope
+func (s Scope) Allows(organizationID string) bool {
+	if s.Global() {
+		return true
+	}
+	return s.OrganizationID != "" && s.OrganizationID == organizationID
+}
+
+// Owner returns the organization new resources are created for
+func (s Scope) Owner() (string, error) {
+	if s.OrganizationID == "" {
+		return "", ErrOrganizationRequired
+	}
+	return s.OrganizationID, nil
+}
+
+// Filter restricts a query to the rows within the scope; column holds the owning organization ID
+func (s Scope) Filter(query *gorm.DB, column string) *gorm.DB {
+	if s.Global() {
+		return query
+	}
+	if _, err := uuid.Parse(s.OrganizationID); err != nil {
+		return query.Where("1 = 0")
+	}
+	return query.Where(column+" = ?", s.OrganizationID)
+}
+
+// FilterByEvent restricts a query on rows owned through their event; column holds the event ID
+func (s Scope) FilterByEvent(query *gorm.DB, column string) *gorm.DB {
+	return s.filterByOwner(query, column, "events")
+}
+
+// FilterByGate restricts a query on rows owned through their gate; column holds the gate ID
+func (s Scope) FilterByGate(query *gorm.DB, column string) *gorm.DB {
+	return s.filterByOwner(query, column, "gates")
+}
+
+// FilterByUser restricts a query on rows of users; column holds the user ID
+func (s Scope) FilterByUser(query *gorm.DB, column string) *gorm.DB {
+	return s.filterByOwner(query, column, "users")
+}
+
+// FilterByTicketCategory restricts a query on rows owned through their ticket category's event;
+// column holds the ticket category ID
+func (s Scope) FilterByTicketCategory(query *gorm.DB, column string) *gorm.DB {
+	if s.Global() {
+		return query
+	}
+	if _, err := uuid.Parse(s.OrganizationID); err != nil {
+		return query.Where("1 = 0")
+	}
+	return query.Where(column+" IN (SELECT tc.id FROM ticket_categories tc JOIN events e ON e.id = tc.event_id WHERE e.organization_id = ?)", s.OrganizationID)
+}
+
+// FilterByOrderItem restricts a query on rows owned through their ticket's event; column holds
+// the order item ID
+func (s Scope) FilterByOrderItem(query *gorm.DB, column string) *gorm.DB {
+	if s.Global() {
+		return query
+	}
+	if _, err := uuid.Parse(s.OrganizationID); err != nil {
+		return query.Where("1 = 0")
+	}
+	return query.Where(column+" IN ("+orderItemsOfOrganization+")", s.OrganizationID)
+}
+
+// FilterByGateOrOrderItem restricts a query on scan records, owned through their gate or, when
+// recorded without a gate, through their ticket's event
+func (s Scope) FilterByGateOrOrderItem(query *gorm.DB, gateColumn, orderItemColumn string) *gorm.DB {
+	if s.Global() {
+		return query
+	}
+	if _, err := uuid.Parse(s.OrganizationID); err != nil {
+		return query.Where("1 = 0")
+	}
+	return query.Where("("+gateColumn+" IN (SELECT id FROM gates WHERE organization_id = ?) OR ("+gateColumn+" IS NULL AND "+orderItemColumn+" IN ("+orderItemsOfOrganization+")))", s.OrganizationID, s.OrganizationID)
+}
+
+// orderItemsOfOrganization selects the tickets of the organization's events
+const orderItemsOfOrganization = "SELECT oi.id FROM order_items oi JOIN ticket_categories tc ON tc.id = oi.category_id JOIN events e ON e.id = tc.event_id WHERE e.organization_id = ?"
+
+func (s Scope) filterByOwner(query *gorm.DB, column, table string) *gorm.DB {
+	if s.Global() {
+		return query
+	}
+	if _, err := uuid.Parse(s.OrganizationID); err != nil {
+		return query.Where("1 = 0")
+	}
+	return query.Where(column+" IN (SELECT id FROM "+table+" WHERE organization_id = ?)", s.OrganizationID)
+}
+
+// OrganizationResponse represents organization response DTO
+type OrganizationResponse struct {
+	ID          string             `json:"id"`
+	Code        string             `json:"code"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Status      OrganizationStatus `json:"status"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ToOrganizationResponse converts Organization to OrganizationResponse
+func (o *Organization) ToOrganizationResponse() *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:          o.ID,
+		Code:        o.Code,
+		Name:        o.Name,
+		Description: o.Description,
+		Status:      o.Status,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+}
+
+// CreateOrganizationRequest represents create organization request DTO
+type CreateOrganizationRequest struct {
+	Code        string             `json:"code" binding:"required,min=1,max=50"`
+	Name        string             `json:"name" binding:"required,min=1,max=255"`
+	Description string             `json:"description" binding:"omitempty"`
+	Status      OrganizationStatus `json:"status" binding:"omitempty,oneof=ACTIVE INACTIVE"`
+}
+
+// UpdateOrganizationRequest represents update organization request DTO
+type UpdateOrganizationRequest struct {
+	Name        *string             `json:"name" binding:"omitempty,min=1,max=255"`
+	Description *string             `json:"description" binding:"omitempty"`
+	Status      *OrganizationStatus `json:"status" binding:"omitempty,oneof=ACTIVE INACTIVE"`
+}
+
+// ListOrganizationsRequest represents list organizations query parameters
+type ListOrganizationsRequest struct {
+	Page    int                `form:"page" binding:"omitempty,min=1"`
+	PerPage int                `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Status  OrganizationStatus `form:"status" binding:"omitempty,oneof=ACTIVE INACTIVE"`
+	Search  string             `form:"search" binding:"omitempty"`
+}
@@ -0,0 +1,228 @@
+package presale
+
+import (
+	"strings"
+	"time"
+
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PresaleBatch represents a batch of presale access codes for a ticket category.
+// While a batch is active and inside its sales window, the category can only be purchased
+// with a code from an open batch or by users holding the batch's AllowedRoleID.
+type PresaleBatch struct {
+	ID               string                         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketCategoryID string                         `gorm:"type:uuid;not null;index" json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategory `gorm:"foreignKey:TicketCategoryID" json:"ticket_category,omitempty"`
+	Name             string                         `gorm:"type:varchar(255);not null" json:"name"`
+	Prefix           string                         `gorm:"type:varchar(20)" json:"prefix"`
+	CodeCount        int                            `gorm:"not null;default:0" json:"code_count"`
+	MaxUsesPerCode   int                            `gorm:"not null;default:1" json:"max_uses_per_code"`      // 1 = single-use code
+	AllowedRoleID    *string                        `gorm:"type:uuid;index" json:"allowed_role_id,omitempty"` // Members of this role may buy without a code
+	SaleStartsAt     *time.Time                     `gorm:"type:timestamp" json:"sale_starts_at"`
+	SaleEndsAt       *time.Time                     `gorm:"type:timestamp" json:"sale_ends_at"`
+	IsActive         bool                           `gorm:"not null;default:true;index" json:"is_active"`
+	CreatedBy        string                         `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt        time.Time                      `json:"created_at"`
+	UpdatedAt        time.Time                      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt                 `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for PresaleBatch
+func (PresaleBatch) TableName() string {
+	return "presale_batches"
+}
+
+// BeforeCreate hook to generate UUID
+func (b *PresaleBatch) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsOpen reports whether the batch currently restricts sales of its ticket category
+func (b *PresaleBatch) IsOpen(now time.Time) bool {
+	if !b.IsActive {
+		return false
+	}
+	if b.SaleStartsAt != nil && now.Before(*b.SaleStartsAt) {
+		return false
+	}
+	if b.SaleEndsAt != nil && !now.Before(*b.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// PresaleCode represents a single presale access code.
+// A code is bound to the first user who redeems it and can only be reused by that user.
+type PresaleCode struct {
+	ID          string        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	BatchID     string        `gorm:"type:uuid;not null;index" json:"batch_id"`
+	Batch       *PresaleBatch `gorm:"foreignKey:BatchID" json:"batch,omitempty"`
+	Code        string        `gorm:"type:varchar(64);uniqueIndex;not null" json:"code"`
+	MaxUses     int           `gorm:"not null;default:1" json:"max_uses"`
+	UsedCount   int           `gorm:"not null;default:0" json:"used_count"`
+	BoundUserID *string       `gorm:"type:uuid;index" json:"bound_user_id,omitempty"`
+	BoundAt     *time.Time    `gorm:"type:timestamp" json:"bound_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// TableName specifies the table name for PresaleCode
+func (PresaleCode) TableName() string {
+	return "presale_codes"
+}
+
+// BeforeCreate hook to generate UUID
+func (pc *PresaleCode) BeforeCreate(tx *gorm.DB) error {
+	if pc.ID == "" {
+		pc.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsExhausted reports whether the code has no uses left
+func (pc *PresaleCode) IsExhausted() bool {
+	return pc.UsedCount >= pc.MaxUses
+}
+
+// GenerateCode generates a random presale code with the given prefix
+func GenerateCode(prefix string) string {
+	code := strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:10])
+	if prefix == "" {
+		return code
+	}
+	return strings.ToUpper(prefix) + "-" + code
+}
+
+// PresaleBatchResponse represents presale batch response DTO
+type PresaleBatchResponse struct {
+	ID               string                                 `json:"id"`
+	TicketCategoryID string                                 `json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategoryResponse `json:"ticket_category,omitempty"`
+	Name             string                                 `json:"name"`
+	Prefix           string                                 `json:"prefix"`
+	CodeCount        int                                    `json:"code_count"`
+	MaxUsesPerCode   int                                    `json:"max_uses_per_code"`
+	AllowedRoleID    *string                                `json:"allowed_role_id,omitempty"`
+	SaleStartsAt     *time.Time                             `json:"sale_starts_at"`
+	SaleEndsAt       *time.Time                             `json:"sale_ends_at"`
+	IsActive         bool                                   `json:"is_active"`
+	IsOpen           bool                                   `json:"is_open"`
+	CreatedBy        string                                 `json:"created_by,omitempty"`
+	CreatedAt        time.Time                              `json:"created_at"`
+	UpdatedAt        time.Time                              `json:"updated_at"`
+}
+
+// ToPresaleBatchResponse converts PresaleBatch to PresaleBatchResponse
+func (b *PresaleBatch) ToPresaleBatchResponse() *PresaleBatchResponse {
+	resp := &PresaleBatchResponse{
+		ID:               b.ID,
+		TicketCategoryID: b.TicketCategoryID,
+		Name:             b.Name,
+		Prefix:           b.Prefix,
+		CodeCount:        b.CodeCount,
+		MaxUsesPerCode:   b.MaxUsesPerCode,
+		AllowedRoleID:    b.AllowedRoleID,
+		SaleStartsAt:     b.SaleStartsAt,
+		SaleEndsAt:       b.SaleEndsAt,
+		IsActive:         b.IsActive,
+		IsOpen:           b.IsOpen(time.Now()),
+		CreatedBy:        b.CreatedBy,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+	}
+	if b.TicketCategory != nil {
+		resp.TicketCategory = b.TicketCategory.ToTicketCategoryResponse()
+	}
+	return resp
+}
+
+// PresaleCodeResponse represents presale code response DTO
+type PresaleCodeResponse struct {
+	ID          string     `json:"id"`
+	BatchID     string     `json:"batch_id"`
+	Code        string     `json:"code"`
+	MaxUses     int        `json:"max_uses"`
+	UsedCount   int        `json:"used_count"`
+	BoundUserID *string    `json:"bound_user_id,omitempty"`
+	BoundAt     *time.Time `json:"bound_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ToPresaleCodeResponse converts PresaleCode to PresaleCodeResponse
+func (pc *PresaleCode) ToPresaleCodeResponse() *PresaleCodeResponse {
+	return &PresaleCodeResponse{
+		ID:          pc.ID,
+		BatchID:     pc.BatchID,
+		Code:        pc.Code,
+		MaxUses:     pc.MaxUses,
+		UsedCount:   pc.UsedCount,
+		BoundUserID: pc.BoundUserID,
+		BoundAt:     pc.BoundAt,
+		CreatedAt:   pc.CreatedAt,
+	}
+}
+
+// PresaleBatchReport represents redemption statistics for a presale batch
+type PresaleBatchReport struct {
+	BatchID        string  `json:"batch_id"`
+	Name           string  `json:"name"`
+	TotalCodes     int     `json:"total_codes"`
+	RedeemedCodes  int     `json:"redeemed_codes"`  // Codes used at least once
+	ExhaustedCodes int     `json:"exhausted_codes"` // Codes with no uses left
+	TotalUses      int     `json:"total_uses"`      // Sum of max uses across codes
+	UsedCount      int     `json:"used_count"`
+	RedemptionRate float64 `json:"redemption_rate"` // RedeemedCodes / TotalCodes * 100
+	Orders         int     `json:"orders"`
+	PaidOrders     int     `json:"paid_orders"`
+	TicketsSold    int     `json:"tickets_sold"`
+	Revenue        float64 `json:"revenue"`
+}
+
+// CreatePresaleBatchRequest represents create presale batch request DTO
+type CreatePresaleBatchRequest struct {
+	TicketCategoryID string     `json:"ticket_category_id" binding:"required,uuid"`
+	Name             string     `json:"name" binding:"required,min=1,max=255"`
+	Prefix           string     `json:"prefix" binding:"omitempty,alphanum,max=20"`
+	CodeCount        int        `json:"code_count" binding:"omitempty,min=0,max=10000"`
+	MaxUsesPerCode   int        `json:"max_uses_per_code" binding:"omitempty,min=1,max=100"`
+	AllowedRoleID    *string    `json:"allowed_role_id" binding:"omitempty,uuid"`
+	SaleStartsAt     *time.Time `json:"sale_starts_at" binding:"omitempty"`
+	SaleEndsAt       *time.Time `json:"sale_ends_at" binding:"omitempty"`
+}
+
+// UpdatePresaleBatchRequest represents update presale batch request DTO
+type UpdatePresaleBatchRequest struct {
+	Name          *string    `json:"name" binding:"omitempty,min=1,max=255"`
+	AllowedRoleID *string    `json:"allowed_role_id" binding:"omitempty,uuid"`
+	SaleStartsAt  *time.Time `json:"sale_starts_at" binding:"omitempty"`
+	SaleEndsAt    *time.Time `json:"sale_ends_at" binding:"omitempty"`
+	IsActive      *bool      `json:"is_active"`
+}
+
+// GeneratePresaleCodesRequest represents generate additional codes request DTO
+type GeneratePresaleCodesRequest struct {
+	Count int `json:"count" binding:"required,min=1,max=10000"`
+}
+
+// ListPresaleBatchesRequest represents list presale batches query parameters
+type ListPresaleBatchesRequest struct {
+	Page             int    `form:"page" binding:"omitempty,min=1"`
+	PerPage          int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	TicketCategoryID string `form:"ticket_category_id" binding:"omitempty,uuid"`
+	EventID          string `form:"event_id" binding:"omitempty,uuid"`
+	IsActive         *bool  `form:"is_active" binding:"omitempty"`
+}
+
+// ListPresaleCodesRequest represents list presale codes query parameters
+type ListPresaleCodesRequest struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Search  string `form:"search" binding:"omitempty,max=64"`
+	Used    *bool  `form:"used" binding:"omitempty"`
+}
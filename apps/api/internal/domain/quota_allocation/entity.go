@@ -0,0 +1,172 @@
+package quotaallocation
+
+import (
+	"strings"
+	"time"
+
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OwnerType represents who a quota allocation is held for
+type OwnerType string
+
+const (
+	OwnerTypeSponsor     OwnerType = "SPONSOR"
+	OwnerTypeReseller    OwnerType = "RESELLER"
+	OwnerTypeArtistGuest OwnerType = "ARTIST_GUEST"
+	OwnerTypeOther       OwnerType = "OTHER"
+)
+
+// AllocationStatus represents quota allocation status enum
+type AllocationStatus string
+
+const (
+	AllocationStatusActive   AllocationStatus = "ACTIVE"
+	AllocationStatusReleased AllocationStatus = "RELEASED" // Released manually by an admin
+	AllocationStatusExpired  AllocationStatus = "EXPIRED"  // Released automatically after ExpiresAt
+)
+
+// QuotaAllocation represents a named block of seats carved out of a ticket category's public quota.
+// Seats in an allocation are only purchasable by OwnerUserID or by holders of AccessCode.
+// Size is taken from TicketCategory.Quota on creation; unused seats (Size - Used) are returned
+// to the category when the allocation is released or expires.
+type QuotaAllocation struct {
+	ID               string                         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketCategoryID string                         `gorm:"type:uuid;not null;index" json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategory `gorm:"foreignKey:TicketCategoryID" json:"ticket_category,omitempty"`
+	Name             string                         `gorm:"type:varchar(255);not null" json:"name"`
+	OwnerType        OwnerType                      `gorm:"type:varchar(20);not null;default:'OTHER'" json:"owner_type"`
+	OwnerName        string                         `gorm:"type:varchar(255);not null" json:"owner_name"`
+	OwnerUserID      *string                        `gorm:"type:uuid;index" json:"owner_user_id,omitempty"`            // Authorized purchaser (optional)
+	AccessCode       *string                        `gorm:"type:varchar(64);uniqueIndex" json:"access_code,omitempty"` // Shared code for purchase/redemption (optional)
+	Complimentary    bool                           `gorm:"not null;default:false" json:"complimentary"`               // Redeemed for free (no payment)
+	Size             int                            `gorm:"not null;default:0" json:"size"`
+	Used             int                            `gorm:"not null;default:0" json:"used"`
+	Status           AllocationStatus               `gorm:"type:varchar(20);not null;default:'ACTIVE';index" json:"status"`
+	ExpiresAt        *time.Time                     `gorm:"type:timestamp;index" json:"expires_at"`
+	ReleasedAt       *time.Time                     `gorm:"type:timestamp" json:"released_at"`
+	CreatedBy        string                         `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt        time.Time                      `json:"created_at"`
+	UpdatedAt        time.Time                      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt                 `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for QuotaAllocation
+func (QuotaAllocation) TableName() string {
+	return "quota_allocations"
+}
+
+// BeforeCreate hook to generate UUID
+func (qa *QuotaAllocation) BeforeCreate(tx *gorm.DB) error {
+	if qa.ID == "" {
+		qa.ID = uuid.New().String()
+	}
+	if qa.Status == "" {
+		qa.Status = AllocationStatusActive
+	}
+	return nil
+}
+
+// Remaining returns the number of seats still available in the allocation
+func (qa *QuotaAllocation) Remaining() int {
+	remaining := qa.Size - qa.Used
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// IsExpired reports whether the allocation's expiry time has passed
+func (qa *QuotaAllocation) IsExpired(now time.Time) bool {
+	return qa.ExpiresAt != nil && !qa.ExpiresAt.After(now)
+}
+
+// GenerateAccessCode generates a random allocation access code
+func GenerateAccessCode() string {
+	return "ALLOC-" + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:10])
+}
+
+// QuotaAllocationResponse represents quota allocation response DTO
+type QuotaAllocationResponse struct {
+	ID               string                                 `json:"id"`
+	TicketCategoryID string                                 `json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategoryResponse `json:"ticket_category,omitempty"`
+	Name             string                                 `json:"name"`
+	OwnerType        OwnerType                              `json:"owner_type"`
+	OwnerName        string                                 `json:"owner_name"`
+	OwnerUserID      *string                                `json:"owner_user_id,omitempty"`
+	AccessCode       *string                                `json:"access_code,omitempty"`
+	Complimentary    bool                                   `json:"complimentary"`
+	Size             int                                    `json:"size"`
+	Used             int                                    `json:"used"`
+	Remaining        int                                    `json:"remaining"`
+	Status           AllocationStatus                       `json:"status"`
+	ExpiresAt        *time.Time                             `json:"expires_at"`
+	ReleasedAt       *time.Time                             `json:"released_at"`
+	CreatedBy        string                                 `json:"created_by,omitempty"`
+	CreatedAt        time.Time                              `json:"created_at"`
+	UpdatedAt        time.Time                              `json:"updated_at"`
+}
+
+// ToQuotaAllocationResponse converts QuotaAllocation to QuotaAllocationResponse
+func (qa *QuotaAllocation) ToQuotaAllocationResponse() *QuotaAllocationResponse {
+	resp := &QuotaAllocationResponse{
+		ID:               qa.ID,
+		TicketCategoryID: qa.TicketCategoryID,
+		Name:             qa.Name,
+		OwnerType:        qa.OwnerType,
+		OwnerName:        qa.OwnerName,
+		OwnerUserID:      qa.OwnerUserID,
+		AccessCode:       qa.AccessCode,
+		Complimentary:    qa.Complimentary,
+		Size:             qa.Size,
+		Used:             qa.Used,
+		Remaining:        qa.Remaining(),
+		Status:           qa.Status,
+		ExpiresAt:        qa.ExpiresAt,
+		ReleasedAt:       qa.ReleasedAt,
+		CreatedBy:        qa.CreatedBy,
+		CreatedAt:        qa.CreatedAt,
+		UpdatedAt:        qa.UpdatedAt,
+	}
+	if qa.TicketCategory != nil {
+		resp.TicketCategory = qa.TicketCategory.ToTicketCategoryResponse()
+	}
+	return resp
+}
+
+// CreateQuotaAllocationRequest represents create quota allocation request DTO
+type CreateQuotaAllocationRequest struct {
+	TicketCategoryID   string     `json:"ticket_category_id" binding:"required,uuid"`
+	Name               string     `json:"name" binding:"required,min=1,max=255"`
+	OwnerType          OwnerType  `json:"owner_type" binding:"required,oneof=SPONSOR RESELLER ARTIST_GUEST OTHER"`
+	OwnerName          string     `json:"owner_name" binding:"required,min=1,max=255"`
+	OwnerUserID        *string    `json:"owner_user_id" binding:"omitempty,uuid"`
+	AccessCode         *string    `json:"access_code" binding:"omitempty,min=6,max=64"`
+	GenerateAccessCode bool       `json:"generate_access_code"`
+	Complimentary      bool       `json:"complimentary"`
+	Size               int        `json:"size" binding:"required,min=1"`
+	ExpiresAt          *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// UpdateQuotaAllocationRequest represents update quota allocation request DTO
+type UpdateQuotaAllocationRequest struct {
+	Name          *string    `json:"name" binding:"omitempty,min=1,max=255"`
+	OwnerName     *string    `json:"owner_name" binding:"omitempty,min=1,max=255"`
+	OwnerUserID   *string    `json:"owner_user_id" binding:"omitempty,uuid"`
+	Complimentary *bool      `json:"complimentary"`
+	Size          *int       `json:"size" binding:"omitempty,min=0"`
+	ExpiresAt     *time.Time `json:"expires_at" binding:"omitempty"`
+}
+
+// ListQuotaAllocationsRequest represents list quota allocations query parameters
+type ListQuotaAllocationsRequest struct {
+	Page             int              `form:"page" binding:"omitempty,min=1"`
+	PerPage          int              `form:"per_page" binding:"omitempty,min=1,max=100"`
+	TicketCategoryID string           `form:"ticket_category_id" binding:"omitempty,uuid"`
+	EventID          string           `form:"event_id" binding:"omitempty,uuid"`
+	Status           AllocationStatus `form:"status" binding:"omitempty,oneof=ACTIVE RELEASED EXPIRED"`
+	OwnerType        OwnerType        `form:"owner_type" binding:"omitempty,oneof=SPONSOR RESELLER ARTIST_GUEST OTHER"`
+}
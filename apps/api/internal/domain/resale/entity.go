@@ -0,0 +1,251 @@
+package resale
+
+import (
+	"math"
+	"time"
+
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListingStatus represents resale listing status enum
+type ListingStatus string
+
+const (
+	ListingStatusActive   ListingStatus = "ACTIVE"   // Visible on the marketplace
+	ListingStatusReserved ListingStatus = "RESERVED" // A buyer's order is awaiting payment
+	ListingStatusSold     ListingStatus = "SOLD"     // Paid, ticket reissued to the buyer
+	ListingStatusCanceled ListingStatus = "CANCELED" // Withdrawn by the seller or an admin
+)
+
+// PayoutStatus represents seller payout status enum
+type PayoutStatus string
+
+const (
+	PayoutStatusPending PayoutStatus = "PENDING" // Credited to the seller, not yet transferred
+	PayoutStatusPaid    PayoutStatus = "PAID"    // Transferred to the seller
+)
+
+// ResaleListing represents an issued ticket offered for resale by its holder
+type ResaleListing struct {
+	ID               string                         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderItemID      string                         `gorm:"type:uuid;not null;index" json:"order_item_id"`
+	SellerID         string                         `gorm:"type:uuid;not null;index" json:"seller_id"`
+	TicketCategoryID string                         `gorm:"type:uuid;not null;index" json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategory `gorm:"foreignKey:TicketCategoryID" json:"ticket_category,omitempty"`
+	ScheduleID       string                         `gorm:"type:uuid;not null;index" json:"schedule_id"`
+	FaceValue        float64                        `gorm:"type:decimal(15,2);not null" json:"face_value"`    // Snapshot: category price when listed
+	Price            float64                        `gorm:"type:decimal(15,2);not null" json:"price"`         // Asking price paid by the buyer
+	PlatformFee      float64                        `gorm:"type:decimal(15,2);not null" json:"platform_fee"`  // Snapshot: fee kept by the platform
+	SellerAmount     float64                        `gorm:"type:decimal(15,2);not null" json:"seller_amount"` // Price minus platform fee
+	Status           ListingStatus                  `gorm:"type:varchar(20);not null;default:'ACTIVE';index" json:"status"`
+	BuyerID          *string                        `gorm:"type:uuid;index" json:"buyer_id,omitempty"`
+	OrderID          *string                        `gorm:"type:uuid;index" json:"order_id,omitempty"` // Buyer's order (pending while RESERVED)
+	SoldAt           *time.Time                     `gorm:"type:timestamp" json:"sold_at,omitempty"`
+	CanceledAt       *time.Time                     `gorm:"type:timestamp" json:"canceled_at,omitempty"`
+	CreatedAt        time.Time                      `json:"created_at"`
+	UpdatedAt        time.Time                      `json:"updated_at"`
+}
+
+// TableName specifies the table name for ResaleListing
+func (ResaleListing) TableName() string {
+	return "resale_listings"
+}
+
+// BeforeCreate hook to generate UUID
+func (l *ResaleListing) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	if l.Status == "" {
+		l.Status = ListingStatusActive
+	}
+	return nil
+}
+
+// SellerPayout records what a seller is owed for a sold listing, for payout reconciliation
+type SellerPayout struct {
+	ID          string       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ListingID   string       `gorm:"type:uuid;not null;uniqueIndex" json:"listing_id"`
+	OrderID     string       `gorm:"type:uuid;not null;index" json:"order_id"`
+	SellerID    string       `gorm:"type:uuid;not null;index" json:"seller_id"`
+	GrossAmount float64      `gorm:"type:decimal(15,2);not null" json:"gross_amount"`
+	PlatformFee float64      `gorm:"type:decimal(15,2);not null" json:"platform_fee"`
+	NetAmount   float64      `gorm:"type:decimal(15,2);not null" json:"net_amount"`
+	Status      PayoutStatus `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"status"`
+	Reference   string       `gorm:"type:varchar(255)" json:"reference"` // Bank transfer reference once paid
+	PaidAt      *time.Time   `gorm:"type:timestamp" json:"paid_at,omitempty"`
+	PaidBy      *string      `gorm:"type:uuid" json:"paid_by,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TableName specifies the table name for SellerPayout
+func (SellerPayout) TableName() string {
+	return "resale_seller_payouts"
+}
+
+// BeforeCreate hook to generate UUID
+func (p *SellerPayout) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	if p.Status == "" {
+		p.Status = PayoutStatusPending
+	}
+	return nil
+}
+
+// MaxPrice returns the highest listing price allowed for a face value
+func MaxPrice(faceValue, maxMarkupPercent float64) float64 {
+	return math.Floor(faceValue * (1 + maxMarkupPercent/100))
+}
+
+// PlatformFee returns the platform fee for a sale price, rounded to whole rupiah
+func PlatformFee(price, feePercent float64) float64 {
+	return math.Round(price * feePercent / 100)
+}
+
+// ResaleListingResponse represents resale listing response DTO
+type ResaleListingResponse struct {
+	ID               string                                 `json:"id"`
+	OrderItemID      string                                 `json:"order_item_id,omitempty"`
+	SellerID         string                                 `json:"seller_id,omitempty"`
+	TicketCategoryID string                                 `json:"ticket_category_id"`
+	TicketCategory   *ticketcategory.TicketCategoryResponse `json:"ticket_category,omitempty"`
+	ScheduleID       string                                 `json:"schedule_id"`
+	FaceValue        float64                                `json:"face_value"`
+	Price            float64                                `json:"price"`
+	PlatformFee      float64                                `json:"platform_fee,omitempty"`
+	SellerAmount     float64                                `json:"seller_amount,omitempty"`
+	Status           ListingStatus                          `json:"status"`
+	BuyerID          *string                                `json:"buyer_id,omitempty"`
+	OrderID          *string                                `json:"order_id,omitempty"`
+	SoldAt           *time.Time                             `json:"sold_at,omitempty"`
+	CanceledAt       *time.Time                             `json:"canceled_at,omitempty"`
+	CreatedAt        time.Time                              `json:"created_at"`
+	UpdatedAt        time.Time                              `json:"updated_at"`
+}
+
+// ToResaleListingResponse converts ResaleListing to ResaleListingResponse
+func (l *ResaleListing) ToResaleListingResponse() *ResaleListingResponse {
+	resp := &ResaleListingResponse{
+		ID:               l.ID,
+		OrderItemID:      l.OrderItemID,
+		SellerID:         l.SellerID,
+		TicketCategoryID: l.TicketCategoryID,
+		ScheduleID:       l.ScheduleID,
+		FaceValue:        l.FaceValue,
+		Price:            l.Price,
+		PlatformFee:      l.PlatformFee,
+		SellerAmount:     l.SellerAmount,
+		Status:           l.Status,
+		BuyerID:          l.BuyerID,
+		OrderID:          l.OrderID,
+		SoldAt:           l.SoldAt,
+		CanceledAt:       l.CanceledAt,
+		CreatedAt:        l.CreatedAt,
+		UpdatedAt:        l.UpdatedAt,
+	}
+	if l.TicketCategory != nil {
+		resp.TicketCategory = l.TicketCategory.ToTicketCategoryResponse()
+	}
+	return resp
+}
+
+// ToPublicResponse converts ResaleListing to the marketplace view, hiding seller and payout details
+func (l *ResaleListing) ToPublicResponse() *ResaleListingResponse {
+	resp := l.ToResaleListingResponse()
+	resp.SellerID = ""
+	resp.OrderItemID = ""
+	resp.PlatformFee = 0
+	resp.SellerAmount = 0
+	resp.BuyerID = nil
+	resp.OrderID = nil
+	return resp
+}
+
+// SellerPayoutResponse represents seller payout response DTO
+type SellerPayoutResponse struct {
+	ID          string       `json:"id"`
+	ListingID   string       `json:"listing_id"`
+	OrderID     string       `json:"order_id"`
+	SellerID    string       `json:"seller_id"`
+	GrossAmount float64      `json:"gross_amount"`
+	PlatformFee float64      `json:"platform_fee"`
+	NetAmount   float64      `json:"net_amount"`
+	Status      PayoutStatus `json:"status"`
+	Reference   string       `json:"reference"`
+	PaidAt      *time.Time   `json:"paid_at,omitempty"`
+	PaidBy      *string      `json:"paid_by,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ToSellerPayoutResponse converts SellerPayout to SellerPayoutResponse
+func (p *SellerPayout) ToSellerPayoutResponse() *SellerPayoutResponse {
+	return &SellerPayoutResponse{
+		ID:          p.ID,
+		ListingID:   p.ListingID,
+		OrderID:     p.OrderID,
+		SellerID:    p.SellerID,
+		GrossAmount: p.GrossAmount,
+		PlatformFee: p.PlatformFee,
+		NetAmount:   p.NetAmount,
+		Status:      p.Status,
+		Reference:   p.Reference,
+		PaidAt:      p.PaidAt,
+		PaidBy:      p.PaidBy,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// PayoutSummary aggregates seller payouts for reconciliation
+type PayoutSummary struct {
+	Sales          int64   `json:"sales"`
+	GrossAmount    float64 `json:"gross_amount"`
+	PlatformFees   float64 `json:"platform_fees"`
+	NetAmount      float64 `json:"net_amount"`
+	PendingPayouts int64   `json:"pending_payouts"`
+	PendingAmount  float64 `json:"pending_amount"`
+	PaidPayouts    int64   `json:"paid_payouts"`
+	PaidAmount     float64 `json:"paid_amount"`
+}
+
+// CreateListingRequest represents create resale listing request DTO
+type CreateListingRequest struct {
+	OrderItemID string  `json:"order_item_id" binding:"required,uuid"`
+	Price       float64 `json:"price" binding:"required,gt=0"`
+}
+
+// PurchaseListingRequest represents purchase resale listing request DTO
+type PurchaseListingRequest struct {
+	BuyerName  string `json:"buyer_name" binding:"required,min=3,max=100"`
+	BuyerEmail string `json:"buyer_email" binding:"required,email"`
+	BuyerPhone string `json:"buyer_phone" binding:"required,min=10,max=20"`
+}
+
+// MarkPayoutPaidRequest represents mark payout paid request DTO
+type MarkPayoutPaidRequest struct {
+	Reference string `json:"reference" binding:"required,min=1,max=255"`
+}
+
+// ListListingsRequest represents list resale listings query parameters
+type ListListingsRequest struct {
+	Page             int           `form:"page" binding:"omitempty,min=1"`
+	PerPage          int           `form:"per_page" binding:"omitempty,min=1,max=100"`
+	ScheduleID       string        `form:"schedule_id" binding:"omitempty,uuid"`
+	TicketCategoryID string        `form:"ticket_category_id" binding:"omitempty,uuid"`
+	Status           ListingStatus `form:"status" binding:"omitempty,oneof=ACTIVE RESERVED SOLD CANCELED"`
+	SellerID         string        `form:"seller_id" binding:"omitempty,uuid"`
+}
+
+// ListPayoutsRequest represents list seller payouts query parameters
+type ListPayoutsRequest struct {
+	Page     int          `form:"page" binding:"omitempty,min=1"`
+	PerPage  int          `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Status   PayoutStatus `form:"status" binding:"omitempty,oneof=PENDING PAID"`
+	SellerID string       `form:"seller_id" binding:"omitempty,uuid"`
+}
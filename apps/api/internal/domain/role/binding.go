@@ -0,0 +1,87 @@
+package role
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContextResource is a kind of resource that identifies the event a request acts on
+type ContextResource string
+
+const (
+	ContextEvent          ContextResource = "event"
+	ContextSchedule       ContextResource = "schedule"
+	ContextTicketCategory ContextResource = "ticket_category"
+	ContextGateShift      ContextResource = "gate_shift"
+	ContextCheckIn        ContextResource = "check_in"
+)
+
+// RoleBinding grants a user the permissions of a role within a single event, on top of the
+// platform-wide role of the user
+type RoleBinding struct {
+	ID        string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_role_bindings_user_role_event;index" json:"user_id"`
+	RoleID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_role_bindings_user_role_event;index" json:"role_id"`
+	EventID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_role_bindings_user_role_event;index" json:"event_id"`
+	Role      *Role     `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+	CreatedBy *string   `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for RoleBinding
+func (RoleBinding) TableName() string {
+	return "role_bindings"
+}
+
+// BeforeCreate hook to generate UUID
+func (b *RoleBinding) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// RoleBindingResponse represents role binding response DTO
+type RoleBindingResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	RoleID    string    `json:"role_id"`
+	RoleCode  string    `json:"role_code,omitempty"`
+	RoleName  string    `json:"role_name,omitempty"`
+	EventID   string    `json:"event_id"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToRoleBindingResponse converts RoleBinding to RoleBindingResponse
+func (b *RoleBinding) ToRoleBindingResponse() *RoleBindingResponse {
+	resp := &RoleBindingResponse{
+		ID:        b.ID,
+		UserID:    b.UserID,
+		RoleID:    b.RoleID,
+		EventID:   b.EventID,
+		CreatedBy: b.CreatedBy,
+		CreatedAt: b.CreatedAt,
+	}
+	if b.Role != nil {
+		resp.RoleCode = b.Role.Code
+		resp.RoleName = b.Role.Name
+	}
+	return resp
+}
+
+// CreateRoleBindingRequest represents bind role to a user within an event request
+type CreateRoleBindingRequest struct {
+	UserID  string `json:"user_id" binding:"required,uuid"`
+	EventID string `json:"event_id" binding:"required,uuid"`
+}
+
+// ListRoleBindingsRequest represents list role bindings query parameters
+type ListRoleBindingsRequest struct {
+	Page    int    `form:"page" binding:"omitempty,min=1"`
+	PerPage int    `form:"per_page" binding:"omitempty,min=1,max=100"`
+	UserID  string `form:"user_id" binding:"omitempty,uuid"`
+	EventID string `form:"event_id" binding:"omitempty,uuid"`
+}
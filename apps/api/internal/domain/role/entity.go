@@ -15,12 +15,20 @@ type Role struct {
 	Description  string          `gorm:"type:text" json:"description"`
 	IsAdmin      bool            `gorm:"default:false" json:"is_admin"`
 	CanLoginAdmin bool           `gorm:"default:true" json:"can_login_admin"`
+	RequireTwoFactor bool        `gorm:"default:false" json:"require_two_factor"` // Only enforced for admin roles, see TwoFactorRequired
+	PermissionsVersion int64     `gorm:"not null;default:0" json:"permissions_version"` // Bumped whenever the role's permissions change
 	Permissions  []RolePermission `gorm:"foreignKey:RoleID" json:"permissions,omitempty"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt  `gorm:"index" json:"-"`
 }
 
+// TwoFactorRequired reports whether users of the role must use two-factor authentication.
+// The policy only applies to roles with admin access (IsAdmin or CanLoginAdmin).
+func (r *Role) TwoFactorRequired() bool {
+	return r.RequireTwoFactor && (r.IsAdmin || r.CanLoginAdmin)
+}
+
 // RolePermission represents the relationship between role and permission
 type RolePermission struct {
 	ID           string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -63,6 +71,7 @@ type RoleResponse struct {
 	Description  string    `json:"description"`
 	IsAdmin      bool      `json:"is_admin"`
 	CanLoginAdmin bool     `json:"can_login_admin"`
+	RequireTwoFactor bool  `json:"require_two_factor"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -75,6 +84,7 @@ type RoleWithPermissionsResponse struct {
 	Description  string                      `json:"description"`
 	IsAdmin      bool                        `json:"is_admin"`
 	CanLoginAdmin bool                       `json:"can_login_admin"`
+	RequireTwoFactor bool                    `json:"require_two_factor"`
 	Permissions  []PermissionResponse        `json:"permissions,omitempty"`
 	CreatedAt    time.Time                   `json:"created_at"`
 	UpdatedAt    time.Time                   `json:"updated_at"`
@@ -98,6 +108,7 @@ func (r *Role) ToRoleResponse() *RoleResponse {
 		Description:  r.Description,
 		IsAdmin:      r.IsAdmin,
 		CanLoginAdmin: r.CanLoginAdmin,
+		RequireTwoFactor: r.RequireTwoFactor,
 		CreatedAt:    r.CreatedAt,
 		UpdatedAt:    r.UpdatedAt,
 	}
@@ -110,6 +121,7 @@ type CreateRoleRequest struct {
 	Description  string `json:"description"`
 	IsAdmin      *bool  `json:"is_admin"`
 	CanLoginAdmin *bool `json:"can_login_admin"`
+	RequireTwoFactor *bool `json:"require_two_factor"`
 }
 
 // UpdateRoleRequest represents update role request DTO
@@ -118,6 +130,7 @@ type UpdateRoleRequest struct {
 	Description  string `json:"description"`
 	IsAdmin      *bool  `json:"is_admin"`
 	CanLoginAdmin *bool `json:"can_login_admin"`
+	RequireTwoFactor *bool `json:"require_two_factor"`
 }
 
 // ListRolesRequest represents list roles query parameters
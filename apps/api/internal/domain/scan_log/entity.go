@@ -0,0 +1,115 @@
+package scanlog
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeviceIDHeader is the request header scanner apps use to identify themselves
+const DeviceIDHeader = "X-Device-ID"
+
+// ScanAction represents the kind of scan that was attempted
+type ScanAction string
+
+const (
+	ScanActionValidate ScanAction = "VALIDATE"
+	ScanActionEntry    ScanAction = "ENTRY"
+	ScanActionExit     ScanAction = "EXIT"
+)
+
+// ResultCodeSuccess is stored for scans that were accepted
+const ResultCodeSuccess = "SUCCESS"
+
+// ScanLog is an append-only record of a single scan attempt, including invalid and rejected codes
+type ScanLog struct {
+	ID          string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Action      ScanAction `gorm:"type:varchar(20);not null;index" json:"action"`
+	RawCode     string     `gorm:"type:text;not null;index" json:"raw_code"`
+	OrderItemID *string    `gorm:"type:uuid;index" json:"order_item_id,omitempty"` // Set when the code matched a ticket
+	ScheduleID  *string    `gorm:"type:uuid;index" json:"schedule_id,omitempty"`
+	GateID      *string    `gorm:"type:uuid;index" json:"gate_id,omitempty"`
+	DeviceID    string     `gorm:"type:varchar(100);index" json:"device_id"`
+	StaffID     *string    `gorm:"type:uuid;index" json:"staff_id,omitempty"`
+	Success     bool       `gorm:"not null;default:false" json:"success"`
+	ResultCode  string     `gorm:"type:varchar(50);not null;index" json:"result_code"`
+	Message     string     `gorm:"type:text" json:"message"`
+	LatencyMs   int64      `gorm:"not null;default:0" json:"latency_ms"`
+	IPAddress   string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent   string     `gorm:"type:text" json:"user_agent"`
+	ScannedAt   time.Time  `gorm:"type:timestamp;not null;index" json:"scanned_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for ScanLog
+func (ScanLog) TableName() string {
+	return "scan_logs"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *ScanLog) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.ScannedAt.IsZero() {
+		s.ScannedAt = time.Now()
+	}
+	return nil
+}
+
+// ScanLogResponse represents scan log response DTO
+type ScanLogResponse struct {
+	ID          string     `json:"id"`
+	Action      ScanAction `json:"action"`
+	RawCode     string     `json:"raw_code"`
+	OrderItemID *string    `json:"order_item_id,omitempty"`
+	ScheduleID  *string    `json:"schedule_id,omitempty"`
+	GateID      *string    `json:"gate_id,omitempty"`
+	DeviceID    string     `json:"device_id"`
+	StaffID     *string    `json:"staff_id,omitempty"`
+	Success     bool       `json:"success"`
+	ResultCode  string     `json:"result_code"`
+	Message     string     `json:"message"`
+	LatencyMs   int64      `json:"latency_ms"`
+	IPAddress   string     `json:"ip_address"`
+	UserAgent   string     `json:"user_agent"`
+	ScannedAt   time.Time  `json:"scanned_at"`
+}
+
+// ToScanLogResponse converts ScanLog to ScanLogResponse
+func (s *ScanLog) ToScanLogResponse() *ScanLogResponse {
+	return &ScanLogResponse{
+		ID:          s.ID,
+		Action:      s.Action,
+		RawCode:     s.RawCode,
+		OrderItemID: s.OrderItemID,
+		ScheduleID:  s.ScheduleID,
+		GateID:      s.GateID,
+		DeviceID:    s.DeviceID,
+		StaffID:     s.StaffID,
+		Success:     s.Success,
+		ResultCode:  s.ResultCode,
+		Message:     s.Message,
+		LatencyMs:   s.LatencyMs,
+		IPAddress:   s.IPAddress,
+		UserAgent:   s.UserAgent,
+		ScannedAt:   s.ScannedAt,
+	}
+}
+
+// ListScanLogsRequest represents list scan logs query parameters
+type ListScanLogsRequest struct {
+	Page        int        `form:"page" binding:"omitempty,min=1"`
+	PerPage     int        `form:"per_page" binding:"omitempty,min=1,max=100"`
+	Action      ScanAction `form:"action" binding:"omitempty,oneof=VALIDATE ENTRY EXIT"`
+	GateID      string     `form:"gate_id" binding:"omitempty,uuid"`
+	DeviceID    string     `form:"device_id" binding:"omitempty,max=100"`
+	StaffID     string     `form:"staff_id" binding:"omitempty,uuid"`
+	OrderItemID string     `form:"order_item_id" binding:"omitempty,uuid"`
+	RawCode     string     `form:"raw_code" binding:"omitempty"`
+	ResultCode  string     `form:"result_code" binding:"omitempty,max=50"`
+	Success     *bool      `form:"success" binding:"omitempty"`
+	StartDate   *time.Time `form:"start_date" binding:"omitempty"`
+	EndDate     *time.Time `form:"end_date" binding:"omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -39,6 +40,17 @@ func (s *Schedule) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// Window returns the schedule's start and end as WIB timestamps; an end time at or before the
+// start time means the session runs past midnight
+func (s *Schedule) Window() (time.Time, time.Time) {
+	start := time.Date(s.Date.Year(), s.Date.Month(), s.Date.Day(), s.StartTime.Hour(), s.StartTime.Minute(), s.StartTime.Second(), 0, response.GetTimezoneWIB())
+	end := time.Date(s.Date.Year(), s.Date.Month(), s.Date.Day(), s.EndTime.Hour(), s.EndTime.Minute(), s.EndTime.Second(), 0, response.GetTimezoneWIB())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
 // ScheduleResponse represents schedule response DTO
 type ScheduleResponse struct {
 	ID           string              `json:"id"`
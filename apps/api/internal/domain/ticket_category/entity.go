@@ -4,10 +4,20 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ReentryPolicy controls whether a checked-in ticket may leave and come back
+type ReentryPolicy string
+
+const (
+	ReentryPolicyNone      ReentryPolicy = "NONE"      // Single admission, no pass-out
+	ReentryPolicyLimited   ReentryPolicy = "LIMITED"   // Up to MaxReentries re-entries
+	ReentryPolicyUnlimited ReentryPolicy = "UNLIMITED" // Free pass-out and re-entry
+)
+
 // TicketCategory represents a ticket category entity
 type TicketCategory struct {
 	ID           string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -17,6 +27,9 @@ type TicketCategory struct {
 	Price        float64        `gorm:"type:decimal(15,2);not null" json:"price"`
 	Quota        int            `gorm:"not null;default:0" json:"quota"`
 	LimitPerUser int            `gorm:"not null;default:1" json:"limit_per_user"`
+	ReentryPolicy ReentryPolicy `gorm:"type:varchar(20);not null;default:'NONE'" json:"reentry_policy"`
+	MaxReentries int            `gorm:"not null;default:0" json:"max_reentries"` // Only used with LIMITED policy
+	PassSchedules []PassSchedule `gorm:"foreignKey:TicketCategoryID" json:"pass_schedules,omitempty"` // Non-empty for multi-day passes
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -35,6 +48,64 @@ func (tc *TicketCategory) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// IsPass reports whether the category is a multi-day pass valid across several schedules
+func (tc *TicketCategory) IsPass() bool {
+	return len(tc.PassSchedules) > 0
+}
+
+// ScheduleIDs returns the schedules covered by a pass category
+func (tc *TicketCategory) ScheduleIDs() []string {
+	ids := make([]string, len(tc.PassSchedules))
+	for i, ps := range tc.PassSchedules {
+		ids[i] = ps.ScheduleID
+	}
+	return ids
+}
+
+// CoversSchedule reports whether a pass category is valid for the schedule
+func (tc *TicketCategory) CoversSchedule(scheduleID string) bool {
+	for _, ps := range tc.PassSchedules {
+		if ps.ScheduleID == scheduleID {
+			return true
+		}
+	}
+	return false
+}
+
+// PassSchedule links a pass category to one of the schedules it admits to (one check-in per schedule)
+type PassSchedule struct {
+	ID               string             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TicketCategoryID string             `gorm:"type:uuid;not null;uniqueIndex:idx_pass_schedule" json:"ticket_category_id"`
+	ScheduleID       string             `gorm:"type:uuid;not null;uniqueIndex:idx_pass_schedule;index" json:"schedule_id"`
+	Schedule         *schedule.Schedule `gorm:"foreignKey:ScheduleID" json:"schedule,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+// TableName specifies the table name for PassSchedule
+func (PassSchedule) TableName() string {
+	return "ticket_category_schedules"
+}
+
+// BeforeCreate hook to generate UUID
+func (ps *PassSchedule) BeforeCreate(tx *gorm.DB) error {
+	if ps.ID == "" {
+		ps.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// AllowsReentry reports whether a ticket that has already re-entered reentriesUsed times may enter again
+func (tc *TicketCategory) AllowsReentry(reentriesUsed int) bool {
+	switch tc.ReentryPolicy {
+	case ReentryPolicyUnlimited:
+		return true
+	case ReentryPolicyLimited:
+		return reentriesUsed < tc.MaxReentries
+	default:
+		return false
+	}
+}
+
 // TicketCategoryResponse represents ticket category response DTO
 type TicketCategoryResponse struct {
 	ID           string              `json:"id"`
@@ -44,6 +115,10 @@ type TicketCategoryResponse struct {
 	Price        float64             `json:"price"`
 	Quota        int                 `json:"quota"`
 	LimitPerUser int                 `json:"limit_per_user"`
+	ReentryPolicy ReentryPolicy      `json:"reentry_policy"`
+	MaxReentries int                 `json:"max_reentries"`
+	IsPass       bool                `json:"is_pass"`
+	ScheduleIDs  []string            `json:"schedule_ids,omitempty"` // Schedules a pass is valid for
 	CreatedAt    time.Time           `json:"created_at"`
 	UpdatedAt    time.Time           `json:"updated_at"`
 }
@@ -57,12 +132,18 @@ func (tc *TicketCategory) ToTicketCategoryResponse() *TicketCategoryResponse {
 		Price:        tc.Price,
 		Quota:        tc.Quota,
 		LimitPerUser: tc.LimitPerUser,
+		ReentryPolicy: tc.ReentryPolicy,
+		MaxReentries: tc.MaxReentries,
+		IsPass:       tc.IsPass(),
 		CreatedAt:    tc.CreatedAt,
 		UpdatedAt:    tc.UpdatedAt,
 	}
 	if tc.Event != nil {
 		resp.Event = tc.Event.ToEventResponse()
 	}
+	if tc.IsPass() {
+		resp.ScheduleIDs = tc.ScheduleIDs()
+	}
 	return resp
 }
 
@@ -73,6 +154,9 @@ type CreateTicketCategoryRequest struct {
 	Price        float64 `json:"price" binding:"required,min=0"`
 	Quota        int     `json:"quota" binding:"required,min=0"`
 	LimitPerUser int     `json:"limit_per_user" binding:"required,min=1"`
+	ReentryPolicy ReentryPolicy `json:"reentry_policy" binding:"omitempty,oneof=NONE LIMITED UNLIMITED"`
+	MaxReentries int     `json:"max_reentries" binding:"omitempty,min=0,max=100"`
+	ScheduleIDs  []string `json:"schedule_ids" binding:"omitempty,dive,uuid"` // Set to sell a multi-day pass
 }
 
 // UpdateTicketCategoryRequest represents update ticket category request DTO
@@ -81,6 +165,9 @@ type UpdateTicketCategoryRequest struct {
 	Price        *float64 `json:"price" binding:"omitempty,min=0"`
 	Quota        *int     `json:"quota" binding:"omitempty,min=0"`
 	LimitPerUser *int     `json:"limit_per_user" binding:"omitempty,min=1"`
+	ReentryPolicy *ReentryPolicy `json:"reentry_policy" binding:"omitempty,oneof=NONE LIMITED UNLIMITED"`
+	MaxReentries *int     `json:"max_reentries" binding:"omitempty,min=0,max=100"`
+	ScheduleIDs  *[]string `json:"schedule_ids" binding:"omitempty,dive,uuid"` // Empty list turns a pass back into a single-schedule ticket
 }
 
 
@@ -17,7 +17,12 @@ type User struct {
 	AvatarURL string    `gorm:"type:text" json:"avatar_url"`
 	RoleID    string    `gorm:"type:uuid;not null;index" json:"role_id"`
 	Role      *role.Role     `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+	OrganizationID *string   `gorm:"type:uuid;index" json:"organization_id,omitempty"` // Organization of staff; nil for buyers and super admins
 	Status    string    `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	EmailVerifiedAt *time.Time `gorm:"type:timestamp" json:"email_verified_at,omitempty"`
+	FailedLoginAttempts int    `gorm:"not null;default:0" json:"-"` // Consecutive failed logins, reset on success or lockout
+	LastFailedLoginAt *time.Time `gorm:"type:timestamp" json:"-"`
+	LockedUntil     *time.Time `gorm:"type:timestamp" json:"locked_until,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -36,6 +41,19 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// IsLocked reports whether the account is locked after too many failed logins
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// OrganizationIDValue returns the organization of the user, empty when there is none
+func (u *User) OrganizationIDValue() string {
+	if u.OrganizationID == nil {
+		return ""
+	}
+	return *u.OrganizationID
+}
+
 // UserResponse represents user response DTO (without sensitive data)
 type UserResponse struct {
 	ID        string         `json:"id"`
@@ -44,7 +62,10 @@ type UserResponse struct {
 	AvatarURL string         `json:"avatar_url"`
 	RoleID    string         `json:"role_id"`
 	Role      *role.RoleResponse  `json:"role,omitempty"`
+	OrganizationID *string   `json:"organization_id,omitempty"`
 	Status    string         `json:"status"`
+	EmailVerified bool       `json:"email_verified"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"` // Set while the account is locked
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 }
@@ -57,10 +78,15 @@ func (u *User) ToUserResponse() *UserResponse {
 		Name:      u.Name,
 		AvatarURL: u.AvatarURL,
 		RoleID:    u.RoleID,
+		OrganizationID: u.OrganizationID,
 		Status:    u.Status,
+		EmailVerified: u.EmailVerifiedAt != nil,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
+	if u.IsLocked(time.Now()) {
+		resp.LockedUntil = u.LockedUntil
+	}
 	if u.Role != nil {
 		resp.Role = u.Role.ToRoleResponse()
 	}
@@ -74,6 +100,8 @@ type CreateUserRequest struct {
 	Name     string `json:"name" binding:"required,min=3"`
 	RoleID   string `json:"role_id" binding:"required,uuid"`
 	Status   string `json:"status" binding:"omitempty,oneof=active inactive"`
+	// Set from the caller's organization; only super admins choose it
+	OrganizationID string `json:"organization_id" binding:"omitempty,uuid"`
 }
 
 // UpdateUserRequest represents update user request DTO
@@ -82,6 +110,8 @@ type UpdateUserRequest struct {
 	Name   string `json:"name" binding:"omitempty,min=3"`
 	RoleID string `json:"role_id" binding:"omitempty,uuid"`
 	Status string `json:"status" binding:"omitempty,oneof=active inactive"`
+	// Moves staff to another organization; super admins only
+	OrganizationID *string `json:"organization_id" binding:"omitempty,uuid"`
 }
 
 // ListUsersRequest represents list users query parameters
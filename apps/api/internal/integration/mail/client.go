@@ -0,0 +1,78 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+)
+
+// Client sends plain-text transactional emails over SMTP
+type Client struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// NewClient creates a new mail client from config
+func NewClient() *Client {
+	cfg := config.AppConfig.Mail
+	return &Client{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.From,
+	}
+}
+
+// Enabled reports whether an SMTP server is configured
+func (c *Client) Enabled() bool {
+	return c.Host != ""
+}
+
+// Send sends an email. Without an SMTP server the email is logged instead, which is
+// what local development relies on to pick up verification and reset links. Bodies carry
+// those links, so outside development only the subject and recipient are logged.
+func (c *Client) Send(to, subject, body string) error {
+	if !c.Enabled() {
+		if config.AppConfig != nil && config.AppConfig.Server.Env == "development" {
+			log.Printf("[Mail] SMTP not configured, not sending %q to %s:\n%s", subject, to, body)
+		} else {
+			log.Printf("[Mail] SMTP not configured, not sending %q to %s", subject, to)
+		}
+		return nil
+	}
+
+	from, err := mail.ParseAddress(c.From)
+	if err != nil {
+		return fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + from.String() + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
+	if err := smtp.SendMail(addr, auth, from.Address, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keyRefetchInterval limits refetching the JWKS for unknown key IDs, so tokens with made-up
+// kids cannot make us hammer the provider
+const keyRefetchInterval = time.Minute
+
+// keySet caches a provider's signing keys by kid
+type keySet struct {
+	mu        sync.Mutex
+	keys      map[string]jwkKey
+	fetchedAt time.Time
+}
+
+type jwkKey struct {
+	algorithm string
+	publicKey crypto.PublicKey
+}
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// signingKey returns the provider key for a token's kid and algorithm, refetching the JWKS when
+// it is stale or does not have the kid (the provider rotated its keys)
+func (p *Provider) signingKey(ctx context.Context, jwksURI, kid, algorithm string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	if p.keys == nil {
+		p.keys = &keySet{}
+	}
+	keys := p.keys
+	p.mu.Unlock()
+
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+
+	key, ok := keys.find(kid)
+	age := time.Since(keys.fetchedAt)
+	if age >= discoveryTTL || (!ok && age >= keyRefetchInterval) {
+		fetched, err := p.fetchKeys(ctx, jwksURI)
+		if err != nil {
+			return nil, err
+		}
+		keys.keys = fetched
+		keys.fetchedAt = time.Now()
+		key, ok = keys.find(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if key.algorithm != "" && key.algorithm != algorithm {
+		return nil, fmt.Errorf("signing key %q is for %s, not %s", kid, key.algorithm, algorithm)
+	}
+	if !keyMatchesAlgorithm(key.publicKey, algorithm) {
+		return nil, fmt.Errorf("signing key %q cannot verify %s", kid, algorithm)
+	}
+	return key.publicKey, nil
+}
+
+// find looks a key up by kid; a token without kid is accepted when the provider has a single key
+func (s *keySet) find(kid string) (jwkKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, jwksURI string) (map[string]jwkKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("jwks endpoint returned %d", status)
+	}
+
+	keys := make(map[string]jwkKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		publicKey, err := k.publicKey()
+		if err != nil {
+			// Skip key types we do not support rather than failing the whole set
+			continue
+		}
+		keys[k.KeyID] = jwkKey{algorithm: k.Algorithm, publicKey: publicKey}
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.X, "="))
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func keyMatchesAlgorithm(key crypto.PublicKey, algorithm string) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(algorithm, "RS") || strings.HasPrefix(algorithm, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(algorithm, "ES")
+	case ed25519.PublicKey:
+		return algorithm == "EdDSA"
+	}
+	return false
+}
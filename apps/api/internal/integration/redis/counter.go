@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Capacity-guarded counter implemented via Redis Lua script.
+// Returns -1 when the counter isn't seeded yet, 0 when it already reached the capacity
+// (capacity <= 0 = unlimited) and 1 when it was incremented.
+var admitScript = redis.NewScript(`
+local key = KEYS[1]
+local capacity = tonumber(ARGV[1])
+local ttl_s = tonumber(ARGV[2])
+
+local current = redis.call('GET', key)
+if not current then
+  return -1
+end
+if capacity > 0 and tonumber(current) >= capacity then
+  return 0
+end
+
+redis.call('INCR', key)
+redis.call('EXPIRE', key, ttl_s)
+return 1
+`)
+
+// Decrements a counter only if it exists, so a missing key is reseeded from the database instead
+var decrementScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+// CounterNotSeeded is returned by AdmitCounter when the counter has to be seeded first
+const CounterNotSeeded = -1
+
+// AdmitCounter increments the counter at key unless it reached capacity; it returns 1 (admitted),
+// 0 (full) or CounterNotSeeded
+func AdmitCounter(ctx context.Context, key string, capacity int, ttl time.Duration) (int, error) {
+	return admitScript.Run(ctx, Client, []string{key}, capacity, int(ttl.Seconds())).Int()
+}
+
+// SeedCounter sets the counter to value unless another instance seeded it first
+func SeedCounter(ctx context.Context, key string, value int64, ttl time.Duration) error {
+	return Client.SetNX(ctx, key, value, ttl).Err()
+}
+
+// DecrementCounter decrements an existing counter
+func DecrementCounter(ctx context.Context, key string) error {
+	return decrementScript.Run(ctx, Client, []string{key}).Err()
+}
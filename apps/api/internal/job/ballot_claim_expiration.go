@@ -0,0 +1,35 @@
+package job
+
+import (
+	"log"
+
+	ballotservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/ballot"
+	"github.com/robfig/cron/v3"
+)
+
+// StartBallotClaimExpirationJob starts the cron job that expires unclaimed ballot wins and
+// promotes waitlisted entries into the freed seats.
+// Returns the *cron.Cron handle so the caller can stop it during graceful shutdown.
+func StartBallotClaimExpirationJob(ballotService *ballotservice.Service) *cron.Cron {
+	c := cron.New()
+
+	_, err := c.AddFunc("* * * * *", func() {
+		promoted, err := ballotService.ExpireClaims()
+		if err != nil {
+			log.Printf("[BallotClaimExpiration] Error expiring ballot claims: %v", err)
+			return
+		}
+		if promoted > 0 {
+			log.Printf("[BallotClaimExpiration] Promoted %d waitlisted entries", promoted)
+		}
+	})
+
+	if err != nil {
+		log.Printf("[BallotClaimExpiration] Error adding cron job: %v", err)
+		return c
+	}
+
+	c.Start()
+	log.Println("[BallotClaimExpiration] Job started (runs every minute)")
+	return c
+}
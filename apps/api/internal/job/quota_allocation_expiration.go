@@ -0,0 +1,35 @@
+package job
+
+import (
+	"log"
+
+	quotaallocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/quota_allocation"
+	"github.com/robfig/cron/v3"
+)
+
+// StartQuotaAllocationExpirationJob starts the cron job that returns unused seats of expired
+// quota allocations to their ticket category's public quota.
+// Returns the *cron.Cron handle so the caller can stop it during graceful shutdown.
+func StartQuotaAllocationExpirationJob(quotaAllocationService *quotaallocationservice.Service) *cron.Cron {
+	c := cron.New()
+
+	_, err := c.AddFunc("* * * * *", func() {
+		released, err := quotaAllocationService.ReleaseExpired()
+		if err != nil {
+			log.Printf("[QuotaAllocationExpiration] Error releasing expired allocations: %v", err)
+			return
+		}
+		if released > 0 {
+			log.Printf("[QuotaAllocationExpiration] Released %d expired allocations", released)
+		}
+	})
+
+	if err != nil {
+		log.Printf("[QuotaAllocationExpiration] Error adding cron job: %v", err)
+		return c
+	}
+
+	c.Start()
+	log.Println("[QuotaAllocationExpiration] Job started (runs every minute)")
+	return c
+}
@@ -0,0 +1,31 @@
+package job
+
+import (
+	"log"
+
+	signingkeyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/signing_key"
+	"github.com/robfig/cron/v3"
+)
+
+// StartSigningKeyRefreshJob starts the cron job that reloads the JWT signing keys, so keys rotated
+// on another instance or from the CLI are picked up and expired keys stop verifying tokens.
+// Returns the *cron.Cron handle so the caller can stop it during graceful shutdown.
+func StartSigningKeyRefreshJob(signingKeyService *signingkeyservice.Service) *cron.Cron {
+	c := cron.New()
+
+	_, err := c.AddFunc("* * * * *", func() {
+		// Keep the keys already loaded when reloading fails
+		if err := signingKeyService.Load(); err != nil {
+			log.Printf("[SigningKeyRefresh] Error loading signing keys: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Printf("[SigningKeyRefresh] Error adding cron job: %v", err)
+		return c
+	}
+
+	c.Start()
+	log.Println("[SigningKeyRefresh] Job started (runs every minute)")
+	return c
+}
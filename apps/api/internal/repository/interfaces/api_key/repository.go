@@ -0,0 +1,20 @@
+package apikey
+
+import (
+	"time"
+
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
+)
+
+// Repository defines the interface for API key operations
+type Repository interface {
+	// Create stores the key together with its permissions
+	Create(key *apikey.APIKey) error
+	FindByID(id string) (*apikey.APIKey, error)
+	FindByKeyHash(keyHash string) (*apikey.APIKey, error)
+	List(page, perPage int, filters map[string]interface{}) ([]*apikey.APIKey, int64, error)
+	Revoke(id, revokedBy string, now time.Time) error
+	TouchLastUsed(id, ipAddress string, now time.Time) error
+	CreateCall(call *apikey.APIKeyCall) error
+	ListCalls(apiKeyID string, page, perPage int, filters map[string]interface{}) ([]*apikey.APIKeyCall, int64, error)
+}
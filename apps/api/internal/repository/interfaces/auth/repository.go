@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 )
 
@@ -17,6 +19,19 @@ type Repository interface {
 	
 	// Update updates a user
 	Update(user *user.User) error
+
+	// UpdatePassword sets a user's password hash
+	UpdatePassword(id, passwordHash string) error
+
+	// MarkEmailVerified records when a user verified their email
+	MarkEmailVerified(id string, at time.Time) error
+
+	// RecordFailedLogin counts a failed login. When the count reaches threshold the account is
+	// locked until lockUntil and the count starts over; it returns the new count and whether it locked.
+	RecordFailedLogin(id string, now time.Time, threshold int, lockUntil time.Time) (int, bool, error)
+
+	// ResetFailedLogins clears the failed login count after a successful login
+	ResetFailedLogins(id string) error
 }
 
 
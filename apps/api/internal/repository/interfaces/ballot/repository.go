@@ -0,0 +1,47 @@
+package ballot
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
+)
+
+// Repository defines the interface for ballot repository operations
+type Repository interface {
+	// FindByID finds a ballot by ID
+	FindByID(id string) (*ballot.Ballot, error)
+
+	// FindActiveByTicketCategoryID finds the OPEN or DRAWN ballot of a ticket category
+	FindActiveByTicketCategoryID(ticketCategoryID string) (*ballot.Ballot, error)
+
+	// FindByStatus finds ballots by status
+	FindByStatus(status ballot.BallotStatus) ([]*ballot.Ballot, error)
+
+	// Create creates a new ballot
+	Create(b *ballot.Ballot) error
+
+	// Update updates a ballot
+	Update(b *ballot.Ballot) error
+
+	// List lists ballots with filters
+	List(page, perPage int, filters map[string]interface{}) ([]*ballot.Ballot, int64, error)
+
+	// FindEntryByBallotAndUser finds a user's entry in a ballot
+	FindEntryByBallotAndUser(ballotID, userID string) (*ballot.BallotEntry, error)
+
+	// FindEntryByClaimToken finds an entry by its claim token
+	FindEntryByClaimToken(token string) (*ballot.BallotEntry, error)
+
+	// FindEntriesByUserID finds all entries of a user
+	FindEntriesByUserID(userID string) ([]*ballot.BallotEntry, error)
+
+	// FindEntriesByBallotID finds all entries of a ballot
+	FindEntriesByBallotID(ballotID string) ([]*ballot.BallotEntry, error)
+
+	// CreateEntry creates a new ballot entry
+	CreateEntry(e *ballot.BallotEntry) error
+
+	// DeleteEntry deletes a ballot entry
+	DeleteEntry(id string) error
+
+	// ListEntries lists entries of a ballot with filters
+	ListEntries(ballotID string, page, perPage int, filters map[string]interface{}) ([]*ballot.BallotEntry, int64, error)
+}
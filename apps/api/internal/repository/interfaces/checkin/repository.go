@@ -38,5 +38,19 @@ type Repository interface {
 
 	// CountByOrderItemID counts check-ins by order item ID
 	CountByOrderItemID(orderItemID string) (int64, error)
-}
 
+	// FindByOrderItemAndSchedule finds the active (not voided) check-in of a ticket for a schedule
+	FindByOrderItemAndSchedule(orderItemID, scheduleID string) (*checkin.CheckIn, error)
+
+	// CountByOrderItemAndSchedule counts active (not voided) check-ins of a ticket for a schedule
+	CountByOrderItemAndSchedule(orderItemID, scheduleID string) (int64, error)
+
+	// CreateScan records an entry or exit scan
+	CreateScan(scan *checkin.TicketScan) error
+
+	// FindScansByOrderItemID finds the scan history of a ticket, oldest first
+	FindScansByOrderItemID(orderItemID string) ([]*checkin.TicketScan, error)
+
+	// GetOccupancy counts tickets whose latest scan is an entry, grouped by schedule and gate
+	GetOccupancy(filters map[string]interface{}) (*checkin.OccupancyResponse, error)
+}
@@ -4,22 +4,23 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/dashboard"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 )
 
 // Repository defines the interface for dashboard repository operations
 type Repository interface {
-	// GetSalesOverview gets sales overview statistics
-	GetSalesOverview(startDate, endDate *time.Time, eventID string) (*dashboard.SalesOverview, error)
+	// GetSalesOverview gets sales overview statistics within the organization scope
+	GetSalesOverview(scope organization.Scope, startDate, endDate *time.Time, eventID string) (*dashboard.SalesOverview, error)
 	
-	// GetCheckInOverview gets check-in overview statistics
-	GetCheckInOverview(startDate, endDate *time.Time, eventID string) (*dashboard.CheckInOverview, error)
+	// GetCheckInOverview gets check-in overview statistics within the organization scope
+	GetCheckInOverview(scope organization.Scope, startDate, endDate *time.Time, eventID string) (*dashboard.CheckInOverview, error)
 	
-	// GetQuotaOverview gets quota overview statistics
-	GetQuotaOverview(eventID string) (*dashboard.QuotaOverview, error)
+	// GetQuotaOverview gets quota overview statistics within the organization scope
+	GetQuotaOverview(scope organization.Scope, eventID string) (*dashboard.QuotaOverview, error)
 	
-	// GetGateActivity gets gate activity statistics
-	GetGateActivity(gateID string) ([]*dashboard.GateActivity, error)
+	// GetGateActivity gets gate activity statistics within the organization scope
+	GetGateActivity(scope organization.Scope, gateID string) ([]*dashboard.GateActivity, error)
 	
-	// GetBuyerList gets buyer list with statistics
-	GetBuyerList(startDate, endDate *time.Time, eventID string) ([]*dashboard.BuyerSummary, error)
+	// GetBuyerList gets the buyers of the events within the organization scope with statistics
+	GetBuyerList(scope organization.Scope, startDate, endDate *time.Time, eventID string) ([]*dashboard.BuyerSummary, error)
 }
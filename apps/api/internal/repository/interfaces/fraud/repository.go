@@ -0,0 +1,25 @@
+package fraud
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
+)
+
+// Repository defines the interface for fraud alert repository operations
+type Repository interface {
+	// Create creates a new alert
+	Create(alert *fraud.Alert) error
+
+	// FindByID finds an alert by ID
+	FindByID(id string) (*fraud.Alert, error)
+
+	// Update updates an alert
+	Update(alert *fraud.Alert) error
+
+	// List lists alerts with filters, newest first
+	List(page, perPage int, filters map[string]interface{}) ([]*fraud.Alert, int64, error)
+
+	// ExistsSince reports whether an alert for the rule and subject was raised since the given time
+	ExistsSince(rule fraud.Rule, subject string, since time.Time) (bool, error)
+}
@@ -0,0 +1,14 @@
+package gate_device
+
+import "github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+
+// Repository defines the interface for gate device operations.
+type Repository interface {
+	Create(device *gate.GateDevice) error
+	FindByID(id string) (*gate.GateDevice, error)
+	FindByTokenHash(tokenHash string) (*gate.GateDevice, error)
+	Update(device *gate.GateDevice) error
+	// RecordHeartbeat updates the reported status fields of a device
+	RecordHeartbeat(id string, fields map[string]interface{}) error
+	List(page, perPage int, filters map[string]interface{}) ([]*gate.GateDevice, int64, error)
+}
@@ -0,0 +1,14 @@
+package gate_occupancy
+
+import "github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+
+// Repository defines the interface for gate occupancy counter operations.
+type Repository interface {
+	// Admit atomically counts one admission unless the gate already admitted capacity tickets for
+	// the schedule (capacity 0 = unlimited); it reports whether the ticket was admitted
+	Admit(gateID, scheduleID string, capacity int) (bool, error)
+	// Apply adds a delta to the counters of a gate and schedule
+	Apply(gateID, scheduleID string, delta gate.OccupancyDelta) error
+	FindByGateAndSchedule(gateID, scheduleID string) (*gate.GateOccupancy, error)
+	List(filters map[string]interface{}) ([]*gate.GateOccupancy, error)
+}
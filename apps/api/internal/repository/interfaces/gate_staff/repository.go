@@ -1,12 +1,37 @@
 package gate_staff
 
-import "github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+)
+
+var (
+	// ErrShiftOverlap is returned when a shift would overlap another shift of the same staff member
+	ErrShiftOverlap = errors.New("staff already has a shift in this period")
+	// ErrShiftHandedOver is returned when the shift was handed over concurrently
+	ErrShiftHandedOver = errors.New("gate staff shift already handed over")
+)
 
 // Repository defines the interface for gate staff assignment operations.
 type Repository interface {
 	Assign(gateID, staffID string) error
+	AssignForShifts(gateID, staffID string) error
 	Unassign(gateID, staffID string) error
 	IsStaffAssignedToGate(gateID, staffID string) (bool, error)
 	ListGatesByStaffID(staffID string) ([]*gate.Gate, error)
 	ListStaffByGateID(gateID string) ([]*gate.GateStaffAssignment, error)
+
+	// Shifts
+	CreateShift(shift *gate.GateStaffShift) error
+	FindShiftByID(id string) (*gate.GateStaffShift, error)
+	UpdateShift(shift *gate.GateStaffShift) error
+	HandoverShift(current, next *gate.GateStaffShift) error
+	DeleteShift(id string) error
+	ListShifts(page, perPage int, filters map[string]interface{}) ([]*gate.GateStaffShift, int64, error)
+	HasOverlappingShift(staffID string, startsAt, endsAt time.Time, excludeID string) (bool, error)
+	FindDuty(gateID, staffID string, at time.Time) (*gate.GateStaffAssignment, []*gate.GateStaffShift, error)
+	IsStaffOnDuty(gateID, staffID, scheduleID string, at time.Time) (bool, error)
+	CountShiftScans(shiftIDs []string) (map[string]*gate.ShiftScanCount, error)
 }
@@ -0,0 +1,27 @@
+package loginattempt
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+)
+
+// Repository defines the interface for login attempt (login history) operations
+type Repository interface {
+	Create(attempt *auth.LoginAttempt) error
+
+	// ListByUser lists a user's login attempts, newest first. Filters: success (bool)
+	ListByUser(userID string, page, perPage int, filters map[string]interface{}) ([]*auth.LoginAttempt, int64, error)
+
+	// CountFailuresByIP counts failed login attempts from an IP address since the given time
+	CountFailuresByIP(ipAddress string, since time.Time) (int64, error)
+
+	// ListSuspiciousIPs lists IP addresses with at least minFailures failed logins since the given time,
+	// counting the attempts on accounts within the organization scope
+	ListSuspiciousIPs(scope organization.Scope, since time.Time, minFailures, limit int) ([]*auth.SuspiciousIP, error)
+
+	// ListSuspiciousAccounts lists the accounts within the organization scope with at least minFailures
+	// failed logins since the given time, and accounts locked at now
+	ListSuspiciousAccounts(scope organization.Scope, since time.Time, minFailures, limit int, now time.Time) ([]*auth.SuspiciousAccount, error)
+}
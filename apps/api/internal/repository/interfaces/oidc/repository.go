@@ -0,0 +1,23 @@
+package oidc
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+)
+
+// Repository defines the interface for OpenID Connect login states and linked identities
+type Repository interface {
+	// CreateState stores a started login and clears expired ones
+	CreateState(state *auth.OIDCLoginState) error
+
+	// ConsumeState uses up an unexpired login state of the provider; it can only be consumed once
+	ConsumeState(stateHash, provider string, now time.Time) (*auth.OIDCLoginState, error)
+
+	FindIdentity(provider, subject string) (*auth.UserIdentity, error)
+
+	CreateIdentity(identity *auth.UserIdentity) error
+
+	// TouchIdentity records a login with the identity and the email the provider reported
+	TouchIdentity(id, email string, at time.Time) error
+}
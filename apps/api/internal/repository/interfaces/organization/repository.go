@@ -0,0 +1,27 @@
+package organization
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+)
+
+// Repository defines the interface for organization repository operations
+type Repository interface {
+	// FindByID finds an organization by ID
+	FindByID(id string) (*organization.Organization, error)
+
+	// FindByCode finds an organization by code
+	FindByCode(code string) (*organization.Organization, error)
+
+	// Create creates a new organization
+	Create(o *organization.Organization) error
+
+	// Update updates an organization
+	Update(o *organization.Organization) error
+
+	// List lists organizations with filters and pagination
+	List(page, perPage int, filters map[string]interface{}) ([]*organization.Organization, int64, error)
+
+	// FindOwner returns the ID of the organization owning a resource; empty when the resource
+	// is not owned by any organization
+	FindOwner(resource organization.Resource, id string) (string, error)
+}
@@ -0,0 +1,32 @@
+package presale
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+)
+
+// Repository defines the interface for presale batch and code repository operations
+type Repository interface {
+	// FindBatchByID finds a presale batch by ID
+	FindBatchByID(id string) (*presale.PresaleBatch, error)
+
+	// CreateBatch creates a presale batch together with its codes
+	CreateBatch(batch *presale.PresaleBatch, codes []*presale.PresaleCode) error
+
+	// UpdateBatch updates a presale batch
+	UpdateBatch(batch *presale.PresaleBatch) error
+
+	// ListBatches lists presale batches with filters
+	ListBatches(page, perPage int, filters map[string]interface{}) ([]*presale.PresaleBatch, int64, error)
+
+	// AddCodes appends codes to an existing batch and updates its code count
+	AddCodes(batch *presale.PresaleBatch, codes []*presale.PresaleCode) error
+
+	// ListCodes lists codes of a batch with filters
+	ListCodes(batchID string, page, perPage int, filters map[string]interface{}) ([]*presale.PresaleCode, int64, error)
+
+	// FindCodesByBatchID finds all codes of a batch (for export)
+	FindCodesByBatchID(batchID string) ([]*presale.PresaleCode, error)
+
+	// GetBatchReport gets redemption statistics for a batch
+	GetBatchReport(batch *presale.PresaleBatch) (*presale.PresaleBatchReport, error)
+}
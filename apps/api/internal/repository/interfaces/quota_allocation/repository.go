@@ -0,0 +1,31 @@
+package quotaallocation
+
+import (
+	"time"
+
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
+)
+
+// Repository defines the interface for quota allocation repository operations
+type Repository interface {
+	// FindByID finds a quota allocation by ID
+	FindByID(id string) (*quotaallocation.QuotaAllocation, error)
+
+	// FindByAccessCode finds a quota allocation by access code
+	FindByAccessCode(code string) (*quotaallocation.QuotaAllocation, error)
+
+	// FindByTicketCategoryID finds quota allocations by ticket category ID
+	FindByTicketCategoryID(ticketCategoryID string) ([]*quotaallocation.QuotaAllocation, error)
+
+	// FindExpiredActive finds active allocations whose expiry time has passed
+	FindExpiredActive(now time.Time) ([]*quotaallocation.QuotaAllocation, error)
+
+	// Create creates a new quota allocation
+	Create(qa *quotaallocation.QuotaAllocation) error
+
+	// Update updates a quota allocation
+	Update(qa *quotaallocation.QuotaAllocation) error
+
+	// List lists quota allocations with filters
+	List(page, perPage int, filters map[string]interface{}) ([]*quotaallocation.QuotaAllocation, int64, error)
+}
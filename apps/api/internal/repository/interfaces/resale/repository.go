@@ -0,0 +1,35 @@
+package resale
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
+)
+
+// Repository defines the interface for resale repository operations
+type Repository interface {
+	// FindListingByID finds a resale listing by ID
+	FindListingByID(id string) (*resale.ResaleListing, error)
+
+	// FindListingsBySellerID finds all listings of a seller
+	FindListingsBySellerID(sellerID string) ([]*resale.ResaleListing, error)
+
+	// UpdateListing updates a resale listing
+	UpdateListing(l *resale.ResaleListing) error
+
+	// ListListings lists resale listings with filters
+	ListListings(page, perPage int, filters map[string]interface{}) ([]*resale.ResaleListing, int64, error)
+
+	// FindPayoutByID finds a seller payout by ID
+	FindPayoutByID(id string) (*resale.SellerPayout, error)
+
+	// FindPayoutsBySellerID finds all payouts of a seller
+	FindPayoutsBySellerID(sellerID string) ([]*resale.SellerPayout, error)
+
+	// UpdatePayout updates a seller payout
+	UpdatePayout(p *resale.SellerPayout) error
+
+	// ListPayouts lists seller payouts with filters
+	ListPayouts(page, perPage int, filters map[string]interface{}) ([]*resale.SellerPayout, int64, error)
+
+	// GetPayoutSummary aggregates payouts for reconciliation
+	GetPayoutSummary(filters map[string]interface{}) (*resale.PayoutSummary, error)
+}
@@ -17,6 +17,7 @@ type Repository interface {
 	RemovePermission(roleID string, permissionID string) error
 	GetPermissions(roleID string) ([]*permission.Permission, error)
 	HasPermission(roleID string, permissionCode string) (bool, error)
+	IncrementPermissionsVersion(roleID string) error
 }
 
 
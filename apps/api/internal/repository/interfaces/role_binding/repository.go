@@ -0,0 +1,33 @@
+package rolebinding
+
+import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+)
+
+// Repository defines the interface for role binding repository operations
+type Repository interface {
+	// FindByID finds a role binding by ID
+	FindByID(id string) (*role.RoleBinding, error)
+
+	// FindByUserRoleAndEvent finds the binding of a role to a user within an event
+	FindByUserRoleAndEvent(userID, roleID, eventID string) (*role.RoleBinding, error)
+
+	// Create creates a new role binding
+	Create(b *role.RoleBinding) error
+
+	// Delete deletes a role binding
+	Delete(id string) error
+
+	// DeleteByRole deletes every binding of a role
+	DeleteByRole(roleID string) error
+
+	// List lists role bindings with filters and pagination
+	List(page, perPage int, filters map[string]interface{}) ([]*role.RoleBinding, int64, error)
+
+	// ListRoleIDs returns the roles bound to a user within an event, or within any event when
+	// eventID is empty
+	ListRoleIDs(userID, eventID string) ([]string, error)
+
+	// FindEvent returns the ID of the event a context resource belongs to
+	FindEvent(resource role.ContextResource, id string) (string, error)
+}
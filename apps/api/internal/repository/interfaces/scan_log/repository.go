@@ -0,0 +1,26 @@
+package scanlog
+
+import (
+	"time"
+
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+)
+
+// Repository defines the interface for scan log repository operations.
+// The scan log is append-only: there are no update or delete operations.
+type Repository interface {
+	// Create appends a scan attempt
+	Create(log *scanlog.ScanLog) error
+
+	// List lists scan attempts with filters, newest first
+	List(page, perPage int, filters map[string]interface{}) ([]*scanlog.ScanLog, int64, error)
+
+	// Count counts scan attempts matching the filters
+	Count(filters map[string]interface{}) (int64, error)
+
+	// FindSuccessfulByRawCode finds accepted scans of a code since the given time, oldest first
+	FindSuccessfulByRawCode(rawCode string, since time.Time) ([]*scanlog.ScanLog, error)
+
+	// Export returns scan attempts matching the filters as CSV rows (header first)
+	Export(filters map[string]interface{}) ([][]string, error)
+}
@@ -3,6 +3,7 @@ package schedule
 import (
 	"time"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 )
 
@@ -26,8 +27,8 @@ type Repository interface {
 	// Delete soft deletes a schedule
 	Delete(id string) error
 	
-	// List lists all schedules
-	List() ([]*schedule.Schedule, error)
+	// List lists the schedules within the organization scope
+	List(scope organization.Scope) ([]*schedule.Schedule, error)
 }
 
 
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+)
+
+// Repository defines the interface for login session repository
+type Repository interface {
+	Create(session *auth.Session) error
+	FindByID(id string) (*auth.Session, error)
+	// Rotate swaps the session's current refresh token ID for a new one. It returns false when the
+	// session is revoked or currentTokenID is no longer the current token (token reuse).
+	Rotate(id, currentTokenID, newTokenID string, fields map[string]interface{}) (bool, error)
+	Revoke(id, reason string, now time.Time) error
+	RevokeAllByUser(userID, reason string, now time.Time) (int64, error)
+	ListActiveByUser(userID string, now time.Time) ([]*auth.Session, error)
+	IsActive(id string, now time.Time) (bool, error)
+}
@@ -0,0 +1,26 @@
+package signingkey
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+)
+
+// Repository defines the interface for JWT signing keys
+type Repository interface {
+	// FindActive returns the key that signs new tokens
+	FindActive() (*auth.SigningKey, error)
+
+	// ListVerifiable returns the active key and the retired keys whose grace period has not ended
+	ListVerifiable(now time.Time) ([]*auth.SigningKey, error)
+
+	List() ([]*auth.SigningKey, error)
+
+	// Rotate makes key the active key, retiring the current one at now with its grace period
+	// ending at expiresAt
+	Rotate(key *auth.SigningKey, now, expiresAt time.Time) error
+
+	// CreateIfNone stores key as the active key unless there already is one, and returns the
+	// active key. Concurrent callers agree on a single key.
+	CreateIfNone(key *auth.SigningKey) (*auth.SigningKey, error)
+}
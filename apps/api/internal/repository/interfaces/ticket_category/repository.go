@@ -1,6 +1,7 @@
 package ticketcategory
 
 import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
 )
 
@@ -21,8 +22,11 @@ type Repository interface {
 	// Delete soft deletes a ticket category
 	Delete(id string) error
 	
-	// List lists all ticket categories
-	List() ([]*ticketcategory.TicketCategory, error)
+	// List lists the ticket categories within the organization scope
+	List(scope organization.Scope) ([]*ticketcategory.TicketCategory, error)
+
+	// ReplacePassSchedules replaces the schedules a pass category is valid for
+	ReplacePassSchedules(categoryID string, scheduleIDs []string) error
 }
 
 
@@ -0,0 +1,32 @@
+package twofactor
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+)
+
+// Repository defines the interface for TOTP enrollments and recovery codes
+type Repository interface {
+	FindByUserID(userID string) (*auth.TwoFactor, error)
+
+	// SavePending stores a new unconfirmed secret, replacing an earlier pending enrollment
+	SavePending(userID, secretEncrypted string) (*auth.TwoFactor, error)
+
+	// Enable confirms the enrollment at the step of the confirming code and replaces the recovery codes
+	Enable(userID string, step int64, at time.Time, codes []*auth.RecoveryCode) error
+
+	// AdvanceStep records an accepted code's time step. It returns false when the step is not
+	// after the last accepted one, i.e. the code was already used.
+	AdvanceStep(userID string, step int64) (bool, error)
+
+	// Delete removes the enrollment and the recovery codes
+	Delete(userID string) error
+
+	ReplaceRecoveryCodes(userID string, codes []*auth.RecoveryCode) error
+
+	// UseRecoveryCode marks an unused recovery code as used; false when there is none with this hash
+	UseRecoveryCode(userID, codeHash string, now time.Time) (bool, error)
+
+	CountUnusedRecoveryCodes(userID string) (int64, error)
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 )
 
@@ -12,8 +13,8 @@ type Repository interface {
 	// FindByEmail finds a user by email
 	FindByEmail(email string) (*user.User, error)
 	
-	// List returns a list of users with pagination
-	List(req *user.ListUsersRequest) ([]user.User, int64, error)
+	// List returns a list of the users within the organization scope with pagination
+	List(scope organization.Scope, req *user.ListUsersRequest) ([]user.User, int64, error)
 	
 	// Create creates a new user
 	Create(user *user.User) error
@@ -23,6 +24,9 @@ type Repository interface {
 	
 	// Delete soft deletes a user
 	Delete(id string) error
+
+	// Unlock lifts a login lockout and clears the failed login count
+	Unlock(id string) error
 }
 
 
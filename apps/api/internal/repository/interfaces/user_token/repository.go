@@ -0,0 +1,23 @@
+package usertoken
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+)
+
+// Repository defines the interface for single-use user token operations
+type Repository interface {
+	// Create stores a token and marks the user's earlier unused tokens of the same purpose as used
+	Create(token *auth.UserToken) error
+
+	// Consume marks an unused, unexpired token as used and returns it; tokens that are unknown,
+	// expired or already used return gorm.ErrRecordNotFound
+	Consume(tokenHash string, purpose auth.TokenPurpose, now time.Time) (*auth.UserToken, error)
+
+	// FindActive finds an unused, unexpired token without using it up
+	FindActive(tokenHash string, purpose auth.TokenPurpose, now time.Time) (*auth.UserToken, error)
+
+	// RecordFailedAttempt counts a failed use of a token and marks it used once maxAttempts is reached
+	RecordFailedAttempt(id string, maxAttempts int, now time.Time) error
+}
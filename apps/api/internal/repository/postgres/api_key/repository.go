@@ -0,0 +1,153 @@
+package apikey
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	apikeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/api_key"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) apikeyrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(key *apikey.APIKey) error {
+	// Permissions are created through the has-many association in the same transaction
+	return r.db.Create(key).Error
+}
+
+func (r *Repository) FindByID(id string) (*apikey.APIKey, error) {
+	return r.find("id = ?", id)
+}
+
+func (r *Repository) FindByKeyHash(keyHash string) (*apikey.APIKey, error) {
+	return r.find("key_hash = ?", keyHash)
+}
+
+func (r *Repository) find(query string, arg interface{}) (*apikey.APIKey, error) {
+	var key apikey.APIKey
+	if err := r.db.Preload("Permissions").Where(query, arg).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrAPIKeyNotFound)
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*apikey.APIKey, int64, error) {
+	var keys []*apikey.APIKey
+	var total int64
+
+	query := r.db.Model(&apikey.APIKey{})
+
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByUser(query, "user_id")
+	}
+	if userID, ok := filters["user_id"].(string); ok && userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if search, ok := filters["search"].(string); ok && search != "" {
+		like := "%" + strings.ToLower(search) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR prefix LIKE ?", like, like)
+	}
+	// status is evaluated at now: revoked keys, unrevoked keys past expires_at, or the rest
+	if status, ok := filters["status"].(apikey.Status); ok {
+		now := filters["now"]
+		switch status {
+		case apikey.StatusRevoked:
+			query = query.Where("revoked_at IS NOT NULL")
+		case apikey.StatusExpired:
+			query = query.Where("revoked_at IS NULL AND expires_at <= ?", now)
+		case apikey.StatusActive:
+			query = query.Where("revoked_at IS NULL AND expires_at > ?", now)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("Permissions").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&keys).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return keys, total, nil
+}
+
+func (r *Repository) Revoke(id, revokedBy string, now time.Time) error {
+	result := r.db.Model(&apikey.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"revoked_by": revokedBy,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.Join(gorm.ErrRecordNotFound, ErrAPIKeyNotFound)
+	}
+	return nil
+}
+
+func (r *Repository) TouchLastUsed(id, ipAddress string, now time.Time) error {
+	// UpdateColumns keeps updated_at for changes made by admins
+	return r.db.Model(&apikey.APIKey{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_used_at": now,
+		"last_used_ip": ipAddress,
+	}).Error
+}
+
+func (r *Repository) CreateCall(call *apikey.APIKeyCall) error {
+	return r.db.Create(call).Error
+}
+
+func (r *Repository) ListCalls(apiKeyID string, page, perPage int, filters map[string]interface{}) ([]*apikey.APIKeyCall, int64, error) {
+	var calls []*apikey.APIKeyCall
+	var total int64
+
+	query := r.db.Model(&apikey.APIKeyCall{}).Where("api_key_id = ?", apiKeyID)
+
+	if method, ok := filters["method"].(string); ok && method != "" {
+		query = query.Where("method = ?", method)
+	}
+	if minStatus, ok := filters["min_status"].(int); ok {
+		query = query.Where("status_code >= ?", minStatus)
+	}
+	if startDate, ok := filters["start_date"].(time.Time); ok {
+		query = query.Where("created_at >= ?", startDate)
+	}
+	if endDate, ok := filters["end_date"].(time.Time); ok {
+		query = query.Where("created_at <= ?", endDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.Order("created_at DESC").Offset(offset).Limit(perPage).Find(&calls).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return calls, total, nil
+}
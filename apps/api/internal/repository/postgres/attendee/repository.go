@@ -3,10 +3,12 @@ package attendee
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/attendee"
 	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	attendeeRepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/attendee"
 	"gorm.io/gorm"
 )
@@ -48,6 +50,34 @@ func mapAttendeeStatus(orderItemStatus orderitem.TicketStatus, hasCheckIn bool)
 	return attendee.AttendeeStatusRegistered
 }
 
+// joinCheckIns joins each ticket's check-ins aggregated to one row; multi-day passes have one check-in
+// per schedule, limited to the filtered schedule when listing a single day's attendance
+func joinCheckIns(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		return query.
+			Joins(`LEFT JOIN (
+				SELECT order_item_id, MIN(checked_in_at) AS checked_in_at, COUNT(*) AS days_attended
+				FROM check_ins WHERE deleted_at IS NULL AND status <> 'VOIDED' AND schedule_id = ? GROUP BY order_item_id
+			) check_ins ON order_items.id = check_ins.order_item_id`, scheduleID).
+			Where("(orders.schedule_id = ? OR order_items.category_id IN (SELECT ticket_category_id FROM ticket_category_schedules WHERE schedule_id = ?))", scheduleID, scheduleID)
+	}
+	return query.Joins(`LEFT JOIN (
+		SELECT order_item_id, MIN(checked_in_at) AS checked_in_at, COUNT(*) AS days_attended
+		FROM check_ins WHERE deleted_at IS NULL AND status <> 'VOIDED' GROUP BY order_item_id
+	) check_ins ON order_items.id = check_ins.order_item_id`)
+}
+
+// checkedInConditions returns the SQL for checked-in and registered attendees. For a single schedule only
+// that day's check-in counts, since a pass is CHECKED-IN after its first day.
+func checkedInConditions(filters map[string]interface{}) (string, string) {
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		return "check_ins.order_item_id IS NOT NULL",
+			fmt.Sprintf("order_items.status IN ('%s', '%s') AND check_ins.order_item_id IS NULL", orderitem.TicketStatusPaid, orderitem.TicketStatusCheckedIn)
+	}
+	return fmt.Sprintf("(order_items.status = '%s' OR check_ins.order_item_id IS NOT NULL)", orderitem.TicketStatusCheckedIn),
+		fmt.Sprintf("order_items.status = '%s' AND check_ins.order_item_id IS NULL", orderitem.TicketStatusPaid)
+}
+
 // List lists attendees with pagination and filters
 func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*attendee.Attendee, int64, error) {
 	var attendees []*attendee.Attendee
@@ -70,16 +100,21 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 			orders.id as order_id,
 			order_items.id as order_item_id,
 			order_items.qr_code,
-			ticket_categories.id as category_id
+			ticket_categories.id as category_id,
+			COALESCE(check_ins.days_attended, 0) as days_attended
 		`).
 		Joins("INNER JOIN orders ON order_items.order_id = orders.id").
 		Joins("INNER JOIN users ON orders.user_id = users.id").
 		Joins("INNER JOIN ticket_categories ON order_items.category_id = ticket_categories.id").
-		Joins("LEFT JOIN check_ins ON order_items.id = check_ins.order_item_id AND check_ins.deleted_at IS NULL").
 		Where("order_items.deleted_at IS NULL").
 		Where("orders.deleted_at IS NULL").
 		Where("users.deleted_at IS NULL").
 		Where("order_items.status IN (?, ?, ?)", orderitem.TicketStatusPaid, orderitem.TicketStatusCheckedIn, orderitem.TicketStatusCanceled)
+	query = joinCheckIns(query, filters)
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByEvent(query, "ticket_categories.event_id")
+	}
+	checkedInCond, registeredCond := checkedInConditions(filters)
 
 	// Apply filters
 	if search, ok := filters["search"]; ok && search != nil && search.(string) != "" {
@@ -108,9 +143,9 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 		case attendee.AttendeeStatusCancelled:
 			query = query.Where("order_items.status = ?", orderitem.TicketStatusCanceled)
 		case attendee.AttendeeStatusCheckedIn:
-			query = query.Where("(order_items.status = ? OR check_ins.id IS NOT NULL)", orderitem.TicketStatusCheckedIn)
+			query = query.Where(checkedInCond)
 		case attendee.AttendeeStatusRegistered:
-			query = query.Where("order_items.status = ? AND check_ins.id IS NULL", orderitem.TicketStatusPaid)
+			query = query.Where(registeredCond)
 		}
 	}
 
@@ -163,6 +198,7 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 			&a.OrderItemID,
 			&a.QRCode,
 			&a.CategoryID,
+			&a.DaysAttended,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -178,9 +214,16 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 			a.CheckedInAt = &checkInTime.Time
 		}
 
-		// Map status
+		// Map status (for a single schedule only that day's check-in counts)
 		hasCheckIn := a.CheckedInAt != nil
-		a.Status = mapAttendeeStatus(orderitem.TicketStatus(orderItemStatus), hasCheckIn)
+		itemStatus := orderitem.TicketStatus(orderItemStatus)
+		if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+			hasCheckIn = checkInAt.Valid
+			if itemStatus == orderitem.TicketStatusCheckedIn {
+				itemStatus = orderitem.TicketStatusPaid
+			}
+		}
+		a.Status = mapAttendeeStatus(itemStatus, hasCheckIn)
 
 		attendees = append(attendees, &a)
 	}
@@ -192,6 +235,7 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 func (r *Repository) GetStatistics(filters map[string]interface{}) (*attendee.AttendeeStatistics, error) {
 	stats := &attendee.AttendeeStatistics{
 		ByTicketTier: make(map[string]int64),
+		BySchedule:   make(map[string]int64),
 	}
 
 	// Base query
@@ -199,11 +243,15 @@ func (r *Repository) GetStatistics(filters map[string]interface{}) (*attendee.At
 		Joins("INNER JOIN orders ON order_items.order_id = orders.id").
 		Joins("INNER JOIN users ON orders.user_id = users.id").
 		Joins("INNER JOIN ticket_categories ON order_items.category_id = ticket_categories.id").
-		Joins("LEFT JOIN check_ins ON order_items.id = check_ins.order_item_id AND check_ins.deleted_at IS NULL").
 		Where("order_items.deleted_at IS NULL").
 		Where("orders.deleted_at IS NULL").
 		Where("users.deleted_at IS NULL").
 		Where("order_items.status IN (?, ?, ?)", orderitem.TicketStatusPaid, orderitem.TicketStatusCheckedIn, orderitem.TicketStatusCanceled)
+	query = joinCheckIns(query, filters)
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByEvent(query, "ticket_categories.event_id")
+	}
+	checkedInCond, registeredCond := checkedInConditions(filters)
 
 	// Apply filters (same as List)
 	if startDate, ok := filters["start_date"]; ok && startDate != nil {
@@ -219,13 +267,13 @@ func (r *Repository) GetStatistics(filters map[string]interface{}) (*attendee.At
 	}
 
 	// Checked in count
-	checkedInQuery := query.Where("(order_items.status = ? OR check_ins.id IS NOT NULL)", orderitem.TicketStatusCheckedIn)
+	checkedInQuery := query.Where(checkedInCond)
 	if err := checkedInQuery.Count(&stats.CheckedInCount).Error; err != nil {
 		return nil, err
 	}
 
 	// Registered count (paid but not checked in)
-	registeredQuery := query.Where("order_items.status = ? AND check_ins.id IS NULL", orderitem.TicketStatusPaid)
+	registeredQuery := query.Where(registeredCond)
 	if err := registeredQuery.Count(&stats.RegisteredCount).Error; err != nil {
 		return nil, err
 	}
@@ -256,6 +304,42 @@ func (r *Repository) GetStatistics(filters map[string]interface{}) (*attendee.At
 		stats.ByTicketTier[string(tier)] = count
 	}
 
+	// Per-day attendance: check-ins per schedule
+	scheduleQuery := r.db.Table("check_ins").
+		Select("check_ins.schedule_id, COUNT(*) as count").
+		Joins("INNER JOIN order_items ON order_items.id = check_ins.order_item_id").
+		Joins("INNER JOIN orders ON order_items.order_id = orders.id").
+		Where("check_ins.deleted_at IS NULL").
+		Where("check_ins.status <> ?", "VOIDED").
+		Where("check_ins.schedule_id IS NOT NULL")
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		scheduleQuery = scope.FilterByTicketCategory(scheduleQuery, "order_items.category_id")
+	}
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		scheduleQuery = scheduleQuery.Where("check_ins.schedule_id = ?", scheduleID)
+	}
+	if startDate, ok := filters["start_date"]; ok && startDate != nil {
+		scheduleQuery = scheduleQuery.Where("orders.created_at >= ?", startDate)
+	}
+	if endDate, ok := filters["end_date"]; ok && endDate != nil {
+		scheduleQuery = scheduleQuery.Where("orders.created_at <= ?", endDate)
+	}
+
+	scheduleRows, err := scheduleQuery.Group("check_ins.schedule_id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer scheduleRows.Close()
+
+	for scheduleRows.Next() {
+		var scheduleID string
+		var count int64
+		if err := scheduleRows.Scan(&scheduleID, &count); err != nil {
+			return nil, err
+		}
+		stats.BySchedule[scheduleID] = count
+	}
+
 	return stats, nil
 }
 
@@ -269,7 +353,7 @@ func (r *Repository) Export(filters map[string]interface{}) ([][]string, error)
 
 	// CSV header
 	result := [][]string{
-		{"ID", "Name", "Email", "Ticket Type", "Ticket Tier", "Registration Date", "Status", "Checked In At"},
+		{"ID", "Name", "Email", "Ticket Type", "Ticket Tier", "Registration Date", "Status", "Checked In At", "Days Attended"},
 	}
 
 	// CSV rows
@@ -287,6 +371,7 @@ func (r *Repository) Export(filters map[string]interface{}) ([][]string, error)
 			a.RegistrationDate.Format("2006-01-02 15:04:05"),
 			string(a.Status),
 			checkedInAt,
+			strconv.Itoa(a.DaysAttended),
 		})
 	}
 
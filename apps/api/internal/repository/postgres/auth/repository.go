@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 	authrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/auth"
 	"gorm.io/gorm"
@@ -41,3 +43,38 @@ func (r *repository) Update(u *user.User) error {
 	return r.db.Save(u).Error
 }
 
+func (r *repository) UpdatePassword(id, passwordHash string) error {
+	return r.db.Model(&user.User{}).Where("id = ?", id).Update("password", passwordHash).Error
+}
+
+func (r *repository) MarkEmailVerified(id string, at time.Time) error {
+	return r.db.Model(&user.User{}).Where("id = ? AND email_verified_at IS NULL", id).Update("email_verified_at", at).Error
+}
+
+func (r *repository) RecordFailedLogin(id string, now time.Time, threshold int, lockUntil time.Time) (int, bool, error) {
+	var result struct {
+		FailedLoginAttempts int
+	}
+	err := r.db.Raw(`
+		UPDATE users SET
+			failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= ? THEN 0 ELSE failed_login_attempts + 1 END,
+			locked_until = CASE WHEN failed_login_attempts + 1 >= ? THEN ?::timestamp ELSE locked_until END,
+			last_failed_login_at = ?
+		WHERE id = ?
+		RETURNING failed_login_attempts
+	`, threshold, threshold, lockUntil, now, id).Scan(&result).Error
+	if err != nil {
+		return 0, false, err
+	}
+	// The count only starts over when the account was locked
+	return result.FailedLoginAttempts, result.FailedLoginAttempts == 0, nil
+}
+
+func (r *repository) ResetFailedLogins(id string) error {
+	return r.db.Model(&user.User{}).
+		Where("id = ? AND (failed_login_attempts <> 0 OR last_failed_login_at IS NOT NULL)", id).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"last_failed_login_at":  nil,
+		}).Error
+}
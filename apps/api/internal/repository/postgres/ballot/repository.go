@@ -0,0 +1,199 @@
+package ballot
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	ballotrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ballot"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBallotNotFound      = errors.New("ballot not found")
+	ErrBallotEntryNotFound = errors.New("ballot entry not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new ballot repository
+func NewRepository(db *gorm.DB) ballotrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindByID finds a ballot by ID
+func (r *Repository) FindByID(id string) (*ballot.Ballot, error) {
+	var b ballot.Ballot
+	if err := r.db.Where("id = ?", id).Preload("TicketCategory").First(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrBallotNotFound)
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// FindActiveByTicketCategoryID finds the OPEN or DRAWN ballot of a ticket category
+func (r *Repository) FindActiveByTicketCategoryID(ticketCategoryID string) (*ballot.Ballot, error) {
+	var b ballot.Ballot
+	if err := r.db.Where("ticket_category_id = ?", ticketCategoryID).
+		Where("status IN ?", []ballot.BallotStatus{ballot.BallotStatusOpen, ballot.BallotStatusDrawn}).
+		First(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrBallotNotFound)
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// FindByStatus finds ballots by status
+func (r *Repository) FindByStatus(status ballot.BallotStatus) ([]*ballot.Ballot, error) {
+	var ballots []*ballot.Ballot
+	if err := r.db.Where("status = ?", status).Find(&ballots).Error; err != nil {
+		return nil, err
+	}
+	return ballots, nil
+}
+
+// Create creates a new ballot
+func (r *Repository) Create(b *ballot.Ballot) error {
+	return r.db.Create(b).Error
+}
+
+// Update updates a ballot
+func (r *Repository) Update(b *ballot.Ballot) error {
+	return r.db.Omit("TicketCategory").Save(b).Error
+}
+
+// List lists ballots with filters
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*ballot.Ballot, int64, error) {
+	var ballots []*ballot.Ballot
+	var total int64
+
+	query := r.db.Model(&ballot.Ballot{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByTicketCategory(query, "ballots.ticket_category_id")
+	}
+	if ticketCategoryID, ok := filters["ticket_category_id"]; ok && ticketCategoryID != nil {
+		query = query.Where("ballots.ticket_category_id = ?", ticketCategoryID)
+	}
+	if eventID, ok := filters["event_id"]; ok && eventID != nil {
+		query = query.Joins("JOIN ticket_categories ON ticket_categories.id = ballots.ticket_category_id").
+			Where("ticket_categories.event_id = ?", eventID)
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("ballots.status = ?", status)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and preloads
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("TicketCategory").
+		Offset(offset).
+		Limit(perPage).
+		Order("ballots.created_at DESC").
+		Find(&ballots).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return ballots, total, nil
+}
+
+// FindEntryByBallotAndUser finds a user's entry in a ballot
+func (r *Repository) FindEntryByBallotAndUser(ballotID, userID string) (*ballot.BallotEntry, error) {
+	var e ballot.BallotEntry
+	if err := r.db.Where("ballot_id = ? AND user_id = ?", ballotID, userID).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrBallotEntryNotFound)
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// FindEntryByClaimToken finds an entry by its claim token
+func (r *Repository) FindEntryByClaimToken(token string) (*ballot.BallotEntry, error) {
+	var e ballot.BallotEntry
+	if err := r.db.Where("claim_token = ?", token).Preload("Ballot").First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrBallotEntryNotFound)
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+// FindEntriesByUserID finds all entries of a user
+func (r *Repository) FindEntriesByUserID(userID string) ([]*ballot.BallotEntry, error) {
+	var entries []*ballot.BallotEntry
+	if err := r.db.Where("user_id = ?", userID).
+		Preload("Ballot").
+		Order("created_at DESC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindEntriesByBallotID finds all entries of a ballot
+func (r *Repository) FindEntriesByBallotID(ballotID string) ([]*ballot.BallotEntry, error) {
+	var entries []*ballot.BallotEntry
+	if err := r.db.Where("ballot_id = ?", ballotID).
+		Order("draw_rank ASC, created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CreateEntry creates a new ballot entry
+func (r *Repository) CreateEntry(e *ballot.BallotEntry) error {
+	return r.db.Create(e).Error
+}
+
+// DeleteEntry deletes a ballot entry
+func (r *Repository) DeleteEntry(id string) error {
+	return r.db.Where("id = ?", id).Delete(&ballot.BallotEntry{}).Error
+}
+
+// ListEntries lists entries of a ballot with filters
+func (r *Repository) ListEntries(ballotID string, page, perPage int, filters map[string]interface{}) ([]*ballot.BallotEntry, int64, error) {
+	var entries []*ballot.BallotEntry
+	var total int64
+
+	query := r.db.Model(&ballot.BallotEntry{}).Where("ballot_id = ?", ballotID)
+
+	// Apply filters
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("status = ?", status)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * perPage
+	if err := query.
+		Offset(offset).
+		Limit(perPage).
+		Order("draw_rank ASC, created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	checkinrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/checkin"
 	"gorm.io/gorm"
 )
@@ -123,6 +124,9 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 	query := r.db.Model(&checkin.CheckIn{})
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByOrderItem(query, "order_item_id")
+	}
 	if orderItemID, ok := filters["order_item_id"]; ok && orderItemID != nil {
 		query = query.Where("order_item_id = ?", orderItemID)
 	}
@@ -186,3 +190,105 @@ func (r *Repository) CountByOrderItemID(orderItemID string) (int64, error) {
 	return count, nil
 }
 
+// FindByOrderItemAndSchedule finds the active (not voided) check-in of a ticket for a schedule
+func (r *Repository) FindByOrderItemAndSchedule(orderItemID, scheduleID string) (*checkin.CheckIn, error) {
+	var c checkin.CheckIn
+	if err := r.db.Where("order_item_id = ? AND schedule_id = ? AND status <> ?", orderItemID, scheduleID, checkin.CheckInStatusVoided).
+		Preload("OrderItem.Order.User").
+		Preload("OrderItem.Order.Schedule.Event").
+		Preload("OrderItem.Category").
+		Preload("Staff").
+		First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrCheckInNotFound)
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CountByOrderItemAndSchedule counts active (not voided) check-ins of a ticket for a schedule
+func (r *Repository) CountByOrderItemAndSchedule(orderItemID, scheduleID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&checkin.CheckIn{}).
+		Where("order_item_id = ? AND schedule_id = ? AND status <> ?", orderItemID, scheduleID, checkin.CheckInStatusVoided).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateScan records an entry or exit scan
+func (r *Repository) CreateScan(scan *checkin.TicketScan) error {
+	return r.db.Create(scan).Error
+}
+
+// FindScansByOrderItemID finds the scan history of a ticket, oldest first
+func (r *Repository) FindScansByOrderItemID(orderItemID string) ([]*checkin.TicketScan, error) {
+	var scans []*checkin.TicketScan
+	if err := r.db.Where("order_item_id = ?", orderItemID).
+		Order("scanned_at ASC").
+		Find(&scans).Error; err != nil {
+		return nil, err
+	}
+	return scans, nil
+}
+
+// GetOccupancy counts tickets whose latest scan is an entry, grouped by schedule and gate
+func (r *Repository) GetOccupancy(filters map[string]interface{}) (*checkin.OccupancyResponse, error) {
+	// Latest scan per ticket decides whether it is inside
+	latest := r.db.Model(&checkin.TicketScan{}).
+		Select("DISTINCT ON (order_item_id) order_item_id, schedule_id, gate_id, direction").
+		Where("voided = ?", false).
+		Order("order_item_id, scanned_at DESC")
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		latest = latest.Where("schedule_id = ?", scheduleID)
+	}
+
+	var rows []struct {
+		ScheduleID string
+		GateID     *string
+		Inside     int64
+	}
+	query := r.db.Table("(?) AS latest", latest).
+		Select("schedule_id, gate_id, COUNT(*) AS inside").
+		Where("direction = ?", checkin.ScanDirectionEntry)
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_id = ?", gateID)
+	}
+	if err := query.Group("schedule_id, gate_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := &checkin.OccupancyResponse{
+		Schedules: []*checkin.ScheduleOccupancy{},
+		Gates:     []*checkin.GateOccupancy{},
+	}
+	schedules := make(map[string]*checkin.ScheduleOccupancy)
+	gates := make(map[string]*checkin.GateOccupancy)
+	for _, row := range rows {
+		result.TotalInside += row.Inside
+
+		so, ok := schedules[row.ScheduleID]
+		if !ok {
+			so = &checkin.ScheduleOccupancy{ScheduleID: row.ScheduleID}
+			schedules[row.ScheduleID] = so
+			result.Schedules = append(result.Schedules, so)
+		}
+		so.Inside += row.Inside
+
+		gateKey := ""
+		if row.GateID != nil {
+			gateKey = *row.GateID
+		}
+		g, ok := gates[gateKey]
+		if !ok {
+			g = &checkin.GateOccupancy{GateID: row.GateID}
+			gates[gateKey] = g
+			result.Gates = append(result.Gates, g)
+		}
+		g.Inside += row.Inside
+	}
+
+	return result, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
 	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
 	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
 	dashboardrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/dashboard"
 	"gorm.io/gorm"
@@ -25,9 +27,10 @@ func NewRepository(db *gorm.DB) dashboardrepo.Repository {
 	}
 }
 
-// GetSalesOverview gets sales overview statistics
-func (r *Repository) GetSalesOverview(startDate, endDate *time.Time, eventID string) (*dashboard.SalesOverview, error) {
+// GetSalesOverview gets sales overview statistics within the organization scope
+func (r *Repository) GetSalesOverview(scope organization.Scope, startDate, endDate *time.Time, eventID string) (*dashboard.SalesOverview, error) {
 	query := r.db.Model(&order.Order{}).Where("deleted_at IS NULL")
+	query = scope.FilterByTicketCategory(query, "orders.ticket_category_id")
 
 	// Apply date filters
 	if startDate != nil {
@@ -102,11 +105,12 @@ func (r *Repository) GetSalesOverview(startDate, endDate *time.Time, eventID str
 	}, nil
 }
 
-// GetCheckInOverview gets check-in overview statistics
-func (r *Repository) GetCheckInOverview(startDate, endDate *time.Time, eventID string) (*dashboard.CheckInOverview, error) {
+// GetCheckInOverview gets check-in overview statistics within the organization scope
+func (r *Repository) GetCheckInOverview(scope organization.Scope, startDate, endDate *time.Time, eventID string) (*dashboard.CheckInOverview, error) {
 	// Get total order items (tickets issued)
 	var totalTickets int64
 	orderItemQuery := r.db.Model(&orderitem.OrderItem{}).Where("deleted_at IS NULL")
+	orderItemQuery = scope.FilterByTicketCategory(orderItemQuery, "order_items.category_id")
 
 	if startDate != nil {
 		orderItemQuery = orderItemQuery.Where("created_at >= ?", startDate)
@@ -130,6 +134,7 @@ func (r *Repository) GetCheckInOverview(startDate, endDate *time.Time, eventID s
 	// Get checked in count
 	var checkedInCount int64
 	checkInQuery := r.db.Model(&checkin.CheckIn{}).Where("status = ?", checkin.CheckInStatusSuccess)
+	checkInQuery = scope.FilterByTicketCategory(checkInQuery, "(SELECT category_id FROM order_items WHERE order_items.id = check_ins.order_item_id)")
 
 	if startDate != nil {
 		checkInQuery = checkInQuery.Where("checked_in_at >= ?", startDate)
@@ -180,9 +185,10 @@ func (r *Repository) GetCheckInOverview(startDate, endDate *time.Time, eventID s
 	}, nil
 }
 
-// GetQuotaOverview gets quota overview statistics
-func (r *Repository) GetQuotaOverview(eventID string) (*dashboard.QuotaOverview, error) {
+// GetQuotaOverview gets quota overview statistics within the organization scope
+func (r *Repository) GetQuotaOverview(scope organization.Scope, eventID string) (*dashboard.QuotaOverview, error) {
 	query := r.db.Model(&ticketcategory.TicketCategory{}).Where("deleted_at IS NULL")
+	query = scope.FilterByEvent(query, "event_id")
 
 	if eventID != "" {
 		query = query.Where("event_id = ?", eventID)
@@ -200,6 +206,7 @@ func (r *Repository) GetQuotaOverview(eventID string) (*dashboard.QuotaOverview,
 			Remaining:       0,
 			UtilizationRate: 0,
 			ByTier:          []dashboard.QuotaByTier{},
+			Allocations:     []dashboard.QuotaAllocationUtilization{},
 		}, nil
 	}
 
@@ -229,6 +236,50 @@ func (r *Repository) GetQuotaOverview(eventID string) (*dashboard.QuotaOverview,
 		soldByCategory[row.CategoryID] = int(row.SoldCount)
 	}
 
+	var allocationRows []quotaallocation.QuotaAllocation
+	if err := r.db.Model(&quotaallocation.QuotaAllocation{}).
+		Where("deleted_at IS NULL").
+		Where("ticket_category_id IN ?", categoryIDs).
+		Order("created_at ASC").
+		Find(&allocationRows).Error; err != nil {
+		return nil, err
+	}
+
+	categoryNames := make(map[string]string, len(categories))
+	for _, cat := range categories {
+		categoryNames[cat.ID] = cat.CategoryName
+	}
+
+	allocatedByCategory := make(map[string]int)
+	allocationUsedByCategory := make(map[string]int)
+	allocations := make([]dashboard.QuotaAllocationUtilization, 0, len(allocationRows))
+	for _, qa := range allocationRows {
+		if qa.Status == quotaallocation.AllocationStatusActive {
+			allocatedByCategory[qa.TicketCategoryID] += qa.Size
+			allocationUsedByCategory[qa.TicketCategoryID] += qa.Used
+		}
+
+		allocationRate := 0.0
+		if qa.Size > 0 {
+			allocationRate = float64(qa.Used) / float64(qa.Size) * 100.0
+		}
+
+		allocations = append(allocations, dashboard.QuotaAllocationUtilization{
+			AllocationID:    qa.ID,
+			Name:            qa.Name,
+			OwnerType:       string(qa.OwnerType),
+			OwnerName:       qa.OwnerName,
+			TierID:          qa.TicketCategoryID,
+			TierName:        categoryNames[qa.TicketCategoryID],
+			Status:          string(qa.Status),
+			Size:            qa.Size,
+			Used:            qa.Used,
+			Remaining:       qa.Remaining(),
+			UtilizationRate: allocationRate,
+			ExpiresAt:       qa.ExpiresAt,
+		})
+	}
+
 	totalQuota := 0
 	totalSold := 0
 	byTier := make([]dashboard.QuotaByTier, 0, len(categories))
@@ -251,6 +302,8 @@ func (r *Repository) GetQuotaOverview(eventID string) (*dashboard.QuotaOverview,
 			Sold:            sold,
 			Remaining:       remaining,
 			UtilizationRate: utilizationRate,
+			Allocated:       allocatedByCategory[cat.ID],
+			AllocationUsed:  allocationUsedByCategory[cat.ID],
 		})
 	}
 
@@ -266,12 +319,14 @@ func (r *Repository) GetQuotaOverview(eventID string) (*dashboard.QuotaOverview,
 		Remaining:       remaining,
 		UtilizationRate: utilizationRate,
 		ByTier:          byTier,
+		Allocations:     allocations,
 	}, nil
 }
 
-// GetGateActivity gets gate activity statistics
-func (r *Repository) GetGateActivity(gateID string) ([]*dashboard.GateActivity, error) {
+// GetGateActivity gets gate activity statistics within the organization scope
+func (r *Repository) GetGateActivity(scope organization.Scope, gateID string) ([]*dashboard.GateActivity, error) {
 	query := r.db.Model(&gate.Gate{}).Where("deleted_at IS NULL")
+	query = scope.Filter(query, "organization_id")
 
 	if gateID != "" {
 		query = query.Where("id = ?", gateID)
@@ -320,12 +375,13 @@ func (r *Repository) GetGateActivity(gateID string) ([]*dashboard.GateActivity,
 	return activities, nil
 }
 
-// GetBuyerList gets buyer list with statistics
-func (r *Repository) GetBuyerList(startDate, endDate *time.Time, eventID string) ([]*dashboard.BuyerSummary, error) {
+// GetBuyerList gets the buyers of the events within the organization scope with statistics
+func (r *Repository) GetBuyerList(scope organization.Scope, startDate, endDate *time.Time, eventID string) ([]*dashboard.BuyerSummary, error) {
 	query := r.db.Model(&order.Order{}).
 		Select("orders.user_id, users.name, users.email, COUNT(orders.id) as total_orders, COALESCE(SUM(CASE WHEN orders.payment_status = 'PAID' THEN orders.total_amount ELSE 0 END), 0) as total_spent, MAX(orders.created_at) as last_order_date").
 		Joins("JOIN users ON orders.user_id = users.id").
 		Where("orders.deleted_at IS NULL")
+	query = scope.FilterByTicketCategory(query, "orders.ticket_category_id")
 
 	// Apply date filters
 	if startDate != nil {
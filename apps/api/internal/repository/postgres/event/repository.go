@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	eventrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/event"
 	"gorm.io/gorm"
 )
@@ -71,6 +72,10 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 	query := r.db.Model(&event.Event{})
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.Filter(query, "organization_id")
+	}
+
 	if search, ok := filters["search"].(string); ok && search != "" {
 		query = query.Where("event_name ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -124,6 +129,10 @@ func (r *Repository) Count(filters map[string]interface{}) (int64, error) {
 	query := r.db.Model(&event.Event{})
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.Filter(query, "organization_id")
+	}
+
 	if search, ok := filters["search"].(string); ok && search != "" {
 		query = query.Where("event_name ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
 	}
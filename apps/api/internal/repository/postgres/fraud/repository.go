@@ -0,0 +1,105 @@
+package fraud
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	fraudrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/fraud"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAlertNotFound = errors.New("fraud alert not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new fraud alert repository
+func NewRepository(db *gorm.DB) fraudrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Create creates a new alert
+func (r *Repository) Create(alert *fraud.Alert) error {
+	return r.db.Create(alert).Error
+}
+
+// FindByID finds an alert by ID
+func (r *Repository) FindByID(id string) (*fraud.Alert, error) {
+	var alert fraud.Alert
+	if err := r.db.Where("id = ?", id).First(&alert).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrAlertNotFound)
+		}
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// Update updates an alert
+func (r *Repository) Update(alert *fraud.Alert) error {
+	return r.db.Save(alert).Error
+}
+
+// List lists alerts with filters, newest first
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*fraud.Alert, int64, error) {
+	var alerts []*fraud.Alert
+	var total int64
+
+	query := r.db.Model(&fraud.Alert{})
+
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByGateOrOrderItem(query, "gate_id", "order_item_id")
+	}
+	if rule, ok := filters["rule"]; ok && rule != nil {
+		query = query.Where("rule = ?", rule)
+	}
+	if severity, ok := filters["severity"]; ok && severity != nil {
+		query = query.Where("severity = ?", severity)
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("status = ?", status)
+	}
+	if orderItemID, ok := filters["order_item_id"]; ok && orderItemID != nil {
+		query = query.Where("order_item_id = ?", orderItemID)
+	}
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_id = ?", gateID)
+	}
+	if startDate, ok := filters["start_date"]; ok && startDate != nil {
+		query = query.Where("created_at >= ?", startDate)
+	}
+	if endDate, ok := filters["end_date"]; ok && endDate != nil {
+		query = query.Where("created_at <= ?", endDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.
+		Offset(offset).
+		Limit(perPage).
+		Order("created_at DESC").
+		Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+// ExistsSince reports whether an alert for the rule and subject was raised since the given time
+func (r *Repository) ExistsSince(rule fraud.Rule, subject string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&fraud.Alert{}).
+		Where("rule = ? AND subject = ? AND created_at >= ?", rule, subject, since).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	gaterepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate"
 	"gorm.io/gorm"
 )
@@ -72,6 +73,9 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 	query := r.db.Model(&gate.Gate{})
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.Filter(query, "organization_id")
+	}
 	if status, ok := filters["status"]; ok && status != nil {
 		query = query.Where("status = ?", status)
 	}
@@ -0,0 +1,98 @@
+package gate_device
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGateDeviceNotFound = errors.New("gate device not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) gatedevicerepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(device *gate.GateDevice) error {
+	return r.db.Create(device).Error
+}
+
+func (r *Repository) FindByID(id string) (*gate.GateDevice, error) {
+	var device gate.GateDevice
+	if err := r.db.Preload("Gate").Where("id = ?", id).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrGateDeviceNotFound)
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *Repository) FindByTokenHash(tokenHash string) (*gate.GateDevice, error) {
+	var device gate.GateDevice
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrGateDeviceNotFound)
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *Repository) Update(device *gate.GateDevice) error {
+	return r.db.Omit("Gate").Save(device).Error
+}
+
+func (r *Repository) RecordHeartbeat(id string, fields map[string]interface{}) error {
+	return r.db.Model(&gate.GateDevice{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*gate.GateDevice, int64, error) {
+	var devices []*gate.GateDevice
+	var total int64
+
+	query := r.db.Model(&gate.GateDevice{})
+
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByGate(query, "gate_id")
+	}
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_id = ?", gateID)
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("status = ?", status)
+	}
+	// online is evaluated against seen_since: active devices with a heartbeat at or after it
+	if online, ok := filters["online"].(bool); ok {
+		seenSince := filters["seen_since"]
+		if online {
+			query = query.Where("status = ? AND last_seen_at >= ?", gate.DeviceStatusActive, seenSince)
+		} else {
+			query = query.Where("status = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", gate.DeviceStatusActive, seenSince)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("Gate").
+		Offset(offset).
+		Limit(perPage).
+		Order("gate_id ASC, name ASC").
+		Find(&devices).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return devices, total, nil
+}
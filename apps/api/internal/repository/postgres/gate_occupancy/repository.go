@@ -0,0 +1,88 @@
+package gate_occupancy
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	gateoccupancyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_occupancy"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGateOccupancyNotFound = errors.New("gate occupancy not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) gateoccupancyrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Admit(gateID, scheduleID string, capacity int) (bool, error) {
+	// The conditional upsert takes the row lock, so concurrent scans can't overshoot the capacity
+	result := r.db.Exec(`
+		INSERT INTO gate_occupancies (id, gate_id, schedule_id, admitted, entries, exits, updated_at)
+		VALUES (?, ?, ?, 1, 1, 0, NOW())
+		ON CONFLICT (gate_id, schedule_id) DO UPDATE
+		SET admitted = gate_occupancies.admitted + 1,
+			entries = gate_occupancies.entries + 1,
+			updated_at = NOW()
+		WHERE ? = 0 OR gate_occupancies.admitted < ?
+	`, uuid.New().String(), gateID, scheduleID, capacity, capacity)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *Repository) Apply(gateID, scheduleID string, delta gate.OccupancyDelta) error {
+	return r.db.Exec(`
+		INSERT INTO gate_occupancies (id, gate_id, schedule_id, admitted, entries, exits, updated_at)
+		VALUES (?, ?, ?, GREATEST(?, 0), GREATEST(?, 0), GREATEST(?, 0), NOW())
+		ON CONFLICT (gate_id, schedule_id) DO UPDATE
+		SET admitted = GREATEST(gate_occupancies.admitted + ?, 0),
+			entries = GREATEST(gate_occupancies.entries + ?, 0),
+			exits = GREATEST(gate_occupancies.exits + ?, 0),
+			updated_at = NOW()
+	`, uuid.New().String(), gateID, scheduleID, delta.Admitted, delta.Entries, delta.Exits,
+		delta.Admitted, delta.Entries, delta.Exits).Error
+}
+
+func (r *Repository) FindByGateAndSchedule(gateID, scheduleID string) (*gate.GateOccupancy, error) {
+	var occupancy gate.GateOccupancy
+	if err := r.db.Where("gate_id = ? AND schedule_id = ?", gateID, scheduleID).First(&occupancy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrGateOccupancyNotFound)
+		}
+		return nil, err
+	}
+	return &occupancy, nil
+}
+
+func (r *Repository) List(filters map[string]interface{}) ([]*gate.GateOccupancy, error) {
+	var occupancies []*gate.GateOccupancy
+	query := r.db.Model(&gate.GateOccupancy{}).Preload("Gate")
+
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByGate(query, "gate_occupancies.gate_id")
+	}
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_occupancies.gate_id = ?", gateID)
+	}
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		query = query.Where("gate_occupancies.schedule_id = ?", scheduleID)
+	}
+	if date, ok := filters["date"]; ok && date != nil {
+		query = query.Joins("JOIN schedules ON schedules.id = gate_occupancies.schedule_id").
+			Where("schedules.date = ?", date)
+	}
+
+	if err := query.Order("gate_occupancies.gate_id, gate_occupancies.schedule_id").Find(&occupancies).Error; err != nil {
+		return nil, err
+	}
+	return occupancies, nil
+}
@@ -2,10 +2,13 @@ package gate_staff
 
 import (
 	"errors"
+	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	gatestaffrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_staff"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
@@ -23,6 +26,18 @@ func (r *Repository) Assign(gateID, staffID string) error {
 	return r.db.FirstOrCreate(assignment, "gate_id = ? AND staff_id = ?", gateID, staffID).Error
 }
 
+// AssignForShifts assigns the staff member to the gate and restricts the assignment to their shifts
+func (r *Repository) AssignForShifts(gateID, staffID string) error {
+	return assignForShifts(r.db, gateID, staffID)
+}
+
+func assignForShifts(db *gorm.DB, gateID, staffID string) error {
+	assignment := &gate.GateStaffAssignment{GateID: gateID, StaffID: staffID}
+	return db.Where("gate_id = ? AND staff_id = ?", gateID, staffID).
+		Assign(map[string]interface{}{"shift_restricted": true}).
+		FirstOrCreate(assignment).Error
+}
+
 func (r *Repository) Unassign(gateID, staffID string) error {
 	return r.db.Where("gate_id = ? AND staff_id = ?", gateID, staffID).
 		Delete(&gate.GateStaffAssignment{}).Error
@@ -65,6 +80,217 @@ func (r *Repository) ListStaffByGateID(gateID string) ([]*gate.GateStaffAssignme
 	return assignments, nil
 }
 
+// CreateShift creates the shift and assigns its staff member to the gate, checking for overlapping
+// shifts of the staff member under lock
+func (r *Repository) CreateShift(shift *gate.GateStaffShift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockStaffShifts(tx, shift.StaffID); err != nil {
+			return err
+		}
+		overlaps, err := hasOverlappingShift(tx, shift.StaffID, shift.StartsAt, shift.EndsAt, "")
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return gatestaffrepo.ErrShiftOverlap
+		}
+
+		if err := assignForShifts(tx, shift.GateID, shift.StaffID); err != nil {
+			return err
+		}
+		return tx.Create(shift).Error
+	})
+}
+
+func (r *Repository) FindShiftByID(id string) (*gate.GateStaffShift, error) {
+	var shift gate.GateStaffShift
+	if err := r.db.Preload("Gate").Preload("Staff").Where("id = ?", id).First(&shift).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrShiftNotFound)
+		}
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *Repository) UpdateShift(shift *gate.GateStaffShift) error {
+	return r.db.Omit("Gate", "Staff").Save(shift).Error
+}
+
+// HandoverShift ends the current shift and creates the follow-up shift in one transaction, assigning
+// the incoming staff member to the gate. The shift and the incoming staff member are locked, so
+// concurrent handovers neither hand the shift over twice nor give the staff member overlapping shifts.
+func (r *Repository) HandoverShift(current, next *gate.GateStaffShift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked gate.GateStaffShift
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", current.ID).First(&locked).Error; err != nil {
+			return err
+		}
+		if locked.HandedOverToID != nil {
+			return gatestaffrepo.ErrShiftHandedOver
+		}
+
+		if err := lockStaffShifts(tx, next.StaffID); err != nil {
+			return err
+		}
+		overlaps, err := hasOverlappingShift(tx, next.StaffID, next.StartsAt, next.EndsAt, "")
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return gatestaffrepo.ErrShiftOverlap
+		}
+
+		if err := assignForShifts(tx, next.GateID, next.StaffID); err != nil {
+			return err
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		current.HandedOverToID = &next.ID
+		return tx.Omit("Gate", "Staff").Save(current).Error
+	})
+}
+
+// lockStaffShifts serializes shift changes of a staff member by locking their user row, which also
+// covers shifts being inserted concurrently
+func lockStaffShifts(tx *gorm.DB, staffID string) error {
+	var ids []string
+	return tx.Raw("SELECT id FROM users WHERE id = ? FOR UPDATE", staffID).Scan(&ids).Error
+}
+
+func (r *Repository) DeleteShift(id string) error {
+	return r.db.Where("id = ?", id).Delete(&gate.GateStaffShift{}).Error
+}
+
+func (r *Repository) ListShifts(page, perPage int, filters map[string]interface{}) ([]*gate.GateStaffShift, int64, error) {
+	var shifts []*gate.GateStaffShift
+	var total int64
+
+	query := r.db.Model(&gate.GateStaffShift{})
+
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByGate(query, "gate_id")
+	}
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_id = ?", gateID)
+	}
+	if staffID, ok := filters["staff_id"]; ok && staffID != nil {
+		query = query.Where("staff_id = ?", staffID)
+	}
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		query = query.Where("schedule_id = ?", scheduleID)
+	}
+	// from/to select shifts overlapping the [from, to) window
+	if from, ok := filters["from"]; ok && from != nil {
+		query = query.Where("ends_at > ?", from)
+	}
+	if to, ok := filters["to"]; ok && to != nil {
+		query = query.Where("starts_at < ?", to)
+	}
+	if activeAt, ok := filters["active_at"]; ok && activeAt != nil {
+		query = query.Where("starts_at <= ? AND ends_at > ?", activeAt, activeAt)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("Gate").
+		Preload("Staff").
+		Offset(offset).
+		Limit(perPage).
+		Order("starts_at ASC, gate_id ASC").
+		Find(&shifts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return shifts, total, nil
+}
+
+func (r *Repository) HasOverlappingShift(staffID string, startsAt, endsAt time.Time, excludeID string) (bool, error) {
+	return hasOverlappingShift(r.db, staffID, startsAt, endsAt, excludeID)
+}
+
+func hasOverlappingShift(db *gorm.DB, staffID string, startsAt, endsAt time.Time, excludeID string) (bool, error) {
+	var count int64
+	query := db.Model(&gate.GateStaffShift{}).
+		Where("staff_id = ? AND starts_at < ? AND ends_at > ?", staffID, endsAt, startsAt)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindDuty returns the staff member's assignment to the gate, nil when they are not assigned, and
+// their shifts there covering the given instant
+func (r *Repository) FindDuty(gateID, staffID string, at time.Time) (*gate.GateStaffAssignment, []*gate.GateStaffShift, error) {
+	var assignment gate.GateStaffAssignment
+	if err := r.db.Where("gate_id = ? AND staff_id = ?", gateID, staffID).First(&assignment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var shifts []*gate.GateStaffShift
+	if err := r.db.Where("gate_id = ? AND staff_id = ? AND starts_at <= ? AND ends_at > ?", gateID, staffID, at, at).
+		Find(&shifts).Error; err != nil {
+		return nil, nil, err
+	}
+	return &assignment, shifts, nil
+}
+
+// IsStaffOnDuty reports whether the staff member is assigned to the gate and either has a shift there
+// covering the given instant and schedule (empty when unknown) or has an assignment not restricted to shifts
+func (r *Repository) IsStaffOnDuty(gateID, staffID, scheduleID string, at time.Time) (bool, error) {
+	assignment, shifts, err := r.FindDuty(gateID, staffID, at)
+	if err != nil || assignment == nil {
+		return false, err
+	}
+	_, onDuty := assignment.OnDutyAt(shifts, at, scheduleID)
+	return onDuty, nil
+}
+
+// CountShiftScans counts the gate scans each shift's staff member made at the shift's gate within the shift
+func (r *Repository) CountShiftScans(shiftIDs []string) (map[string]*gate.ShiftScanCount, error) {
+	counts := make(map[string]*gate.ShiftScanCount, len(shiftIDs))
+	if len(shiftIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []*gate.ShiftScanCount
+	err := r.db.Raw(`
+		SELECT s.id AS shift_id,
+			COUNT(l.id) FILTER (WHERE l.action = 'ENTRY' AND l.success) AS entries,
+			COUNT(l.id) FILTER (WHERE l.action = 'EXIT' AND l.success) AS exits,
+			COUNT(l.id) FILTER (WHERE NOT l.success) AS rejected
+		FROM gate_staff_shifts s
+		LEFT JOIN scan_logs l ON l.staff_id = s.staff_id
+			AND l.gate_id = s.gate_id
+			AND l.action IN ('ENTRY', 'EXIT')
+			AND l.scanned_at >= s.starts_at
+			AND l.scanned_at < s.ends_at
+		WHERE s.id IN ?
+		GROUP BY s.id`, shiftIDs).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ShiftID] = row
+	}
+	return counts, nil
+}
+
 var _ gatestaffrepo.Repository = (*Repository)(nil)
 
-var ErrNotFound = errors.New("gate staff assignment not found")
+var (
+	ErrNotFound      = errors.New("gate staff assignment not found")
+	ErrShiftNotFound = errors.New("gate staff shift not found")
+)
@@ -0,0 +1,92 @@
+package loginattempt
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	loginattemptrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/login_attempt"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new login attempt repository
+func NewRepository(db *gorm.DB) loginattemptrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(attempt *auth.LoginAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+func (r *Repository) ListByUser(userID string, page, perPage int, filters map[string]interface{}) ([]*auth.LoginAttempt, int64, error) {
+	var attempts []*auth.LoginAttempt
+	var total int64
+
+	query := r.db.Model(&auth.LoginAttempt{}).Where("user_id = ?", userID)
+	if success, ok := filters["success"].(bool); ok {
+		query = query.Where("success = ?", success)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.Order("created_at DESC").Offset(offset).Limit(perPage).Find(&attempts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, total, nil
+}
+
+func (r *Repository) CountFailuresByIP(ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&auth.LoginAttempt{}).
+		Where("ip_address = ? AND success = false AND created_at >= ?", ipAddress, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *Repository) ListSuspiciousIPs(scope organization.Scope, since time.Time, minFailures, limit int) ([]*auth.SuspiciousIP, error) {
+	var ips []*auth.SuspiciousIP
+	query := r.db.Table("login_attempts").
+		Select(`ip_address,
+			COUNT(*) AS failures,
+			COUNT(DISTINCT LOWER(email)) AS distinct_emails,
+			MAX(created_at) AS last_attempt_at`).
+		Where("success = false AND created_at >= ?", since)
+	query = scope.FilterByUser(query, "user_id")
+	err := query.
+		Group("ip_address").
+		Having("COUNT(*) >= ?", minFailures).
+		Order("failures DESC, last_attempt_at DESC").
+		Limit(limit).
+		Scan(&ips).Error
+	return ips, err
+}
+
+func (r *Repository) ListSuspiciousAccounts(scope organization.Scope, since time.Time, minFailures, limit int, now time.Time) ([]*auth.SuspiciousAccount, error) {
+	var accounts []*auth.SuspiciousAccount
+	query := r.db.Table("users").
+		Select(`users.id AS user_id,
+			users.email,
+			COUNT(login_attempts.id) FILTER (WHERE login_attempts.success = false) AS failures,
+			COUNT(DISTINCT login_attempts.ip_address) FILTER (WHERE login_attempts.success = false) AS distinct_ips,
+			users.locked_until,
+			MAX(login_attempts.created_at) AS last_attempt_at`).
+		Joins("JOIN login_attempts ON login_attempts.user_id = users.id AND login_attempts.created_at >= ?", since).
+		Where("users.deleted_at IS NULL")
+	query = scope.Filter(query, "users.organization_id")
+	err := query.
+		Group("users.id, users.email, users.locked_until").
+		Having(`COUNT(login_attempts.id) FILTER (WHERE login_attempts.success = false) >= ?
+			OR users.locked_until > ?`, minFailures, now).
+		Order("failures DESC, last_attempt_at DESC").
+		Limit(limit).
+		Scan(&accounts).Error
+	return accounts, err
+}
@@ -2,6 +2,7 @@ package merchandise
 
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/merchandise"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	merchandiserepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/merchandise"
 	"gorm.io/gorm"
 )
@@ -48,6 +49,9 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 		Where("deleted_at IS NULL")
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByEvent(query, "event_id")
+	}
 	if eventID, ok := filters["event_id"].(string); ok && eventID != "" {
 		query = query.Where("event_id = ?", eventID)
 	}
@@ -0,0 +1,73 @@
+package oidc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	oidcrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/oidc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLoginStateNotFound = errors.New("oidc login state not found")
+	ErrIdentityNotFound   = errors.New("user identity not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new OpenID Connect repository
+func NewRepository(db *gorm.DB) oidcrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) CreateState(state *auth.OIDCLoginState) error {
+	// Abandoned logins never reach the callback, so prune them here
+	if err := r.db.Where("expires_at < ?", time.Now().Add(-time.Hour)).Delete(&auth.OIDCLoginState{}).Error; err != nil {
+		return err
+	}
+	return r.db.Create(state).Error
+}
+
+// ConsumeState is a single conditional update, so a state can only be used once even under concurrent callbacks
+func (r *Repository) ConsumeState(stateHash, provider string, now time.Time) (*auth.OIDCLoginState, error) {
+	var state auth.OIDCLoginState
+	result := r.db.Model(&state).
+		Clauses(clause.Returning{}).
+		Where("state_hash = ? AND provider = ? AND consumed_at IS NULL AND expires_at > ?", stateHash, provider, now).
+		Update("consumed_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.Join(gorm.ErrRecordNotFound, ErrLoginStateNotFound)
+	}
+	return &state, nil
+}
+
+func (r *Repository) FindIdentity(provider, subject string) (*auth.UserIdentity, error) {
+	var identity auth.UserIdentity
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrIdentityNotFound)
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *Repository) CreateIdentity(identity *auth.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+func (r *Repository) TouchIdentity(id, email string, at time.Time) error {
+	return r.db.Model(&auth.UserIdentity{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"email":         email,
+			"last_login_at": at,
+		}).Error
+}
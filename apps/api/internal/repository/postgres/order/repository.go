@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	orderrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/order"
 	"gorm.io/gorm"
 )
@@ -126,6 +127,9 @@ func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]
 	query := r.db.Model(&order.Order{})
 
 	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByTicketCategory(query, "ticket_category_id")
+	}
 	if paymentStatus, ok := filters["payment_status"]; ok && paymentStatus != nil {
 		query = query.Where("payment_status = ?", paymentStatus)
 	}
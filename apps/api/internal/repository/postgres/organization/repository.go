@@ -0,0 +1,147 @@
+package organization
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	organizationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/organization"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrUnknownResource      = errors.New("unknown organization resource")
+)
+
+// ownerQueries select the owning organization of each resource kind by the resource ID
+var ownerQueries = map[organization.Resource]string{
+	organization.ResourceEvent:           "SELECT organization_id FROM events WHERE id = ? AND deleted_at IS NULL",
+	organization.ResourceTicketCategory:  "SELECT e.organization_id FROM ticket_categories tc JOIN events e ON e.id = tc.event_id WHERE tc.id = ? AND tc.deleted_at IS NULL",
+	organization.ResourceSchedule:        "SELECT e.organization_id FROM schedules s JOIN events e ON e.id = s.event_id WHERE s.id = ? AND s.deleted_at IS NULL",
+	organization.ResourceMerchandise:     "SELECT e.organization_id FROM merchandises m JOIN events e ON e.id = m.event_id WHERE m.id = ? AND m.deleted_at IS NULL",
+	organization.ResourceGate:            "SELECT organization_id FROM gates WHERE id = ? AND deleted_at IS NULL",
+	organization.ResourceGateDevice:      "SELECT g.organization_id FROM gate_devices d JOIN gates g ON g.id = d.gate_id WHERE d.id = ?",
+	organization.ResourceGateShift:       "SELECT g.organization_id FROM gate_staff_shifts s JOIN gates g ON g.id = s.gate_id WHERE s.id = ?",
+	organization.ResourceOrder:           "SELECT e.organization_id FROM orders o JOIN ticket_categories tc ON tc.id = o.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE o.id = ? AND o.deleted_at IS NULL",
+	organization.ResourceOrderCode:       "SELECT e.organization_id FROM orders o JOIN ticket_categories tc ON tc.id = o.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE o.order_code = ? AND o.deleted_at IS NULL",
+	organization.ResourceUser:            "SELECT organization_id FROM users WHERE id = ? AND deleted_at IS NULL",
+	organization.ResourceAPIKey:          "SELECT u.organization_id FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.id = ?",
+	organization.ResourceRoleBinding:     "SELECT e.organization_id FROM role_bindings b JOIN events e ON e.id = b.event_id WHERE b.id = ?",
+	organization.ResourceQuotaAllocation: "SELECT e.organization_id FROM quota_allocations qa JOIN ticket_categories tc ON tc.id = qa.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE qa.id = ?",
+	organization.ResourcePresaleBatch:    "SELECT e.organization_id FROM presale_batches pb JOIN ticket_categories tc ON tc.id = pb.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE pb.id = ?",
+	organization.ResourceBallot:          "SELECT e.organization_id FROM ballots b JOIN ticket_categories tc ON tc.id = b.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE b.id = ?",
+	organization.ResourceResaleListing:   "SELECT e.organization_id FROM resale_listings rl JOIN ticket_categories tc ON tc.id = rl.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE rl.id = ?",
+	organization.ResourceResalePayout:    "SELECT e.organization_id FROM resale_seller_payouts p JOIN resale_listings rl ON rl.id = p.listing_id JOIN ticket_categories tc ON tc.id = rl.ticket_category_id JOIN events e ON e.id = tc.event_id WHERE p.id = ?",
+	organization.ResourceFraudAlert:      "SELECT COALESCE(g.organization_id, e.organization_id) FROM fraud_alerts a LEFT JOIN gates g ON g.id = a.gate_id LEFT JOIN order_items oi ON oi.id = a.order_item_id LEFT JOIN ticket_categories tc ON tc.id = oi.category_id LEFT JOIN events e ON e.id = tc.event_id WHERE a.id = ?",
+	organization.ResourceOrderItem:       "SELECT e.organization_id FROM order_items oi JOIN ticket_categories tc ON tc.id = oi.category_id JOIN events e ON e.id = tc.event_id WHERE oi.id = ? AND oi.deleted_at IS NULL",
+	organization.ResourceQRCode:          "SELECT e.organization_id FROM order_items oi JOIN ticket_categories tc ON tc.id = oi.category_id JOIN events e ON e.id = tc.event_id WHERE oi.qr_code = ? AND oi.deleted_at IS NULL",
+	organization.ResourceCheckIn:         "SELECT e.organization_id FROM check_ins ci JOIN order_items oi ON oi.id = ci.order_item_id JOIN ticket_categories tc ON tc.id = oi.category_id JOIN events e ON e.id = tc.event_id WHERE ci.id = ? AND ci.deleted_at IS NULL",
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new organization repository
+func NewRepository(db *gorm.DB) organizationrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindByID finds an organization by ID
+func (r *Repository) FindByID(id string) (*organization.Organization, error) {
+	var o organization.Organization
+	if err := r.db.Where("id = ?", id).First(&o).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrOrganizationNotFound)
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+// FindByCode finds an organization by code
+func (r *Repository) FindByCode(code string) (*organization.Organization, error) {
+	var o organization.Organization
+	if err := r.db.Where("code = ?", code).First(&o).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrOrganizationNotFound)
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Create creates a new organization
+func (r *Repository) Create(o *organization.Organization) error {
+	return r.db.Create(o).Error
+}
+
+// Update updates an organization
+func (r *Repository) Update(o *organization.Organization) error {
+	return r.db.Save(o).Error
+}
+
+// List lists organizations with filters and pagination
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*organization.Organization, int64, error) {
+	var organizations []*organization.Organization
+	var total int64
+
+	query := r.db.Model(&organization.Organization{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.Filter(query, "id")
+	}
+	if status, ok := filters["status"].(string); ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search, ok := filters["search"].(string); ok && search != "" {
+		searchStr := fmt.Sprintf("%%%s%%", strings.TrimSpace(search))
+		query = query.Where("name ILIKE ? OR code ILIKE ?", searchStr, searchStr)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * perPage
+	if err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&organizations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return organizations, total, nil
+}
+
+// FindOwner returns the ID of the organization owning a resource
+func (r *Repository) FindOwner(resource organization.Resource, id string) (string, error) {
+	query, ok := ownerQueries[resource]
+	if !ok {
+		return "", ErrUnknownResource
+	}
+	// Malformed IDs match nothing rather than failing the query
+	if resource != organization.ResourceOrderCode && resource != organization.ResourceQRCode {
+		if _, err := uuid.Parse(id); err != nil {
+			return "", gorm.ErrRecordNotFound
+		}
+	}
+
+	var owner []sql.NullString
+	if err := r.db.Raw(query, id).Scan(&owner).Error; err != nil {
+		return "", err
+	}
+	if len(owner) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return owner[0].String, nil
+}
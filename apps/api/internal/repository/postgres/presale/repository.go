@@ -0,0 +1,224 @@
+package presale
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+	presalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/presale"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPresaleBatchNotFound = errors.New("presale batch not found")
+)
+
+// codeInsertBatchSize bounds the number of rows per INSERT when generating codes
+const codeInsertBatchSize = 500
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new presale repository
+func NewRepository(db *gorm.DB) presalerepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindBatchByID finds a presale batch by ID
+func (r *Repository) FindBatchByID(id string) (*presale.PresaleBatch, error) {
+	var batch presale.PresaleBatch
+	if err := r.db.Where("id = ?", id).Preload("TicketCategory").First(&batch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrPresaleBatchNotFound)
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// CreateBatch creates a presale batch together with its codes
+func (r *Repository) CreateBatch(batch *presale.PresaleBatch, codes []*presale.PresaleCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(batch).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		for _, code := range codes {
+			code.BatchID = batch.ID
+		}
+		return tx.CreateInBatches(codes, codeInsertBatchSize).Error
+	})
+}
+
+// UpdateBatch updates a presale batch
+func (r *Repository) UpdateBatch(batch *presale.PresaleBatch) error {
+	return r.db.Omit("TicketCategory").Save(batch).Error
+}
+
+// ListBatches lists presale batches with filters
+func (r *Repository) ListBatches(page, perPage int, filters map[string]interface{}) ([]*presale.PresaleBatch, int64, error) {
+	var batches []*presale.PresaleBatch
+	var total int64
+
+	query := r.db.Model(&presale.PresaleBatch{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByTicketCategory(query, "presale_batches.ticket_category_id")
+	}
+	if ticketCategoryID, ok := filters["ticket_category_id"]; ok && ticketCategoryID != nil {
+		query = query.Where("presale_batches.ticket_category_id = ?", ticketCategoryID)
+	}
+	if eventID, ok := filters["event_id"]; ok && eventID != nil {
+		query = query.Joins("JOIN ticket_categories ON ticket_categories.id = presale_batches.ticket_category_id").
+			Where("ticket_categories.event_id = ?", eventID)
+	}
+	if isActive, ok := filters["is_active"]; ok && isActive != nil {
+		query = query.Where("presale_batches.is_active = ?", isActive)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and preloads
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("TicketCategory").
+		Offset(offset).
+		Limit(perPage).
+		Order("presale_batches.created_at DESC").
+		Find(&batches).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return batches, total, nil
+}
+
+// AddCodes appends codes to an existing batch and updates its code count
+func (r *Repository) AddCodes(batch *presale.PresaleBatch, codes []*presale.PresaleCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, code := range codes {
+			code.BatchID = batch.ID
+		}
+		if err := tx.CreateInBatches(codes, codeInsertBatchSize).Error; err != nil {
+			return err
+		}
+		return tx.Model(&presale.PresaleBatch{}).
+			Where("id = ?", batch.ID).
+			UpdateColumn("code_count", gorm.Expr("code_count + ?", len(codes))).Error
+	})
+}
+
+// ListCodes lists codes of a batch with filters
+func (r *Repository) ListCodes(batchID string, page, perPage int, filters map[string]interface{}) ([]*presale.PresaleCode, int64, error) {
+	var codes []*presale.PresaleCode
+	var total int64
+
+	query := r.db.Model(&presale.PresaleCode{}).Where("batch_id = ?", batchID)
+
+	// Apply filters
+	if search, ok := filters["search"]; ok && search != nil {
+		query = query.Where("code ILIKE ?", "%"+search.(string)+"%")
+	}
+	if used, ok := filters["used"]; ok && used != nil {
+		if used.(bool) {
+			query = query.Where("used_count > 0")
+		} else {
+			query = query.Where("used_count = 0")
+		}
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * perPage
+	if err := query.
+		Offset(offset).
+		Limit(perPage).
+		Order("created_at ASC, code ASC").
+		Find(&codes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return codes, total, nil
+}
+
+// FindCodesByBatchID finds all codes of a batch (for export)
+func (r *Repository) FindCodesByBatchID(batchID string) ([]*presale.PresaleCode, error) {
+	var codes []*presale.PresaleCode
+	if err := r.db.Where("batch_id = ?", batchID).
+		Order("created_at ASC, code ASC").
+		Find(&codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// GetBatchReport gets redemption statistics for a batch
+func (r *Repository) GetBatchReport(batch *presale.PresaleBatch) (*presale.PresaleBatchReport, error) {
+	var codeStats struct {
+		TotalCodes     int64 `gorm:"column:total_codes"`
+		RedeemedCodes  int64 `gorm:"column:redeemed_codes"`
+		ExhaustedCodes int64 `gorm:"column:exhausted_codes"`
+		TotalUses      int64 `gorm:"column:total_uses"`
+		UsedCount      int64 `gorm:"column:used_count"`
+	}
+	if err := r.db.Model(&presale.PresaleCode{}).
+		Select(`COUNT(*) AS total_codes,
+			COUNT(*) FILTER (WHERE used_count > 0) AS redeemed_codes,
+			COUNT(*) FILTER (WHERE used_count >= max_uses) AS exhausted_codes,
+			COALESCE(SUM(max_uses), 0) AS total_uses,
+			COALESCE(SUM(used_count), 0) AS used_count`).
+		Where("batch_id = ?", batch.ID).
+		Scan(&codeStats).Error; err != nil {
+		return nil, err
+	}
+
+	var orderStats struct {
+		Orders      int64   `gorm:"column:orders"`
+		PaidOrders  int64   `gorm:"column:paid_orders"`
+		TicketsSold int64   `gorm:"column:tickets_sold"`
+		Revenue     float64 `gorm:"column:revenue"`
+	}
+	if err := r.db.Model(&order.Order{}).
+		Select(`COUNT(*) AS orders,
+			COUNT(*) FILTER (WHERE payment_status = ?) AS paid_orders,
+			COALESCE(SUM(quantity) FILTER (WHERE payment_status = ?), 0) AS tickets_sold,
+			COALESCE(SUM(total_amount) FILTER (WHERE payment_status = ?), 0) AS revenue`,
+			order.PaymentStatusPaid, order.PaymentStatusPaid, order.PaymentStatusPaid).
+		Where("presale_code_id IN (?)", r.db.Model(&presale.PresaleCode{}).Select("id").Where("batch_id = ?", batch.ID)).
+		Scan(&orderStats).Error; err != nil {
+		return nil, err
+	}
+
+	redemptionRate := 0.0
+	if codeStats.TotalCodes > 0 {
+		redemptionRate = float64(codeStats.RedeemedCodes) / float64(codeStats.TotalCodes) * 100.0
+	}
+
+	return &presale.PresaleBatchReport{
+		BatchID:        batch.ID,
+		Name:           batch.Name,
+		TotalCodes:     int(codeStats.TotalCodes),
+		RedeemedCodes:  int(codeStats.RedeemedCodes),
+		ExhaustedCodes: int(codeStats.ExhaustedCodes),
+		TotalUses:      int(codeStats.TotalUses),
+		UsedCount:      int(codeStats.UsedCount),
+		RedemptionRate: redemptionRate,
+		Orders:         int(orderStats.Orders),
+		PaidOrders:     int(orderStats.PaidOrders),
+		TicketsSold:    int(orderStats.TicketsSold),
+		Revenue:        orderStats.Revenue,
+	}, nil
+}
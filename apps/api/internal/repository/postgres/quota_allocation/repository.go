@@ -0,0 +1,126 @@
+package quotaallocation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
+	quotaallocationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/quota_allocation"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrQuotaAllocationNotFound = errors.New("quota allocation not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new quota allocation repository
+func NewRepository(db *gorm.DB) quotaallocationrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindByID finds a quota allocation by ID
+func (r *Repository) FindByID(id string) (*quotaallocation.QuotaAllocation, error) {
+	var qa quotaallocation.QuotaAllocation
+	if err := r.db.Where("id = ?", id).Preload("TicketCategory").First(&qa).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrQuotaAllocationNotFound)
+		}
+		return nil, err
+	}
+	return &qa, nil
+}
+
+// FindByAccessCode finds a quota allocation by access code
+func (r *Repository) FindByAccessCode(code string) (*quotaallocation.QuotaAllocation, error) {
+	var qa quotaallocation.QuotaAllocation
+	if err := r.db.Where("access_code = ?", code).Preload("TicketCategory").First(&qa).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrQuotaAllocationNotFound)
+		}
+		return nil, err
+	}
+	return &qa, nil
+}
+
+// FindByTicketCategoryID finds quota allocations by ticket category ID
+func (r *Repository) FindByTicketCategoryID(ticketCategoryID string) ([]*quotaallocation.QuotaAllocation, error) {
+	var allocations []*quotaallocation.QuotaAllocation
+	if err := r.db.Where("ticket_category_id = ?", ticketCategoryID).
+		Order("created_at ASC").
+		Find(&allocations).Error; err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// FindExpiredActive finds active allocations whose expiry time has passed
+func (r *Repository) FindExpiredActive(now time.Time) ([]*quotaallocation.QuotaAllocation, error) {
+	var allocations []*quotaallocation.QuotaAllocation
+	if err := r.db.Where("status = ?", quotaallocation.AllocationStatusActive).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Find(&allocations).Error; err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// Create creates a new quota allocation
+func (r *Repository) Create(qa *quotaallocation.QuotaAllocation) error {
+	return r.db.Create(qa).Error
+}
+
+// Update updates a quota allocation
+func (r *Repository) Update(qa *quotaallocation.QuotaAllocation) error {
+	return r.db.Save(qa).Error
+}
+
+// List lists quota allocations with filters
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*quotaallocation.QuotaAllocation, int64, error) {
+	var allocations []*quotaallocation.QuotaAllocation
+	var total int64
+
+	query := r.db.Model(&quotaallocation.QuotaAllocation{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByTicketCategory(query, "quota_allocations.ticket_category_id")
+	}
+	if ticketCategoryID, ok := filters["ticket_category_id"]; ok && ticketCategoryID != nil {
+		query = query.Where("quota_allocations.ticket_category_id = ?", ticketCategoryID)
+	}
+	if eventID, ok := filters["event_id"]; ok && eventID != nil {
+		query = query.Joins("JOIN ticket_categories ON ticket_categories.id = quota_allocations.ticket_category_id").
+			Where("ticket_categories.event_id = ?", eventID)
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("quota_allocations.status = ?", status)
+	}
+	if ownerType, ok := filters["owner_type"]; ok && ownerType != nil {
+		query = query.Where("quota_allocations.owner_type = ?", ownerType)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and preloads
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("TicketCategory").
+		Offset(offset).
+		Limit(perPage).
+		Order("quota_allocations.created_at DESC").
+		Find(&allocations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return allocations, total, nil
+}
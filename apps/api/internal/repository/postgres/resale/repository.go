@@ -0,0 +1,186 @@
+package resale
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
+	resalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/resale"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrListingNotFound = errors.New("resale listing not found")
+	ErrPayoutNotFound  = errors.New("seller payout not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new resale repository
+func NewRepository(db *gorm.DB) resalerepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindListingByID finds a resale listing by ID
+func (r *Repository) FindListingByID(id string) (*resale.ResaleListing, error) {
+	var l resale.ResaleListing
+	if err := r.db.Where("id = ?", id).Preload("TicketCategory").First(&l).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrListingNotFound)
+		}
+		return nil, err
+	}
+	return &l, nil
+}
+
+// FindListingsBySellerID finds all listings of a seller
+func (r *Repository) FindListingsBySellerID(sellerID string) ([]*resale.ResaleListing, error) {
+	var listings []*resale.ResaleListing
+	if err := r.db.Where("seller_id = ?", sellerID).
+		Preload("TicketCategory").
+		Order("created_at DESC").
+		Find(&listings).Error; err != nil {
+		return nil, err
+	}
+	return listings, nil
+}
+
+// UpdateListing updates a resale listing
+func (r *Repository) UpdateListing(l *resale.ResaleListing) error {
+	return r.db.Omit("TicketCategory").Save(l).Error
+}
+
+// ListListings lists resale listings with filters
+func (r *Repository) ListListings(page, perPage int, filters map[string]interface{}) ([]*resale.ResaleListing, int64, error) {
+	var listings []*resale.ResaleListing
+	var total int64
+
+	query := r.db.Model(&resale.ResaleListing{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByTicketCategory(query, "ticket_category_id")
+	}
+	if scheduleID, ok := filters["schedule_id"]; ok && scheduleID != nil {
+		query = query.Where("schedule_id = ?", scheduleID)
+	}
+	if ticketCategoryID, ok := filters["ticket_category_id"]; ok && ticketCategoryID != nil {
+		query = query.Where("ticket_category_id = ?", ticketCategoryID)
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("status = ?", status)
+	}
+	if sellerID, ok := filters["seller_id"]; ok && sellerID != nil {
+		query = query.Where("seller_id = ?", sellerID)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination; cheapest first so buyers see the best offers
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("TicketCategory").
+		Offset(offset).
+		Limit(perPage).
+		Order("price ASC, created_at ASC").
+		Find(&listings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return listings, total, nil
+}
+
+// FindPayoutByID finds a seller payout by ID
+func (r *Repository) FindPayoutByID(id string) (*resale.SellerPayout, error) {
+	var p resale.SellerPayout
+	if err := r.db.Where("id = ?", id).First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrPayoutNotFound)
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindPayoutsBySellerID finds all payouts of a seller
+func (r *Repository) FindPayoutsBySellerID(sellerID string) ([]*resale.SellerPayout, error) {
+	var payouts []*resale.SellerPayout
+	if err := r.db.Where("seller_id = ?", sellerID).
+		Order("created_at DESC").
+		Find(&payouts).Error; err != nil {
+		return nil, err
+	}
+	return payouts, nil
+}
+
+// UpdatePayout updates a seller payout
+func (r *Repository) UpdatePayout(p *resale.SellerPayout) error {
+	return r.db.Save(p).Error
+}
+
+// ListPayouts lists seller payouts with filters
+func (r *Repository) ListPayouts(page, perPage int, filters map[string]interface{}) ([]*resale.SellerPayout, int64, error) {
+	var payouts []*resale.SellerPayout
+	var total int64
+
+	query := applyPayoutFilters(r.db.Model(&resale.SellerPayout{}), filters)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * perPage
+	if err := query.
+		Offset(offset).
+		Limit(perPage).
+		Order("created_at DESC").
+		Find(&payouts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return payouts, total, nil
+}
+
+// GetPayoutSummary aggregates payouts for reconciliation
+func (r *Repository) GetPayoutSummary(filters map[string]interface{}) (*resale.PayoutSummary, error) {
+	var summary resale.PayoutSummary
+	if err := applyPayoutFilters(r.db.Model(&resale.SellerPayout{}), filters).
+		Select(`COUNT(*) AS sales,
+			COALESCE(SUM(gross_amount), 0) AS gross_amount,
+			COALESCE(SUM(platform_fee), 0) AS platform_fees,
+			COALESCE(SUM(net_amount), 0) AS net_amount,
+			COUNT(*) FILTER (WHERE status = ?) AS pending_payouts,
+			COALESCE(SUM(net_amount) FILTER (WHERE status = ?), 0) AS pending_amount,
+			COUNT(*) FILTER (WHERE status = ?) AS paid_payouts,
+			COALESCE(SUM(net_amount) FILTER (WHERE status = ?), 0) AS paid_amount`,
+			resale.PayoutStatusPending, resale.PayoutStatusPending, resale.PayoutStatusPaid, resale.PayoutStatusPaid).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// applyPayoutFilters applies the shared payout list/summary filters
+func applyPayoutFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	// Payouts belong to the organization of the listing's ticket category
+	if scope, ok := filters["scope"].(organization.Scope); ok && !scope.Global() {
+		listings := query.Session(&gorm.Session{NewDB: true}).Model(&resale.ResaleListing{}).Select("id")
+		query = query.Where("listing_id IN (?)", scope.FilterByTicketCategory(listings, "ticket_category_id"))
+	}
+	if status, ok := filters["status"]; ok && status != nil {
+		query = query.Where("status = ?", status)
+	}
+	if sellerID, ok := filters["seller_id"]; ok && sellerID != nil {
+		query = query.Where("seller_id = ?", sellerID)
+	}
+	return query
+}
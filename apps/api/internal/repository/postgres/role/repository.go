@@ -83,6 +83,13 @@ func (r *Repository) HasPermission(roleID string, permissionCode string) (bool,
 	return count > 0, err
 }
 
+// IncrementPermissionsVersion bumps the permissions version of a role after its permissions changed
+func (r *Repository) IncrementPermissionsVersion(roleID string) error {
+	return r.db.Model(&role.Role{}).
+		Where("id = ?", roleID).
+		UpdateColumn("permissions_version", gorm.Expr("permissions_version + ?", 1)).Error
+}
+
 // List returns a list of roles with pagination
 func (r *Repository) List(req *role.ListRolesRequest) ([]role.Role, int64, error) {
 	var roles []role.Role
@@ -0,0 +1,149 @@
+package rolebinding
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	rolebindingrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role_binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleBindingNotFound    = errors.New("role binding not found")
+	ErrUnknownContextResource = errors.New("unknown event context resource")
+)
+
+// eventQueries select the event of each context resource kind by the resource ID
+var eventQueries = map[role.ContextResource]string{
+	role.ContextEvent:          "SELECT id FROM events WHERE id = ? AND deleted_at IS NULL",
+	role.ContextSchedule:       "SELECT event_id FROM schedules WHERE id = ? AND deleted_at IS NULL",
+	role.ContextTicketCategory: "SELECT event_id FROM ticket_categories WHERE id = ? AND deleted_at IS NULL",
+	role.ContextGateShift:      "SELECT s.event_id FROM gate_staff_shifts gs JOIN schedules s ON s.id = gs.schedule_id WHERE gs.id = ?",
+	role.ContextCheckIn:        "SELECT s.event_id FROM check_ins c JOIN schedules s ON s.id = c.schedule_id WHERE c.id = ? AND c.deleted_at IS NULL",
+}
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new role binding repository
+func NewRepository(db *gorm.DB) rolebindingrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// FindByID finds a role binding by ID
+func (r *Repository) FindByID(id string) (*role.RoleBinding, error) {
+	return r.find("id = ?", id)
+}
+
+// FindByUserRoleAndEvent finds the binding of a role to a user within an event
+func (r *Repository) FindByUserRoleAndEvent(userID, roleID, eventID string) (*role.RoleBinding, error) {
+	return r.find("user_id = ? AND role_id = ? AND event_id = ?", userID, roleID, eventID)
+}
+
+func (r *Repository) find(query string, args ...interface{}) (*role.RoleBinding, error) {
+	var b role.RoleBinding
+	if err := r.db.Preload("Role").Where(query, args...).First(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrRoleBindingNotFound)
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Create creates a new role binding
+func (r *Repository) Create(b *role.RoleBinding) error {
+	return r.db.Create(b).Error
+}
+
+// Delete deletes a role binding
+func (r *Repository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&role.RoleBinding{}).Error
+}
+
+// DeleteByRole deletes every binding of a role
+func (r *Repository) DeleteByRole(roleID string) error {
+	return r.db.Where("role_id = ?", roleID).Delete(&role.RoleBinding{}).Error
+}
+
+// List lists role bindings with filters and pagination
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*role.RoleBinding, int64, error) {
+	var bindings []*role.RoleBinding
+	var total int64
+
+	query := r.db.Model(&role.RoleBinding{})
+
+	// Apply filters
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByEvent(query, "event_id")
+	}
+	if roleID, ok := filters["role_id"].(string); ok && roleID != "" {
+		query = query.Where("role_id = ?", roleID)
+	}
+	if userID, ok := filters["user_id"].(string); ok && userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if eventID, ok := filters["event_id"].(string); ok && eventID != "" {
+		query = query.Where("event_id = ?", eventID)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := (page - 1) * perPage
+	if err := query.
+		Preload("Role").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(perPage).
+		Find(&bindings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return bindings, total, nil
+}
+
+// ListRoleIDs returns the roles bound to a user within an event, or within any event when
+// eventID is empty
+func (r *Repository) ListRoleIDs(userID, eventID string) ([]string, error) {
+	var roleIDs []string
+	query := r.db.Model(&role.RoleBinding{}).
+		Joins("INNER JOIN roles ON roles.id = role_bindings.role_id AND roles.deleted_at IS NULL").
+		Where("role_bindings.user_id = ?", userID)
+	if eventID != "" {
+		query = query.Where("role_bindings.event_id = ?", eventID)
+	}
+	err := query.Distinct().Pluck("role_bindings.role_id", &roleIDs).Error
+	return roleIDs, err
+}
+
+// FindEvent returns the ID of the event a context resource belongs to
+func (r *Repository) FindEvent(resource role.ContextResource, id string) (string, error) {
+	query, ok := eventQueries[resource]
+	if !ok {
+		return "", ErrUnknownContextResource
+	}
+	// Malformed IDs match nothing rather than failing the query
+	if _, err := uuid.Parse(id); err != nil {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	var eventID []sql.NullString
+	if err := r.db.Raw(query, id).Scan(&eventID).Error; err != nil {
+		return "", err
+	}
+	// Shifts and check-ins without a schedule belong to no event
+	if len(eventID) == 0 || !eventID[0].Valid {
+		return "", gorm.ErrRecordNotFound
+	}
+	return eventID[0].String, nil
+}
@@ -0,0 +1,154 @@
+package scanlog
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	scanlogrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/scan_log"
+	"gorm.io/gorm"
+)
+
+// exportLimit caps the number of rows in a single CSV export
+const exportLimit = 50000
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new scan log repository
+func NewRepository(db *gorm.DB) scanlogrepo.Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Create appends a scan attempt
+func (r *Repository) Create(log *scanlog.ScanLog) error {
+	return r.db.Create(log).Error
+}
+
+// applyFilters applies list/export filters to a scan log query
+func (r *Repository) applyFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	if scope, ok := filters["scope"].(organization.Scope); ok {
+		query = scope.FilterByGateOrOrderItem(query, "gate_id", "order_item_id")
+	}
+	if action, ok := filters["action"]; ok && action != nil {
+		query = query.Where("action = ?", action)
+	}
+	if gateID, ok := filters["gate_id"]; ok && gateID != nil {
+		query = query.Where("gate_id = ?", gateID)
+	}
+	if deviceID, ok := filters["device_id"]; ok && deviceID != nil {
+		query = query.Where("device_id = ?", deviceID)
+	}
+	if staffID, ok := filters["staff_id"]; ok && staffID != nil {
+		query = query.Where("staff_id = ?", staffID)
+	}
+	if orderItemID, ok := filters["order_item_id"]; ok && orderItemID != nil {
+		query = query.Where("order_item_id = ?", orderItemID)
+	}
+	if rawCode, ok := filters["raw_code"]; ok && rawCode != nil {
+		query = query.Where("raw_code = ?", rawCode)
+	}
+	if resultCode, ok := filters["result_code"]; ok && resultCode != nil {
+		query = query.Where("result_code = ?", resultCode)
+	}
+	if success, ok := filters["success"]; ok && success != nil {
+		query = query.Where("success = ?", success)
+	}
+	if startDate, ok := filters["start_date"]; ok && startDate != nil {
+		query = query.Where("scanned_at >= ?", startDate)
+	}
+	if endDate, ok := filters["end_date"]; ok && endDate != nil {
+		query = query.Where("scanned_at <= ?", endDate)
+	}
+	return query
+}
+
+// List lists scan attempts with filters, newest first
+func (r *Repository) List(page, perPage int, filters map[string]interface{}) ([]*scanlog.ScanLog, int64, error) {
+	var logs []*scanlog.ScanLog
+	var total int64
+
+	query := r.applyFilters(r.db.Model(&scanlog.ScanLog{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	if err := query.
+		Offset(offset).
+		Limit(perPage).
+		Order("scanned_at DESC").
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// Count counts scan attempts matching the filters
+func (r *Repository) Count(filters map[string]interface{}) (int64, error) {
+	var count int64
+	err := r.applyFilters(r.db.Model(&scanlog.ScanLog{}), filters).Count(&count).Error
+	return count, err
+}
+
+// FindSuccessfulByRawCode finds accepted scans of a code since the given time, oldest first
+func (r *Repository) FindSuccessfulByRawCode(rawCode string, since time.Time) ([]*scanlog.ScanLog, error) {
+	var logs []*scanlog.ScanLog
+	err := r.db.
+		Where("raw_code = ? AND success = ? AND scanned_at >= ?", rawCode, true, since).
+		Order("scanned_at ASC").
+		Find(&logs).Error
+	return logs, err
+}
+
+// Export returns scan attempts matching the filters as CSV rows (header first)
+func (r *Repository) Export(filters map[string]interface{}) ([][]string, error) {
+	var logs []*scanlog.ScanLog
+	if err := r.applyFilters(r.db.Model(&scanlog.ScanLog{}), filters).
+		Limit(exportLimit).
+		Order("scanned_at ASC").
+		Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	// CSV header
+	result := [][]string{
+		{"ID", "Scanned At", "Action", "Raw Code", "Order Item ID", "Schedule ID", "Gate ID", "Device ID", "Staff ID", "Success", "Result Code", "Message", "Latency (ms)", "IP Address", "User Agent"},
+	}
+
+	// CSV rows
+	for _, l := range logs {
+		result = append(result, []string{
+			l.ID,
+			l.ScannedAt.Format("2006-01-02 15:04:05.000"),
+			string(l.Action),
+			l.RawCode,
+			stringValue(l.OrderItemID),
+			stringValue(l.ScheduleID),
+			stringValue(l.GateID),
+			l.DeviceID,
+			stringValue(l.StaffID),
+			strconv.FormatBool(l.Success),
+			l.ResultCode,
+			l.Message,
+			strconv.FormatInt(l.LatencyMs, 10),
+			l.IPAddress,
+			l.UserAgent,
+		})
+	}
+
+	return result, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
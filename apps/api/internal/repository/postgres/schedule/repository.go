@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/schedule"
 	"gorm.io/gorm"
@@ -70,10 +71,11 @@ func (r *Repository) Delete(id string) error {
 	return r.db.Where("id = ?", id).Delete(&schedule.Schedule{}).Error
 }
 
-// List lists all schedules
-func (r *Repository) List() ([]*schedule.Schedule, error) {
+// List lists the schedules within the organization scope
+func (r *Repository) List(scope organization.Scope) ([]*schedule.Schedule, error) {
 	var schedules []*schedule.Schedule
-	if err := r.db.Preload("Event").Order("date ASC, start_time ASC").Find(&schedules).Error; err != nil {
+	query := scope.FilterByEvent(r.db, "event_id")
+	if err := query.Preload("Event").Order("date ASC, start_time ASC").Find(&schedules).Error; err != nil {
 		return nil, err
 	}
 	return schedules, nil
@@ -0,0 +1,94 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	sessionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/session"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) sessionrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(session *auth.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *Repository) FindByID(id string) (*auth.Session, error) {
+	var session auth.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrSessionNotFound)
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate is a compare-and-swap on the current refresh token ID, so two concurrent refreshes
+// with the same token cannot both succeed
+func (r *Repository) Rotate(id, currentTokenID, newTokenID string, fields map[string]interface{}) (bool, error) {
+	updates := map[string]interface{}{"refresh_token_id": newTokenID}
+	for k, v := range fields {
+		updates[k] = v
+	}
+
+	result := r.db.Model(&auth.Session{}).
+		Where("id = ? AND refresh_token_id = ? AND revoked_at IS NULL", id, currentTokenID).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *Repository) Revoke(id, reason string, now time.Time) error {
+	return r.db.Model(&auth.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+		}).Error
+}
+
+func (r *Repository) RevokeAllByUser(userID, reason string, now time.Time) (int64, error) {
+	result := r.db.Model(&auth.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *Repository) ListActiveByUser(userID string, now time.Time) ([]*auth.Session, error) {
+	var sessions []*auth.Session
+	if err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *Repository) IsActive(id string, now time.Time) (bool, error) {
+	var count int64
+	if err := r.db.Model(&auth.Session{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, now).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,105 @@
+package signingkey
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	signingkeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/signing_key"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+)
+
+// rotationLockID is the transaction advisory lock that serializes key rotations across instances
+const rotationLockID = 4520451
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new signing key repository
+func NewRepository(db *gorm.DB) signingkeyrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) FindActive() (*auth.SigningKey, error) {
+	return findActive(r.db)
+}
+
+func (r *Repository) ListVerifiable(now time.Time) ([]*auth.SigningKey, error) {
+	var keys []*auth.SigningKey
+	err := r.db.Where("retired_at IS NULL OR expires_at > ?", now).
+		Order("activated_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *Repository) List() ([]*auth.SigningKey, error) {
+	var keys []*auth.SigningKey
+	err := r.db.Order("activated_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *Repository) Rotate(key *auth.SigningKey, now, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", rotationLockID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&auth.SigningKey{}).
+			Where("retired_at IS NULL").
+			Updates(map[string]interface{}{
+				"retired_at": now,
+				"expires_at": expiresAt,
+			}).Error; err != nil {
+			return err
+		}
+		// A shorter grace period also cuts short keys retired by earlier rotations
+		if err := tx.Model(&auth.SigningKey{}).
+			Where("expires_at > ?", expiresAt).
+			Update("expires_at", expiresAt).Error; err != nil {
+			return err
+		}
+		key.ActivatedAt = now
+		return tx.Create(key).Error
+	})
+}
+
+func (r *Repository) CreateIfNone(key *auth.SigningKey) (*auth.SigningKey, error) {
+	var active *auth.SigningKey
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", rotationLockID).Error; err != nil {
+			return err
+		}
+		existing, err := findActive(tx)
+		if err == nil {
+			active = existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := tx.Create(key).Error; err != nil {
+			return err
+		}
+		active = key
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+func findActive(db *gorm.DB) (*auth.SigningKey, error) {
+	var key auth.SigningKey
+	if err := db.Where("retired_at IS NULL").Order("activated_at DESC").First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrSigningKeyNotFound)
+		}
+		return nil, err
+	}
+	return &key, nil
+}
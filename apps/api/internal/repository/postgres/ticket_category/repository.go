@@ -3,6 +3,7 @@ package ticketcategory
 import (
 	"errors"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
 	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ticket_category"
 	"gorm.io/gorm"
@@ -26,7 +27,7 @@ func NewRepository(db *gorm.DB) ticketcategoryrepo.Repository {
 // FindByID finds a ticket category by ID
 func (r *Repository) FindByID(id string) (*ticketcategory.TicketCategory, error) {
 	var tc ticketcategory.TicketCategory
-	if err := r.db.Where("id = ?", id).Preload("Event").First(&tc).Error; err != nil {
+	if err := r.db.Where("id = ?", id).Preload("Event").Preload("PassSchedules").First(&tc).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.Join(gorm.ErrRecordNotFound, ErrTicketCategoryNotFound)
 		}
@@ -38,7 +39,7 @@ func (r *Repository) FindByID(id string) (*ticketcategory.TicketCategory, error)
 // FindByEventID finds ticket categories by event ID
 func (r *Repository) FindByEventID(eventID string) ([]*ticketcategory.TicketCategory, error) {
 	var categories []*ticketcategory.TicketCategory
-	if err := r.db.Where("event_id = ?", eventID).Preload("Event").Find(&categories).Error; err != nil {
+	if err := r.db.Where("event_id = ?", eventID).Preload("Event").Preload("PassSchedules").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
@@ -51,7 +52,8 @@ func (r *Repository) Create(tc *ticketcategory.TicketCategory) error {
 
 // Update updates a ticket category
 func (r *Repository) Update(tc *ticketcategory.TicketCategory) error {
-	return r.db.Save(tc).Error
+	// Pass schedules are managed by ReplacePassSchedules
+	return r.db.Omit("PassSchedules").Save(tc).Error
 }
 
 // Delete soft deletes a ticket category
@@ -59,16 +61,31 @@ func (r *Repository) Delete(id string) error {
 	return r.db.Where("id = ?", id).Delete(&ticketcategory.TicketCategory{}).Error
 }
 
-// List lists all ticket categories
-func (r *Repository) List() ([]*ticketcategory.TicketCategory, error) {
+// List lists the ticket categories within the organization scope
+func (r *Repository) List(scope organization.Scope) ([]*ticketcategory.TicketCategory, error) {
 	var categories []*ticketcategory.TicketCategory
-	if err := r.db.Preload("Event").Find(&categories).Error; err != nil {
+	query := scope.FilterByEvent(r.db, "event_id")
+	if err := query.Preload("Event").Preload("PassSchedules").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
 }
 
-
-
-
-
+// ReplacePassSchedules replaces the schedules a pass category is valid for
+func (r *Repository) ReplacePassSchedules(categoryID string, scheduleIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ticket_category_id = ?", categoryID).Delete(&ticketcategory.PassSchedule{}).Error; err != nil {
+			return err
+		}
+		for _, scheduleID := range scheduleIDs {
+			ps := &ticketcategory.PassSchedule{
+				TicketCategoryID: categoryID,
+				ScheduleID:       scheduleID,
+			}
+			if err := tx.Create(ps).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,125 @@
+package twofactor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	twofactorrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/two_factor"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTwoFactorNotFound = errors.New("two-factor enrollment not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new two-factor repository
+func NewRepository(db *gorm.DB) twofactorrepo.Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) FindByUserID(userID string) (*auth.TwoFactor, error) {
+	var tf auth.TwoFactor
+	if err := r.db.Where("user_id = ?", userID).First(&tf).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrTwoFactorNotFound)
+		}
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// SavePending never touches an enabled enrollment; the service checks that beforehand
+func (r *Repository) SavePending(userID, secretEncrypted string) (*auth.TwoFactor, error) {
+	var tf auth.TwoFactor
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND enabled_at IS NULL", userID).Delete(&auth.TwoFactor{}).Error; err != nil {
+			return err
+		}
+		tf = auth.TwoFactor{
+			UserID:          userID,
+			SecretEncrypted: secretEncrypted,
+		}
+		return tx.Create(&tf).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+func (r *Repository) Enable(userID string, step int64, at time.Time, codes []*auth.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&auth.TwoFactor{}).
+			Where("user_id = ? AND enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"enabled_at":     at,
+				"last_used_step": step,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.Join(gorm.ErrRecordNotFound, ErrTwoFactorNotFound)
+		}
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+// AdvanceStep is a single conditional update, so concurrent logins cannot both use the same code
+func (r *Repository) AdvanceStep(userID string, step int64) (bool, error) {
+	result := r.db.Model(&auth.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *Repository) Delete(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&auth.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&auth.TwoFactor{}).Error
+	})
+}
+
+func (r *Repository) ReplaceRecoveryCodes(userID string, codes []*auth.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+func (r *Repository) UseRecoveryCode(userID, codeHash string, now time.Time) (bool, error) {
+	result := r.db.Model(&auth.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *Repository) CountUnusedRecoveryCodes(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&auth.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID string, codes []*auth.RecoveryCode) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&auth.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"strings"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user"
 	"gorm.io/gorm"
@@ -35,11 +36,12 @@ func (r *repository) FindByEmail(email string) (*user.User, error) {
 	return &u, nil
 }
 
-func (r *repository) List(req *user.ListUsersRequest) ([]user.User, int64, error) {
+func (r *repository) List(scope organization.Scope, req *user.ListUsersRequest) ([]user.User, int64, error) {
 	var users []user.User
 	var total int64
 
 	query := r.db.Model(&user.User{}).Preload("Role").Preload("Role.Permissions")
+	query = scope.Filter(query, "users.organization_id")
 
 	// Apply filters
 	if req.Search != "" {
@@ -100,3 +102,11 @@ func (r *repository) Delete(id string) error {
 	return r.db.Where("id = ?", id).Delete(&user.User{}).Error
 }
 
+func (r *repository) Unlock(id string) error {
+	return r.db.Model(&user.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	}).Error
+}
+
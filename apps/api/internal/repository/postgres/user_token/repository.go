@@ -0,0 +1,74 @@
+package usertoken
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	usertokenrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user_token"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUserTokenNotFound = errors.New("user token not found")
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new user token repository
+func NewRepository(db *gorm.DB) usertokenrepo.Repository {
+	return &Repository{db: db}
+}
+
+// Create stores a token and supersedes the user's earlier unused tokens of the same purpose
+func (r *Repository) Create(token *auth.UserToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&auth.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// Consume is a single conditional update, so a token can only be used once even under concurrent requests
+func (r *Repository) Consume(tokenHash string, purpose auth.TokenPurpose, now time.Time) (*auth.UserToken, error) {
+	var token auth.UserToken
+	result := r.db.Model(&token).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.Join(gorm.ErrRecordNotFound, ErrUserTokenNotFound)
+	}
+	return &token, nil
+}
+
+func (r *Repository) FindActive(tokenHash string, purpose auth.TokenPurpose, now time.Time) (*auth.UserToken, error) {
+	var token auth.UserToken
+	err := r.db.Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(gorm.ErrRecordNotFound, ErrUserTokenNotFound)
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *Repository) RecordFailedAttempt(id string, maxAttempts int, now time.Time) error {
+	return r.db.Model(&auth.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+			"used_at":  gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ?::timestamp ELSE NULL END", maxAttempts, now),
+		}).Error
+}
@@ -0,0 +1,357 @@
+package apikey
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	apikey "github.com/gilabs/webapp-ticket-konser/api/internal/domain/api_key"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	apikeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/api_key"
+	permissionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/permission"
+	rolerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user"
+	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrAPIKeyAlreadyRevoked = errors.New("api key already revoked")
+	ErrOwnerNotFound        = errors.New("api key owner not found")
+	ErrOwnerInactive        = errors.New("api key owner is inactive")
+	ErrPermissionNotFound   = errors.New("permission not found")
+	ErrPermissionNotGranted = errors.New("permission cannot be granted to the api key")
+	ErrInvalidExpiry        = errors.New("api key expiry is out of range")
+)
+
+// PermissionError names the permission an API key could not be created with
+type PermissionError struct {
+	Code string
+	Err  error // ErrPermissionNotFound or ErrPermissionNotGranted
+}
+
+func (e *PermissionError) Error() string {
+	return e.Err.Error() + ": " + e.Code
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+type Service struct {
+	repo           apikeyrepo.Repository
+	userRepo       userrepo.Repository
+	roleRepo       rolerepo.Repository
+	permissionRepo permissionrepo.Repository
+	auditService   *auditservice.Service
+}
+
+func NewService(repo apikeyrepo.Repository, userRepo userrepo.Repository, roleRepo rolerepo.Repository, permissionRepo permissionrepo.Repository, auditService *auditservice.Service) *Service {
+	return &Service{
+		repo:           repo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+		auditService:   auditService,
+	}
+}
+
+// Create creates an API key acting as req.UserID (or the creator) and returns it with the key,
+// which is shown only once. Both the owner and the creator must have every requested permission,
+// so a key never grants more than either of them could do.
+func (s *Service) Create(req *apikey.CreateAPIKeyRequest, createdBy string) (*apikey.CreateAPIKeyResponse, error) {
+	ownerID := req.UserID
+	if ownerID == "" {
+		ownerID = createdBy
+	}
+	owner, err := s.userRepo.FindByID(ownerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOwnerNotFound
+		}
+		return nil, err
+	}
+	if owner.Status != "active" {
+		return nil, ErrOwnerInactive
+	}
+	creator := owner
+	if createdBy != ownerID {
+		if creator, err = s.userRepo.FindByID(createdBy); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	expiresAt := now.AddDate(0, 0, config.AppConfig.APIKey.DefaultTTLDays)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.AddDate(0, 0, config.AppConfig.APIKey.MaxTTLDays)) {
+		return nil, ErrInvalidExpiry
+	}
+
+	rateLimit := config.AppConfig.APIKey.DefaultRateLimitPerMinute
+	if req.RateLimitPerMinute != nil {
+		rateLimit = *req.RateLimitPerMinute
+	}
+
+	seen := make(map[string]bool, len(req.Permissions))
+	var permissions []apikey.APIKeyPermission
+	for _, code := range req.Permissions {
+		code = strings.TrimSpace(code)
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		if _, err := s.permissionRepo.FindByCode(code); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, &PermissionError{Code: code, Err: ErrPermissionNotFound}
+			}
+			return nil, err
+		}
+		for _, roleID := range []string{owner.RoleID, creator.RoleID} {
+			granted, err := s.roleRepo.HasPermission(roleID, code)
+			if err != nil {
+				return nil, err
+			}
+			if !granted {
+				return nil, &PermissionError{Code: code, Err: ErrPermissionNotGranted}
+			}
+		}
+		permissions = append(permissions, apikey.APIKeyPermission{PermissionCode: code})
+	}
+
+	key, prefix, err := apikey.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &apikey.APIKey{
+		Name:               strings.TrimSpace(req.Name),
+		Description:        req.Description,
+		Prefix:             prefix,
+		KeyHash:            apikey.HashKey(key),
+		UserID:             owner.ID,
+		Permissions:        permissions,
+		RateLimitPerMinute: rateLimit,
+		ExpiresAt:          expiresAt,
+		CreatedBy:          createdBy,
+	}
+	if err := s.repo.Create(apiKey); err != nil {
+		return nil, err
+	}
+
+	return &apikey.CreateAPIKeyResponse{
+		APIKey: apiKey.ToAPIKeyResponse(now),
+		Key:    key,
+	}, nil
+}
+
+// CreateWithAudit creates an API key and records it in the audit log
+func (s *Service) CreateWithAudit(c *gin.Context, req *apikey.CreateAPIKeyRequest, createdBy string) (*apikey.CreateAPIKeyResponse, error) {
+	resp, err := s.Create(req, createdBy)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "API_KEY_CREATE", "api_key", resp.APIKey.ID, nil, resp.APIKey)
+	}
+	return resp, err
+}
+
+// GetByID returns an API key
+func (s *Service) GetByID(id string) (*apikey.APIKeyResponse, error) {
+	key, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return key.ToAPIKeyResponse(time.Now()), nil
+}
+
+// List lists API keys, newest first
+func (s *Service) List(scope organization.Scope, req *apikey.ListAPIKeysRequest) ([]*apikey.APIKeyResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	now := time.Now()
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.UserID != "" {
+		filters["user_id"] = req.UserID
+	}
+	if req.Search != "" {
+		filters["search"] = req.Search
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+		filters["now"] = now
+	}
+
+	keys, total, err := s.repo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*apikey.APIKeyResponse, len(keys))
+	for i, k := range keys {
+		responses[i] = k.ToAPIKeyResponse(now)
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// Revoke revokes an API key; requests with it are rejected from then on
+func (s *Service) Revoke(id, revokedBy string) (*apikey.APIKeyResponse, error) {
+	key, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyAlreadyRevoked
+	}
+
+	now := time.Now()
+	if err := s.repo.Revoke(id, revokedBy, now); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyAlreadyRevoked
+		}
+		return nil, err
+	}
+
+	key.RevokedAt = &now
+	key.RevokedBy = &revokedBy
+	return key.ToAPIKeyResponse(now), nil
+}
+
+// RevokeWithAudit revokes an API key and records it in the audit log
+func (s *Service) RevokeWithAudit(c *gin.Context, id, revokedBy string) (*apikey.APIKeyResponse, error) {
+	oldKey, _ := s.GetByID(id)
+	resp, err := s.Revoke(id, revokedBy)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "API_KEY_REVOKE", "api_key", id, oldKey, resp)
+	}
+	return resp, err
+}
+
+// ListCalls lists the requests made with an API key, newest first
+func (s *Service) ListCalls(id string, req *apikey.ListAPIKeyCallsRequest) ([]*apikey.APIKeyCallResponse, *response.PaginationMeta, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAPIKeyNotFound
+		}
+		return nil, nil, err
+	}
+
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := make(map[string]interface{})
+	if req.Method != "" {
+		filters["method"] = req.Method
+	}
+	if req.MinStatus > 0 {
+		filters["min_status"] = req.MinStatus
+	}
+	if req.StartDate != nil {
+		filters["start_date"] = *req.StartDate
+	}
+	if req.EndDate != nil {
+		filters["end_date"] = *req.EndDate
+	}
+
+	calls, total, err := s.repo.ListCalls(id, page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*apikey.APIKeyCallResponse, len(calls))
+	for i, call := range calls {
+		responses[i] = call.ToAPIKeyCallResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// AuthenticateAPIKey resolves the owner and permissions of an API key. Keys of inactive owners
+// are rejected like unknown ones.
+func (s *Service) AuthenticateAPIKey(key, ipAddress string) (*apikey.Principal, error) {
+	apiKey, err := s.repo.FindByKeyHash(apikey.HashKey(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.ErrKeyInvalid
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	switch apiKey.Status(now) {
+	case apikey.StatusRevoked:
+		return nil, apikey.ErrKeyRevoked
+	case apikey.StatusExpired:
+		return nil, apikey.ErrKeyExpired
+	}
+
+	owner, err := s.userRepo.FindByID(apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.ErrKeyInvalid
+		}
+		return nil, err
+	}
+	if owner.Status != "active" {
+		return nil, apikey.ErrKeyInvalid
+	}
+
+	// Throttled so a busy integration does not write the key row on every request
+	interval := time.Duration(config.AppConfig.APIKey.LastUsedIntervalSeconds) * time.Second
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= interval || apiKey.LastUsedIP != ipAddress {
+		if err := s.repo.TouchLastUsed(apiKey.ID, ipAddress, now); err != nil {
+			log.Printf("[APIKey] Failed to update last use of key %s: %v", apiKey.Prefix, err)
+		}
+	}
+
+	principal := &apikey.Principal{
+		KeyID:              apiKey.ID,
+		UserID:             owner.ID,
+		Email:              owner.Email,
+		RoleID:             owner.RoleID,
+		OrganizationID:     owner.OrganizationIDValue(),
+		Permissions:        apiKey.PermissionCodes(),
+		RateLimitPerMinute: apiKey.RateLimitPerMinute,
+	}
+	if owner.Role != nil {
+		principal.Role = owner.Role.Code
+	}
+	return principal, nil
+}
+
+// RecordAPIKeyCall adds a request to the audit trail of its API key
+func (s *Service) RecordAPIKeyCall(call *apikey.APIKeyCall) {
+	if err := s.repo.CreateCall(call); err != nil {
+		log.Printf("[APIKey] Failed to record call %s %s of key %s: %v", call.Method, call.Path, call.APIKeyID, err)
+	}
+}
@@ -2,6 +2,7 @@ package attendee
 
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/attendee"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	attendeeRepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/attendee"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 )
@@ -16,8 +17,8 @@ func NewService(repo attendeeRepo.Repository) *Service {
 	}
 }
 
-// List lists attendees with pagination and filters
-func (s *Service) List(req *attendee.ListAttendeesRequest) ([]*attendee.AttendeeResponse, *response.PaginationMeta, error) {
+// List lists the attendees of the events within the organization scope with pagination and filters
+func (s *Service) List(scope organization.Scope, req *attendee.ListAttendeesRequest) ([]*attendee.AttendeeResponse, *response.PaginationMeta, error) {
 	page := 1
 	perPage := 20
 
@@ -29,7 +30,9 @@ func (s *Service) List(req *attendee.ListAttendeesRequest) ([]*attendee.Attendee
 	}
 
 	// Build filters map
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.Search != "" {
 		filters["search"] = req.Search
 	}
@@ -45,6 +48,9 @@ func (s *Service) List(req *attendee.ListAttendeesRequest) ([]*attendee.Attendee
 	if req.EndDate != nil {
 		filters["end_date"] = req.EndDate
 	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
 
 	// Get attendees from repository
 	attendees, total, err := s.repo.List(page, perPage, filters)
@@ -64,24 +70,31 @@ func (s *Service) List(req *attendee.ListAttendeesRequest) ([]*attendee.Attendee
 	return responses, paginationMeta, nil
 }
 
-// GetStatistics gets attendee statistics
-func (s *Service) GetStatistics(req *attendee.ListAttendeesRequest) (*attendee.AttendeeStatistics, error) {
+// GetStatistics gets attendee statistics within the organization scope
+func (s *Service) GetStatistics(scope organization.Scope, req *attendee.ListAttendeesRequest) (*attendee.AttendeeStatistics, error) {
 	// Build filters map
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.StartDate != nil {
 		filters["start_date"] = req.StartDate
 	}
 	if req.EndDate != nil {
 		filters["end_date"] = req.EndDate
 	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
 
 	return s.repo.GetStatistics(filters)
 }
 
-// Export exports attendees to CSV format
-func (s *Service) Export(req *attendee.ListAttendeesRequest) ([][]string, error) {
+// Export exports the attendees within the organization scope to CSV format
+func (s *Service) Export(scope organization.Scope, req *attendee.ListAttendeesRequest) ([][]string, error) {
 	// Build filters map
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.Search != "" {
 		filters["search"] = req.Search
 	}
@@ -97,6 +110,9 @@ func (s *Service) Export(req *attendee.ListAttendeesRequest) ([][]string, error)
 	if req.EndDate != nil {
 		filters["end_date"] = req.EndDate
 	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
 
 	return s.repo.Export(filters)
 }
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+)
+
+var (
+	ErrAccountLocked  = errors.New("account is temporarily locked after too many failed logins")
+	ErrLoginThrottled = errors.New("too many failed logins, try again later")
+)
+
+// LoginBlockedError carries how long a blocked login has to wait. It unwraps to ErrAccountLocked
+// or ErrLoginThrottled.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", e.Err.Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// checkIPThrottle rejects logins from an IP address with too many recent failures
+func (s *Service) checkIPThrottle(ipAddress string, now time.Time) error {
+	cfg := config.AppConfig.Auth
+	if cfg.IPMaxFailures <= 0 || ipAddress == "" {
+		return nil
+	}
+	window := time.Duration(cfg.IPWindowMinutes) * time.Minute
+	failures, err := s.loginAttemptRepo.CountFailuresByIP(ipAddress, now.Add(-window))
+	if err != nil {
+		return err
+	}
+	if failures >= int64(cfg.IPMaxFailures) {
+		return &LoginBlockedError{Err: ErrLoginThrottled, RetryAfter: window}
+	}
+	return nil
+}
+
+// checkAccountThrottle rejects logins to a locked account, or made before the progressive delay
+// after the account's last failed login has passed
+func checkAccountThrottle(u *user.User, now time.Time) error {
+	if u.IsLocked(now) {
+		return &LoginBlockedError{Err: ErrAccountLocked, RetryAfter: u.LockedUntil.Sub(now)}
+	}
+	if u.LastFailedLoginAt == nil {
+		return nil
+	}
+	if next := u.LastFailedLoginAt.Add(loginDelay(u.FailedLoginAttempts)); now.Before(next) {
+		return &LoginBlockedError{Err: ErrLoginThrottled, RetryAfter: next.Sub(now)}
+	}
+	return nil
+}
+
+// loginDelay is the wait after the given number of consecutive failures: none up to
+// LoginDelayAfterFailures, then 1s doubling with every failure up to LoginMaxDelaySeconds
+func loginDelay(failures int) time.Duration {
+	cfg := config.AppConfig.Auth
+	if cfg.LoginDelayAfterFailures <= 0 || failures < cfg.LoginDelayAfterFailures {
+		return 0
+	}
+	maxDelay := time.Duration(cfg.LoginMaxDelaySeconds) * time.Second
+	exp := failures - cfg.LoginDelayAfterFailures
+	if exp > 30 {
+		return maxDelay
+	}
+	delay := time.Second << uint(exp)
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// recordLoginFailure counts a failed password or two-factor code against the account, locking it
+// at the threshold, and records the attempt
+func (s *Service) recordLoginFailure(u *user.User, client *auth.ClientInfo, reason string) {
+	cfg := config.AppConfig.Auth
+	threshold := cfg.LockoutThreshold
+	if threshold <= 0 {
+		threshold = math.MaxInt32
+	}
+
+	now := time.Now()
+	lockUntil := now.Add(time.Duration(cfg.LockoutMinutes) * time.Minute)
+	if _, locked, err := s.repo.RecordFailedLogin(u.ID, now, threshold, lockUntil); err != nil {
+		log.Printf("[Auth] Failed to count failed login for user %s: %v", u.ID, err)
+	} else if locked {
+		log.Printf("[Auth] Account %s locked until %s after %d failed logins", u.ID, lockUntil.Format(time.RFC3339), threshold)
+	}
+	s.recordLoginAttempt(&u.ID, u.Email, client, false, reason)
+}
+
+// recordLoginSuccess clears the failed login count and records the attempt
+func (s *Service) recordLoginSuccess(u *user.User, client *auth.ClientInfo) {
+	if u.FailedLoginAttempts > 0 || u.LastFailedLoginAt != nil {
+		if err := s.repo.ResetFailedLogins(u.ID); err != nil {
+			log.Printf("[Auth] Failed to reset failed logins for user %s: %v", u.ID, err)
+		}
+	}
+	s.recordLoginAttempt(&u.ID, u.Email, client, true, "")
+}
+
+// recordLoginAttempt adds an entry to the login history; failing to record never fails the login
+func (s *Service) recordLoginAttempt(userID *string, email string, client *auth.ClientInfo, success bool, reason string) {
+	attempt := &auth.LoginAttempt{
+		UserID:        userID,
+		Email:         email,
+		IPAddress:     client.IPAddress,
+		UserAgent:     client.UserAgent,
+		Success:       success,
+		FailureReason: reason,
+	}
+	if err := s.loginAttemptRepo.Create(attempt); err != nil {
+		log.Printf("[Auth] Failed to record login attempt for %s: %v", email, err)
+	}
+}
+
+// ListLoginHistory lists the user's own login attempts
+func (s *Service) ListLoginHistory(userID string, req *auth.ListLoginHistoryRequest) ([]*auth.LoginAttemptResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := make(map[string]interface{})
+	if req.Result != "" {
+		filters["success"] = req.Result == "success"
+	}
+
+	attempts, total, err := s.loginAttemptRepo.ListByUser(userID, page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*auth.LoginAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		responses[i] = attempt.ToLoginAttemptResponse()
+	}
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/integration/oidc"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOIDCProviderNotFound    = errors.New("oidc provider not configured")
+	ErrOIDCProviderUnavailable = errors.New("oidc provider could not be reached")
+	ErrOIDCStateInvalid        = errors.New("oidc login state is invalid, expired or already used")
+	ErrOIDCLoginFailed         = errors.New("oidc login was not accepted by the provider")
+	ErrOIDCEmailNotVerified    = errors.New("provider has not verified the email address")
+	ErrOIDCRoleNotAllowed      = errors.New("social login is only available for buyer accounts")
+)
+
+// ListOIDCProviders lists the providers buyers can log in with, in configuration order
+func (s *Service) ListOIDCProviders() []*auth.OIDCProviderResponse {
+	providers := make([]*auth.OIDCProviderResponse, 0, len(config.AppConfig.OIDC.Providers))
+	for _, cfg := range config.AppConfig.OIDC.Providers {
+		providers = append(providers, &auth.OIDCProviderResponse{
+			Name:        cfg.Name,
+			DisplayName: cfg.DisplayName,
+		})
+	}
+	return providers
+}
+
+// StartOIDCLogin starts an authorization code login with PKCE and returns the provider URL to
+// send the browser to
+func (s *Service) StartOIDCLogin(ctx context.Context, providerName string) (*auth.OIDCStartResponse, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	state, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := oidc.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := provider.AuthorizationURL(ctx, state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		log.Printf("[Auth] OIDC discovery failed for provider %s: %v", provider.Name, err)
+		return nil, ErrOIDCProviderUnavailable
+	}
+
+	ttl := time.Duration(config.AppConfig.OIDC.StateTTLMinutes) * time.Minute
+	loginState := &auth.OIDCLoginState{
+		Provider:     provider.Name,
+		StateHash:    auth.HashUserToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(ttl),
+	}
+	if err := s.oidcRepo.CreateState(loginState); err != nil {
+		return nil, err
+	}
+
+	return &auth.OIDCStartResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+		ExpiresIn:        int(ttl.Seconds()),
+	}, nil
+}
+
+// CompleteOIDCLogin redeems the code the provider redirected back with and logs the user in.
+// Users are matched by their linked identity, then by the provider-verified email; unknown
+// emails get a new buyer account. Two-factor authentication still applies.
+func (s *Service) CompleteOIDCLogin(ctx context.Context, providerName string, req *auth.OIDCCallbackRequest, client *auth.ClientInfo) (*auth.LoginResponse, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	now := time.Now()
+	if err := s.checkIPThrottle(client.IPAddress, now); err != nil {
+		return nil, err
+	}
+
+	state, err := s.oidcRepo.ConsumeState(auth.HashUserToken(strings.TrimSpace(req.State)), provider.Name, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOIDCStateInvalid
+		}
+		return nil, err
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, req.Code, state.CodeVerifier)
+	if err != nil {
+		log.Printf("[Auth] OIDC code exchange failed for provider %s: %v", provider.Name, err)
+		return nil, ErrOIDCLoginFailed
+	}
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		log.Printf("[Auth] OIDC id token rejected for provider %s: %v", provider.Name, err)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	u, err := s.resolveOIDCUser(provider.Name, claims, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Status != "active" {
+		s.recordLoginAttempt(&u.ID, u.Email, client, false, auth.LoginFailureAccountDisabled)
+		return nil, ErrUserInactive
+	}
+
+	challenge, err := s.twoFactorChallenge(u)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &auth.LoginResponse{TwoFactor: challenge}, nil
+	}
+
+	return s.completeLogin(u, req.DeviceName, client)
+}
+
+// resolveOIDCUser finds the user of a provider account, linking or creating one on first login
+func (s *Service) resolveOIDCUser(providerName string, claims *oidc.IDTokenClaims, now time.Time) (*user.User, error) {
+	identity, err := s.oidcRepo.FindIdentity(providerName, claims.Subject)
+	if err == nil {
+		u, err := s.repo.FindByID(identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !oidcLoginAllowed(u) {
+			return nil, ErrOIDCRoleNotAllowed
+		}
+		if err := s.oidcRepo.TouchIdentity(identity.ID, claims.Email, now); err != nil {
+			log.Printf("[Auth] Failed to update identity %s: %v", identity.ID, err)
+		}
+		return u, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if claims.Email == "" || !bool(claims.EmailVerified) {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	u, err := s.repo.FindByEmail(claims.Email)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		u, err = s.createOIDCUser(claims, now)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if !oidcLoginAllowed(u) {
+			return nil, ErrOIDCRoleNotAllowed
+		}
+		// An unverified account may have been registered by someone else with this address before
+		// its owner showed up, so its password and sessions are not carried over
+		if u.EmailVerifiedAt == nil {
+			hashed, err := randomPasswordHash()
+			if err != nil {
+				return nil, err
+			}
+			if err := s.repo.UpdatePassword(u.ID, hashed); err != nil {
+				return nil, err
+			}
+			if _, err := s.revokeAllSessions(u.ID, auth.SessionRevokedPassword, now); err != nil {
+				return nil, err
+			}
+			if err := s.repo.MarkEmailVerified(u.ID, now); err != nil {
+				return nil, err
+			}
+			u.EmailVerifiedAt = &now
+		}
+	}
+
+	if err := s.oidcRepo.CreateIdentity(&auth.UserIdentity{
+		UserID:      u.ID,
+		Provider:    providerName,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		LastLoginAt: &now,
+	}); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// createOIDCUser creates a buyer account for a provider-verified email. The account gets a random
+// password; the buyer can set one through forgot password.
+func (s *Service) createOIDCUser(claims *oidc.IDTokenClaims, now time.Time) (*user.User, error) {
+	guestRole, err := s.roleRepo.FindByCode("guest")
+	if err != nil {
+		return nil, ErrGuestRoleNotFound
+	}
+
+	hashed, err := randomPasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	avatarURL := claims.Picture
+	if avatarURL == "" {
+		avatarURL = "https://api.dicebear.com/7.x/lorelei/svg?seed=" + url.QueryEscape(claims.Email)
+	}
+
+	newUser := &user.User{
+		Email:           claims.Email,
+		Password:        hashed,
+		Name:            name,
+		AvatarURL:       avatarURL,
+		RoleID:          guestRole.ID,
+		Status:          "active",
+		EmailVerifiedAt: &now,
+	}
+	if err := s.repo.Create(newUser); err != nil {
+		return nil, err
+	}
+
+	// Reload to hydrate the Role association
+	return s.repo.FindByID(newUser.ID)
+}
+
+// oidcLoginAllowed reports whether the user may log in through a provider. Staff accounts keep
+// logging in with their password (and 2FA).
+func oidcLoginAllowed(u *user.User) bool {
+	return u.Role == nil || !(u.Role.IsAdmin || u.Role.CanLoginAdmin)
+}
+
+// randomPasswordHash hashes a random password nobody knows
+func randomPasswordHash() (string, error) {
+	password, err := oidc.RandomToken()
+	if err != nil {
+		return "", err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	redisint "github.com/gilabs/webapp-ticket-konser/api/internal/integration/redis"
+	"golang.org/x/time/rate"
+)
+
+// emailLimiterIdle is how long an in-memory limiter is kept after its last use
+const emailLimiterIdle = 2 * time.Hour
+
+type emailLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// emailRateLimiter limits how many reset/verification emails can be requested per address.
+// Redis is used when enabled so the limit holds across instances; otherwise limits are kept in memory.
+type emailRateLimiter struct {
+	mu      sync.Mutex
+	entries map[string]*emailLimiterEntry
+}
+
+func newEmailRateLimiter() *emailRateLimiter {
+	return &emailRateLimiter{entries: make(map[string]*emailLimiterEntry)}
+}
+
+// Allow reports whether another email of the given purpose may be sent to the address
+func (l *emailRateLimiter) Allow(purpose auth.TokenPurpose, email string) bool {
+	perHour := 3
+	if config.AppConfig != nil {
+		perHour = config.AppConfig.Auth.EmailsPerHour
+	}
+	if perHour <= 0 {
+		return true
+	}
+	perSecond := float64(perHour) / 3600
+
+	key := string(purpose) + ":" + strings.ToLower(strings.TrimSpace(email))
+	if redisint.Client != nil {
+		prefix := "ticketing_api"
+		if config.AppConfig != nil && config.AppConfig.Redis.Prefix != "" {
+			prefix = config.AppConfig.Redis.Prefix
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		res, err := redisint.AllowTokenBucket(ctx, redisint.Key(prefix, "auth_email", redisint.HashKeySuffix(key)), perSecond, perHour)
+		if err == nil {
+			return res.Allowed
+		}
+		log.Printf("[Auth] Redis email rate limit failed, falling back to memory: %v", err)
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for k, e := range l.entries {
+		if now.Sub(e.lastSeen) > emailLimiterIdle {
+			delete(l.entries, k)
+		}
+	}
+
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &emailLimiterEntry{limiter: rate.NewLimiter(rate.Limit(perSecond), perHour)}
+		l.entries[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.Allow()
+}
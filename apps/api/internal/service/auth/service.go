@@ -2,15 +2,25 @@ package auth
 
 import (
 	"errors"
+	"log"
 	"net/url"
+	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/menu"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/permission"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/integration/mail"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/integration/oidc"
 	authrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/auth"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	sessionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/session"
+	loginattemptrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/login_attempt"
+	oidcrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/oidc"
+	twofactorrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/two_factor"
+	usertokenrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user_token"
 	menuservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/menu"
+	sessioncacheservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/session_cache"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -26,51 +36,106 @@ var (
 )
 
 type Service struct {
-	repo        authrepo.Repository
-	roleRepo    role.Repository
-	menuService *menuservice.Service
-	jwtManager  *jwt.JWTManager
+	repo             authrepo.Repository
+	sessionRepo      sessionrepo.Repository
+	sessionCache     *sessioncacheservice.Service
+	tokenRepo        usertokenrepo.Repository
+	twoFactorRepo    twofactorrepo.Repository
+	loginAttemptRepo loginattemptrepo.Repository
+	oidcRepo         oidcrepo.Repository
+	roleRepo         role.Repository
+	menuService      *menuservice.Service
+	jwtManager       *jwt.JWTManager
+	mailer           *mail.Client
+	emailLimiter     *emailRateLimiter
+	oidcProviders    map[string]*oidc.Provider
 }
 
-func NewService(repo authrepo.Repository, roleRepo role.Repository, menuService *menuservice.Service, jwtManager *jwt.JWTManager) *Service {
+func NewService(repo authrepo.Repository, sessionRepo sessionrepo.Repository, sessionCache *sessioncacheservice.Service, tokenRepo usertokenrepo.Repository, twoFactorRepo twofactorrepo.Repository, loginAttemptRepo loginattemptrepo.Repository, oidcRepo oidcrepo.Repository, roleRepo role.Repository, menuService *menuservice.Service, jwtManager *jwt.JWTManager) *Service {
 	return &Service{
-		repo:        repo,
-		roleRepo:    roleRepo,
-		menuService: menuService,
-		jwtManager:  jwtManager,
+		repo:             repo,
+		sessionRepo:      sessionRepo,
+		sessionCache:     sessionCache,
+		tokenRepo:        tokenRepo,
+		twoFactorRepo:    twoFactorRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		oidcRepo:         oidcRepo,
+		roleRepo:         roleRepo,
+		menuService:      menuService,
+		jwtManager:       jwtManager,
+		mailer:           mail.NewClient(),
+		emailLimiter:     newEmailRateLimiter(),
+		oidcProviders:    oidc.NewProviders(),
 	}
 }
 
-// Login authenticates a user and returns tokens
-func (s *Service) Login(req *auth.LoginRequest) (*auth.LoginResponse, error) {
+// Login authenticates a user and returns tokens, or a two-factor challenge to complete first
+func (s *Service) Login(req *auth.LoginRequest, client *auth.ClientInfo) (*auth.LoginResponse, error) {
+	now := time.Now()
+
+	// Throttle IP addresses with many recent failures (password spraying across accounts)
+	if err := s.checkIPThrottle(client.IPAddress, now); err != nil {
+		if errors.Is(err, ErrLoginThrottled) {
+			s.recordLoginAttempt(nil, req.Email, client, false, auth.LoginFailureIPThrottled)
+		}
+		return nil, err
+	}
+
 	// Find user by email
 	user, err := s.repo.FindByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.recordLoginAttempt(nil, req.Email, client, false, auth.LoginFailureUnknownEmail)
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
 	}
 
+	// Locked accounts and attempts within the progressive delay are rejected before the password is checked
+	if err := checkAccountThrottle(user, now); err != nil {
+		reason := auth.LoginFailureAccountThrottled
+		if errors.Is(err, ErrAccountLocked) {
+			reason = auth.LoginFailureAccountLocked
+		}
+		s.recordLoginAttempt(&user.ID, user.Email, client, false, reason)
+		return nil, err
+	}
+
 	// Check if user is active
 	if user.Status != "active" {
+		s.recordLoginAttempt(&user.ID, user.Email, client, false, auth.LoginFailureAccountDisabled)
 		return nil, ErrUserInactive
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.recordLoginFailure(user, client, auth.LoginFailureInvalidPassword)
 		return nil, ErrInvalidCredentials
 	}
 
 	// Note: CanLoginAdmin is enforced at the admin dashboard routes level,
 	// not at login. All active users (including guests) can authenticate.
 
+	// Users with two-factor authentication (or whose role requires it) get a challenge instead of tokens
+	challenge, err := s.twoFactorChallenge(user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &auth.LoginResponse{TwoFactor: challenge}, nil
+	}
+
+	return s.completeLogin(user, req.DeviceName, client)
+}
+
+// completeLogin starts a session for an authenticated user and returns its tokens
+func (s *Service) completeLogin(u *user.User, deviceName string, client *auth.ClientInfo) (*auth.LoginResponse, error) {
 	// Get role code and role ID
 	roleCode := "user"
 	roleID := ""
-	if user.Role != nil {
-		roleCode = user.Role.Code
-		roleID = user.Role.ID
+	if u.Role != nil {
+		roleCode = u.Role.Code
+		roleID = u.Role.ID
 	}
 
 	// Get permissions for the role
@@ -85,13 +150,17 @@ func (s *Service) Login(req *auth.LoginRequest) (*auth.LoginResponse, error) {
 		}
 	}
 
-	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, roleCode, roleID)
+	// Start a session and generate tokens
+	if client.DeviceName == "" {
+		client.DeviceName = deviceName
+	}
+	sessionID, refreshToken, err := s.startSession(u.ID, client)
 	if err != nil {
 		return nil, err
 	}
+	s.recordLoginSuccess(u, client)
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	accessToken, err := s.jwtManager.GenerateAccessToken(u.ID, u.Email, roleCode, roleID, u.OrganizationIDValue(), sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +169,7 @@ func (s *Service) Login(req *auth.LoginRequest) (*auth.LoginResponse, error) {
 	expiresIn := int(s.jwtManager.AccessTokenTTL().Seconds())
 
 	// Convert to auth response format
-	userResp := user.ToUserResponse()
+	userResp := u.ToUserResponse()
 	authUserResp := &auth.UserResponse{
 		ID:         userResp.ID,
 		Email:      userResp.Email,
@@ -108,6 +177,7 @@ func (s *Service) Login(req *auth.LoginRequest) (*auth.LoginResponse, error) {
 		AvatarURL:  userResp.AvatarURL,
 		Role:       roleCode,
 		Status:     userResp.Status,
+		EmailVerified: userResp.EmailVerified,
 		Permissions: permissions,
 		CreatedAt:  userResp.CreatedAt,
 		UpdatedAt:  userResp.UpdatedAt,
@@ -121,8 +191,8 @@ func (s *Service) Login(req *auth.LoginRequest) (*auth.LoginResponse, error) {
 	}, nil
 }
 
-// Register creates a new buyer (guest) account and returns tokens for immediate login
-func (s *Service) Register(req *auth.RegisterRequest) (*auth.LoginResponse, error) {
+// Register creates a new buyer (guest) account, emails a verification link and returns tokens for immediate login
+func (s *Service) Register(req *auth.RegisterRequest, client *auth.ClientInfo) (*auth.LoginResponse, error) {
 	// Validate passwords match
 	if req.Password != req.ConfirmPassword {
 		return nil, ErrPasswordMismatch
@@ -171,6 +241,12 @@ func (s *Service) Register(req *auth.RegisterRequest) (*auth.LoginResponse, erro
 		return nil, err
 	}
 
+	// The account can be used right away; the verification email only gates order creation,
+	// so a mail outage must not fail the registration
+	if err := s.sendVerificationEmail(created); err != nil {
+		log.Printf("[Auth] Failed to send verification email to user %s: %v", created.ID, err)
+	}
+
 	// Build token response (same shape as Login)
 	roleCode := guestRole.Code
 	roleID := guestRole.ID
@@ -184,12 +260,15 @@ func (s *Service) Register(req *auth.RegisterRequest) (*auth.LoginResponse, erro
 		}
 	}
 
-	accessToken, err := s.jwtManager.GenerateAccessToken(created.ID, created.Email, roleCode, roleID)
+	if client.DeviceName == "" {
+		client.DeviceName = req.DeviceName
+	}
+	sessionID, refreshToken, err := s.startSession(created.ID, client)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(created.ID)
+	accessToken, err := s.jwtManager.GenerateAccessToken(created.ID, created.Email, roleCode, roleID, created.OrganizationIDValue(), sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,15 +277,16 @@ func (s *Service) Register(req *auth.RegisterRequest) (*auth.LoginResponse, erro
 
 	userResp := created.ToUserResponse()
 	authUserResp := &auth.UserResponse{
-		ID:          userResp.ID,
-		Email:       userResp.Email,
-		Name:        userResp.Name,
-		AvatarURL:   userResp.AvatarURL,
-		Role:        roleCode,
-		Status:      userResp.Status,
-		Permissions: permissions,
-		CreatedAt:   userResp.CreatedAt,
-		UpdatedAt:   userResp.UpdatedAt,
+		ID:            userResp.ID,
+		Email:         userResp.Email,
+		Name:          userResp.Name,
+		AvatarURL:     userResp.AvatarURL,
+		Role:          roleCode,
+		Status:        userResp.Status,
+		EmailVerified: userResp.EmailVerified,
+		Permissions:   permissions,
+		CreatedAt:     userResp.CreatedAt,
+		UpdatedAt:     userResp.UpdatedAt,
 	}
 
 	return &auth.LoginResponse{
@@ -217,16 +297,27 @@ func (s *Service) Register(req *auth.RegisterRequest) (*auth.LoginResponse, erro
 	}, nil
 }
 
-// RefreshToken refreshes an access token using refresh token
-func (s *Service) RefreshToken(refreshToken string) (*auth.LoginResponse, error) {
+// RefreshToken exchanges a refresh token for new tokens, rotating the session's refresh token.
+// Presenting a refresh token that was already rotated revokes the whole session.
+func (s *Service) RefreshToken(refreshToken string, client *auth.ClientInfo) (*auth.LoginResponse, error) {
 	// Validate refresh token
-	userID, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) {
+			return nil, ErrRefreshTokenExpired
+		}
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	// Rotate the session's refresh token
+	sessionID := claims.SessionID
+	newRefreshToken, err := s.rotateSession(claims, client)
 	if err != nil {
 		return nil, err
 	}
 
 	// Find user
-	user, err := s.repo.FindByID(userID)
+	user, err := s.repo.FindByID(claims.Subject)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
@@ -256,13 +347,8 @@ func (s *Service) RefreshToken(refreshToken string) (*auth.LoginResponse, error)
 		}
 	}
 
-	// Generate new tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, roleCode, roleID)
-	if err != nil {
-		return nil, err
-	}
-
-	newRefreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	// Generate new access token
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, roleCode, roleID, user.OrganizationIDValue(), sessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +364,7 @@ func (s *Service) RefreshToken(refreshToken string) (*auth.LoginResponse, error)
 		AvatarURL:  userResp.AvatarURL,
 		Role:       roleCode,
 		Status:     userResp.Status,
+		EmailVerified: userResp.EmailVerified,
 		Permissions: permissions,
 		CreatedAt:  userResp.CreatedAt,
 		UpdatedAt:  userResp.UpdatedAt,
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid")
+	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// startSession creates a login session and returns its ID with the session's first refresh token
+func (s *Service) startSession(userID string, client *auth.ClientInfo) (string, string, error) {
+	now := time.Now()
+	session := &auth.Session{
+		UserID:         userID,
+		RefreshTokenID: uuid.New().String(),
+		DeviceName:     client.DeviceName,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		LastUsedAt:     now,
+		ExpiresAt:      now.Add(s.jwtManager.RefreshTokenTTL()),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := s.jwtManager.GenerateRefreshToken(userID, session.ID, session.RefreshTokenID)
+	if err != nil {
+		return "", "", err
+	}
+	return session.ID, refreshToken, nil
+}
+
+// rotateSession replaces the session's refresh token and returns the new one. A token that is
+// not the session's current one means it was rotated before and is being replayed, so the
+// session (the whole token family) is revoked.
+func (s *Service) rotateSession(claims *jwt.RefreshClaims, client *auth.ClientInfo) (string, error) {
+	session, err := s.sessionRepo.FindByID(claims.SessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrRefreshTokenInvalid
+		}
+		return "", err
+	}
+
+	now := time.Now()
+	if session.UserID != claims.Subject || !session.IsActive(now) {
+		return "", ErrRefreshTokenInvalid
+	}
+
+	newTokenID := uuid.New().String()
+	rotated, err := s.sessionRepo.Rotate(session.ID, claims.ID, newTokenID, map[string]interface{}{
+		"last_used_at": now,
+		"ip_address":   client.IPAddress,
+		"user_agent":   client.UserAgent,
+		"expires_at":   now.Add(s.jwtManager.RefreshTokenTTL()),
+	})
+	if err != nil {
+		return "", err
+	}
+	if !rotated {
+		log.Printf("[Auth] Refresh token reuse detected for session %s of user %s, revoking session", session.ID, session.UserID)
+		if err := s.revokeSession(session.ID, auth.SessionRevokedTokenReuse, now); err != nil {
+			return "", err
+		}
+		return "", ErrRefreshTokenReused
+	}
+
+	return s.jwtManager.GenerateRefreshToken(session.UserID, session.ID, newTokenID)
+}
+
+// Logout revokes the session the request was made with. Tokens issued before sessions
+// existed have no session ID; there is nothing to revoke for them.
+func (s *Service) Logout(userID, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return s.revokeOwnSession(userID, sessionID, auth.SessionRevokedLogout)
+}
+
+// LogoutAll revokes every session of the user and returns how many were revoked
+func (s *Service) LogoutAll(userID string) (int64, error) {
+	return s.revokeAllSessions(userID, auth.SessionRevokedLogoutAll, time.Now())
+}
+
+// ListSessions lists the user's active sessions, marking the one the request was made with
+func (s *Service) ListSessions(userID, currentSessionID string) ([]*auth.SessionResponse, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*auth.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = session.ToSessionResponse(currentSessionID)
+	}
+	return responses, nil
+}
+
+// RevokeSession revokes one of the user's own sessions, e.g. a lost phone
+func (s *Service) RevokeSession(userID, sessionID string) error {
+	return s.revokeOwnSession(userID, sessionID, auth.SessionRevokedByUser)
+}
+
+func (s *Service) revokeOwnSession(userID, sessionID, reason string) error {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+	return s.revokeSession(session.ID, reason, time.Now())
+}
+
+// revokeSession revokes a session and drops it from the session cache, so its access tokens are
+// rejected right away
+func (s *Service) revokeSession(sessionID, reason string, now time.Time) error {
+	if err := s.sessionRepo.Revoke(sessionID, reason, now); err != nil {
+		return err
+	}
+	s.sessionCache.Invalidate(sessionID)
+	return nil
+}
+
+// revokeAllSessions revokes every session of the user and drops them from the session cache
+func (s *Service) revokeAllSessions(userID, reason string, now time.Time) (int64, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(userID, now)
+	if err != nil {
+		return 0, err
+	}
+	revoked, err := s.sessionRepo.RevokeAllByUser(userID, reason, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		s.sessionCache.Invalidate(session.ID)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,434 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// recoveryCodeCount is how many recovery codes are generated at a time
+const recoveryCodeCount = 10
+
+var (
+	ErrTwoFactorChallengeInvalid = errors.New("two-factor challenge is invalid or expired")
+	ErrTwoFactorInvalidCode      = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp         = errors.New("two-factor authentication has not been set up")
+	ErrTwoFactorRequired         = errors.New("two-factor authentication is required for this role")
+)
+
+// twoFactorChallenge returns the challenge a login must complete before tokens are issued, or nil
+// when the user has no two-factor enrollment and their role does not require one
+func (s *Service) twoFactorChallenge(u *user.User) (*auth.TwoFactorChallenge, error) {
+	tf, err := s.twoFactorRepo.FindByUserID(u.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	enabled := tf != nil && tf.IsEnabled()
+	required := u.Role != nil && u.Role.TwoFactorRequired()
+	if !enabled && !required {
+		return nil, nil
+	}
+
+	method := auth.TwoFactorMethodTOTP
+	if !enabled {
+		method = auth.TwoFactorMethodEnroll
+	}
+
+	ttl := time.Duration(config.AppConfig.Auth.TwoFactorChallengeTTLMinutes) * time.Minute
+	token, err := s.issueUserToken(u, auth.TokenPurposeLoginChallenge, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.TwoFactorChallenge{
+		ChallengeToken: token,
+		Method:         method,
+		ExpiresIn:      int(ttl.Seconds()),
+	}, nil
+}
+
+// EnrollTwoFactorChallenge provisions a TOTP secret during a login whose role requires 2FA
+// but the user has not enrolled yet. The login is completed by VerifyTwoFactor.
+func (s *Service) EnrollTwoFactorChallenge(req *auth.TwoFactorChallengeRequest) (*auth.TwoFactorSetupResponse, error) {
+	_, u, err := s.findChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.setupTwoFactor(u)
+}
+
+// VerifyTwoFactor completes a login challenge with a TOTP code or a recovery code. For users
+// enrolling during login the code confirms the enrollment and the response carries their recovery codes.
+func (s *Service) VerifyTwoFactor(req *auth.VerifyTwoFactorRequest, client *auth.ClientInfo) (*auth.LoginResponse, error) {
+	challenge, u, err := s.findChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := checkAccountThrottle(u, now); err != nil {
+		return nil, err
+	}
+
+	tf, err := s.twoFactorRepo.FindByUserID(u.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorNotSetUp
+		}
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	var ok bool
+	if tf.IsEnabled() {
+		ok, err = s.checkSecondFactor(tf, req.Code, now)
+	} else {
+		recoveryCodes, ok, err = s.enableTwoFactor(tf, req.Code, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.tokenRepo.RecordFailedAttempt(challenge.ID, config.AppConfig.Auth.TwoFactorMaxAttempts, now); err != nil {
+			return nil, err
+		}
+		s.recordLoginFailure(u, client, auth.LoginFailureInvalidTwoFactor)
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	// The challenge is single-use: a concurrent request with the same challenge gets no tokens
+	if _, err := s.tokenRepo.Consume(challenge.TokenHash, auth.TokenPurposeLoginChallenge, now); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, err
+	}
+
+	resp, err := s.completeLogin(u, req.DeviceName, client)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// GetTwoFactorStatus returns the user's two-factor status
+func (s *Service) GetTwoFactorStatus(userID string) (*auth.TwoFactorStatusResponse, error) {
+	u, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &auth.TwoFactorStatusResponse{
+		Required: u.Role != nil && u.Role.TwoFactorRequired(),
+	}
+	tf, err := s.twoFactorRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return status, nil
+		}
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return status, nil
+	}
+
+	status.Enabled = true
+	status.EnabledAt = tf.EnabledAt
+	status.RecoveryCodesRemaining, err = s.twoFactorRepo.CountUnusedRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// SetupTwoFactor provisions a new TOTP secret for the user; it is enabled by ConfirmTwoFactor
+func (s *Service) SetupTwoFactor(userID string) (*auth.TwoFactorSetupResponse, error) {
+	u, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.setupTwoFactor(u)
+}
+
+// ConfirmTwoFactor enables the pending enrollment with a code from the authenticator app
+func (s *Service) ConfirmTwoFactor(userID string, req *auth.TwoFactorCodeRequest) (*auth.RecoveryCodesResponse, error) {
+	tf, err := s.twoFactorRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorNotSetUp
+		}
+		return nil, err
+	}
+	if tf.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	codes, ok, err := s.enableTwoFactor(tf, req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+	return &auth.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor removes the user's enrollment after checking their password and a second factor.
+// Users whose role requires 2FA cannot disable it.
+func (s *Service) DisableTwoFactor(userID string, req *auth.DisableTwoFactorRequest) error {
+	u, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if u.Role != nil && u.Role.TwoFactorRequired() {
+		return ErrTwoFactorRequired
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(req.Password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	tf, err := s.findEnabledTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.checkSecondFactor(tf, req.Code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTwoFactorInvalidCode
+	}
+	return s.twoFactorRepo.Delete(userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, invalidating the old ones
+func (s *Service) RegenerateRecoveryCodes(userID string, req *auth.TwoFactorCodeRequest) (*auth.RecoveryCodesResponse, error) {
+	tf, err := s.findEnabledTwoFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.checkSecondFactor(tf, req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	codes, records, err := generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, records); err != nil {
+		return nil, err
+	}
+	return &auth.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *Service) setupTwoFactor(u *user.User) (*auth.TwoFactorSetupResponse, error) {
+	tf, err := s.twoFactorRepo.FindByUserID(u.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if tf != nil && tf.IsEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealTOTPSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.twoFactorRepo.SavePending(u.ID, sealed); err != nil {
+		return nil, err
+	}
+
+	return &auth.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(config.AppConfig.Auth.TOTPIssuer, u.Email, secret),
+	}, nil
+}
+
+// enableTwoFactor confirms a pending enrollment with a TOTP code and returns the new recovery codes
+func (s *Service) enableTwoFactor(tf *auth.TwoFactor, code string, now time.Time) ([]string, bool, error) {
+	secret, err := openTOTPSecret(tf.SecretEncrypted)
+	if err != nil {
+		return nil, false, err
+	}
+	step, ok := totp.Validate(secret, code, now)
+	if !ok {
+		return nil, false, nil
+	}
+
+	codes, records, err := generateRecoveryCodes(tf.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.twoFactorRepo.Enable(tf.UserID, step, now, records); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrTwoFactorAlreadyEnabled
+		}
+		return nil, false, err
+	}
+	return codes, true, nil
+}
+
+// checkSecondFactor accepts a TOTP code that was not used before, or an unused recovery code
+func (s *Service) checkSecondFactor(tf *auth.TwoFactor, code string, now time.Time) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		secret, err := openTOTPSecret(tf.SecretEncrypted)
+		if err != nil {
+			return false, err
+		}
+		step, ok := totp.Validate(secret, code, now)
+		if !ok {
+			return false, nil
+		}
+		return s.twoFactorRepo.AdvanceStep(tf.UserID, step)
+	}
+	return s.twoFactorRepo.UseRecoveryCode(tf.UserID, hashRecoveryCode(code), now)
+}
+
+// findChallenge resolves a pending login challenge and its user
+func (s *Service) findChallenge(token string) (*auth.UserToken, *user.User, error) {
+	challenge, err := s.tokenRepo.FindActive(auth.HashUserToken(strings.TrimSpace(token)), auth.TokenPurposeLoginChallenge, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, nil, err
+	}
+
+	u, err := s.repo.FindByID(challenge.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrTwoFactorChallengeInvalid
+		}
+		return nil, nil, err
+	}
+	if u.Status != "active" {
+		return nil, nil, ErrUserInactive
+	}
+	return challenge, u, nil
+}
+
+func (s *Service) findEnabledTwoFactor(userID string) (*auth.TwoFactor, error) {
+	tf, err := s.twoFactorRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+	if !tf.IsEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	return tf, nil
+}
+
+func (s *Service) findUser(userID string) (*user.User, error) {
+	u, err := s.repo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// generateRecoveryCodes returns new recovery codes (xxxxx-xxxxx) and the records storing their hashes
+func generateRecoveryCodes(userID string) ([]string, []*auth.RecoveryCode, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]*auth.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(buf)
+		codes[i] = raw[:5] + "-" + raw[5:]
+		records[i] = &auth.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(raw),
+		}
+	}
+	return codes, records, nil
+}
+
+// hashRecoveryCode hashes a recovery code, ignoring case, spaces and dashes
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// totpKey is the AES-256 key TOTP secrets are encrypted with
+func totpKey() []byte {
+	key := config.AppConfig.Auth.TOTPEncryptionKey
+	if key == "" {
+		key = "totp:" + config.AppConfig.JWT.SecretKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// sealTOTPSecret encrypts a TOTP secret with AES-GCM
+func sealTOTPSecret(secret string) (string, error) {
+	block, err := aes.NewCipher(totpKey())
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// openTOTPSecret decrypts a TOTP secret sealed by sealTOTPSecret
+func openTOTPSecret(sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(totpKey())
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("sealed totp secret is too short")
+	}
+	secret, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserTokenInvalid     = errors.New("token is invalid, expired or already used")
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+	ErrTooManyEmailRequests = errors.New("too many email requests for this address")
+)
+
+// ForgotPassword emails a password reset link. Unknown and inactive addresses get the same
+// result as known ones so the endpoint cannot be used to discover registered emails.
+func (s *Service) ForgotPassword(req *auth.ForgotPasswordRequest) error {
+	if !s.emailLimiter.Allow(auth.TokenPurposePasswordReset, req.Email) {
+		return ErrTooManyEmailRequests
+	}
+
+	u, err := s.repo.FindByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if u.Status != "active" {
+		return nil
+	}
+
+	ttl := time.Duration(config.AppConfig.Auth.PasswordResetTTLMinutes) * time.Minute
+	token, err := s.issueUserToken(u, auth.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := appLink("/reset-password", token)
+	body := fmt.Sprintf("Halo %s,\n\nKami menerima permintaan untuk mengatur ulang password akun Anda. "+
+		"Buka tautan berikut untuk membuat password baru:\n\n%s\n\n"+
+		"Tautan ini berlaku selama %d menit dan hanya dapat digunakan sekali. "+
+		"Abaikan email ini jika Anda tidak meminta pengaturan ulang password.\n",
+		u.Name, link, config.AppConfig.Auth.PasswordResetTTLMinutes)
+
+	// Do not surface mail failures here, the response must not differ between addresses
+	if err := s.mailer.Send(u.Email, "Atur ulang password", body); err != nil {
+		log.Printf("[Auth] Failed to send password reset email to user %s: %v", u.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and signs the user out everywhere.
+// Receiving the reset link proves ownership of the address, so the email is marked verified too.
+func (s *Service) ResetPassword(req *auth.ResetPasswordRequest) error {
+	if req.Password != req.ConfirmPassword {
+		return ErrPasswordMismatch
+	}
+
+	now := time.Now()
+	token, err := s.consumeUserToken(req.Token, auth.TokenPurposePasswordReset, now)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdatePassword(token.UserID, string(hashed)); err != nil {
+		return err
+	}
+	if err := s.repo.MarkEmailVerified(token.UserID, now); err != nil {
+		return err
+	}
+
+	if _, err := s.revokeAllSessions(token.UserID, auth.SessionRevokedPassword, now); err != nil {
+		return err
+	}
+	return nil
+}
+
+// VerifyEmail marks the user's email as verified using a verification token
+func (s *Service) VerifyEmail(req *auth.VerifyEmailRequest) error {
+	now := time.Now()
+	token, err := s.consumeUserToken(req.Token, auth.TokenPurposeEmailVerification, now)
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkEmailVerified(token.UserID, now)
+}
+
+// ResendVerification emails a new verification link to the user, invalidating earlier ones
+func (s *Service) ResendVerification(userID string) error {
+	u, err := s.repo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if u.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+	if !s.emailLimiter.Allow(auth.TokenPurposeEmailVerification, u.Email) {
+		return ErrTooManyEmailRequests
+	}
+	return s.sendVerificationEmail(u)
+}
+
+func (s *Service) sendVerificationEmail(u *user.User) error {
+	ttl := time.Duration(config.AppConfig.Auth.EmailVerificationTTLHours) * time.Hour
+	token, err := s.issueUserToken(u, auth.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := appLink("/verify-email", token)
+	body := fmt.Sprintf("Halo %s,\n\nTerima kasih telah mendaftar. Buka tautan berikut untuk memverifikasi email Anda:\n\n%s\n\n"+
+		"Tautan ini berlaku selama %d jam. Anda perlu memverifikasi email sebelum dapat membeli tiket.\n",
+		u.Name, link, config.AppConfig.Auth.EmailVerificationTTLHours)
+	return s.mailer.Send(u.Email, "Verifikasi email Anda", body)
+}
+
+// issueUserToken stores the hash of a new token for the user and returns the token itself
+func (s *Service) issueUserToken(u *user.User, purpose auth.TokenPurpose, ttl time.Duration) (string, error) {
+	token, err := auth.GenerateUserToken()
+	if err != nil {
+		return "", err
+	}
+
+	record := &auth.UserToken{
+		UserID:    u.ID,
+		Purpose:   purpose,
+		TokenHash: auth.HashUserToken(token),
+		Email:     u.Email,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.Create(record); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeUserToken uses up a token. Tokens sent to an address the user no longer has are rejected.
+func (s *Service) consumeUserToken(token string, purpose auth.TokenPurpose, now time.Time) (*auth.UserToken, error) {
+	record, err := s.tokenRepo.Consume(auth.HashUserToken(strings.TrimSpace(token)), purpose, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserTokenInvalid
+		}
+		return nil, err
+	}
+
+	u, err := s.repo.FindByID(record.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserTokenInvalid
+		}
+		return nil, err
+	}
+	if !strings.EqualFold(u.Email, record.Email) {
+		return nil, ErrUserTokenInvalid
+	}
+	return record, nil
+}
+
+func appLink(path, token string) string {
+	return strings.TrimRight(config.AppConfig.Auth.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
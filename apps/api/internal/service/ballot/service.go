@@ -0,0 +1,672 @@
+package ballot
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	ballotrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ballot"
+	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/schedule"
+	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ticket_category"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBallotNotFound          = errors.New("ballot not found")
+	ErrTicketCategoryNotFound  = errors.New("ticket category not found")
+	ErrScheduleNotFound        = errors.New("schedule not found")
+	ErrBallotExists            = errors.New("ticket category already has an active ballot")
+	ErrInvalidEntryWindow      = errors.New("entry close time must be after entry open time")
+	ErrBallotNotOpen           = errors.New("ballot is not open")
+	ErrBallotNotDrawn          = errors.New("ballot has not been drawn")
+	ErrEntryWindowClosed       = errors.New("ballot is not accepting entries")
+	ErrEntryWindowStillOpen    = errors.New("ballot entry window is still open")
+	ErrEntryExists             = errors.New("user already entered this ballot")
+	ErrEntryNotFound           = errors.New("ballot entry not found")
+	ErrQuantityExceedsLimit    = errors.New("requested quantity exceeds the ballot limit")
+	ErrClaimNotFound           = errors.New("ballot claim not found")
+	ErrClaimExpired            = errors.New("ballot claim has expired")
+	ErrOrderCreatorUnavailable = errors.New("order creation is not available")
+)
+
+// OrderCreatorInterface defines interface for OrderService to avoid circular dependency
+type OrderCreatorInterface interface {
+	CreateOrder(req *order.CreateOrderRequest, userID string, idempotencyKey string) (*order.OrderResponse, error)
+}
+
+type Service struct {
+	repo               ballotrepo.Repository
+	ticketCategoryRepo ticketcategoryrepo.Repository
+	scheduleRepo       schedulerepo.Repository
+	orderCreator       OrderCreatorInterface
+	db                 *gorm.DB
+}
+
+func NewService(repo ballotrepo.Repository, ticketCategoryRepo ticketcategoryrepo.Repository, scheduleRepo schedulerepo.Repository, orderCreator OrderCreatorInterface) *Service {
+	return &Service{
+		repo:               repo,
+		ticketCategoryRepo: ticketCategoryRepo,
+		scheduleRepo:       scheduleRepo,
+		orderCreator:       orderCreator,
+		db:                 database.DB,
+	}
+}
+
+// GetByID returns a ballot by ID
+func (s *Service) GetByID(id string) (*ballot.BallotResponse, error) {
+	b, err := s.findBallot(id)
+	if err != nil {
+		return nil, err
+	}
+	return b.ToBallotResponse(), nil
+}
+
+// List lists ballots with pagination and filters
+func (s *Service) List(scope organization.Scope, req *ballot.ListBallotsRequest) ([]*ballot.BallotResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	// Build filters
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.TicketCategoryID != "" {
+		filters["ticket_category_id"] = req.TicketCategoryID
+	}
+	if req.EventID != "" {
+		filters["event_id"] = req.EventID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	ballots, total, err := s.repo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*ballot.BallotResponse, len(ballots))
+	for i, b := range ballots {
+		responses[i] = b.ToBallotResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// Create creates a ballot for a ticket category
+func (s *Service) Create(req *ballot.CreateBallotRequest, createdBy string) (*ballot.BallotResponse, error) {
+	if !req.EntryClosesAt.After(req.EntryOpensAt) {
+		return nil, ErrInvalidEntryWindow
+	}
+
+	category, err := s.ticketCategoryRepo.FindByID(req.TicketCategoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTicketCategoryNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := s.repo.FindActiveByTicketCategoryID(req.TicketCategoryID); err == nil {
+		return nil, ErrBallotExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	maxQuantity := req.MaxQuantityPerEntry
+	if maxQuantity == 0 {
+		maxQuantity = category.LimitPerUser
+	}
+	claimWindow := req.ClaimWindowMinutes
+	if claimWindow == 0 {
+		claimWindow = 60
+	}
+
+	b := &ballot.Ballot{
+		TicketCategoryID:    req.TicketCategoryID,
+		Name:                req.Name,
+		EntryOpensAt:        req.EntryOpensAt,
+		EntryClosesAt:       req.EntryClosesAt,
+		Seats:               req.Seats,
+		MaxQuantityPerEntry: maxQuantity,
+		ClaimWindowMinutes:  claimWindow,
+		WaitlistLosers:      req.WaitlistLosers,
+		Status:              ballot.BallotStatusOpen,
+		CreatedBy:           createdBy,
+	}
+
+	if err := s.repo.Create(b); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(b.ID)
+}
+
+// Update updates a ballot before its draw
+func (s *Service) Update(id string, req *ballot.UpdateBallotRequest) (*ballot.BallotResponse, error) {
+	b, err := s.findBallot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Status != ballot.BallotStatusOpen {
+		return nil, ErrBallotNotOpen
+	}
+
+	if req.Name != nil {
+		b.Name = *req.Name
+	}
+	if req.EntryOpensAt != nil {
+		b.EntryOpensAt = *req.EntryOpensAt
+	}
+	if req.EntryClosesAt != nil {
+		b.EntryClosesAt = *req.EntryClosesAt
+	}
+	if req.Seats != nil {
+		b.Seats = *req.Seats
+	}
+	if req.MaxQuantityPerEntry != nil {
+		b.MaxQuantityPerEntry = *req.MaxQuantityPerEntry
+	}
+	if req.ClaimWindowMinutes != nil {
+		b.ClaimWindowMinutes = *req.ClaimWindowMinutes
+	}
+	if req.WaitlistLosers != nil {
+		b.WaitlistLosers = *req.WaitlistLosers
+	}
+
+	if !b.EntryClosesAt.After(b.EntryOpensAt) {
+		return nil, ErrInvalidEntryWindow
+	}
+
+	if err := s.repo.Update(b); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+// Close closes a ballot: outstanding claims expire, the waitlist is dropped and the
+// category returns to public sale
+func (s *Service) Close(id string) (*ballot.BallotResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var b ballot.Ballot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&b).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBallotNotFound
+			}
+			return err
+		}
+
+		if b.Status == ballot.BallotStatusClosed {
+			return nil
+		}
+
+		if err := tx.Model(&ballot.BallotEntry{}).
+			Where("ballot_id = ? AND status IN ?", b.ID, []ballot.EntryStatus{ballot.EntryStatusWon}).
+			Update("status", ballot.EntryStatusExpired).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ballot.BallotEntry{}).
+			Where("ballot_id = ? AND status IN ?", b.ID, []ballot.EntryStatus{ballot.EntryStatusPending, ballot.EntryStatusWaitlisted}).
+			Update("status", ballot.EntryStatusLost).Error; err != nil {
+			return err
+		}
+
+		b.Status = ballot.BallotStatusClosed
+		return tx.Save(&b).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+// Draw runs the seeded draw for a ballot whose entry window has closed.
+// Entries are ranked with ballot.RankEntries; winners are taken in rank order while their
+// quantity fits the remaining seats. An empty seed is generated randomly and recorded.
+func (s *Service) Draw(id string, seed string, drawnBy string) (*ballot.DrawResult, error) {
+	if seed == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate seed: %w", err)
+		}
+		seed = hex.EncodeToString(buf)
+	}
+
+	result := &ballot.DrawResult{}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var b ballot.Ballot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&b).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBallotNotFound
+			}
+			return err
+		}
+
+		if b.Status != ballot.BallotStatusOpen {
+			return ErrBallotNotOpen
+		}
+
+		now := time.Now()
+		if now.Before(b.EntryClosesAt) {
+			return ErrEntryWindowStillOpen
+		}
+
+		// Never draw more seats than the category can still sell
+		var category ticketcategory.TicketCategory
+		if err := tx.Where("id = ?", b.TicketCategoryID).First(&category).Error; err != nil {
+			return err
+		}
+		seats := b.Seats
+		if category.Quota < seats {
+			seats = category.Quota
+		}
+
+		var entries []*ballot.BallotEntry
+		if err := tx.Where("ballot_id = ? AND status = ?", b.ID, ballot.EntryStatusPending).Find(&entries).Error; err != nil {
+			return err
+		}
+
+		byID := make(map[string]*ballot.BallotEntry, len(entries))
+		ids := make([]string, 0, len(entries))
+		for _, e := range entries {
+			byID[e.ID] = e
+			ids = append(ids, e.ID)
+		}
+
+		ranked := ballot.RankEntries(seed, ids)
+		claimExpiresAt := now.Add(time.Duration(b.ClaimWindowMinutes) * time.Minute)
+		remaining := seats
+
+		for i, entryID := range ranked {
+			e := byID[entryID]
+			e.DrawRank = i + 1
+			switch {
+			case e.Quantity <= remaining:
+				token := ballot.GenerateClaimToken()
+				e.Status = ballot.EntryStatusWon
+				e.ClaimToken = &token
+				e.ClaimExpiresAt = &claimExpiresAt
+				remaining -= e.Quantity
+				result.Winners++
+				result.SeatsWon += e.Quantity
+			case b.WaitlistLosers:
+				e.Status = ballot.EntryStatusWaitlisted
+				result.Waitlisted++
+			default:
+				e.Status = ballot.EntryStatusLost
+				result.Losers++
+			}
+			if err := tx.Save(e).Error; err != nil {
+				return err
+			}
+		}
+
+		digest := ballot.DrawDigest(ranked)
+		b.Seats = seats
+		b.Seed = &seed
+		b.DrawDigest = &digest
+		b.DrawnAt = &now
+		b.DrawnBy = &drawnBy
+		b.Status = ballot.BallotStatusDrawn
+		result.Entries = len(ranked)
+		return tx.Save(&b).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	drawn, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	result.Ballot = drawn
+	log.Printf("[Ballot] Drawn ballot %s with seed %s: %d entries, %d winners, %d waitlisted", id, seed, result.Entries, result.Winners, result.Waitlisted)
+	return result, nil
+}
+
+// VerifyDraw re-runs the ranking from the recorded seed and compares it with the stored draw
+func (s *Service) VerifyDraw(id string) (*ballot.DrawVerification, error) {
+	b, err := s.findBallot(id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Seed == nil || b.DrawDigest == nil {
+		return nil, ErrBallotNotDrawn
+	}
+
+	entries, err := s.repo.FindEntriesByBallotID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	storedRank := make(map[string]int, len(entries))
+	for _, e := range entries {
+		if e.DrawRank == 0 {
+			continue // Not part of the draw
+		}
+		ids = append(ids, e.ID)
+		storedRank[e.ID] = e.DrawRank
+	}
+
+	ranked := ballot.RankEntries(*b.Seed, ids)
+	mismatched := 0
+	for i, entryID := range ranked {
+		if storedRank[entryID] != i+1 {
+			mismatched++
+		}
+	}
+	recomputed := ballot.DrawDigest(ranked)
+
+	return &ballot.DrawVerification{
+		BallotID:          b.ID,
+		Seed:              *b.Seed,
+		DrawDigest:        *b.DrawDigest,
+		RecomputedDigest:  recomputed,
+		RankedEntries:     len(ranked),
+		MismatchedEntries: mismatched,
+		Verified:          mismatched == 0 && recomputed == *b.DrawDigest,
+	}, nil
+}
+
+// ListEntries lists entries of a ballot with pagination and filters
+func (s *Service) ListEntries(id string, req *ballot.ListBallotEntriesRequest) ([]*ballot.BallotEntryResponse, *response.PaginationMeta, error) {
+	if _, err := s.findBallot(id); err != nil {
+		return nil, nil, err
+	}
+
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	// Build filters
+	filters := make(map[string]interface{})
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+
+	entries, total, err := s.repo.ListEntries(id, page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*ballot.BallotEntryResponse, len(entries))
+	for i, e := range entries {
+		responses[i] = e.ToBallotEntryResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// Enter registers a user's entry in a ballot during its entry window
+func (s *Service) Enter(id string, req *ballot.CreateBallotEntryRequest, userID string) (*ballot.BallotEntryResponse, error) {
+	b, err := s.findBallot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !b.IsAcceptingEntries(time.Now()) {
+		return nil, ErrEntryWindowClosed
+	}
+
+	if req.Quantity > b.MaxQuantityPerEntry {
+		return nil, ErrQuantityExceedsLimit
+	}
+
+	sched, err := s.scheduleRepo.FindByID(req.ScheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	if b.TicketCategory != nil && sched.EventID != b.TicketCategory.EventID {
+		return nil, ErrScheduleNotFound
+	}
+
+	if _, err := s.repo.FindEntryByBallotAndUser(id, userID); err == nil {
+		return nil, ErrEntryExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	e := &ballot.BallotEntry{
+		BallotID:   id,
+		UserID:     userID,
+		ScheduleID: req.ScheduleID,
+		Quantity:   req.Quantity,
+		BuyerName:  req.BuyerName,
+		BuyerEmail: req.BuyerEmail,
+		BuyerPhone: req.BuyerPhone,
+		Status:     ballot.EntryStatusPending,
+	}
+
+	if err := s.repo.CreateEntry(e); err != nil {
+		return nil, err
+	}
+
+	return e.ToBallotEntryResponse(), nil
+}
+
+// Withdraw removes a user's entry while the entry window is still open
+func (s *Service) Withdraw(id string, userID string) error {
+	b, err := s.findBallot(id)
+	if err != nil {
+		return err
+	}
+
+	if !b.IsAcceptingEntries(time.Now()) {
+		return ErrEntryWindowClosed
+	}
+
+	e, err := s.repo.FindEntryByBallotAndUser(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEntryNotFound
+		}
+		return err
+	}
+
+	return s.repo.DeleteEntry(e.ID)
+}
+
+// GetMyEntries returns all ballot entries of a user; claim tokens are only included while claimable
+func (s *Service) GetMyEntries(userID string) ([]*ballot.BallotEntryResponse, error) {
+	entries, err := s.repo.FindEntriesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*ballot.BallotEntryResponse, len(entries))
+	for i, e := range entries {
+		resp := e.ToBallotEntryResponse()
+		if e.Status == ballot.EntryStatusWon && e.ClaimExpiresAt != nil && e.ClaimExpiresAt.After(now) {
+			resp.ClaimToken = e.ClaimToken
+		}
+		responses[i] = resp
+	}
+	return responses, nil
+}
+
+// Claim turns a winning entry into an order through the normal order flow.
+// The order is then paid via the regular payment initiation endpoint.
+func (s *Service) Claim(token string, userID string, idempotencyKey string) (*order.OrderResponse, error) {
+	if s.orderCreator == nil {
+		return nil, ErrOrderCreatorUnavailable
+	}
+
+	e, err := s.repo.FindEntryByClaimToken(token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClaimNotFound
+		}
+		return nil, err
+	}
+
+	// Tokens are personal: someone else's token is reported as not found
+	if e.UserID != userID || e.Status != ballot.EntryStatusWon || e.Ballot == nil {
+		return nil, ErrClaimNotFound
+	}
+	if e.ClaimExpiresAt == nil || !e.ClaimExpiresAt.After(time.Now()) {
+		return nil, ErrClaimExpired
+	}
+
+	req := &order.CreateOrderRequest{
+		ScheduleID:       e.ScheduleID,
+		TicketCategoryID: e.Ballot.TicketCategoryID,
+		Quantity:         e.Quantity,
+		BuyerName:        e.BuyerName,
+		BuyerEmail:       e.BuyerEmail,
+		BuyerPhone:       e.BuyerPhone,
+		BallotClaimToken: token,
+	}
+	return s.orderCreator.CreateOrder(req, userID, idempotencyKey)
+}
+
+// ExpireClaims expires unclaimed wins, reopens claims whose order failed, and promotes
+// waitlisted entries into freed seats. Returns the number of entries promoted.
+func (s *Service) ExpireClaims() (int, error) {
+	ballots, err := s.repo.FindByStatus(ballot.BallotStatusDrawn)
+	if err != nil {
+		return 0, err
+	}
+
+	promoted := 0
+	for _, b := range ballots {
+		n, err := s.expireClaimsForBallot(b.ID)
+		if err != nil {
+			log.Printf("[Ballot] Error expiring claims for ballot %s: %v", b.ID, err)
+			continue
+		}
+		promoted += n
+	}
+	return promoted, nil
+}
+
+// expireClaimsForBallot processes claim expiry and waitlist promotion for a single ballot
+func (s *Service) expireClaimsForBallot(id string) (int, error) {
+	promoted := 0
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var b ballot.Ballot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&b).Error; err != nil {
+			return err
+		}
+		if b.Status != ballot.BallotStatusDrawn {
+			return nil
+		}
+
+		now := time.Now()
+
+		// Winners who never claimed in time
+		if err := tx.Model(&ballot.BallotEntry{}).
+			Where("ballot_id = ? AND status = ? AND claim_expires_at <= ?", b.ID, ballot.EntryStatusWon, now).
+			Update("status", ballot.EntryStatusExpired).Error; err != nil {
+			return err
+		}
+
+		// Claims whose order was canceled or failed: reopen while the window lasts, otherwise expire
+		var failedClaims []*ballot.BallotEntry
+		if err := tx.Where("ballot_id = ? AND status = ?", b.ID, ballot.EntryStatusClaimed).
+			Where("order_id IN (?)", tx.Model(&order.Order{}).Select("id").
+				Where("payment_status IN ?", []order.PaymentStatus{order.PaymentStatusCanceled, order.PaymentStatusFailed})).
+			Find(&failedClaims).Error; err != nil {
+			return err
+		}
+		for _, e := range failedClaims {
+			e.OrderID = nil
+			if e.ClaimExpiresAt != nil && e.ClaimExpiresAt.After(now) {
+				e.Status = ballot.EntryStatusWon
+			} else {
+				e.Status = ballot.EntryStatusExpired
+			}
+			if err := tx.Save(e).Error; err != nil {
+				return err
+			}
+		}
+
+		if !b.WaitlistLosers {
+			return nil
+		}
+
+		// Seats still held by live wins and claims
+		var committed int64
+		if err := tx.Model(&ballot.BallotEntry{}).
+			Select("COALESCE(SUM(quantity), 0)").
+			Where("ballot_id = ? AND status IN ?", b.ID, []ballot.EntryStatus{ballot.EntryStatusWon, ballot.EntryStatusClaimed}).
+			Scan(&committed).Error; err != nil {
+			return err
+		}
+
+		free := b.Seats - int(committed)
+		if free <= 0 {
+			return nil
+		}
+
+		var waitlist []*ballot.BallotEntry
+		if err := tx.Where("ballot_id = ? AND status = ?", b.ID, ballot.EntryStatusWaitlisted).
+			Order("draw_rank ASC").
+			Find(&waitlist).Error; err != nil {
+			return err
+		}
+
+		claimExpiresAt := now.Add(time.Duration(b.ClaimWindowMinutes) * time.Minute)
+		for _, e := range waitlist {
+			if e.Quantity > free {
+				continue
+			}
+			token := ballot.GenerateClaimToken()
+			e.Status = ballot.EntryStatusWon
+			e.ClaimToken = &token
+			e.ClaimExpiresAt = &claimExpiresAt
+			if err := tx.Save(e).Error; err != nil {
+				return err
+			}
+			free -= e.Quantity
+			promoted++
+			if free == 0 {
+				break
+			}
+		}
+		return nil
+	})
+	return promoted, err
+}
+
+// findBallot loads a ballot and maps not-found errors
+func (s *Service) findBallot(id string) (*ballot.Ballot, error) {
+	b, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBallotNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,282 @@
+package checkin
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTicketAlreadyInside = errors.New("ticket is already inside")
+	ErrReentryNotAllowed   = errors.New("re-entry not allowed for this ticket")
+	ErrTicketNotInside     = errors.New("ticket is not inside")
+)
+
+// scanState summarises the scan history of a checked-in ticket
+type scanState struct {
+	inside        bool
+	reentriesUsed int
+}
+
+// newScanState derives presence and re-entry usage from scans ordered oldest first. Tickets checked
+// in before scans were recorded have no history and count as inside after a single entry.
+func newScanState(scans []*checkin.TicketScan) *scanState {
+	if len(scans) == 0 {
+		return &scanState{inside: true}
+	}
+
+	entries := 0
+	for _, scan := range scans {
+		if scan.Direction == checkin.ScanDirectionEntry {
+			entries++
+		}
+	}
+	if scans[0].Direction != checkin.ScanDirectionEntry {
+		// History starts with an exit: the first entry predates scan tracking
+		entries++
+	}
+
+	return &scanState{
+		inside:        scans[len(scans)-1].Direction == checkin.ScanDirectionEntry,
+		reentriesUsed: entries - 1,
+	}
+}
+
+// scansForSchedule filters a ticket's scan history down to one schedule (passes have one per day),
+// skipping scans of voided check-ins
+func scansForSchedule(scans []*checkin.TicketScan, scheduleID string) []*checkin.TicketScan {
+	filtered := make([]*checkin.TicketScan, 0, len(scans))
+	for _, scan := range scans {
+		if scan.ScheduleID == scheduleID && !scan.Voided {
+			filtered = append(filtered, scan)
+		}
+	}
+	return filtered
+}
+
+// evaluateReentry validates a ticket already checked in for the schedule against its category's re-entry policy
+func (s *Service) evaluateReentry(orderItem *orderitem.OrderItem, scheduleID string) (*checkin.ValidateQRCodeResponse, string, error) {
+	scans, err := s.checkInRepo.FindScansByOrderItemID(orderItem.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	state := newScanState(scansForSchedule(scans, scheduleID))
+
+	result := &checkin.ValidateQRCodeResponse{
+		Valid:       false,
+		OrderItemID: orderItem.ID,
+		ScheduleID:  scheduleID,
+		Status:      string(orderItem.Status),
+		AlreadyUsed: true,
+		Inside:      state.inside,
+	}
+
+	switch {
+	case orderItem.Category == nil || orderItem.Category.ReentryPolicy == "" || orderItem.Category.ReentryPolicy == ticketcategory.ReentryPolicyNone:
+		result.Message = "QR code sudah pernah digunakan"
+		return result, "DUPLICATE_CHECK_IN", nil
+	case state.inside:
+		result.Message = "Tiket sudah berada di dalam venue"
+		return result, "ALREADY_INSIDE", nil
+	case !orderItem.Category.AllowsReentry(state.reentriesUsed):
+		result.Message = "Batas re-entry tiket sudah habis"
+		return result, "REENTRY_NOT_ALLOWED", nil
+	}
+
+	result.Valid = true
+	result.Reentry = true
+	result.Message = "QR code valid untuk re-entry"
+	return result, "", nil
+}
+
+// reenter records an entry scan for a ticket that scanned out of the schedule earlier
+func (s *Service) reenter(orderItem *orderitem.OrderItem, scheduleID string, gateID, deviceID *string, staffID string) (*checkin.CheckInResultResponse, error) {
+	var checkIn checkin.CheckIn
+	var scan *checkin.TicketScan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the ticket so concurrent scans at different gates see each other's history
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", orderItem.ID).First(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("order_item_id = ? AND schedule_id = ? AND status <> ?", orderItem.ID, scheduleID, checkin.CheckInStatusVoided).First(&checkIn).Error; err != nil {
+			return err
+		}
+
+		var scans []*checkin.TicketScan
+		if err := tx.Where("order_item_id = ? AND schedule_id = ? AND voided = ?", orderItem.ID, scheduleID, false).Order("scanned_at ASC").Find(&scans).Error; err != nil {
+			return err
+		}
+		state := newScanState(scans)
+		if state.inside {
+			return ErrTicketAlreadyInside
+		}
+		if orderItem.Category == nil || !orderItem.Category.AllowsReentry(state.reentriesUsed) {
+			return ErrReentryNotAllowed
+		}
+
+		scan = &checkin.TicketScan{
+			OrderItemID: orderItem.ID,
+			CheckInID:   checkIn.ID,
+			ScheduleID:  scheduleID,
+			GateID:      gateID,
+			DeviceID:    deviceID,
+			StaffID:     staffID,
+			Direction:   checkin.ScanDirectionEntry,
+		}
+		return tx.Create(scan).Error
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTicketAlreadyInside):
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "Tiket sudah berada di dalam venue",
+				ErrorCode: "ALREADY_INSIDE",
+			}, nil
+		case errors.Is(err, ErrReentryNotAllowed):
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "Batas re-entry tiket sudah habis",
+				ErrorCode: "REENTRY_NOT_ALLOWED",
+			}, nil
+		}
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Gagal menyimpan re-entry",
+			ErrorCode: "CREATE_CHECK_IN_ERROR",
+		}, err
+	}
+
+	s.occupancy.RecordReentry(gateID, scheduleID)
+
+	return &checkin.CheckInResultResponse{
+		Success: true,
+		CheckIn: checkIn.ToCheckInResponse(),
+		Scan:    scan.ToTicketScanResponse(),
+		Message: "Re-entry berhasil",
+	}, nil
+}
+
+// Exit records an exit scan for a ticket that is currently inside
+func (s *Service) Exit(req *checkin.ExitScanRequest, staffID string) (*checkin.CheckInResultResponse, error) {
+	orderItem, err := s.orderItemRepo.FindByQRCode(req.QRCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "QR code tidak valid",
+				ErrorCode: "INVALID_QR_CODE",
+			}, nil
+		}
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Terjadi kesalahan saat validasi QR code",
+			ErrorCode: "VALIDATION_ERROR",
+		}, err
+	}
+
+	var checkIn checkin.CheckIn
+	var scan *checkin.TicketScan
+	canReturn := false
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", orderItem.ID).First(&item).Error; err != nil {
+			return err
+		}
+		// The ticket exits the schedule it last entered (passes check in once per day)
+		var scans []*checkin.TicketScan
+		if err := tx.Where("order_item_id = ? AND voided = ?", orderItem.ID, false).Order("scanned_at ASC").Find(&scans).Error; err != nil {
+			return err
+		}
+		checkInQuery := tx.Where("order_item_id = ? AND status <> ?", orderItem.ID, checkin.CheckInStatusVoided)
+		if len(scans) > 0 {
+			checkInQuery = checkInQuery.Where("schedule_id = ?", scans[len(scans)-1].ScheduleID)
+		}
+		if err := checkInQuery.Order("checked_in_at DESC").First(&checkIn).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketNotInside
+			}
+			return err
+		}
+		scheduleID := orderItem.Order.ScheduleID
+		if checkIn.ScheduleID != nil {
+			scheduleID = *checkIn.ScheduleID
+		}
+
+		state := newScanState(scansForSchedule(scans, scheduleID))
+		if !state.inside {
+			return ErrTicketNotInside
+		}
+		canReturn = orderItem.Category != nil && orderItem.Category.AllowsReentry(state.reentriesUsed)
+
+		scan = &checkin.TicketScan{
+			OrderItemID: orderItem.ID,
+			CheckInID:   checkIn.ID,
+			ScheduleID:  scheduleID,
+			GateID:      req.GateID,
+			DeviceID:    req.DeviceID,
+			StaffID:     staffID,
+			Direction:   checkin.ScanDirectionExit,
+		}
+		return tx.Create(scan).Error
+	})
+	if err != nil {
+		if errors.Is(err, ErrTicketNotInside) {
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "Tiket tidak sedang berada di dalam venue",
+				ErrorCode: "NOT_INSIDE",
+			}, nil
+		}
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Gagal menyimpan exit",
+			ErrorCode: "CREATE_CHECK_IN_ERROR",
+		}, err
+	}
+
+	s.occupancy.RecordExit(req.GateID, scan.ScheduleID)
+
+	message := "Exit berhasil"
+	if !canReturn {
+		message = "Exit berhasil, tiket tidak dapat digunakan untuk masuk kembali"
+	}
+
+	return &checkin.CheckInResultResponse{
+		Success: true,
+		CheckIn: checkIn.ToCheckInResponse(),
+		Scan:    scan.ToTicketScanResponse(),
+		Message: message,
+	}, nil
+}
+
+// GetScansByOrderItemID returns the entry/exit history of a ticket, oldest first
+func (s *Service) GetScansByOrderItemID(orderItemID string) ([]*checkin.TicketScanResponse, error) {
+	scans, err := s.checkInRepo.FindScansByOrderItemID(orderItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*checkin.TicketScanResponse, len(scans))
+	for i, scan := range scans {
+		responses[i] = scan.ToTicketScanResponse()
+	}
+	return responses, nil
+}
+
+// GetOccupancy returns how many tickets are currently inside, per schedule and per gate
+func (s *Service) GetOccupancy(req *checkin.GetOccupancyRequest) (*checkin.OccupancyResponse, error) {
+	filters := make(map[string]interface{})
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	return s.checkInRepo.GetOccupancy(filters)
+}
@@ -0,0 +1,94 @@
+package checkin
+
+import (
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+)
+
+// scheduleCheck is the schedule a scan admits to, or why the scan doesn't fit any of the ticket's schedules
+type scheduleCheck struct {
+	scheduleID string
+	errorCode  string
+	message    string
+}
+
+// checkInWindow returns when check-in opens and closes for a schedule, including the configured grace periods
+func checkInWindow(sched *schedule.Schedule) (time.Time, time.Time) {
+	start, end := sched.Window()
+	if config.AppConfig != nil {
+		start = start.Add(-time.Duration(config.AppConfig.CheckIn.EarlyOpenMinutes) * time.Minute)
+		end = end.Add(time.Duration(config.AppConfig.CheckIn.LateCloseMinutes) * time.Minute)
+	}
+	return start, end
+}
+
+// resolveSchedule returns the schedule a scan of the ticket applies to. Single-schedule tickets use
+// their order's schedule; multi-day passes use the covered schedule whose check-in window contains the
+// scan. When the scanner is admitting a specific schedule (expectedScheduleID), the ticket must be valid for it.
+func (s *Service) resolveSchedule(orderItem *orderitem.OrderItem, expectedScheduleID string, now time.Time) (*scheduleCheck, error) {
+	var passSchedules []ticketcategory.PassSchedule
+	if err := s.db.Where("ticket_category_id = ?", orderItem.CategoryID).Preload("Schedule").Find(&passSchedules).Error; err != nil {
+		return nil, err
+	}
+
+	var candidates []*schedule.Schedule
+	if len(passSchedules) == 0 {
+		sched := orderItem.Order.Schedule
+		if sched == nil {
+			sched = &schedule.Schedule{}
+			if err := s.db.Where("id = ?", orderItem.Order.ScheduleID).First(sched).Error; err != nil {
+				return nil, err
+			}
+		}
+		candidates = append(candidates, sched)
+	} else {
+		for _, ps := range passSchedules {
+			if ps.Schedule != nil {
+				candidates = append(candidates, ps.Schedule)
+			}
+		}
+	}
+
+	if expectedScheduleID != "" {
+		var matched []*schedule.Schedule
+		for _, sched := range candidates {
+			if sched.ID == expectedScheduleID {
+				matched = append(matched, sched)
+			}
+		}
+		if len(matched) == 0 {
+			return &scheduleCheck{
+				errorCode: "WRONG_SCHEDULE",
+				message:   "Tiket tidak berlaku untuk jadwal ini",
+			}, nil
+		}
+		candidates = matched
+	}
+
+	// Inside a check-in window; otherwise report whether the next window is still ahead
+	var nextOpen *time.Time
+	for _, sched := range candidates {
+		opens, closes := checkInWindow(sched)
+		if !now.Before(opens) && now.Before(closes) {
+			return &scheduleCheck{scheduleID: sched.ID}, nil
+		}
+		if now.Before(opens) && (nextOpen == nil || opens.Before(*nextOpen)) {
+			nextOpen = &opens
+		}
+	}
+
+	if nextOpen != nil {
+		return &scheduleCheck{
+			errorCode: "TOO_EARLY",
+			message:   "Check-in belum dibuka, dibuka pada " + nextOpen.Format("02 Jan 2006 15:04"),
+		}, nil
+	}
+	return &scheduleCheck{
+		errorCode: "SCHEDULE_ENDED",
+		message:   "Jadwal sudah berakhir",
+	}, nil
+}
@@ -2,12 +2,17 @@ package checkin
 
 import (
 	"errors"
+	"log"
 	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
 	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	checkinrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/checkin"
 	orderitemrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/order_item"
+	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
+	occupancyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/occupancy"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
@@ -22,106 +27,160 @@ var (
 )
 
 type Service struct {
+	db            *gorm.DB
 	checkInRepo   checkinrepo.Repository
 	orderItemRepo orderitemrepo.Repository
+	auditService  *auditservice.Service
+	occupancy     *occupancyservice.Service
 }
 
-func NewService(checkInRepo checkinrepo.Repository, orderItemRepo orderitemrepo.Repository) *Service {
+func NewService(checkInRepo checkinrepo.Repository, orderItemRepo orderitemrepo.Repository, auditService *auditservice.Service, occupancy *occupancyservice.Service) *Service {
 	return &Service{
+		db:            database.DB,
 		checkInRepo:   checkInRepo,
 		orderItemRepo: orderItemRepo,
+		auditService:  auditService,
+		occupancy:     occupancy,
 	}
 }
 
-// ValidateQRCode validates a QR code and returns validation result
-func (s *Service) ValidateQRCode(qrCode string) (*checkin.ValidateQRCodeResponse, error) {
+// ValidateQRCode validates a QR code and returns validation result; scheduleID is the schedule
+// being admitted by the scanner (optional)
+func (s *Service) ValidateQRCode(qrCode, scheduleID string) (*checkin.ValidateQRCodeResponse, error) {
 	// Find order item by QR code
 	orderItem, err := s.orderItemRepo.FindByQRCode(qrCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return &checkin.ValidateQRCodeResponse{
-				Valid:   false,
-				Message: "QR code tidak valid",
+				Valid:     false,
+				Message:   "QR code tidak valid",
+				ErrorCode: "INVALID_QR_CODE",
 			}, nil
 		}
 		return nil, err
 	}
 
-	// Check if ticket is paid
-	if orderItem.Status != orderitem.TicketStatusPaid {
+	validation, errorCode, err := s.validateOrderItem(orderItem, scheduleID)
+	if validation != nil && !validation.Valid {
+		validation.ErrorCode = errorCode
+	}
+	return validation, err
+}
+
+// validateOrderItem validates a ticket for admission and returns the error code a check-in would fail with
+func (s *Service) validateOrderItem(orderItem *orderitem.OrderItem, expectedScheduleID string) (*checkin.ValidateQRCodeResponse, string, error) {
+	// Check if ticket is paid (checked-in tickets are evaluated for re-entry below)
+	if orderItem.Status != orderitem.TicketStatusPaid && orderItem.Status != orderitem.TicketStatusCheckedIn {
 		return &checkin.ValidateQRCodeResponse{
 			Valid:   false,
 			Status:  string(orderItem.Status),
 			Message: "Tiket belum dibayar",
-		}, nil
+		}, "TICKET_NOT_PAID", nil
 	}
 
-	// Check if already checked in (one-scan validation)
-	checkInCount, err := s.checkInRepo.CountByOrderItemID(orderItem.ID)
-	if err != nil {
-		return nil, err
+	// Tickets on the resale marketplace can't be used until the listing is canceled or sold
+	if orderItem.ListedForResale {
+		return &checkin.ValidateQRCodeResponse{
+			Valid:       false,
+			OrderItemID: orderItem.ID,
+			Status:      string(orderItem.Status),
+			Message:     "Tiket sedang dijual di resale",
+		}, "INVALID_QR_CODE", nil
 	}
 
-	if checkInCount > 0 {
+	// Tickets held by a fraud alert can't enter until a supervisor reviews the alert
+	if orderItem.FraudHold {
 		return &checkin.ValidateQRCodeResponse{
 			Valid:       false,
 			OrderItemID: orderItem.ID,
 			Status:      string(orderItem.Status),
-			Message:     "QR code sudah pernah digunakan",
-			AlreadyUsed: true,
-		}, nil
+			Message:     "Tiket ditahan untuk pemeriksaan keamanan",
+		}, "TICKET_BLOCKED", nil
+	}
+
+	// Scan must fall within a schedule's check-in window; passes are checked in once per schedule
+	sc, err := s.resolveSchedule(orderItem, expectedScheduleID, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	if sc.errorCode != "" {
+		return &checkin.ValidateQRCodeResponse{
+			Valid:       false,
+			OrderItemID: orderItem.ID,
+			Status:      string(orderItem.Status),
+			Message:     sc.message,
+		}, sc.errorCode, nil
+	}
+
+	// Check if already checked in (one-scan validation, unless the category allows re-entry)
+	checkInCount, err := s.checkInRepo.CountByOrderItemAndSchedule(orderItem.ID, sc.scheduleID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if checkInCount > 0 {
+		return s.evaluateReentry(orderItem, sc.scheduleID)
 	}
 
 	return &checkin.ValidateQRCodeResponse{
 		Valid:       true,
 		OrderItemID: orderItem.ID,
+		ScheduleID:  sc.scheduleID,
 		Status:      string(orderItem.Status),
 		Message:     "QR code valid",
 		AlreadyUsed: false,
-	}, nil
+	}, "", nil
 }
 
 // CheckIn performs check-in operation
 func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userAgent string) (*checkin.CheckInResultResponse, error) {
-	// Validate QR code first
-	validation, err := s.ValidateQRCode(req.QRCode)
+	// Find order item
+	orderItem, err := s.orderItemRepo.FindByQRCode(req.QRCode)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "QR code tidak valid",
+				ErrorCode: "INVALID_QR_CODE",
+			}, nil
+		}
 		return &checkin.CheckInResultResponse{
 			Success:   false,
-			Message:   "Terjadi kesalahan saat validasi QR code",
-			ErrorCode: "VALIDATION_ERROR",
+			Message:   "Order item tidak ditemukan",
+			ErrorCode: "ORDER_ITEM_NOT_FOUND",
 		}, err
 	}
 
-	if !validation.Valid {
+	// Validate ticket first
+	validation, errorCode, err := s.validateOrderItem(orderItem, req.ScheduleID)
+	if err != nil {
 		return &checkin.CheckInResultResponse{
 			Success:   false,
-			Message:   validation.Message,
-			ErrorCode: "INVALID_QR_CODE",
-		}, nil
+			Message:   "Terjadi kesalahan saat validasi QR code",
+			ErrorCode: "VALIDATION_ERROR",
+		}, err
 	}
 
-	if validation.AlreadyUsed {
-		return &checkin.CheckInResultResponse{
-			Success:   false,
-			Message:   "QR code sudah pernah digunakan",
-			ErrorCode: "QR_CODE_ALREADY_USED",
-		}, nil
+	// Ticket scanned out earlier and its category allows coming back in
+	if validation.Reentry {
+		return s.reenter(orderItem, validation.ScheduleID, req.GateID, req.DeviceID, staffID)
 	}
 
-	// Find order item
-	orderItem, err := s.orderItemRepo.FindByQRCode(req.QRCode)
-	if err != nil {
+	// Tickets that were already admitted fall through to duplicate detection below
+	if !validation.Valid && errorCode != "DUPLICATE_CHECK_IN" {
+		if errorCode == "" {
+			errorCode = "INVALID_QR_CODE"
+		}
 		return &checkin.CheckInResultResponse{
 			Success:   false,
-			Message:   "Order item tidak ditemukan",
-			ErrorCode: "ORDER_ITEM_NOT_FOUND",
-		}, err
+			Message:   validation.Message,
+			ErrorCode: errorCode,
+		}, nil
 	}
 
-	// Check if already checked in (duplicate detection)
-	existingCheckIns, err := s.checkInRepo.FindByOrderItemID(orderItem.ID)
-	if err != nil {
+	// Check if already checked in for this schedule (duplicate detection)
+	existingCheckIn, err := s.checkInRepo.FindByOrderItemAndSchedule(orderItem.ID, validation.ScheduleID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return &checkin.CheckInResultResponse{
 			Success:   false,
 			Message:   "Terjadi kesalahan saat cek duplicate",
@@ -129,18 +188,18 @@ func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userA
 		}, err
 	}
 
-	if len(existingCheckIns) > 0 {
+	if existingCheckIn != nil {
 		// Duplicate detected - return existing check-in
 		return &checkin.CheckInResultResponse{
 			Success: false,
-			CheckIn: existingCheckIns[0].ToCheckInResponse(),
+			CheckIn: existingCheckIn.ToCheckInResponse(),
 			Message: "QR code sudah pernah digunakan (duplicate detected)",
 			ErrorCode: "DUPLICATE_CHECK_IN",
 		}, nil
 	}
 
-	// Check if ticket is paid
-	if orderItem.Status != orderitem.TicketStatusPaid {
+	// Check if ticket is paid (passes are already CHECKED-IN from an earlier day)
+	if orderItem.Status != orderitem.TicketStatusPaid && orderItem.Status != orderitem.TicketStatusCheckedIn {
 		return &checkin.CheckInResultResponse{
 			Success:   false,
 			Message:   "Tiket belum dibayar",
@@ -148,12 +207,31 @@ func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userA
 		}, nil
 	}
 
+	// Reserve a place at the gate first, so concurrent scans can't overshoot its capacity
+	admitted, err := s.occupancy.Admit(req.GateID, validation.ScheduleID)
+	if err != nil {
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Terjadi kesalahan saat cek kapasitas gate",
+			ErrorCode: "CAPACITY_CHECK_ERROR",
+		}, err
+	}
+	if !admitted {
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Kapasitas gate sudah penuh",
+			ErrorCode: "GATE_CAPACITY_EXCEEDED",
+		}, nil
+	}
+
 	// Create check-in record
 	now := time.Now()
 	checkIn := &checkin.CheckIn{
 		OrderItemID: orderItem.ID,
+		ScheduleID:  &validation.ScheduleID,
 		QRCode:      req.QRCode,
 		GateID:      req.GateID,
+		DeviceID:    req.DeviceID,
 		StaffID:     staffID,
 		Status:      checkin.CheckInStatusSuccess,
 		Location:    req.Location,
@@ -163,13 +241,14 @@ func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userA
 	}
 
 	if err := s.checkInRepo.Create(checkIn); err != nil {
+		s.occupancy.Release(req.GateID, validation.ScheduleID)
 		if isPostgresUniqueViolation(err) {
 			// Another concurrent request inserted the check-in first.
-			existingCheckIns, fetchErr := s.checkInRepo.FindByOrderItemID(orderItem.ID)
-			if fetchErr == nil && len(existingCheckIns) > 0 {
+			existingCheckIn, fetchErr := s.checkInRepo.FindByOrderItemAndSchedule(orderItem.ID, validation.ScheduleID)
+			if fetchErr == nil {
 				return &checkin.CheckInResultResponse{
 					Success:   false,
-					CheckIn:   existingCheckIns[0].ToCheckInResponse(),
+					CheckIn:   existingCheckIn.ToCheckInResponse(),
 					Message:   "QR code sudah pernah digunakan (duplicate detected)",
 					ErrorCode: "DUPLICATE_CHECK_IN",
 				}, nil
@@ -189,6 +268,23 @@ func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userA
 		}, err
 	}
 
+	// First entry starts the ticket's scan history
+	scan := &checkin.TicketScan{
+		OrderItemID: orderItem.ID,
+		CheckInID:   checkIn.ID,
+		ScheduleID:  validation.ScheduleID,
+		GateID:      req.GateID,
+		DeviceID:    req.DeviceID,
+		StaffID:     staffID,
+		Direction:   checkin.ScanDirectionEntry,
+		ScannedAt:   now,
+	}
+	if err := s.checkInRepo.CreateScan(scan); err != nil {
+		// Without scans the ticket still counts as inside, so exit/re-entry keep working
+		log.Printf("[CheckIn] Failed to record entry scan for ticket %s: %v", orderItem.ID, err)
+		scan = nil
+	}
+
 	// Update order item status to CHECKED-IN
 	orderItem.Status = orderitem.TicketStatusCheckedIn
 	orderItem.CheckInTime = &now
@@ -208,11 +304,15 @@ func (s *Service) CheckIn(req *checkin.CheckInRequest, staffID, ipAddress, userA
 		}, err
 	}
 
-	return &checkin.CheckInResultResponse{
+	result := &checkin.CheckInResultResponse{
 		Success: true,
 		CheckIn:  createdCheckIn.ToCheckInResponse(),
 		Message: "Check-in berhasil",
-	}, nil
+	}
+	if scan != nil {
+		result.Scan = scan.ToTicketScanResponse()
+	}
+	return result, nil
 }
 
 func isPostgresUniqueViolation(err error) bool {
@@ -236,7 +336,7 @@ func (s *Service) GetByID(id string) (*checkin.CheckInResponse, error) {
 }
 
 // List lists check-ins with pagination and filters
-func (s *Service) List(req *checkin.ListCheckInsRequest) ([]*checkin.CheckInResponse, *response.PaginationMeta, error) {
+func (s *Service) List(scope organization.Scope, req *checkin.ListCheckInsRequest) ([]*checkin.CheckInResponse, *response.PaginationMeta, error) {
 	page := 1
 	perPage := 20
 
@@ -248,7 +348,9 @@ func (s *Service) List(req *checkin.ListCheckInsRequest) ([]*checkin.CheckInResp
 	}
 
 	// Build filters
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.OrderItemID != "" {
 		filters["order_item_id"] = req.OrderItemID
 	}
@@ -0,0 +1,89 @@
+package checkin
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCheckInAlreadyVoided = errors.New("check-in already voided")
+)
+
+// Void reverts a mistaken check-in: the row is kept but marked voided with reason and actor, its scans
+// stop counting, and the ticket goes back to PAID unless another schedule's check-in (passes) remains
+func (s *Service) Void(id string, req *checkin.VoidCheckInRequest, actorID string) (*checkin.CheckInResponse, error) {
+	var voided checkin.CheckIn
+	var voidedScans []*checkin.TicketScan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var c checkin.CheckIn
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&c).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCheckInNotFound
+			}
+			return err
+		}
+		if c.Status == checkin.CheckInStatusVoided {
+			return ErrCheckInAlreadyVoided
+		}
+
+		now := time.Now()
+		c.Status = checkin.CheckInStatusVoided
+		c.VoidedAt = &now
+		c.VoidedBy = &actorID
+		c.VoidReason = req.Reason
+		if err := tx.Save(&c).Error; err != nil {
+			return err
+		}
+
+		var scans []*checkin.TicketScan
+		if err := tx.Where("check_in_id = ? AND voided = ?", c.ID, false).Find(&scans).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&checkin.TicketScan{}).Where("check_in_id = ?", c.ID).Update("voided", true).Error; err != nil {
+			return err
+		}
+		voided = c
+		voidedScans = scans
+
+		var remaining int64
+		if err := tx.Model(&checkin.CheckIn{}).
+			Where("order_item_id = ? AND status <> ?", c.OrderItemID, checkin.CheckInStatusVoided).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return nil
+		}
+
+		return tx.Model(&orderitem.OrderItem{}).
+			Where("id = ? AND status = ?", c.OrderItemID, orderitem.TicketStatusCheckedIn).
+			Updates(map[string]interface{}{
+				"status":        orderitem.TicketStatusPaid,
+				"check_in_time": nil,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The voided check-in and its scans no longer count towards the gate counters
+	s.occupancy.RevertCheckIn(&voided, voidedScans)
+
+	return s.GetByID(id)
+}
+
+// VoidWithAudit voids a check-in and records the correction in the audit log
+func (s *Service) VoidWithAudit(c *gin.Context, id string, req *checkin.VoidCheckInRequest, actorID string) (*checkin.CheckInResponse, error) {
+	oldCheckIn, _ := s.GetByID(id)
+	resp, err := s.Void(id, req, actorID)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "CHECK_IN_VOID", "check_in", id, oldCheckIn, resp)
+	}
+	return resp, err
+}
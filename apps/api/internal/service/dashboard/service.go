@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/dashboard"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	dashboardrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/dashboard"
 )
 
@@ -16,53 +17,53 @@ func NewService(repo dashboardrepo.Repository) *Service {
 }
 
 // GetSalesOverview gets sales overview
-func (s *Service) GetSalesOverview(filters *dashboard.DashboardFilters) (*dashboard.SalesOverview, error) {
-	return s.repo.GetSalesOverview(filters.StartDate, filters.EndDate, filters.EventID)
+func (s *Service) GetSalesOverview(scope organization.Scope, filters *dashboard.DashboardFilters) (*dashboard.SalesOverview, error) {
+	return s.repo.GetSalesOverview(scope, filters.StartDate, filters.EndDate, filters.EventID)
 }
 
 // GetCheckInOverview gets check-in overview
-func (s *Service) GetCheckInOverview(filters *dashboard.DashboardFilters) (*dashboard.CheckInOverview, error) {
-	return s.repo.GetCheckInOverview(filters.StartDate, filters.EndDate, filters.EventID)
+func (s *Service) GetCheckInOverview(scope organization.Scope, filters *dashboard.DashboardFilters) (*dashboard.CheckInOverview, error) {
+	return s.repo.GetCheckInOverview(scope, filters.StartDate, filters.EndDate, filters.EventID)
 }
 
 // GetQuotaOverview gets quota overview
-func (s *Service) GetQuotaOverview(filters *dashboard.DashboardFilters) (*dashboard.QuotaOverview, error) {
-	return s.repo.GetQuotaOverview(filters.EventID)
+func (s *Service) GetQuotaOverview(scope organization.Scope, filters *dashboard.DashboardFilters) (*dashboard.QuotaOverview, error) {
+	return s.repo.GetQuotaOverview(scope, filters.EventID)
 }
 
 // GetGateActivity gets gate activity
-func (s *Service) GetGateActivity(filters *dashboard.DashboardFilters) ([]*dashboard.GateActivity, error) {
-	return s.repo.GetGateActivity(filters.GateID)
+func (s *Service) GetGateActivity(scope organization.Scope, filters *dashboard.DashboardFilters) ([]*dashboard.GateActivity, error) {
+	return s.repo.GetGateActivity(scope, filters.GateID)
 }
 
 // GetBuyerList gets buyer list
-func (s *Service) GetBuyerList(filters *dashboard.DashboardFilters) ([]*dashboard.BuyerSummary, error) {
-	return s.repo.GetBuyerList(filters.StartDate, filters.EndDate, filters.EventID)
+func (s *Service) GetBuyerList(scope organization.Scope, filters *dashboard.DashboardFilters) ([]*dashboard.BuyerSummary, error) {
+	return s.repo.GetBuyerList(scope, filters.StartDate, filters.EndDate, filters.EventID)
 }
 
-// GetDashboardOverview gets complete dashboard overview
-func (s *Service) GetDashboardOverview(filters *dashboard.DashboardFilters) (*dashboard.DashboardOverview, error) {
-	sales, err := s.GetSalesOverview(filters)
+// GetDashboardOverview gets complete dashboard overview within the organization scope
+func (s *Service) GetDashboardOverview(scope organization.Scope, filters *dashboard.DashboardFilters) (*dashboard.DashboardOverview, error) {
+	sales, err := s.GetSalesOverview(scope, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	checkIns, err := s.GetCheckInOverview(filters)
+	checkIns, err := s.GetCheckInOverview(scope, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	quota, err := s.GetQuotaOverview(filters)
+	quota, err := s.GetQuotaOverview(scope, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	gates, err := s.GetGateActivity(filters)
+	gates, err := s.GetGateActivity(scope, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	buyers, err := s.GetBuyerList(filters)
+	buyers, err := s.GetBuyerList(scope, filters)
 	if err != nil {
 		return nil, err
 	}
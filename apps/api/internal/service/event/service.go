@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	eventrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/event"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"gorm.io/gorm"
@@ -38,8 +39,8 @@ func (s *Service) GetByID(id string) (*event.EventResponse, error) {
 	return e.ToEventResponse(), nil
 }
 
-// Create creates a new event
-func (s *Service) Create(req *event.CreateEventRequest) (*event.EventResponse, error) {
+// Create creates a new event owned by the organization
+func (s *Service) Create(organizationID string, req *event.CreateEventRequest) (*event.EventResponse, error) {
 	// Validate date range
 	if req.EndDate.Before(req.StartDate) {
 		return nil, ErrInvalidDateRange
@@ -65,12 +66,13 @@ func (s *Service) Create(req *event.CreateEventRequest) (*event.EventResponse, e
 
 	// Create event
 	e := &event.Event{
-		EventName:   req.EventName,
-		Description: req.Description,
-		BannerImage: req.BannerImage,
-		Status:      status,
-		StartDate:   req.StartDate,
-		EndDate:     req.EndDate,
+		OrganizationID: &organizationID,
+		EventName:      req.EventName,
+		Description:    req.Description,
+		BannerImage:    req.BannerImage,
+		Status:         status,
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
 	}
 
 	if err := s.repo.Create(e); err != nil {
@@ -166,8 +168,8 @@ func (s *Service) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-// List lists all events with pagination and filters
-func (s *Service) List(req *event.ListEventRequest) ([]*event.EventResponse, int64, *response.PaginationMeta, error) {
+// List lists the events within the organization scope with pagination and filters
+func (s *Service) List(scope organization.Scope, req *event.ListEventRequest) ([]*event.EventResponse, int64, *response.PaginationMeta, error) {
 	// Set defaults
 	page := req.Page
 	if page < 1 {
@@ -183,7 +185,9 @@ func (s *Service) List(req *event.ListEventRequest) ([]*event.EventResponse, int
 	}
 
 	// Build filters
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.Search != "" {
 		filters["search"] = req.Search
 	}
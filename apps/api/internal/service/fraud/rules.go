@@ -0,0 +1,217 @@
+package fraud
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	"gorm.io/gorm"
+)
+
+// ruleDedupWindow suppresses repeat alerts for the same rule and subject
+const ruleDedupWindow = time.Hour
+
+// rule inspects a scan attempt and returns an alert when it matches a suspicious pattern
+type rule func(s *Service, entry *scanlog.ScanLog, cfg config.FraudConfig) (*fraud.Alert, error)
+
+var rules = []rule{
+	checkMultiGate,
+	checkInvalidBurst,
+	checkUnassignedStaff,
+	checkRefundedTicket,
+}
+
+// Evaluate runs the fraud rules over a recorded scan attempt, storing an alert for every match.
+// Rule failures are logged and never affect the scan itself.
+func (s *Service) Evaluate(entry *scanlog.ScanLog) {
+	cfg := config.AppConfig.Fraud
+	for _, r := range rules {
+		alert, err := r(s, entry, cfg)
+		if err != nil {
+			log.Printf("[Fraud] Failed to evaluate scan %s: %v", entry.ID, err)
+			continue
+		}
+		if alert == nil {
+			continue
+		}
+		s.raise(alert, entry, cfg)
+	}
+}
+
+// raise stores an alert unless one was already raised recently for the same rule and subject
+func (s *Service) raise(alert *fraud.Alert, entry *scanlog.ScanLog, cfg config.FraudConfig) {
+	exists, err := s.alertRepo.ExistsSince(alert.Rule, alert.Subject, time.Now().Add(-ruleDedupWindow))
+	if err != nil {
+		log.Printf("[Fraud] Failed to check existing %s alerts: %v", alert.Rule, err)
+		return
+	}
+	if exists {
+		return
+	}
+
+	alert.Status = fraud.AlertStatusOpen
+	alert.ScanLogID = &entry.ID
+	alert.GateID = entry.GateID
+	alert.DeviceID = entry.DeviceID
+	alert.StaffID = entry.StaffID
+	if alert.OrderItemID == nil {
+		alert.OrderItemID = entry.OrderItemID
+	}
+
+	if err := s.alertRepo.Create(alert); err != nil {
+		log.Printf("[Fraud] Failed to store %s alert: %v", alert.Rule, err)
+		return
+	}
+
+	if cfg.AutoBlock && alert.Severity == fraud.SeverityHigh && alert.OrderItemID != nil {
+		if _, err := s.BlockTicket(alert.ID); err != nil {
+			log.Printf("[Fraud] Failed to block ticket %s for alert %s: %v", *alert.OrderItemID, alert.ID, err)
+		}
+	}
+}
+
+// checkMultiGate flags a ticket scanned for entry at one gate while it was admitted at another
+// gate within the window, without an exit in between (shared screenshots of the same QR code)
+func checkMultiGate(s *Service, entry *scanlog.ScanLog, cfg config.FraudConfig) (*fraud.Alert, error) {
+	if entry.Action != scanlog.ScanActionEntry || entry.GateID == nil || entry.RawCode == "" || cfg.MultiGateWindowMinutes <= 0 {
+		return nil, nil
+	}
+
+	since := entry.ScannedAt.Add(-time.Duration(cfg.MultiGateWindowMinutes) * time.Minute)
+	accepted, err := s.scanLogRepo.FindSuccessfulByRawCode(entry.RawCode, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the accepted scans before this one; an exit clears the gate the ticket is inside through
+	var admitted *scanlog.ScanLog
+	for _, l := range accepted {
+		if l.ID == entry.ID {
+			continue
+		}
+		switch l.Action {
+		case scanlog.ScanActionEntry:
+			admitted = l
+		case scanlog.ScanActionExit:
+			admitted = nil
+		}
+	}
+	if admitted == nil || admitted.GateID == nil || *admitted.GateID == *entry.GateID {
+		return nil, nil
+	}
+
+	alert := &fraud.Alert{
+		Rule:     fraud.RuleMultiGate,
+		Subject:  entry.RawCode,
+		Severity: fraud.SeverityHigh,
+		Message: fmt.Sprintf("QR code scanned at gate %s %s after being admitted at gate %s",
+			*entry.GateID, entry.ScannedAt.Sub(admitted.ScannedAt).Round(time.Second), *admitted.GateID),
+		OrderItemID: admitted.OrderItemID,
+	}
+	return alert, nil
+}
+
+// checkInvalidBurst flags a device (or staff member without a device ID) scanning many invalid codes
+func checkInvalidBurst(s *Service, entry *scanlog.ScanLog, cfg config.FraudConfig) (*fraud.Alert, error) {
+	if entry.ResultCode != "INVALID_QR_CODE" || cfg.InvalidBurstThreshold <= 0 || cfg.InvalidBurstWindowMinutes <= 0 {
+		return nil, nil
+	}
+
+	filters := map[string]interface{}{
+		"result_code": entry.ResultCode,
+		"start_date":  entry.ScannedAt.Add(-time.Duration(cfg.InvalidBurstWindowMinutes) * time.Minute),
+	}
+	var subject string
+	switch {
+	case entry.DeviceID != "":
+		filters["device_id"] = entry.DeviceID
+		subject = "device:" + entry.DeviceID
+	case entry.StaffID != nil:
+		filters["staff_id"] = *entry.StaffID
+		subject = "staff:" + *entry.StaffID
+	default:
+		return nil, nil
+	}
+
+	count, err := s.scanLogRepo.Count(filters)
+	if err != nil {
+		return nil, err
+	}
+	if count < int64(cfg.InvalidBurstThreshold) {
+		return nil, nil
+	}
+
+	return &fraud.Alert{
+		Rule:     fraud.RuleInvalidBurst,
+		Subject:  subject,
+		Severity: fraud.SeverityMedium,
+		Message:  fmt.Sprintf("%d invalid codes scanned within %d minutes", count, cfg.InvalidBurstWindowMinutes),
+	}, nil
+}
+
+// checkUnassignedStaff flags gate scans by staff who are not assigned to that gate or are outside
+// their shifts there. Rejected attempts are more suspicious than admin overrides, which are only
+// flagged at low severity.
+func checkUnassignedStaff(s *Service, entry *scanlog.ScanLog, cfg config.FraudConfig) (*fraud.Alert, error) {
+	if entry.Action == scanlog.ScanActionValidate || entry.GateID == nil || entry.StaffID == nil {
+		return nil, nil
+	}
+
+	severity := fraud.SeverityMedium
+	if entry.ResultCode != "GATE_STAFF_NOT_ASSIGNED" && entry.ResultCode != "GATE_STAFF_OFF_SHIFT" {
+		if !entry.Success {
+			return nil, nil
+		}
+		scheduleID := ""
+		if entry.ScheduleID != nil {
+			scheduleID = *entry.ScheduleID
+		}
+		onDuty, err := s.gateStaffRepo.IsStaffOnDuty(*entry.GateID, *entry.StaffID, scheduleID, entry.ScannedAt)
+		if err != nil {
+			return nil, err
+		}
+		if onDuty {
+			return nil, nil
+		}
+		severity = fraud.SeverityLow
+	}
+
+	return &fraud.Alert{
+		Rule:     fraud.RuleUnassignedStaff,
+		Subject:  *entry.StaffID + ":" + *entry.GateID,
+		Severity: severity,
+		Message:  fmt.Sprintf("Staff %s scanned at gate %s without being assigned to it or on shift there", *entry.StaffID, *entry.GateID),
+	}, nil
+}
+
+// checkRefundedTicket flags rejected scans of a refunded or canceled ticket, usually a screenshot
+// kept after the refund
+func checkRefundedTicket(s *Service, entry *scanlog.ScanLog, cfg config.FraudConfig) (*fraud.Alert, error) {
+	if entry.Success || entry.RawCode == "" || entry.ResultCode != "TICKET_NOT_PAID" {
+		return nil, nil
+	}
+
+	orderItem, err := s.orderItemRepo.FindByQRCode(entry.RawCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if orderItem.Status != orderitem.TicketStatusRefunded && orderItem.Status != orderitem.TicketStatusCanceled {
+		return nil, nil
+	}
+
+	return &fraud.Alert{
+		Rule:        fraud.RuleRefundedTicket,
+		Subject:     entry.RawCode,
+		Severity:    fraud.SeverityHigh,
+		Message:     fmt.Sprintf("%s ticket presented for %s", orderItem.Status, entry.Action),
+		OrderItemID: &orderItem.ID,
+	}, nil
+}
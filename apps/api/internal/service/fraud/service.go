@@ -0,0 +1,256 @@
+package fraud
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/fraud"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	fraudrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/fraud"
+	gatestaffrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_staff"
+	orderitemrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/order_item"
+	scanlogrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/scan_log"
+	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
+	revocationservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/revocation"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAlertNotFound        = errors.New("fraud alert not found")
+	ErrAlertAlreadyReviewed = errors.New("fraud alert already reviewed")
+	ErrAlertHasNoTicket     = errors.New("fraud alert is not linked to a ticket")
+)
+
+type Service struct {
+	db            *gorm.DB
+	alertRepo     fraudrepo.Repository
+	scanLogRepo   scanlogrepo.Repository
+	orderItemRepo orderitemrepo.Repository
+	gateStaffRepo gatestaffrepo.Repository
+	auditService  *auditservice.Service
+	revocations   *revocationservice.Service
+}
+
+func NewService(
+	alertRepo fraudrepo.Repository,
+	scanLogRepo scanlogrepo.Repository,
+	orderItemRepo orderitemrepo.Repository,
+	gateStaffRepo gatestaffrepo.Repository,
+	auditService *auditservice.Service,
+	revocations *revocationservice.Service,
+) *Service {
+	return &Service{
+		db:            database.DB,
+		alertRepo:     alertRepo,
+		scanLogRepo:   scanLogRepo,
+		orderItemRepo: orderItemRepo,
+		gateStaffRepo: gateStaffRepo,
+		auditService:  auditService,
+		revocations:   revocations,
+	}
+}
+
+// GetByID returns an alert by ID
+func (s *Service) GetByID(id string) (*fraud.AlertResponse, error) {
+	alert, err := s.alertRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+	return alert.ToAlertResponse(), nil
+}
+
+// List lists alerts with pagination and filters
+func (s *Service) List(scope organization.Scope, req *fraud.ListAlertsRequest) ([]*fraud.AlertResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.Rule != "" {
+		filters["rule"] = req.Rule
+	}
+	if req.Severity != "" {
+		filters["severity"] = req.Severity
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	if req.OrderItemID != "" {
+		filters["order_item_id"] = req.OrderItemID
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	if req.StartDate != nil {
+		filters["start_date"] = req.StartDate
+	}
+	if req.EndDate != nil {
+		filters["end_date"] = req.EndDate
+	}
+
+	alerts, total, err := s.alertRepo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*fraud.AlertResponse, len(alerts))
+	for i, a := range alerts {
+		responses[i] = a.ToAlertResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// BlockTicket puts the alert's ticket on hold so it can't enter until the alert is reviewed
+func (s *Service) BlockTicket(id string) (*fraud.AlertResponse, error) {
+	var blocked *fraud.Alert
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var alert fraud.Alert
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&alert).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAlertNotFound
+			}
+			return err
+		}
+		if alert.Status != fraud.AlertStatusOpen {
+			return ErrAlertAlreadyReviewed
+		}
+		if alert.OrderItemID == nil {
+			return ErrAlertHasNoTicket
+		}
+		if alert.TicketBlocked {
+			return nil
+		}
+
+		alert.TicketBlocked = true
+		if err := tx.Save(&alert).Error; err != nil {
+			return err
+		}
+		if err := setFraudHold(tx, *alert.OrderItemID, true); err != nil {
+			return err
+		}
+		blocked = &alert
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if blocked != nil {
+		s.publishHold(*blocked.OrderItemID, true, blocked.Message)
+	}
+
+	return s.GetByID(id)
+}
+
+// Review closes an open alert; dismissing it releases the ticket unless another alert still holds it
+func (s *Service) Review(id string, req *fraud.ReviewAlertRequest, reviewerID string) (*fraud.AlertResponse, error) {
+	var released *fraud.Alert
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var alert fraud.Alert
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&alert).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAlertNotFound
+			}
+			return err
+		}
+		if alert.Status != fraud.AlertStatusOpen {
+			return ErrAlertAlreadyReviewed
+		}
+
+		now := time.Now()
+		alert.Status = req.Status
+		alert.ReviewedBy = &reviewerID
+		alert.ReviewedAt = &now
+		alert.ReviewNote = req.Note
+		if err := tx.Save(&alert).Error; err != nil {
+			return err
+		}
+
+		if req.Status != fraud.AlertStatusDismissed || !alert.TicketBlocked || alert.OrderItemID == nil {
+			return nil
+		}
+
+		var blocking int64
+		if err := tx.Model(&fraud.Alert{}).
+			Where("order_item_id = ? AND id <> ? AND ticket_blocked = ?", *alert.OrderItemID, alert.ID, true).
+			Where("status IN ?", []fraud.AlertStatus{fraud.AlertStatusOpen, fraud.AlertStatusConfirmed}).
+			Count(&blocking).Error; err != nil {
+			return err
+		}
+		if blocking > 0 {
+			return nil
+		}
+		if err := setFraudHold(tx, *alert.OrderItemID, false); err != nil {
+			return err
+		}
+		released = &alert
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if released != nil {
+		s.publishHold(*released.OrderItemID, false, "Fraud alert dismissed")
+	}
+
+	return s.GetByID(id)
+}
+
+// BlockTicketWithAudit blocks the alert's ticket and records it in the audit log
+func (s *Service) BlockTicketWithAudit(c *gin.Context, id string) (*fraud.AlertResponse, error) {
+	oldAlert, _ := s.GetByID(id)
+	resp, err := s.BlockTicket(id)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "FRAUD_ALERT_BLOCK", "fraud_alert", id, oldAlert, resp)
+	}
+	return resp, err
+}
+
+// ReviewWithAudit reviews an alert and records the decision in the audit log
+func (s *Service) ReviewWithAudit(c *gin.Context, id string, req *fraud.ReviewAlertRequest, reviewerID string) (*fraud.AlertResponse, error) {
+	oldAlert, _ := s.GetByID(id)
+	resp, err := s.Review(id, req, reviewerID)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "FRAUD_ALERT_REVIEW", "fraud_alert", id, oldAlert, resp)
+	}
+	return resp, err
+}
+
+// publishHold tells connected gate devices that a ticket was put on hold or released
+func (s *Service) publishHold(orderItemID string, revoked bool, reason string) {
+	if s.revocations == nil {
+		return
+	}
+	orderItem, err := s.orderItemRepo.FindByID(orderItemID)
+	if err != nil {
+		log.Printf("[Fraud] Failed to load ticket %s for revocation update: %v", orderItemID, err)
+		return
+	}
+	s.revocations.Publish(revocationservice.Event{
+		OrderItemID: orderItem.ID,
+		QRCode:      orderItem.QRCode,
+		Revoked:     revoked,
+		Reason:      reason,
+	})
+}
+
+func setFraudHold(tx *gorm.DB, orderItemID string, hold bool) error {
+	return tx.Model(&orderitem.OrderItem{}).Where("id = ?", orderItemID).Update("fraud_hold", hold).Error
+}
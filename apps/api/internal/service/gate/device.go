@@ -0,0 +1,181 @@
+package gate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDeviceNotFound       = errors.New("gate device not found")
+	ErrDeviceAlreadyRevoked = errors.New("gate device already revoked")
+)
+
+// deviceOfflineAfter returns how long a device may go without a heartbeat before it is reported offline
+func deviceOfflineAfter() time.Duration {
+	seconds := 120
+	if config.AppConfig != nil && config.AppConfig.CheckIn.DeviceOfflineSeconds > 0 {
+		seconds = config.AppConfig.CheckIn.DeviceOfflineSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// EnrollDevice registers a scanner device for a gate and returns its token (shown only once)
+func (s *Service) EnrollDevice(gateID string, req *gate.EnrollDeviceRequest, enrolledBy string) (*gate.EnrollDeviceResponse, error) {
+	if _, err := s.gateRepo.FindByID(gateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGateNotFound
+		}
+		return nil, err
+	}
+
+	token, err := gate.GenerateDeviceToken()
+	if err != nil {
+		return nil, err
+	}
+
+	device := &gate.GateDevice{
+		GateID:     gateID,
+		Name:       req.Name,
+		TokenHash:  gate.HashDeviceToken(token),
+		Status:     gate.DeviceStatusActive,
+		EnrolledBy: enrolledBy,
+	}
+	if err := s.deviceRepo.Create(device); err != nil {
+		return nil, err
+	}
+
+	created, err := s.deviceRepo.FindByID(device.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gate.EnrollDeviceResponse{
+		Device:      created.ToGateDeviceResponse(time.Now(), deviceOfflineAfter()),
+		DeviceToken: token,
+	}, nil
+}
+
+// RevokeDevice revokes a device so its token can no longer scan or send heartbeats
+func (s *Service) RevokeDevice(deviceID, revokedBy string) (*gate.GateDeviceResponse, error) {
+	device, err := s.deviceRepo.FindByID(deviceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	if device.Status == gate.DeviceStatusRevoked {
+		return nil, ErrDeviceAlreadyRevoked
+	}
+
+	now := time.Now()
+	device.Status = gate.DeviceStatusRevoked
+	device.RevokedAt = &now
+	device.RevokedBy = &revokedBy
+	if err := s.deviceRepo.Update(device); err != nil {
+		return nil, err
+	}
+
+	return device.ToGateDeviceResponse(now, deviceOfflineAfter()), nil
+}
+
+// Heartbeat records that a device is alive along with its reported status
+func (s *Service) Heartbeat(deviceID string, req *gate.DeviceHeartbeatRequest, ipAddress string) (*gate.GateDeviceResponse, error) {
+	now := time.Now()
+	fields := map[string]interface{}{
+		"last_seen_at": now,
+		"last_ip":      ipAddress,
+		"queue_depth":  req.QueueDepth,
+	}
+	if req.BatteryLevel != nil {
+		fields["battery_level"] = *req.BatteryLevel
+	}
+	if req.AppVersion != "" {
+		fields["app_version"] = req.AppVersion
+	}
+	if err := s.deviceRepo.RecordHeartbeat(deviceID, fields); err != nil {
+		return nil, err
+	}
+
+	device, err := s.deviceRepo.FindByID(deviceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	return device.ToGateDeviceResponse(now, deviceOfflineAfter()), nil
+}
+
+// ListDevices lists scanner devices with their online state
+func (s *Service) ListDevices(scope organization.Scope, req *gate.ListDevicesRequest) ([]*gate.GateDeviceResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	now := time.Now()
+	offlineAfter := deviceOfflineAfter()
+
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	if req.Online != nil {
+		filters["online"] = *req.Online
+		filters["seen_since"] = now.Add(-offlineAfter)
+	}
+
+	devices, total, err := s.deviceRepo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*gate.GateDeviceResponse, len(devices))
+	for i, d := range devices {
+		responses[i] = d.ToGateDeviceResponse(now, offlineAfter)
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// verifyDeviceGate rejects scans from a registered device at a gate other than the one it is enrolled to
+func (s *Service) verifyDeviceGate(deviceID *string, gateID string) (*checkin.CheckInResultResponse, error) {
+	if deviceID == nil {
+		return nil, nil
+	}
+
+	device, err := s.deviceRepo.FindByID(*deviceID)
+	if err != nil {
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Terjadi kesalahan saat validasi perangkat",
+			ErrorCode: "DEVICE_VALIDATION_ERROR",
+		}, err
+	}
+	if device.GateID != gateID {
+		return &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Perangkat tidak terdaftar untuk gate ini",
+			ErrorCode: "DEVICE_GATE_MISMATCH",
+		}, nil
+	}
+	return nil, nil
+}
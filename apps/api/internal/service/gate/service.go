@@ -7,12 +7,17 @@ import (
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
 	checkinrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/checkin"
 	gaterepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate"
+	gatedevicerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_device"
 	gatestaffrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_staff"
 	orderitemrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/order_item"
+	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/schedule"
+	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user"
 	checkinservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/checkin"
+	occupancyservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/occupancy"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"gorm.io/gorm"
 )
@@ -29,24 +34,36 @@ var (
 type Service struct {
 	gateRepo       gaterepo.Repository
 	gateStaffRepo  gatestaffrepo.Repository
+	deviceRepo     gatedevicerepo.Repository
 	orderItemRepo  orderitemrepo.Repository
 	checkInRepo    checkinrepo.Repository
+	userRepo       userrepo.Repository
+	scheduleRepo   schedulerepo.Repository
 	checkInService *checkinservice.Service
+	occupancy      *occupancyservice.Service
 }
 
 func NewService(
 	gateRepo gaterepo.Repository,
 	gateStaffRepo gatestaffrepo.Repository,
+	deviceRepo gatedevicerepo.Repository,
 	orderItemRepo orderitemrepo.Repository,
 	checkInRepo checkinrepo.Repository,
+	userRepo userrepo.Repository,
+	scheduleRepo schedulerepo.Repository,
 	checkInService *checkinservice.Service,
+	occupancy *occupancyservice.Service,
 ) *Service {
 	return &Service{
 		gateRepo:       gateRepo,
 		gateStaffRepo:  gateStaffRepo,
+		deviceRepo:     deviceRepo,
 		orderItemRepo:  orderItemRepo,
 		checkInRepo:    checkInRepo,
+		userRepo:       userRepo,
+		scheduleRepo:   scheduleRepo,
 		checkInService: checkInService,
+		occupancy:      occupancy,
 	}
 }
 
@@ -119,8 +136,8 @@ func (s *Service) GetByID(id string) (*gate.GateResponse, error) {
 	return g.ToGateResponse(), nil
 }
 
-// Create creates a new gate
-func (s *Service) Create(req *gate.CreateGateRequest) (*gate.GateResponse, error) {
+// Create creates a new gate owned by the organization
+func (s *Service) Create(organizationID string, req *gate.CreateGateRequest) (*gate.GateResponse, error) {
 	// Check if code already exists
 	_, err := s.gateRepo.FindByCode(req.Code)
 	if err == nil {
@@ -138,13 +155,14 @@ func (s *Service) Create(req *gate.CreateGateRequest) (*gate.GateResponse, error
 
 	// Create gate
 	g := &gate.Gate{
-		Code:        req.Code,
-		Name:        req.Name,
-		Location:    req.Location,
-		Description: req.Description,
-		IsVIP:       req.IsVIP,
-		Status:      status,
-		Capacity:    req.Capacity,
+		OrganizationID: &organizationID,
+		Code:           req.Code,
+		Name:           req.Name,
+		Location:       req.Location,
+		Description:    req.Description,
+		IsVIP:          req.IsVIP,
+		Status:         status,
+		Capacity:       req.Capacity,
 	}
 
 	if err := s.gateRepo.Create(g); err != nil {
@@ -231,7 +249,7 @@ func (s *Service) Delete(id string) error {
 }
 
 // List lists gates with pagination and filters
-func (s *Service) List(req *gate.ListGatesRequest) ([]*gate.GateResponse, *response.PaginationMeta, error) {
+func (s *Service) List(scope organization.Scope, req *gate.ListGatesRequest) ([]*gate.GateResponse, *response.PaginationMeta, error) {
 	page := 1
 	perPage := 20
 
@@ -243,7 +261,9 @@ func (s *Service) List(req *gate.ListGatesRequest) ([]*gate.GateResponse, *respo
 	}
 
 	// Build filters
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.Status != "" {
 		filters["status"] = req.Status
 	}
@@ -338,9 +358,10 @@ func (s *Service) GateCheckIn(req *gate.GateCheckInRequest, staffID, ipAddress,
 		}, ErrGateInactive
 	}
 
-	// Enforce staff-gate assignment for non-admin staff members
+	// Enforce staff-gate assignment and shifts for non-admin staff members
+	scheduleID := req.ScheduleID
 	if !isAdmin {
-		assigned, err := s.gateStaffRepo.IsStaffAssignedToGate(req.GateID, staffID)
+		dutyScheduleID, result, err := s.checkStaffOnDuty(req.GateID, staffID, req.ScheduleID)
 		if err != nil {
 			return &checkin.CheckInResultResponse{
 				Success:   false,
@@ -348,50 +369,28 @@ func (s *Service) GateCheckIn(req *gate.GateCheckInRequest, staffID, ipAddress,
 				ErrorCode: "GATE_ASSIGNMENT_CHECK_ERROR",
 			}, err
 		}
-		if !assigned {
-			return &checkin.CheckInResultResponse{
-				Success:   false,
-				Message:   "Anda tidak ditugaskan untuk gate ini",
-				ErrorCode: "GATE_STAFF_NOT_ASSIGNED",
-			}, nil
+		if result != nil {
+			return result, nil
 		}
+		// Staff on a shift for one schedule only admit tickets for that schedule
+		scheduleID = dutyScheduleID
 	}
 
-	// Check gate capacity if set
-	if g.Capacity > 0 {
-		// Count check-ins for this gate today
-		today := time.Now().Truncate(24 * time.Hour)
-		todayEnd := today.Add(24 * time.Hour)
-
-		checkIns, err := s.checkInRepo.FindByGateID(req.GateID)
-		if err != nil {
-			return &checkin.CheckInResultResponse{
-				Success:   false,
-				Message:   "Terjadi kesalahan saat cek kapasitas gate",
-				ErrorCode: "CAPACITY_CHECK_ERROR",
-			}, err
-		}
-
-		// Count check-ins today
-		todayCount := int64(0)
-		for _, ci := range checkIns {
-			if ci.CheckedInAt.After(today) && ci.CheckedInAt.Before(todayEnd) {
-				todayCount++
-			}
-		}
-
-		if todayCount >= int64(g.Capacity) {
-			return &checkin.CheckInResultResponse{
-				Success:   false,
-				Message:   "Kapasitas gate sudah penuh",
-				ErrorCode: "GATE_CAPACITY_EXCEEDED",
-			}, ErrGateCapacityExceeded
-		}
+	// Registered devices may only scan at the gate they are enrolled to
+	if result, err := s.verifyDeviceGate(req.DeviceID, req.GateID); result != nil || err != nil {
+		return result, err
 	}
 
 	// Find order item by QR code
 	orderItem, err := s.orderItemRepo.FindByQRCode(req.QRCode)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &checkin.CheckInResultResponse{
+				Success:   false,
+				Message:   "QR code tidak valid",
+				ErrorCode: "INVALID_QR_CODE",
+			}, nil
+		}
 		return &checkin.CheckInResultResponse{
 			Success:   false,
 			Message:   "QR code tidak valid",
@@ -413,16 +412,50 @@ func (s *Service) GateCheckIn(req *gate.GateCheckInRequest, staffID, ipAddress,
 		}
 	}
 
-	// Perform check-in using check-in service
+	// Perform check-in using check-in service (it also enforces the gate capacity)
 	checkInReq := &checkin.CheckInRequest{
-		QRCode:   req.QRCode,
-		GateID:   &req.GateID,
-		Location: req.Location,
+		QRCode:     req.QRCode,
+		GateID:     &req.GateID,
+		Location:   req.Location,
+		ScheduleID: scheduleID,
+		DeviceID:   req.DeviceID,
 	}
 
 	return s.checkInService.CheckIn(checkInReq, staffID, ipAddress, userAgent)
 }
 
+// GateExit records an exit scan at a specific gate
+func (s *Service) GateExit(gateID string, req *gate.GateExitRequest, staffID string, isAdmin bool) (*checkin.CheckInResultResponse, error) {
+	g, err := s.gateRepo.FindByID(gateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGateNotFound
+		}
+		return nil, err
+	}
+
+	if g.Status != gate.GateStatusActive {
+		return nil, ErrGateInactive
+	}
+
+	// Enforce staff-gate assignment and shifts for non-admin staff members
+	if !isAdmin {
+		if _, result, err := s.checkStaffOnDuty(gateID, staffID, ""); result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	if result, err := s.verifyDeviceGate(req.DeviceID, gateID); result != nil || err != nil {
+		return result, err
+	}
+
+	return s.checkInService.Exit(&checkin.ExitScanRequest{
+		QRCode:   req.QRCode,
+		GateID:   &gateID,
+		DeviceID: req.DeviceID,
+	}, staffID)
+}
+
 // GetStatistics returns gate statistics
 func (s *Service) GetStatistics(gateID string) (*gate.GateStatisticsResponse, error) {
 	// Find gate
@@ -440,16 +473,23 @@ func (s *Service) GetStatistics(gateID string) (*gate.GateStatisticsResponse, er
 		return nil, err
 	}
 
-	// Calculate statistics
-	totalCheckIns := int64(len(checkIns))
+	// Calculate statistics (voided check-ins don't count)
+	totalCheckIns := int64(0)
 	todayCheckIns := int64(0)
 	vipCheckIns := int64(0)
 	regularCheckIns := int64(0)
 
-	today := time.Now().Truncate(24 * time.Hour)
-	todayEnd := today.Add(24 * time.Hour)
+	// "Today" is the venue day (WIB), not the UTC day
+	now := time.Now().In(response.GetTimezoneWIB())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	todayEnd := today.AddDate(0, 0, 1)
 
 	for _, ci := range checkIns {
+		if ci.Status == checkin.CheckInStatusVoided {
+			continue
+		}
+		totalCheckIns++
+
 		// Count today's check-ins
 		if ci.CheckedInAt.After(today) && ci.CheckedInAt.Before(todayEnd) {
 			todayCheckIns++
@@ -468,6 +508,12 @@ func (s *Service) GetStatistics(gateID string) (*gate.GateStatisticsResponse, er
 		}
 	}
 
+	// Live presence comes from the entry/exit scan history
+	occupancy, err := s.checkInService.GetOccupancy(&checkin.GetOccupancyRequest{GateID: gateID})
+	if err != nil {
+		return nil, err
+	}
+
 	return &gate.GateStatisticsResponse{
 		GateID:          g.ID,
 		GateCode:        g.Code,
@@ -476,9 +522,23 @@ func (s *Service) GetStatistics(gateID string) (*gate.GateStatisticsResponse, er
 		TodayCheckIns:   todayCheckIns,
 		VIPCheckIns:     vipCheckIns,
 		RegularCheckIns: regularCheckIns,
+		CurrentlyInside: occupancy.TotalInside,
 	}, nil
 }
 
+// GetOccupancy returns the live admission counters per gate and schedule
+func (s *Service) GetOccupancy(scope organization.Scope, req *gate.GetGateOccupancyRequest) ([]*gate.GateOccupancyResponse, error) {
+	if req.GateID != "" {
+		if _, err := s.gateRepo.FindByID(req.GateID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrGateNotFound
+			}
+			return nil, err
+		}
+	}
+	return s.occupancy.List(scope, req)
+}
+
 // containsVIP checks if a string contains "VIP" (case-insensitive)
 func containsVIP(s string) bool {
 	return strings.Contains(strings.ToUpper(s), "VIP")
@@ -0,0 +1,337 @@
+package gate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	gatestaffrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_staff"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrShiftNotFound          = errors.New("gate staff shift not found")
+	ErrShiftOverlap           = errors.New("staff already has a shift in this period")
+	ErrShiftNotActive         = errors.New("gate staff shift is not active")
+	ErrShiftStarted           = errors.New("gate staff shift has already started")
+	ErrShiftAlreadyHandedOver = errors.New("gate staff shift already handed over")
+	ErrShiftHandoverSameStaff = errors.New("cannot hand over a shift to the same staff member")
+	ErrShiftHandoverForbidden = errors.New("only the shift owner, a supervisor on duty at the gate or an admin can hand over a shift")
+	ErrShiftStaffInvalid      = errors.New("shift staff must be an active user of the gate's organization")
+	ErrShiftScheduleInvalid   = errors.New("shift schedule must belong to an event of the gate's organization")
+)
+
+// checkStaffOnDuty rejects non-admin scans by staff who are not assigned to the gate or are outside
+// their shifts there for scheduleID (empty when the scan names none). It returns the schedule the
+// scan is limited to by the staff member's shift.
+func (s *Service) checkStaffOnDuty(gateID, staffID, scheduleID string) (string, *checkin.CheckInResultResponse, error) {
+	now := time.Now()
+	assignment, shifts, err := s.gateStaffRepo.FindDuty(gateID, staffID, now)
+	if err != nil {
+		return "", nil, err
+	}
+	if assignment == nil {
+		return "", &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Anda tidak ditugaskan untuk gate ini",
+			ErrorCode: "GATE_STAFF_NOT_ASSIGNED",
+		}, nil
+	}
+
+	dutyScheduleID, onDuty := assignment.OnDutyAt(shifts, now, scheduleID)
+	if !onDuty {
+		return "", &checkin.CheckInResultResponse{
+			Success:   false,
+			Message:   "Anda tidak sedang dalam shift di gate ini untuk jadwal ini",
+			ErrorCode: "GATE_STAFF_OFF_SHIFT",
+		}, nil
+	}
+	return dutyScheduleID, nil, nil
+}
+
+// checkShiftStaff rejects shift staff who don't exist, are not active or belong to another
+// organization than the gate
+func (s *Service) checkShiftStaff(g *gate.Gate, staffID string) error {
+	u, err := s.userRepo.FindByID(staffID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShiftStaffInvalid
+		}
+		return err
+	}
+	if u.Status != "active" || u.OrganizationIDValue() != g.OrganizationIDValue() {
+		return ErrShiftStaffInvalid
+	}
+	return nil
+}
+
+// checkShiftSchedule rejects shift schedules that don't exist or belong to an event of another
+// organization than the gate
+func (s *Service) checkShiftSchedule(g *gate.Gate, scheduleID *string) error {
+	if scheduleID == nil {
+		return nil
+	}
+	sched, err := s.scheduleRepo.FindByID(*scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShiftScheduleInvalid
+		}
+		return err
+	}
+	if sched.Event == nil || sched.Event.OrganizationID == nil || *sched.Event.OrganizationID != g.OrganizationIDValue() {
+		return ErrShiftScheduleInvalid
+	}
+	return nil
+}
+
+// CreateShift schedules a shift for a staff member at a gate, assigning them to the gate if needed.
+// From then on the staff member only scans at the gate during their shifts.
+func (s *Service) CreateShift(gateID string, req *gate.CreateShiftRequest, createdBy string) (*gate.GateStaffShiftResponse, error) {
+	g, err := s.gateRepo.FindByID(gateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGateNotFound
+		}
+		return nil, err
+	}
+	if err := s.checkShiftStaff(g, req.StaffID); err != nil {
+		return nil, err
+	}
+	if err := s.checkShiftSchedule(g, req.ScheduleID); err != nil {
+		return nil, err
+	}
+
+	shift := &gate.GateStaffShift{
+		GateID:     gateID,
+		StaffID:    req.StaffID,
+		ScheduleID: req.ScheduleID,
+		Role:       req.Role,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Notes:      req.Notes,
+		CreatedBy:  createdBy,
+	}
+	if err := s.gateStaffRepo.CreateShift(shift); err != nil {
+		if errors.Is(err, gatestaffrepo.ErrShiftOverlap) {
+			return nil, ErrShiftOverlap
+		}
+		return nil, err
+	}
+
+	created, err := s.gateStaffRepo.FindShiftByID(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	return created.ToGateStaffShiftResponse(time.Now()), nil
+}
+
+// DeleteShift cancels a shift that has not started yet
+func (s *Service) DeleteShift(shiftID string) error {
+	shift, err := s.findShift(shiftID)
+	if err != nil {
+		return err
+	}
+	if !time.Now().Before(shift.StartsAt) {
+		return ErrShiftStarted
+	}
+	return s.gateStaffRepo.DeleteShift(shiftID)
+}
+
+// EndShift ends an active shift early, e.g. when a volunteer leaves without a replacement
+func (s *Service) EndShift(shiftID string) (*gate.GateStaffShiftResponse, error) {
+	shift, err := s.findShift(shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !shift.IsActiveAt(now) {
+		return nil, ErrShiftNotActive
+	}
+
+	shift.EndsAt = now
+	if err := s.gateStaffRepo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return shift.ToGateStaffShiftResponse(now), nil
+}
+
+// HandoverShift ends a shift at the handover time and starts a shift for the incoming staff member
+// covering the rest of it. Staff may hand over their own shift, supervisors on duty any shift at
+// their gate, and admins any shift.
+func (s *Service) HandoverShift(shiftID string, req *gate.HandoverShiftRequest, actorID string, isAdmin bool) (*gate.ShiftHandoverResponse, error) {
+	current, err := s.findShift(shiftID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !isAdmin && current.StaffID != actorID {
+		assignment, shifts, err := s.gateStaffRepo.FindDuty(current.GateID, actorID, now)
+		if err != nil {
+			return nil, err
+		}
+		if assignment == nil || !assignment.SupervisingAt(shifts, now) {
+			return nil, ErrShiftHandoverForbidden
+		}
+	}
+	if current.HandedOverToID != nil {
+		return nil, ErrShiftAlreadyHandedOver
+	}
+	if req.ToStaffID == current.StaffID {
+		return nil, ErrShiftHandoverSameStaff
+	}
+
+	at := now
+	if req.At != nil {
+		at = *req.At
+	}
+	if !current.IsActiveAt(at) {
+		return nil, ErrShiftNotActive
+	}
+
+	g := current.Gate
+	if g == nil {
+		if g, err = s.gateRepo.FindByID(current.GateID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkShiftStaff(g, req.ToStaffID); err != nil {
+		return nil, err
+	}
+
+	next := &gate.GateStaffShift{
+		GateID:           current.GateID,
+		StaffID:          req.ToStaffID,
+		ScheduleID:       current.ScheduleID,
+		Role:             current.Role,
+		StartsAt:         at,
+		EndsAt:           current.EndsAt,
+		HandedOverFromID: &current.ID,
+		Notes:            req.Notes,
+		CreatedBy:        actorID,
+	}
+	current.EndsAt = at
+	current.HandedOverAt = &at
+	if err := s.gateStaffRepo.HandoverShift(current, next); err != nil {
+		switch {
+		case errors.Is(err, gatestaffrepo.ErrShiftOverlap):
+			return nil, ErrShiftOverlap
+		case errors.Is(err, gatestaffrepo.ErrShiftHandedOver):
+			return nil, ErrShiftAlreadyHandedOver
+		}
+		return nil, err
+	}
+
+	created, err := s.gateStaffRepo.FindShiftByID(next.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &gate.ShiftHandoverResponse{
+		Previous: current.ToGateStaffShiftResponse(now),
+		Next:     created.ToGateStaffShiftResponse(now),
+	}, nil
+}
+
+// ListShifts lists gate staff shifts with filters
+func (s *Service) ListShifts(scope organization.Scope, req *gate.ListShiftsRequest) ([]*gate.GateStaffShiftResponse, *response.PaginationMeta, error) {
+	shifts, page, perPage, total, err := s.listShifts(scope, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*gate.GateStaffShiftResponse, len(shifts))
+	for i, shift := range shifts {
+		responses[i] = shift.ToGateStaffShiftResponse(now)
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// GetShiftReport reports the entry, exit and rejected scans each staff member made during each shift.
+// Scans are attributed to a shift by staff, gate and scan time.
+func (s *Service) GetShiftReport(scope organization.Scope, req *gate.ListShiftsRequest) ([]*gate.ShiftReportResponse, *response.PaginationMeta, error) {
+	shifts, page, perPage, total, err := s.listShifts(scope, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shiftIDs := make([]string, len(shifts))
+	for i, shift := range shifts {
+		shiftIDs[i] = shift.ID
+	}
+	counts, err := s.gateStaffRepo.CountShiftScans(shiftIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	reports := make([]*gate.ShiftReportResponse, len(shifts))
+	for i, shift := range shifts {
+		report := &gate.ShiftReportResponse{Shift: shift.ToGateStaffShiftResponse(now)}
+		if count, ok := counts[shift.ID]; ok {
+			report.Entries = count.Entries
+			report.Exits = count.Exits
+			report.Rejected = count.Rejected
+			report.Total = count.Entries + count.Exits + count.Rejected
+		}
+		reports[i] = report
+	}
+
+	return reports, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+func (s *Service) listShifts(scope organization.Scope, req *gate.ListShiftsRequest) ([]*gate.GateStaffShift, int, int, int64, error) {
+	page := 1
+	perPage := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	if req.StaffID != "" {
+		filters["staff_id"] = req.StaffID
+	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
+	if req.Date != "" {
+		day, err := time.ParseInLocation("2006-01-02", req.Date, response.GetTimezoneWIB())
+		if err != nil {
+			return nil, 0, 0, 0, err
+		}
+		filters["from"] = day
+		filters["to"] = day.AddDate(0, 0, 1)
+	}
+	if req.ActiveOnly {
+		filters["active_at"] = time.Now()
+	}
+
+	shifts, total, err := s.gateStaffRepo.ListShifts(page, perPage, filters)
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+	return shifts, page, perPage, total, nil
+}
+
+func (s *Service) findShift(shiftID string) (*gate.GateStaffShift, error) {
+	shift, err := s.gateStaffRepo.FindShiftByID(shiftID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShiftNotFound
+		}
+		return nil, err
+	}
+	return shift, nil
+}
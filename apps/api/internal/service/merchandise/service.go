@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/merchandise"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	merchandiserepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/merchandise"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"gorm.io/gorm"
@@ -174,8 +175,8 @@ func (s *Service) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-// List lists all merchandises with pagination and filters
-func (s *Service) List(req *merchandise.ListMerchandiseRequest) ([]*merchandise.MerchandiseResponse, *response.PaginationMeta, error) {
+// List lists the merchandises within the organization scope with pagination and filters
+func (s *Service) List(scope organization.Scope, req *merchandise.ListMerchandiseRequest) ([]*merchandise.MerchandiseResponse, *response.PaginationMeta, error) {
 	page := 1
 	perPage := 20
 
@@ -187,7 +188,9 @@ func (s *Service) List(req *merchandise.ListMerchandiseRequest) ([]*merchandise.
 	}
 
 	// Build filters
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.EventID != "" {
 		filters["event_id"] = req.EventID
 	}
@@ -213,9 +216,11 @@ func (s *Service) List(req *merchandise.ListMerchandiseRequest) ([]*merchandise.
 	return responses, pagination, nil
 }
 
-// GetInventory gets merchandise inventory summary
-func (s *Service) GetInventory(eventID string) (*merchandise.InventoryResponse, error) {
-	filters := make(map[string]interface{})
+// GetInventory gets the inventory summary of the merchandises within the organization scope
+func (s *Service) GetInventory(scope organization.Scope, eventID string) (*merchandise.InventoryResponse, error) {
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if eventID != "" {
 		filters["event_id"] = eventID
 	}
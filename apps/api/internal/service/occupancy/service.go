@@ -0,0 +1,197 @@
+package occupancy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/gate"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	redisint "github.com/gilabs/webapp-ticket-konser/api/internal/integration/redis"
+	gaterepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate"
+	gateoccupancyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/gate_occupancy"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+)
+
+const (
+	// redisCounterTTL keeps a schedule's admission counter in Redis well past the end of the show
+	redisCounterTTL = 48 * time.Hour
+	// redisTimeout bounds Redis calls on the scan path; on failure admission falls back to the database
+	redisTimeout = 500 * time.Millisecond
+)
+
+// Service maintains per gate, per schedule occupancy counters. The database rows are the source
+// of truth; when Redis is enabled it guards admissions so busy gates don't contend on a row lock.
+type Service struct {
+	repo     gateoccupancyrepo.Repository
+	gateRepo gaterepo.Repository
+}
+
+func NewService(repo gateoccupancyrepo.Repository, gateRepo gaterepo.Repository) *Service {
+	return &Service{
+		repo:     repo,
+		gateRepo: gateRepo,
+	}
+}
+
+// Admit reserves a place for a first check-in at a gate, refusing it once the gate admitted its
+// capacity for the schedule. Scans without a gate are always admitted.
+func (s *Service) Admit(gateID *string, scheduleID string) (bool, error) {
+	if gateID == nil || *gateID == "" || scheduleID == "" {
+		return true, nil
+	}
+
+	g, err := s.gateRepo.FindByID(*gateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Unknown gates have no capacity to enforce
+			return true, nil
+		}
+		return false, err
+	}
+
+	if redisint.Client != nil {
+		admitted, err := s.admitRedis(*gateID, scheduleID, g.Capacity)
+		if err == nil {
+			if admitted {
+				s.apply(*gateID, scheduleID, gate.OccupancyDelta{Admitted: 1, Entries: 1})
+			}
+			return admitted, nil
+		}
+		log.Printf("[Occupancy] Redis admission failed for gate %s, using database: %v", *gateID, err)
+	}
+
+	return s.repo.Admit(*gateID, scheduleID, g.Capacity)
+}
+
+// Release gives back a place reserved by Admit when the check-in could not be stored
+func (s *Service) Release(gateID *string, scheduleID string) {
+	if gateID == nil || *gateID == "" || scheduleID == "" {
+		return
+	}
+	s.decrementRedis(*gateID, scheduleID)
+	s.apply(*gateID, scheduleID, gate.OccupancyDelta{Admitted: -1, Entries: -1})
+}
+
+// RecordReentry counts a re-entry; re-entries don't use up the gate capacity
+func (s *Service) RecordReentry(gateID *string, scheduleID string) {
+	if gateID == nil || *gateID == "" {
+		return
+	}
+	s.apply(*gateID, scheduleID, gate.OccupancyDelta{Entries: 1})
+}
+
+// RecordExit counts an exit scan
+func (s *Service) RecordExit(gateID *string, scheduleID string) {
+	if gateID == nil || *gateID == "" {
+		return
+	}
+	s.apply(*gateID, scheduleID, gate.OccupancyDelta{Exits: 1})
+}
+
+// RevertCheckIn takes a voided check-in and its scans back out of the counters
+func (s *Service) RevertCheckIn(c *checkin.CheckIn, scans []*checkin.TicketScan) {
+	if c.GateID != nil && c.ScheduleID != nil {
+		s.decrementRedis(*c.GateID, *c.ScheduleID)
+		s.apply(*c.GateID, *c.ScheduleID, gate.OccupancyDelta{Admitted: -1})
+	}
+	for _, scan := range scans {
+		if scan.Voided || scan.GateID == nil {
+			continue
+		}
+		delta := gate.OccupancyDelta{Entries: -1}
+		if scan.Direction == checkin.ScanDirectionExit {
+			delta = gate.OccupancyDelta{Exits: -1}
+		}
+		s.apply(*scan.GateID, scan.ScheduleID, delta)
+	}
+}
+
+// List returns the live counters, by default for the schedules on today's venue day
+func (s *Service) List(scope organization.Scope, req *gate.GetGateOccupancyRequest) ([]*gate.GateOccupancyResponse, error) {
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	} else {
+		date := req.Date
+		if date == "" {
+			date = time.Now().In(response.GetTimezoneWIB()).Format("2006-01-02")
+		}
+		filters["date"] = date
+	}
+
+	occupancies, err := s.repo.List(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*gate.GateOccupancyResponse, len(occupancies))
+	for i, o := range occupancies {
+		responses[i] = o.ToGateOccupancyResponse()
+	}
+	return responses, nil
+}
+
+// apply updates the database counters; counting failures never fail the scan
+func (s *Service) apply(gateID, scheduleID string, delta gate.OccupancyDelta) {
+	if err := s.repo.Apply(gateID, scheduleID, delta); err != nil {
+		log.Printf("[Occupancy] Failed to update counters of gate %s for schedule %s: %v", gateID, scheduleID, err)
+	}
+}
+
+func (s *Service) admitRedis(gateID, scheduleID string, capacity int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	key := counterKey(gateID, scheduleID)
+	result, err := redisint.AdmitCounter(ctx, key, capacity, redisCounterTTL)
+	if err != nil {
+		return false, err
+	}
+	if result == redisint.CounterNotSeeded {
+		// First scan since Redis was (re)started: continue from the database count
+		var admitted int64
+		existing, err := s.repo.FindByGateAndSchedule(gateID, scheduleID)
+		if err == nil {
+			admitted = existing.Admitted
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+		if err := redisint.SeedCounter(ctx, key, admitted, redisCounterTTL); err != nil {
+			return false, err
+		}
+		result, err = redisint.AdmitCounter(ctx, key, capacity, redisCounterTTL)
+		if err != nil {
+			return false, err
+		}
+	}
+	return result == 1, nil
+}
+
+func (s *Service) decrementRedis(gateID, scheduleID string) {
+	if redisint.Client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := redisint.DecrementCounter(ctx, counterKey(gateID, scheduleID)); err != nil {
+		log.Printf("[Occupancy] Failed to decrement Redis counter of gate %s: %v", gateID, err)
+	}
+}
+
+func counterKey(gateID, scheduleID string) string {
+	prefix := "ticketing_api"
+	if config.AppConfig != nil {
+		prefix = config.AppConfig.Redis.Prefix
+	}
+	return redisint.Key(prefix, "gate_occupancy", gateID, scheduleID)
+}
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrScheduleNotInPass = errors.New("schedule is not covered by this pass")
+)
+
+// lockPassSchedules locks every schedule covered by a pass category (in ID order so concurrent pass
+// orders can't deadlock) and checks each day has seats left. It returns the covered schedules other
+// than the one the order is placed on; single-schedule categories return nil.
+func lockPassSchedules(tx *gorm.DB, categoryID, scheduleID string, quantity int) ([]*schedule.Schedule, error) {
+	var passSchedules []ticketcategory.PassSchedule
+	if err := tx.Where("ticket_category_id = ?", categoryID).Find(&passSchedules).Error; err != nil {
+		return nil, err
+	}
+	if len(passSchedules) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(passSchedules))
+	covered := false
+	for _, ps := range passSchedules {
+		if ps.ScheduleID == scheduleID {
+			covered = true
+		}
+		ids = append(ids, ps.ScheduleID)
+	}
+	if !covered {
+		return nil, ErrScheduleNotInPass
+	}
+
+	var scheds []*schedule.Schedule
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id").Find(&scheds).Error; err != nil {
+		return nil, err
+	}
+
+	others := make([]*schedule.Schedule, 0, len(scheds))
+	for _, sched := range scheds {
+		if sched.RemainingSeat < quantity {
+			return nil, ErrInsufficientSeats
+		}
+		if sched.ID != scheduleID {
+			others = append(others, sched)
+		}
+	}
+	return others, nil
+}
+
+// restorePassSeats returns the seats a canceled pass order held on the pass's other schedules
+func restorePassSeats(tx *gorm.DB, o *order.Order) error {
+	var passSchedules []ticketcategory.PassSchedule
+	if err := tx.Where("ticket_category_id = ? AND schedule_id <> ?", o.TicketCategoryID, o.ScheduleID).Find(&passSchedules).Error; err != nil {
+		return err
+	}
+	if len(passSchedules) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(passSchedules))
+	for i, ps := range passSchedules {
+		ids[i] = ps.ScheduleID
+	}
+	return tx.Model(&schedule.Schedule{}).
+		Where("id IN ?", ids).
+		UpdateColumn("remaining_seat", gorm.Expr("remaining_seat + ?", o.Quantity)).Error
+}
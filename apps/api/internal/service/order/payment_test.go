@@ -0,0 +1,43 @@
+package order
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+)
+
+func TestPaymentItemMatchesGrossAmount(t *testing.T) {
+	tests := []struct {
+		name  string
+		order *order.Order
+	}{
+		{
+			name:  "regular order",
+			order: &order.Order{TicketCategoryID: "category", Quantity: 3, UnitPrice: 150000, TotalAmount: 450000},
+		},
+		{
+			// CreateUpgradeOrder bills only the difference to the target category
+			name:  "upgrade order",
+			order: &order.Order{TicketCategoryID: "target-category", Quantity: 1, UnitPrice: 250000, TotalAmount: 250000},
+		},
+		{
+			// CreateResaleOrder bills the listing price rather than the face value
+			name:  "resale order",
+			order: &order.Order{TicketCategoryID: "category", Quantity: 1, UnitPrice: 175000, TotalAmount: 175000},
+		},
+		{
+			name:  "order without unit price snapshot",
+			order: &order.Order{TicketCategoryID: "category", Quantity: 2, TotalAmount: 300000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := paymentItem(tt.order, "Ticket")
+			if total := item.Price * float64(item.Quantity); math.Abs(total-tt.order.TotalAmount) > 0.01 {
+				t.Fatalf("item total %.2f does not match gross amount %.2f", total, tt.order.TotalAmount)
+			}
+		})
+	}
+}
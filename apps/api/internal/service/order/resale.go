@@ -0,0 +1,207 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrListingNotFound     = errors.New("resale listing not found")
+	ErrListingNotAvailable = errors.New("resale listing is no longer available")
+	ErrOwnListing          = errors.New("cannot buy your own resale listing")
+)
+
+// CreateResaleOrder reserves an active resale listing for the buyer and creates the order that
+// pays for it. The ticket is reissued to the buyer once that order is paid.
+func (s *Service) CreateResaleOrder(listingID string, req *resale.PurchaseListingRequest, userID string, idempotencyKey string) (*order.OrderResponse, error) {
+	// Idempotency check: return the order already created with this key
+	if idempotencyKey != "" {
+		existingOrder, err := s.repo.FindByIdempotencyKey(idempotencyKey)
+		if err == nil && existingOrder != nil {
+			return existingOrder.ToOrderResponse(), nil
+		}
+	}
+
+	if err := s.ensureBuyer(userID); err != nil {
+		return nil, err
+	}
+
+	var newOrder *order.Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL statement_timeout = '30s'").Error; err != nil {
+			return fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+
+		// Lock the listing so only one buyer can reserve it
+		var listing resale.ResaleListing
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", listingID).First(&listing).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrListingNotFound
+			}
+			return err
+		}
+		if listing.Status != resale.ListingStatusActive {
+			return ErrListingNotAvailable
+		}
+		if listing.SellerID == userID {
+			return ErrOwnListing
+		}
+
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", listing.OrderItemID).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrListingNotAvailable
+			}
+			return err
+		}
+		if item.Status != orderitem.TicketStatusPaid || !item.ListedForResale {
+			return ErrListingNotAvailable
+		}
+
+		var sched schedule.Schedule
+		if err := tx.Where("id = ?", listing.ScheduleID).First(&sched).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrScheduleNotFound
+			}
+			return err
+		}
+		if !sched.Date.IsZero() && sched.Date.Before(time.Now().Truncate(24*time.Hour)) {
+			return ErrSchedulePassed
+		}
+
+		var category ticketcategory.TicketCategory
+		if err := tx.Where("id = ?", listing.TicketCategoryID).First(&category).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketCategoryNotFound
+			}
+			return err
+		}
+
+		// Event and session names are copied from the seller's order
+		var sellerOrder order.Order
+		if err := tx.Where("id = ?", item.OrderID).First(&sellerOrder).Error; err != nil {
+			return err
+		}
+
+		var idempotencyKeyPtr *string
+		if idempotencyKey != "" {
+			idempotencyKeyPtr = &idempotencyKey
+		}
+
+		paymentExpiresAt := time.Now().Add(15 * time.Minute)
+		newOrder = &order.Order{
+			UserID:               userID,
+			ScheduleID:           listing.ScheduleID,
+			TicketCategoryID:     listing.TicketCategoryID,
+			ResaleListingID:      &listing.ID,
+			Quantity:             1,
+			UnitPrice:            listing.Price,
+			TotalAmount:          listing.Price,
+			CategoryNameSnapshot: category.CategoryName,
+			EventNameSnapshot:    sellerOrder.EventNameSnapshot,
+			ScheduleNameSnapshot: sched.SessionName,
+			PaymentStatus:        order.PaymentStatusUnpaid,
+			PaymentExpiresAt:     &paymentExpiresAt,
+			IdempotencyKey:       idempotencyKeyPtr,
+			BuyerName:            req.BuyerName,
+			BuyerEmail:           req.BuyerEmail,
+			BuyerPhone:           req.BuyerPhone,
+		}
+		if err := tx.Create(newOrder).Error; err != nil {
+			return err
+		}
+
+		listing.Status = resale.ListingStatusReserved
+		listing.BuyerID = &userID
+		listing.OrderID = &newOrder.ID
+		return tx.Omit("TicketCategory").Save(&listing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createdOrder, err := s.repo.FindByID(newOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	return createdOrder.ToOrderResponse(), nil
+}
+
+// applyResale settles a paid resale order: the ticket moves to the buyer's order with a new QR
+// code, the listing is marked sold and the seller is credited (idempotent)
+func (s *Service) applyResale(o *order.Order) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var listing resale.ResaleListing
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *o.ResaleListingID).First(&listing).Error; err != nil {
+			return err
+		}
+
+		// Already settled (webhook and status sync can both report the payment)
+		if listing.Status == resale.ListingStatusSold {
+			return nil
+		}
+		if listing.Status != resale.ListingStatusReserved || listing.OrderID == nil || *listing.OrderID != o.ID {
+			return fmt.Errorf("listing %s is %s, resale order %s needs manual refund", listing.ID, listing.Status, o.ID)
+		}
+
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", listing.OrderItemID).First(&item).Error; err != nil {
+			return err
+		}
+		if item.Status != orderitem.TicketStatusPaid {
+			return fmt.Errorf("ticket %s is %s, resale order %s needs manual refund", item.ID, item.Status, o.ID)
+		}
+
+		// Reissue the ticket to the buyer; the seller's QR code stops working
+		item.OrderID = o.ID
+		item.ListedForResale = false
+		item.ReissueQRCode()
+		if err := tx.Save(&item).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		listing.Status = resale.ListingStatusSold
+		listing.SoldAt = &now
+		if err := tx.Omit("TicketCategory").Save(&listing).Error; err != nil {
+			return err
+		}
+
+		payout := &resale.SellerPayout{
+			ListingID:   listing.ID,
+			OrderID:     o.ID,
+			SellerID:    listing.SellerID,
+			GrossAmount: o.TotalAmount,
+			PlatformFee: listing.PlatformFee,
+			NetAmount:   listing.SellerAmount,
+		}
+		if err := tx.Create(payout).Error; err != nil {
+			return err
+		}
+
+		log.Printf("[Resale] Listing %s sold to %s by order %s (fee %.2f, seller %.2f)", listing.ID, o.UserID, o.ID, listing.PlatformFee, listing.SellerAmount)
+		return nil
+	})
+}
+
+// releaseResaleReservation puts a listing back on the marketplace when its buyer's order was
+// canceled or failed
+func releaseResaleReservation(tx *gorm.DB, o *order.Order) error {
+	return tx.Model(&resale.ResaleListing{}).
+		Where("id = ? AND status = ? AND order_id = ?", *o.ResaleListingID, resale.ListingStatusReserved, o.ID).
+		Updates(map[string]interface{}{
+			"status":   resale.ListingStatusActive,
+			"buyer_id": nil,
+			"order_id": nil,
+		}).Error
+}
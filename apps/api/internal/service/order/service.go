@@ -7,9 +7,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ballot"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/event"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
 	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
@@ -20,6 +25,7 @@ import (
 	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ticket_category"
 	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -29,8 +35,23 @@ var (
 	ErrInsufficientQuota      = errors.New("insufficient quota")
 	ErrInsufficientSeats      = errors.New("insufficient remaining seats")
 	ErrUserNotFound           = errors.New("user not found")
+	ErrEmailNotVerified       = errors.New("email must be verified before ordering")
 	ErrEventNotAvailable      = errors.New("event is not available for purchase")
 	ErrSchedulePassed         = errors.New("event schedule has already passed")
+
+	ErrAllocationNotFound     = errors.New("quota allocation not found")
+	ErrAllocationForbidden    = errors.New("not authorized to use this quota allocation")
+	ErrAllocationNotActive    = errors.New("quota allocation is no longer active")
+	ErrInsufficientAllocation = errors.New("insufficient seats remaining in quota allocation")
+
+	ErrPresaleCodeRequired  = errors.New("a presale access code is required for this ticket category")
+	ErrPresaleCodeInvalid   = errors.New("presale access code is invalid for this ticket category")
+	ErrPresaleCodeForbidden = errors.New("presale access code is bound to another user")
+	ErrPresaleCodeExhausted = errors.New("presale access code has no uses left")
+
+	ErrBallotOnly         = errors.New("this ticket category is sold by ballot")
+	ErrBallotClaimInvalid = errors.New("ballot claim is invalid for this order")
+	ErrBallotClaimExpired = errors.New("ballot claim has expired")
 )
 
 type Service struct {
@@ -126,7 +147,7 @@ func (s *Service) GetByUserID(userID string) ([]*order.OrderResponse, error) {
 }
 
 // List lists orders with pagination and filters
-func (s *Service) List(req *order.ListOrdersRequest) ([]*order.OrderResponse, *response.PaginationMeta, error) {
+func (s *Service) List(scope organization.Scope, req *order.ListOrdersRequest) ([]*order.OrderResponse, *response.PaginationMeta, error) {
 	page := 1
 	perPage := 20
 
@@ -138,7 +159,9 @@ func (s *Service) List(req *order.ListOrdersRequest) ([]*order.OrderResponse, *r
 	}
 
 	// Build filters
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	if req.PaymentStatus != "" {
 		filters["payment_status"] = req.PaymentStatus
 	}
@@ -218,12 +241,14 @@ func (s *Service) Delete(id string) error {
 }
 
 // GetRecentOrders returns recent orders (for dashboard)
-func (s *Service) GetRecentOrders(limit int) ([]*order.OrderResponse, error) {
+func (s *Service) GetRecentOrders(scope organization.Scope, limit int) ([]*order.OrderResponse, error) {
 	if limit <= 0 || limit > 50 {
 		limit = 10
 	}
 
-	filters := make(map[string]interface{})
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
 	orders, _, err := s.repo.List(1, limit, filters)
 	if err != nil {
 		return nil, err
@@ -237,6 +262,25 @@ func (s *Service) GetRecentOrders(limit int) ([]*order.OrderResponse, error) {
 	return responses, nil
 }
 
+// ensureBuyer checks that the user exists and, when AUTH_REQUIRE_VERIFIED_EMAIL_FOR_ORDERS is on,
+// has verified their email
+func (s *Service) ensureBuyer(userID string) error {
+	var buyer struct {
+		ID              string
+		EmailVerifiedAt *time.Time
+	}
+	if err := s.db.Raw("SELECT id, email_verified_at FROM users WHERE id = ? AND deleted_at IS NULL", userID).Scan(&buyer).Error; err != nil {
+		return fmt.Errorf("failed to validate user: %w", err)
+	}
+	if buyer.ID == "" {
+		return ErrUserNotFound
+	}
+	if config.AppConfig.Auth.RequireVerifiedEmailForOrders && buyer.EmailVerifiedAt == nil {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
 // CreateOrder creates a new order with quota management, transaction lock, idempotency, and price snapshots
 func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idempotencyKey string) (*order.OrderResponse, error) {
 	// Idempotency check: if client provided a key, check for existing order with same key
@@ -248,13 +292,9 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 		}
 	}
 
-	// Validate user exists before starting transaction
-	var userExists bool
-	if err := s.db.Raw("SELECT EXISTS(SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL)", userID).Scan(&userExists).Error; err != nil {
-		return nil, fmt.Errorf("failed to validate user: %w", err)
-	}
-	if !userExists {
-		return nil, ErrUserNotFound
+	// Validate user exists (and is verified, if required) before starting transaction
+	if err := s.ensureBuyer(userID); err != nil {
+		return nil, err
 	}
 
 	// Start transaction with timeout to prevent indefinite lock holding
@@ -281,12 +321,54 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 		return nil, err
 	}
 
-	// Check quota
-	if ticketCategory.Quota < req.Quantity {
+	// Resolve quota allocation (reserved seats) if the buyer is purchasing against one
+	var allocation *quotaallocation.QuotaAllocation
+	if req.QuotaAllocationID != "" || req.AllocationCode != "" {
+		qa, err := lockAllocation(tx, req, userID, ticketCategory.ID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if qa.Remaining() < req.Quantity {
+			tx.Rollback()
+			return nil, ErrInsufficientAllocation
+		}
+		allocation = qa
+	} else if ticketCategory.Quota < req.Quantity {
+		// Check public quota
 		tx.Rollback()
 		return nil, ErrInsufficientQuota
 	}
 
+	// Enforce ballot and presale restrictions (allocation holders are already authorized)
+	var ballotEntry *ballot.BallotEntry
+	var presaleCode *presale.PresaleCode
+	if allocation == nil {
+		be, err := checkBallotAccess(tx, req, userID, ticketCategory.ID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		ballotEntry = be
+
+		// Ballot winners were selected by the draw, presale codes don't apply to them
+		if ballotEntry == nil {
+			pc, err := checkPresaleAccess(tx, req, userID, ticketCategory.ID)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			presaleCode = pc
+		}
+	}
+
+	// Multi-day passes hold a seat on every schedule they cover
+	passSchedules, err := lockPassSchedules(tx, ticketCategory.ID, req.ScheduleID, req.Quantity)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Lock schedule (SELECT FOR UPDATE)
 	var sched schedule.Schedule
 	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", req.ScheduleID).First(&sched).Error; err != nil {
@@ -338,13 +420,42 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 
 	// Snapshot the unit price and names at purchase time (immutable historical record)
 	unitPrice := ticketCategory.Price
+	if allocation != nil && allocation.Complimentary {
+		unitPrice = 0
+	}
 	totalAmount := unitPrice * float64(req.Quantity)
 
-	// Decrement quota atomically
-	ticketCategory.Quota -= req.Quantity
-	if err := tx.Save(&ticketCategory).Error; err != nil {
-		tx.Rollback()
-		return nil, err
+	// Decrement allocation or public quota atomically
+	var allocationID *string
+	if allocation != nil {
+		allocation.Used += req.Quantity
+		if err := tx.Save(allocation).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		allocationID = &allocation.ID
+	} else {
+		ticketCategory.Quota -= req.Quantity
+		if err := tx.Save(&ticketCategory).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// Consume one use of the presale code and bind it to the buyer on first use
+	var presaleCodeID *string
+	if presaleCode != nil {
+		presaleCode.UsedCount++
+		if presaleCode.BoundUserID == nil {
+			now := time.Now()
+			presaleCode.BoundUserID = &userID
+			presaleCode.BoundAt = &now
+		}
+		if err := tx.Save(presaleCode).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		presaleCodeID = &presaleCode.ID
 	}
 
 	// Decrement remaining seats atomically
@@ -353,9 +464,22 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 		tx.Rollback()
 		return nil, err
 	}
+	for _, ps := range passSchedules {
+		ps.RemainingSeat -= req.Quantity
+		if err := tx.Save(ps).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
 
-	// Set payment expiration (15 minutes from now)
+	// Set payment expiration (15 minutes from now); complimentary redemptions need no payment
+	paymentStatus := order.PaymentStatusUnpaid
 	paymentExpiresAt := time.Now().Add(15 * time.Minute)
+	paymentExpiresAtPtr := &paymentExpiresAt
+	if totalAmount == 0 && allocation != nil {
+		paymentStatus = order.PaymentStatusPaid
+		paymentExpiresAtPtr = nil
+	}
 
 	// Build idempotency key pointer
 	var idempotencyKeyPtr *string
@@ -368,14 +492,17 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 		UserID:               userID,
 		ScheduleID:           req.ScheduleID,
 		TicketCategoryID:     req.TicketCategoryID,
+		QuotaAllocationID:    allocationID,
+		PresaleCodeID:        presaleCodeID,
+		BallotEntryID:        ballotEntryID(ballotEntry),
 		Quantity:             req.Quantity,
 		UnitPrice:            unitPrice,
 		TotalAmount:          totalAmount,
 		CategoryNameSnapshot: ticketCategory.CategoryName,
 		EventNameSnapshot:    eventName,
 		ScheduleNameSnapshot: sched.SessionName,
-		PaymentStatus:        order.PaymentStatusUnpaid,
-		PaymentExpiresAt:     &paymentExpiresAt,
+		PaymentStatus:        paymentStatus,
+		PaymentExpiresAt:     paymentExpiresAtPtr,
 		IdempotencyKey:       idempotencyKeyPtr,
 		BuyerName:            req.BuyerName,
 		BuyerEmail:           req.BuyerEmail,
@@ -387,11 +514,28 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 		return nil, err
 	}
 
+	// Mark the winning ballot entry as claimed by this order
+	if ballotEntry != nil {
+		ballotEntry.Status = ballot.EntryStatusClaimed
+		ballotEntry.OrderID = &newOrder.ID
+		if err := tx.Save(ballotEntry).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
+	// Complimentary redemptions are paid on creation, so issue tickets right away
+	if newOrder.PaymentStatus == order.PaymentStatusPaid && s.orderItemService != nil {
+		if _, err := s.orderItemService.GenerateTickets(newOrder.ID, []string{newOrder.TicketCategoryID}, []int{newOrder.Quantity}); err != nil {
+			log.Printf("[CreateOrder] Error generating tickets for complimentary order %s: %v", newOrder.ID, err)
+		}
+	}
+
 	// Reload order with relations
 	createdOrder, err := s.repo.FindByID(newOrder.ID)
 	if err != nil {
@@ -401,6 +545,151 @@ func (s *Service) CreateOrder(req *order.CreateOrderRequest, userID string, idem
 	return createdOrder.ToOrderResponse(), nil
 }
 
+// lockAllocation locks the quota allocation referenced by the request and verifies the
+// caller may purchase against it (owner user or matching access code)
+func lockAllocation(tx *gorm.DB, req *order.CreateOrderRequest, userID, ticketCategoryID string) (*quotaallocation.QuotaAllocation, error) {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	if req.QuotaAllocationID != "" {
+		query = query.Where("id = ?", req.QuotaAllocationID)
+	} else {
+		query = query.Where("access_code = ?", req.AllocationCode)
+	}
+
+	var qa quotaallocation.QuotaAllocation
+	if err := query.First(&qa).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAllocationNotFound
+		}
+		return nil, err
+	}
+
+	if qa.TicketCategoryID != ticketCategoryID {
+		return nil, ErrAllocationNotFound
+	}
+
+	// Selecting an allocation by ID is reserved for its owner; everyone else needs the code
+	authorized := qa.OwnerUserID != nil && *qa.OwnerUserID == userID
+	if !authorized && req.AllocationCode != "" && qa.AccessCode != nil && *qa.AccessCode == req.AllocationCode {
+		authorized = true
+	}
+	if !authorized {
+		return nil, ErrAllocationForbidden
+	}
+
+	if qa.Status != quotaallocation.AllocationStatusActive || qa.IsExpired(time.Now()) {
+		return nil, ErrAllocationNotActive
+	}
+
+	return &qa, nil
+}
+
+// checkBallotAccess enforces an active ballot on a ticket category.
+// Returns the locked winning entry being claimed, or nil when the category is not sold by ballot.
+func checkBallotAccess(tx *gorm.DB, req *order.CreateOrderRequest, userID, ticketCategoryID string) (*ballot.BallotEntry, error) {
+	var b ballot.Ballot
+	err := tx.Where("ticket_category_id = ?", ticketCategoryID).
+		Where("status IN ?", []ballot.BallotStatus{ballot.BallotStatusOpen, ballot.BallotStatusDrawn}).
+		First(&b).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.BallotClaimToken == "" {
+		return nil, ErrBallotOnly
+	}
+
+	var e ballot.BallotEntry
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("claim_token = ?", req.BallotClaimToken).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBallotClaimInvalid
+		}
+		return nil, err
+	}
+
+	if e.BallotID != b.ID || e.UserID != userID || e.Status != ballot.EntryStatusWon {
+		return nil, ErrBallotClaimInvalid
+	}
+	if e.ScheduleID != req.ScheduleID || req.Quantity > e.Quantity {
+		return nil, ErrBallotClaimInvalid
+	}
+	if e.ClaimExpiresAt == nil || !e.ClaimExpiresAt.After(time.Now()) {
+		return nil, ErrBallotClaimExpired
+	}
+
+	return &e, nil
+}
+
+// ballotEntryID returns the ID of a claimed ballot entry, if any
+func ballotEntryID(e *ballot.BallotEntry) *string {
+	if e == nil {
+		return nil
+	}
+	return &e.ID
+}
+
+// checkPresaleAccess enforces open presale batches on a ticket category.
+// Returns the locked code to consume, or nil when no code is needed.
+func checkPresaleAccess(tx *gorm.DB, req *order.CreateOrderRequest, userID, ticketCategoryID string) (*presale.PresaleCode, error) {
+	now := time.Now()
+	var batches []presale.PresaleBatch
+	if err := tx.Where("ticket_category_id = ? AND is_active = ?", ticketCategoryID, true).
+		Where("sale_starts_at IS NULL OR sale_starts_at <= ?", now).
+		Where("sale_ends_at IS NULL OR sale_ends_at > ?", now).
+		Find(&batches).Error; err != nil {
+		return nil, err
+	}
+
+	// No open presale: the category is on public sale
+	if len(batches) == 0 {
+		return nil, nil
+	}
+
+	if req.PresaleCode == "" {
+		// Members of an allowed role may buy without a code
+		var roleID string
+		if err := tx.Raw("SELECT role_id FROM users WHERE id = ? AND deleted_at IS NULL", userID).Scan(&roleID).Error; err != nil {
+			return nil, err
+		}
+		for _, batch := range batches {
+			if batch.AllowedRoleID != nil && *batch.AllowedRoleID == roleID {
+				return nil, nil
+			}
+		}
+		return nil, ErrPresaleCodeRequired
+	}
+
+	var pc presale.PresaleCode
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", strings.ToUpper(req.PresaleCode)).First(&pc).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPresaleCodeInvalid
+		}
+		return nil, err
+	}
+
+	open := false
+	for _, batch := range batches {
+		if batch.ID == pc.BatchID {
+			open = true
+			break
+		}
+	}
+	if !open {
+		return nil, ErrPresaleCodeInvalid
+	}
+
+	if pc.BoundUserID != nil && *pc.BoundUserID != userID {
+		return nil, ErrPresaleCodeForbidden
+	}
+	if pc.IsExhausted() {
+		return nil, ErrPresaleCodeExhausted
+	}
+
+	return &pc, nil
+}
+
 // RestoreQuota restores quota and remaining seats for an order (idempotent via QuotaRestored flag)
 // Uses SELECT FOR UPDATE on the order to prevent concurrent double-restoration
 func (s *Service) RestoreQuota(orderID string) error {
@@ -431,10 +720,49 @@ func (s *Service) RestoreQuota(orderID string) error {
 		return nil
 	}
 
-	// Cancel OrderItems if they exist (for orders that were PAID and OrderItems were generated)
+	// Upgrade orders only reserved one seat in the target category; the ticket and its
+	// schedule seat still belong to the original order
+	if o.UpgradeOrderItemID != nil {
+		if err := tx.Model(&ticketcategory.TicketCategory{}).
+			Where("id = ?", o.TicketCategoryID).
+			UpdateColumn("quota", gorm.Expr("quota + ?", o.Quantity)).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		o.QuotaRestored = true
+		if err := tx.Save(&o).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to mark quota as restored: %w", err)
+		}
+		return tx.Commit().Error
+	}
+
+	// Resale orders never took quota; the listing just goes back on the marketplace
+	if o.ResaleListingID != nil {
+		if err := releaseResaleReservation(tx, &o); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to release resale listing: %w", err)
+		}
+		o.QuotaRestored = true
+		if err := tx.Save(&o).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to mark quota as restored: %w", err)
+		}
+		return tx.Commit().Error
+	}
+
+	// Cancel OrderItems if they exist (for orders that were PAID and OrderItems were generated).
+	// Upgraded tickets hold a seat of the category they were upgraded to; the seat of the
+	// order's category was already given back when the upgrade was paid.
+	upgradedSeats := make(map[string]int)
+	originalSeats := o.Quantity
 	orderItems, err := s.orderItemRepo.FindByOrderID(orderID)
 	if err == nil && len(orderItems) > 0 {
 		for _, item := range orderItems {
+			if item.CategoryID != o.TicketCategoryID {
+				upgradedSeats[item.CategoryID]++
+				originalSeats--
+			}
 			item.Status = orderitem.TicketStatusCanceled
 			if err := tx.Save(item).Error; err != nil {
 				tx.Rollback()
@@ -442,17 +770,65 @@ func (s *Service) RestoreQuota(orderID string) error {
 			}
 		}
 	}
+	if originalSeats < 0 {
+		originalSeats = 0
+	}
+
+	// Seats taken from an active allocation go back to it; once the allocation has been
+	// released or expired they return to the public quota instead
+	returnToPublic := originalSeats
+	if o.QuotaAllocationID != nil {
+		var qa quotaallocation.QuotaAllocation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *o.QuotaAllocationID).First(&qa).Error; err == nil {
+			qa.Used -= originalSeats
+			if qa.Used < 0 {
+				qa.Used = 0
+			}
+			if qa.Status == quotaallocation.AllocationStatusActive {
+				returnToPublic = 0
+			} else {
+				qa.Size = qa.Used
+			}
+			if err := tx.Save(&qa).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to restore quota allocation: %w", err)
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Give the presale code use back so the buyer can retry; the code stays bound to them
+	if o.PresaleCodeID != nil {
+		if err := tx.Model(&presale.PresaleCode{}).
+			Where("id = ? AND used_count > 0", *o.PresaleCodeID).
+			UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to restore presale code: %w", err)
+		}
+	}
 
 	// Lock and restore ticket category quota
-	var ticketCategory ticketcategory.TicketCategory
-	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", o.TicketCategoryID).First(&ticketCategory).Error; err != nil {
-		tx.Rollback()
-		return err
+	if returnToPublic > 0 {
+		var ticketCategory ticketcategory.TicketCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", o.TicketCategoryID).First(&ticketCategory).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		ticketCategory.Quota += returnToPublic
+		if err := tx.Save(&ticketCategory).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
-	ticketCategory.Quota += o.Quantity
-	if err := tx.Save(&ticketCategory).Error; err != nil {
-		tx.Rollback()
-		return err
+	for categoryID, seats := range upgradedSeats {
+		if err := tx.Model(&ticketcategory.TicketCategory{}).
+			Where("id = ?", categoryID).
+			UpdateColumn("quota", gorm.Expr("quota + ?", seats)).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
 	// Lock and restore schedule remaining seats
@@ -466,6 +842,10 @@ func (s *Service) RestoreQuota(orderID string) error {
 		tx.Rollback()
 		return err
 	}
+	if err := restorePassSeats(tx, &o); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	// Mark quota as restored (prevents double-restoration on concurrent webhook + cron race)
 	o.QuotaRestored = true
@@ -503,6 +883,24 @@ func (s *Service) CancelExpiredOrder(orderID string) error {
 	return s.repo.Update(o)
 }
 
+// paymentItem bills the order at its own price snapshot rather than the category's current price,
+// since Midtrans rejects charges whose items don't add up to the gross amount. Upgrade orders cost
+// the price difference and resale orders the listing price; orders whose snapshot doesn't add up
+// to the total are billed as a single item.
+func paymentItem(o *order.Order, name string) midtrans.ItemDetail {
+	item := midtrans.ItemDetail{
+		ID:       o.TicketCategoryID,
+		Price:    o.UnitPrice,
+		Quantity: o.Quantity,
+		Name:     name,
+	}
+	if o.Quantity <= 0 || abs(o.UnitPrice*float64(o.Quantity)-o.TotalAmount) > 0.01 {
+		item.Price = o.TotalAmount
+		item.Quantity = 1
+	}
+	return item
+}
+
 // InitiatePayment initiates payment via Midtrans
 func (s *Service) InitiatePayment(orderID string, paymentMethod string) (*PaymentInitiationResponse, error) {
 	// Find order
@@ -630,14 +1028,7 @@ func (s *Service) InitiatePayment(orderID string, paymentMethod string) (*Paymen
 			OrderID:     o.OrderCode,
 			GrossAmount: o.TotalAmount,
 		},
-		ItemDetails: []midtrans.ItemDetail{
-			{
-				ID:       o.TicketCategoryID,
-				Price:    ticketCategory.Price,
-				Quantity: o.Quantity,
-				Name:     itemName,
-			},
-		},
+		ItemDetails: []midtrans.ItemDetail{paymentItem(o, itemName)},
 		CustomerDetails: midtrans.CustomerDetails{
 			FirstName: firstName,
 			LastName:  lastName,
@@ -811,10 +1202,10 @@ func (s *Service) CheckPaymentStatus(orderID string) (*PaymentStatusResponse, er
 					return err
 				}
 
-				// If status changed to PAID, generate tickets (self-healing)
+				// If status changed to PAID, generate tickets or apply the upgrade (self-healing)
 				if paymentStatus == order.PaymentStatusPaid {
 					go func() {
-						_, _ = s.orderItemService.GenerateTickets(lo.ID, []string{lo.TicketCategoryID}, []int{lo.Quantity})
+						_ = s.fulfillPaidOrder(&lo)
 					}()
 				}
 			}
@@ -979,18 +1370,12 @@ func (s *Service) ProcessPaymentWebhook(payload *midtrans.WebhookPayload) error
 
 	// If status changed to PAID, trigger OrderItem generation
 	if newPaymentStatus == order.PaymentStatusPaid && oldStatus == order.PaymentStatusUnpaid {
-		// Generate OrderItems for this order
+		// Generate OrderItems for this order (or switch the ticket for an upgrade order)
 		// Use order's TicketCategoryID and Quantity to generate tickets
-		categories := []string{o.TicketCategoryID}
-		quantities := []int{o.Quantity}
-
-		if s.orderItemService != nil {
-			_, err := s.orderItemService.GenerateTickets(o.ID, categories, quantities)
-			if err != nil {
-				// Log error but don't fail webhook processing
-				// OrderItem generation can be retried manually if needed
-				// Error will be logged by the service
-			}
+		if err := s.fulfillPaidOrder(o); err != nil {
+			// Log error but don't fail webhook processing
+			// OrderItem generation can be retried manually if needed
+			log.Printf("[ProcessPaymentWebhook] Error fulfilling order %s: %v", o.ID, err)
 		}
 	}
 
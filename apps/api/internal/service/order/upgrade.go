@@ -0,0 +1,222 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOrderItemNotFound    = errors.New("ticket not found")
+	ErrUpgradeForbidden     = errors.New("not authorized to upgrade this ticket")
+	ErrTicketNotUpgradable  = errors.New("ticket cannot be upgraded in its current status")
+	ErrUpgradeInvalidTarget = errors.New("target category is not a valid upgrade for this ticket")
+	ErrUpgradePending       = errors.New("ticket already has an upgrade awaiting payment")
+)
+
+// CreateUpgradeOrder reserves a seat in the target category and creates a supplementary order
+// for the price difference. The ticket itself is only switched once that order is paid.
+func (s *Service) CreateUpgradeOrder(orderID, itemID string, req *order.UpgradeTicketRequest, userID string, idempotencyKey string) (*order.OrderResponse, error) {
+	// Idempotency check: return the upgrade order already created with this key
+	if idempotencyKey != "" {
+		existingOrder, err := s.repo.FindByIdempotencyKey(idempotencyKey)
+		if err == nil && existingOrder != nil {
+			return existingOrder.ToOrderResponse(), nil
+		}
+	}
+
+	if err := s.ensureBuyer(userID); err != nil {
+		return nil, err
+	}
+
+	var newOrder *order.Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL statement_timeout = '30s'").Error; err != nil {
+			return fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+
+		// Lock the ticket so concurrent upgrade requests are serialized
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND order_id = ?", itemID, orderID).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderItemNotFound
+			}
+			return err
+		}
+
+		var parent order.Order
+		if err := tx.Where("id = ?", item.OrderID).First(&parent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+		if parent.UserID != userID {
+			return ErrUpgradeForbidden
+		}
+		if item.Status != orderitem.TicketStatusPaid || item.ListedForResale {
+			return ErrTicketNotUpgradable
+		}
+
+		// Only one upgrade may await payment per ticket
+		var pending int64
+		if err := tx.Model(&order.Order{}).
+			Where("upgrade_order_item_id = ? AND payment_status = ?", item.ID, order.PaymentStatusUnpaid).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending > 0 {
+			return ErrUpgradePending
+		}
+
+		var sched schedule.Schedule
+		if err := tx.Where("id = ?", parent.ScheduleID).First(&sched).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrScheduleNotFound
+			}
+			return err
+		}
+		if !sched.Date.IsZero() && sched.Date.Before(time.Now().Truncate(24*time.Hour)) {
+			return ErrSchedulePassed
+		}
+
+		var current ticketcategory.TicketCategory
+		if err := tx.Where("id = ?", item.CategoryID).First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketCategoryNotFound
+			}
+			return err
+		}
+
+		// Lock the target category (SELECT FOR UPDATE) before checking its quota
+		var target ticketcategory.TicketCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", req.TargetCategoryID).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketCategoryNotFound
+			}
+			return err
+		}
+		if target.ID == current.ID || target.EventID != current.EventID {
+			return ErrUpgradeInvalidTarget
+		}
+
+		// The buyer has paid the purchase-time unit price plus any earlier upgrades of this ticket
+		var upgradesPaid float64
+		if err := tx.Model(&order.Order{}).
+			Where("upgrade_order_item_id = ? AND payment_status = ?", item.ID, order.PaymentStatusPaid).
+			Select("COALESCE(SUM(total_amount), 0)").
+			Scan(&upgradesPaid).Error; err != nil {
+			return err
+		}
+		difference := target.Price - (parent.UnitPrice + upgradesPaid)
+		if difference <= 0 {
+			return ErrUpgradeInvalidTarget
+		}
+
+		if target.Quota < 1 {
+			return ErrInsufficientQuota
+		}
+		target.Quota--
+		if err := tx.Save(&target).Error; err != nil {
+			return err
+		}
+
+		var idempotencyKeyPtr *string
+		if idempotencyKey != "" {
+			idempotencyKeyPtr = &idempotencyKey
+		}
+
+		paymentExpiresAt := time.Now().Add(15 * time.Minute)
+		newOrder = &order.Order{
+			UserID:               userID,
+			ScheduleID:           parent.ScheduleID,
+			TicketCategoryID:     target.ID,
+			UpgradeOrderItemID:   &item.ID,
+			Quantity:             1,
+			UnitPrice:            difference,
+			TotalAmount:          difference,
+			CategoryNameSnapshot: target.CategoryName,
+			EventNameSnapshot:    parent.EventNameSnapshot,
+			ScheduleNameSnapshot: parent.ScheduleNameSnapshot,
+			PaymentStatus:        order.PaymentStatusUnpaid,
+			PaymentExpiresAt:     &paymentExpiresAt,
+			IdempotencyKey:       idempotencyKeyPtr,
+			BuyerName:            parent.BuyerName,
+			BuyerEmail:           parent.BuyerEmail,
+			BuyerPhone:           parent.BuyerPhone,
+		}
+		return tx.Create(newOrder).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createdOrder, err := s.repo.FindByID(newOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	return createdOrder.ToOrderResponse(), nil
+}
+
+// fulfillPaidOrder issues what a newly paid order bought: tickets for a regular order, the
+// category switch for an upgrade order, or the ticket transfer for a resale order
+func (s *Service) fulfillPaidOrder(o *order.Order) error {
+	if o.UpgradeOrderItemID != nil {
+		return s.applyUpgrade(o)
+	}
+	if o.ResaleListingID != nil {
+		return s.applyResale(o)
+	}
+	if s.orderItemService == nil {
+		return nil
+	}
+	_, err := s.orderItemService.GenerateTickets(o.ID, []string{o.TicketCategoryID}, []int{o.Quantity})
+	return err
+}
+
+// applyUpgrade atomically moves the ticket into the upgrade order's category, reissues its QR
+// code and returns the old seat to the source category quota (idempotent)
+func (s *Service) applyUpgrade(o *order.Order) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *o.UpgradeOrderItemID).First(&item).Error; err != nil {
+			return err
+		}
+
+		// Already applied (webhook and status sync can both report the payment)
+		if item.CategoryID == o.TicketCategoryID {
+			return nil
+		}
+		if item.Status != orderitem.TicketStatusPaid && item.Status != orderitem.TicketStatusCheckedIn {
+			return fmt.Errorf("ticket %s is %s, upgrade order %s needs manual refund", item.ID, item.Status, o.ID)
+		}
+
+		var source ticketcategory.TicketCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", item.CategoryID).First(&source).Error; err != nil {
+			return err
+		}
+		source.Quota++
+		if err := tx.Save(&source).Error; err != nil {
+			return err
+		}
+
+		fromCategoryID := item.CategoryID
+		item.UpgradedFromCategoryID = &fromCategoryID
+		item.CategoryID = o.TicketCategoryID
+		item.ReissueQRCode()
+		if err := tx.Save(&item).Error; err != nil {
+			return err
+		}
+
+		log.Printf("[Upgrade] Ticket %s upgraded from category %s to %s by order %s", item.ID, fromCategoryID, item.CategoryID, o.ID)
+		return nil
+	})
+}
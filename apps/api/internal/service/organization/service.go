@@ -0,0 +1,173 @@
+package organization
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	organizationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/organization"
+	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound   = errors.New("organization not found")
+	ErrOrganizationCodeExists = errors.New("organization code already exists")
+)
+
+type Service struct {
+	repo         organizationrepo.Repository
+	auditService *auditservice.Service
+}
+
+func NewService(repo organizationrepo.Repository, auditService *auditservice.Service) *Service {
+	return &Service{
+		repo:         repo,
+		auditService: auditService,
+	}
+}
+
+// GetByID returns an organization
+func (s *Service) GetByID(id string) (*organization.OrganizationResponse, error) {
+	o, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return o.ToOrganizationResponse(), nil
+}
+
+// Create creates an organization
+func (s *Service) Create(req *organization.CreateOrganizationRequest) (*organization.OrganizationResponse, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	_, err := s.repo.FindByCode(code)
+	if err == nil {
+		return nil, ErrOrganizationCodeExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	status := req.Status
+	if status == "" {
+		status = organization.OrganizationStatusActive
+	}
+
+	o := &organization.Organization{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      status,
+	}
+	if err := s.repo.Create(o); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(o.ID)
+}
+
+// CreateWithAudit creates an organization and records it in the audit log
+func (s *Service) CreateWithAudit(c *gin.Context, req *organization.CreateOrganizationRequest) (*organization.OrganizationResponse, error) {
+	resp, err := s.Create(req)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "ORGANIZATION_CREATE", "organization", resp.ID, nil, resp)
+	}
+	return resp, err
+}
+
+// Update updates an organization
+func (s *Service) Update(id string, req *organization.UpdateOrganizationRequest) (*organization.OrganizationResponse, error) {
+	o, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	if req.Name != nil {
+		o.Name = *req.Name
+	}
+	if req.Description != nil {
+		o.Description = *req.Description
+	}
+	if req.Status != nil {
+		o.Status = *req.Status
+	}
+
+	if err := s.repo.Update(o); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(o.ID)
+}
+
+// UpdateWithAudit updates an organization and records it in the audit log
+func (s *Service) UpdateWithAudit(c *gin.Context, id string, req *organization.UpdateOrganizationRequest) (*organization.OrganizationResponse, error) {
+	oldOrganization, _ := s.GetByID(id)
+	resp, err := s.Update(id, req)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "ORGANIZATION_UPDATE", "organization", id, oldOrganization, resp)
+	}
+	return resp, err
+}
+
+// List lists the organizations within the scope, newest first
+func (s *Service) List(scope organization.Scope, req *organization.ListOrganizationsRequest) ([]*organization.OrganizationResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.Status != "" {
+		filters["status"] = string(req.Status)
+	}
+	if req.Search != "" {
+		filters["search"] = req.Search
+	}
+
+	organizations, total, err := s.repo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*organization.OrganizationResponse, len(organizations))
+	for i, o := range organizations {
+		responses[i] = o.ToOrganizationResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// IsActive reports whether the organization exists and is active
+func (s *Service) IsActive(organizationID string) (bool, error) {
+	if _, err := uuid.Parse(organizationID); err != nil {
+		return false, nil
+	}
+	o, err := s.repo.FindByID(organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return o.Status == organization.OrganizationStatusActive, nil
+}
+
+// OwnerOf returns the ID of the organization owning a resource, see middleware.OrganizationResource
+func (s *Service) OwnerOf(resource organization.Resource, id string) (string, error) {
+	return s.repo.FindOwner(resource, id)
+}
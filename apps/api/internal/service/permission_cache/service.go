@@ -0,0 +1,168 @@
+package permissioncache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	redisint "github.com/gilabs/webapp-ticket-konser/api/internal/integration/redis"
+	rolerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	"gorm.io/gorm"
+)
+
+const redisTimeout = 2 * time.Second
+
+// entry is the cached permissions of one role
+type entry struct {
+	codes    map[string]struct{}
+	version  int64
+	loadedAt time.Time
+}
+
+// Service caches the permission codes and permissions version of each role, so RequirePermission
+// doesn't query the database on every request. Invalidations are published over Redis when it is
+// enabled so every replica drops its copy; the TTL bounds how stale a replica that missed one can be.
+type Service struct {
+	roleRepo rolerepo.Repository
+	ttl      time.Duration
+
+	mu         sync.RWMutex
+	entries    map[string]*entry
+	generation uint64 // Bumped on every invalidation, so a load racing one is not stored
+}
+
+func NewService(roleRepo rolerepo.Repository, ttl time.Duration) *Service {
+	return &Service{
+		roleRepo: roleRepo,
+		ttl:      ttl,
+		entries:  make(map[string]*entry),
+	}
+}
+
+// HasPermission reports whether the role has the permission
+func (s *Service) HasPermission(roleID, permissionCode string) (bool, error) {
+	e, err := s.get(roleID)
+	if err != nil {
+		return false, err
+	}
+	_, ok := e.codes[permissionCode]
+	return ok, nil
+}
+
+// PermissionsVersion returns the permissions version of the role, embedded in access tokens
+func (s *Service) PermissionsVersion(roleID string) (int64, error) {
+	e, err := s.get(roleID)
+	if err != nil {
+		return 0, err
+	}
+	return e.version, nil
+}
+
+// Invalidate drops the cached permissions of the role on every replica
+func (s *Service) Invalidate(roleID string) {
+	s.invalidateLocal(roleID)
+	s.publish(roleID)
+}
+
+// Listen applies the invalidations published by other replicas until ctx is done. It returns
+// immediately when Redis is disabled.
+func (s *Service) Listen(ctx context.Context) {
+	if redisint.Client == nil {
+		return
+	}
+
+	pubsub := redisint.Client.Subscribe(ctx, channel())
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			s.invalidateLocal(msg.Payload)
+		}
+	}
+}
+
+func (s *Service) get(roleID string) (*entry, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	e, ok := s.entries[roleID]
+	generation := s.generation
+	s.mu.RUnlock()
+	if ok && now.Sub(e.loadedAt) < s.ttl {
+		return e, nil
+	}
+
+	e, err := s.load(roleID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.generation == generation {
+		s.entries[roleID] = e
+	}
+	s.mu.Unlock()
+	return e, nil
+}
+
+func (s *Service) load(roleID string, now time.Time) (*entry, error) {
+	r, err := s.roleRepo.FindByID(roleID)
+	if err != nil {
+		// A deleted role has no permissions
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entry{codes: map[string]struct{}{}, loadedAt: now}, nil
+		}
+		return nil, err
+	}
+	permissions, err := s.roleRepo.GetPermissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]struct{}, len(permissions))
+	for _, p := range permissions {
+		codes[p.Code] = struct{}{}
+	}
+	return &entry{
+		codes:    codes,
+		version:  r.PermissionsVersion,
+		loadedAt: now,
+	}, nil
+}
+
+func (s *Service) invalidateLocal(roleID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	delete(s.entries, roleID)
+}
+
+func (s *Service) publish(roleID string) {
+	if redisint.Client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := redisint.Client.Publish(ctx, channel(), roleID).Err(); err != nil {
+		log.Printf("[PermissionCache] Failed to publish invalidation of role %s: %v", roleID, err)
+	}
+}
+
+func channel() string {
+	prefix := "ticketing_api"
+	if config.AppConfig != nil && config.AppConfig.Redis.Prefix != "" {
+		prefix = config.AppConfig.Redis.Prefix
+	}
+	return redisint.Key(prefix, "permission_cache", "invalidate")
+}
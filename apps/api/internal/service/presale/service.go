@@ -0,0 +1,287 @@
+package presale
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/presale"
+	presalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/presale"
+	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ticket_category"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPresaleBatchNotFound   = errors.New("presale batch not found")
+	ErrTicketCategoryNotFound = errors.New("ticket category not found")
+	ErrInvalidSaleWindow      = errors.New("sale end time must be after sale start time")
+	ErrNoAccessMethod         = errors.New("presale batch needs codes or an allowed role")
+)
+
+type Service struct {
+	repo               presalerepo.Repository
+	ticketCategoryRepo ticketcategoryrepo.Repository
+}
+
+func NewService(repo presalerepo.Repository, ticketCategoryRepo ticketcategoryrepo.Repository) *Service {
+	return &Service{
+		repo:               repo,
+		ticketCategoryRepo: ticketCategoryRepo,
+	}
+}
+
+// GetBatchByID returns a presale batch by ID
+func (s *Service) GetBatchByID(id string) (*presale.PresaleBatchResponse, error) {
+	batch, err := s.findBatch(id)
+	if err != nil {
+		return nil, err
+	}
+	return batch.ToPresaleBatchResponse(), nil
+}
+
+// ListBatches lists presale batches with pagination and filters
+func (s *Service) ListBatches(scope organization.Scope, req *presale.ListPresaleBatchesRequest) ([]*presale.PresaleBatchResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	// Build filters
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.TicketCategoryID != "" {
+		filters["ticket_category_id"] = req.TicketCategoryID
+	}
+	if req.EventID != "" {
+		filters["event_id"] = req.EventID
+	}
+	if req.IsActive != nil {
+		filters["is_active"] = *req.IsActive
+	}
+
+	batches, total, err := s.repo.ListBatches(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*presale.PresaleBatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = batch.ToPresaleBatchResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// CreateBatch creates a presale batch and generates its codes
+func (s *Service) CreateBatch(req *presale.CreatePresaleBatchRequest, createdBy string) (*presale.PresaleBatchResponse, error) {
+	if req.SaleStartsAt != nil && req.SaleEndsAt != nil && !req.SaleEndsAt.After(*req.SaleStartsAt) {
+		return nil, ErrInvalidSaleWindow
+	}
+	if req.CodeCount == 0 && req.AllowedRoleID == nil {
+		return nil, ErrNoAccessMethod
+	}
+
+	if _, err := s.ticketCategoryRepo.FindByID(req.TicketCategoryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTicketCategoryNotFound
+		}
+		return nil, err
+	}
+
+	maxUses := req.MaxUsesPerCode
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	batch := &presale.PresaleBatch{
+		TicketCategoryID: req.TicketCategoryID,
+		Name:             req.Name,
+		Prefix:           strings.ToUpper(req.Prefix),
+		CodeCount:        req.CodeCount,
+		MaxUsesPerCode:   maxUses,
+		AllowedRoleID:    req.AllowedRoleID,
+		SaleStartsAt:     req.SaleStartsAt,
+		SaleEndsAt:       req.SaleEndsAt,
+		IsActive:         true,
+		CreatedBy:        createdBy,
+	}
+
+	codes := generateCodes(batch, req.CodeCount)
+	if err := s.repo.CreateBatch(batch, codes); err != nil {
+		return nil, err
+	}
+
+	return s.GetBatchByID(batch.ID)
+}
+
+// UpdateBatch updates a presale batch
+func (s *Service) UpdateBatch(id string, req *presale.UpdatePresaleBatchRequest) (*presale.PresaleBatchResponse, error) {
+	batch, err := s.findBatch(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		batch.Name = *req.Name
+	}
+	if req.AllowedRoleID != nil {
+		batch.AllowedRoleID = req.AllowedRoleID
+	}
+	if req.SaleStartsAt != nil {
+		batch.SaleStartsAt = req.SaleStartsAt
+	}
+	if req.SaleEndsAt != nil {
+		batch.SaleEndsAt = req.SaleEndsAt
+	}
+	if req.IsActive != nil {
+		batch.IsActive = *req.IsActive
+	}
+
+	if batch.SaleStartsAt != nil && batch.SaleEndsAt != nil && !batch.SaleEndsAt.After(*batch.SaleStartsAt) {
+		return nil, ErrInvalidSaleWindow
+	}
+
+	if err := s.repo.UpdateBatch(batch); err != nil {
+		return nil, err
+	}
+
+	return s.GetBatchByID(id)
+}
+
+// GenerateCodes generates additional codes for an existing batch
+func (s *Service) GenerateCodes(id string, req *presale.GeneratePresaleCodesRequest) (*presale.PresaleBatchResponse, error) {
+	batch, err := s.findBatch(id)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := generateCodes(batch, req.Count)
+	if err := s.repo.AddCodes(batch, codes); err != nil {
+		return nil, err
+	}
+
+	return s.GetBatchByID(id)
+}
+
+// ListCodes lists codes of a batch with pagination and filters
+func (s *Service) ListCodes(id string, req *presale.ListPresaleCodesRequest) ([]*presale.PresaleCodeResponse, *response.PaginationMeta, error) {
+	if _, err := s.findBatch(id); err != nil {
+		return nil, nil, err
+	}
+
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	// Build filters
+	filters := make(map[string]interface{})
+	if req.Search != "" {
+		filters["search"] = req.Search
+	}
+	if req.Used != nil {
+		filters["used"] = *req.Used
+	}
+
+	codes, total, err := s.repo.ListCodes(id, page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*presale.PresaleCodeResponse, len(codes))
+	for i, code := range codes {
+		responses[i] = code.ToPresaleCodeResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// ExportCodes exports all codes of a batch as CSV rows (header included)
+func (s *Service) ExportCodes(id string) (string, [][]string, error) {
+	batch, err := s.findBatch(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	codes, err := s.repo.FindCodesByBatchID(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows := make([][]string, 0, len(codes)+1)
+	rows = append(rows, []string{"Code", "Max Uses", "Used Count", "Bound User ID", "Bound At"})
+	for _, code := range codes {
+		boundUserID := ""
+		if code.BoundUserID != nil {
+			boundUserID = *code.BoundUserID
+		}
+		boundAt := ""
+		if code.BoundAt != nil {
+			boundAt = code.BoundAt.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			code.Code,
+			strconv.Itoa(code.MaxUses),
+			strconv.Itoa(code.UsedCount),
+			boundUserID,
+			boundAt,
+		})
+	}
+
+	filename := fmt.Sprintf("presale-codes-%s.csv", batch.ID)
+	return filename, rows, nil
+}
+
+// GetBatchReport returns redemption statistics for a batch
+func (s *Service) GetBatchReport(id string) (*presale.PresaleBatchReport, error) {
+	batch, err := s.findBatch(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetBatchReport(batch)
+}
+
+// findBatch loads a batch and maps not-found errors
+func (s *Service) findBatch(id string) (*presale.PresaleBatch, error) {
+	batch, err := s.repo.FindBatchByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPresaleBatchNotFound
+		}
+		return nil, err
+	}
+	return batch, nil
+}
+
+// generateCodes builds count unique codes for a batch
+func generateCodes(batch *presale.PresaleBatch, count int) []*presale.PresaleCode {
+	seen := make(map[string]struct{}, count)
+	codes := make([]*presale.PresaleCode, 0, count)
+	for len(codes) < count {
+		code := presale.GenerateCode(batch.Prefix)
+		if _, dup := seen[code]; dup {
+			continue
+		}
+		seen[code] = struct{}{}
+		codes = append(codes, &presale.PresaleCode{
+			Code:    code,
+			MaxUses: batch.MaxUsesPerCode,
+		})
+	}
+	return codes
+}
@@ -0,0 +1,304 @@
+package quotaallocation
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	quotaallocation "github.com/gilabs/webapp-ticket-konser/api/internal/domain/quota_allocation"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	quotaallocationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/quota_allocation"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrQuotaAllocationNotFound = errors.New("quota allocation not found")
+	ErrTicketCategoryNotFound  = errors.New("ticket category not found")
+	ErrInsufficientQuota       = errors.New("insufficient category quota for allocation")
+	ErrAllocationNotActive     = errors.New("quota allocation is not active")
+	ErrSizeBelowUsed           = errors.New("allocation size cannot be less than seats already used")
+	ErrAccessCodeExists        = errors.New("access code already in use")
+	ErrExpiryInPast            = errors.New("expiry time must be in the future")
+)
+
+type Service struct {
+	repo quotaallocationrepo.Repository
+	db   *gorm.DB
+}
+
+func NewService(repo quotaallocationrepo.Repository) *Service {
+	return &Service{
+		repo: repo,
+		db:   database.DB,
+	}
+}
+
+// GetByID returns a quota allocation by ID
+func (s *Service) GetByID(id string) (*quotaallocation.QuotaAllocationResponse, error) {
+	qa, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuotaAllocationNotFound
+		}
+		return nil, err
+	}
+	return qa.ToQuotaAllocationResponse(), nil
+}
+
+// List lists quota allocations with pagination and filters
+func (s *Service) List(scope organization.Scope, req *quotaallocation.ListQuotaAllocationsRequest) ([]*quotaallocation.QuotaAllocationResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	// Build filters
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.TicketCategoryID != "" {
+		filters["ticket_category_id"] = req.TicketCategoryID
+	}
+	if req.EventID != "" {
+		filters["event_id"] = req.EventID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	if req.OwnerType != "" {
+		filters["owner_type"] = req.OwnerType
+	}
+
+	allocations, total, err := s.repo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*quotaallocation.QuotaAllocationResponse, len(allocations))
+	for i, qa := range allocations {
+		responses[i] = qa.ToQuotaAllocationResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// Create carves a new allocation out of a ticket category's public quota
+func (s *Service) Create(req *quotaallocation.CreateQuotaAllocationRequest, createdBy string) (*quotaallocation.QuotaAllocationResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	accessCode := req.AccessCode
+	if accessCode == nil && req.GenerateAccessCode {
+		code := quotaallocation.GenerateAccessCode()
+		accessCode = &code
+	}
+	if accessCode != nil {
+		if _, err := s.repo.FindByAccessCode(*accessCode); err == nil {
+			return nil, ErrAccessCodeExists
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	qa := &quotaallocation.QuotaAllocation{
+		TicketCategoryID: req.TicketCategoryID,
+		Name:             req.Name,
+		OwnerType:        req.OwnerType,
+		OwnerName:        req.OwnerName,
+		OwnerUserID:      req.OwnerUserID,
+		AccessCode:       accessCode,
+		Complimentary:    req.Complimentary,
+		Size:             req.Size,
+		Status:           quotaallocation.AllocationStatusActive,
+		ExpiresAt:        req.ExpiresAt,
+		CreatedBy:        createdBy,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock ticket category so allocation and public sales can't oversell together
+		var category ticketcategory.TicketCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", req.TicketCategoryID).First(&category).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketCategoryNotFound
+			}
+			return err
+		}
+
+		if category.Quota < req.Size {
+			return ErrInsufficientQuota
+		}
+
+		category.Quota -= req.Size
+		if err := tx.Save(&category).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(qa).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.FindByID(qa.ID)
+	if err != nil {
+		return nil, err
+	}
+	return created.ToQuotaAllocationResponse(), nil
+}
+
+// Update updates an active allocation; resizing moves the difference between the
+// allocation and the category's public quota
+func (s *Service) Update(id string, req *quotaallocation.UpdateQuotaAllocationRequest) (*quotaallocation.QuotaAllocationResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrExpiryInPast
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var qa quotaallocation.QuotaAllocation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&qa).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrQuotaAllocationNotFound
+			}
+			return err
+		}
+
+		if qa.Status != quotaallocation.AllocationStatusActive {
+			return ErrAllocationNotActive
+		}
+
+		if req.Size != nil && *req.Size != qa.Size {
+			if *req.Size < qa.Used {
+				return ErrSizeBelowUsed
+			}
+
+			var category ticketcategory.TicketCategory
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", qa.TicketCategoryID).First(&category).Error; err != nil {
+				return err
+			}
+
+			delta := *req.Size - qa.Size
+			if delta > 0 && category.Quota < delta {
+				return ErrInsufficientQuota
+			}
+			category.Quota -= delta
+			if err := tx.Save(&category).Error; err != nil {
+				return err
+			}
+			qa.Size = *req.Size
+		}
+
+		if req.Name != nil {
+			qa.Name = *req.Name
+		}
+		if req.OwnerName != nil {
+			qa.OwnerName = *req.OwnerName
+		}
+		if req.OwnerUserID != nil {
+			qa.OwnerUserID = req.OwnerUserID
+		}
+		if req.Complimentary != nil {
+			qa.Complimentary = *req.Complimentary
+		}
+		if req.ExpiresAt != nil {
+			qa.ExpiresAt = req.ExpiresAt
+		}
+
+		return tx.Save(&qa).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return updated.ToQuotaAllocationResponse(), nil
+}
+
+// Release manually releases an allocation, returning unused seats to the public quota
+func (s *Service) Release(id string) (*quotaallocation.QuotaAllocationResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return releaseInTx(tx, id, quotaallocation.AllocationStatusReleased)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	released, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return released.ToQuotaAllocationResponse(), nil
+}
+
+// ReleaseExpired releases all active allocations whose expiry time has passed.
+// Returns the number of allocations released.
+func (s *Service) ReleaseExpired() (int, error) {
+	expired, err := s.repo.FindExpiredActive(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, qa := range expired {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			return releaseInTx(tx, qa.ID, quotaallocation.AllocationStatusExpired)
+		})
+		if err != nil {
+			if errors.Is(err, ErrAllocationNotActive) {
+				continue // Released concurrently
+			}
+			log.Printf("[QuotaAllocation] Error releasing expired allocation %s: %v", qa.ID, err)
+			continue
+		}
+		released++
+	}
+	return released, nil
+}
+
+// releaseInTx locks an allocation and returns its unused seats to the ticket category
+func releaseInTx(tx *gorm.DB, id string, status quotaallocation.AllocationStatus) error {
+	var qa quotaallocation.QuotaAllocation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&qa).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrQuotaAllocationNotFound
+		}
+		return err
+	}
+
+	if qa.Status != quotaallocation.AllocationStatusActive {
+		return ErrAllocationNotActive
+	}
+
+	unused := qa.Remaining()
+	if unused > 0 {
+		var category ticketcategory.TicketCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", qa.TicketCategoryID).First(&category).Error; err != nil {
+			return fmt.Errorf("failed to lock ticket category: %w", err)
+		}
+		category.Quota += unused
+		if err := tx.Save(&category).Error; err != nil {
+			return err
+		}
+	}
+
+	// Shrink the allocation to what was actually used so later order
+	// cancellations against it return seats to the public quota instead
+	now := time.Now()
+	qa.Size = qa.Used
+	qa.Status = status
+	qa.ReleasedAt = &now
+	return tx.Save(&qa).Error
+}
@@ -0,0 +1,383 @@
+package resale
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/order"
+	orderitem "github.com/gilabs/webapp-ticket-konser/api/internal/domain/order_item"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/resale"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
+	ticketcategory "github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	resalerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/resale"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrListingNotFound         = errors.New("resale listing not found")
+	ErrPayoutNotFound          = errors.New("seller payout not found")
+	ErrTicketNotFound          = errors.New("ticket not found")
+	ErrNotTicketHolder         = errors.New("only the ticket holder can list it for resale")
+	ErrTicketNotResellable     = errors.New("ticket cannot be listed for resale in its current status")
+	ErrTicketAlreadyListed     = errors.New("ticket is already listed for resale")
+	ErrPriceAboveCap           = errors.New("listing price exceeds the resale price cap")
+	ErrListingNotActive        = errors.New("resale listing is not active")
+	ErrNotListingSeller        = errors.New("only the seller can cancel this listing")
+	ErrPayoutAlreadyPaid       = errors.New("seller payout is already paid")
+	ErrScheduleNotFound        = errors.New("schedule not found")
+	ErrSchedulePassed          = errors.New("event schedule has already passed")
+	ErrOrderCreatorUnavailable = errors.New("order creation is not available")
+)
+
+// OrderCreatorInterface defines interface for OrderService to avoid circular dependency
+type OrderCreatorInterface interface {
+	CreateResaleOrder(listingID string, req *resale.PurchaseListingRequest, userID string, idempotencyKey string) (*order.OrderResponse, error)
+}
+
+type Service struct {
+	repo         resalerepo.Repository
+	orderCreator OrderCreatorInterface
+	db           *gorm.DB
+}
+
+func NewService(repo resalerepo.Repository, orderCreator OrderCreatorInterface) *Service {
+	return &Service{
+		repo:         repo,
+		orderCreator: orderCreator,
+		db:           database.DB,
+	}
+}
+
+// PriceCapError carries the highest price allowed for a rejected listing
+type PriceCapError struct {
+	FaceValue float64
+	MaxPrice  float64
+}
+
+func (e *PriceCapError) Error() string {
+	return fmt.Sprintf("%s (max %.2f)", ErrPriceAboveCap.Error(), e.MaxPrice)
+}
+
+func (e *PriceCapError) Unwrap() error {
+	return ErrPriceAboveCap
+}
+
+// CreateListing lists an issued ticket on the marketplace at up to the configured cap over face value
+func (s *Service) CreateListing(req *resale.CreateListingRequest, userID string) (*resale.ResaleListingResponse, error) {
+	var listing *resale.ResaleListing
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the ticket so it cannot be listed twice or upgraded concurrently
+		var item orderitem.OrderItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", req.OrderItemID).First(&item).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketNotFound
+			}
+			return err
+		}
+
+		var holderOrder order.Order
+		if err := tx.Where("id = ?", item.OrderID).First(&holderOrder).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTicketNotFound
+			}
+			return err
+		}
+		if holderOrder.UserID != userID {
+			return ErrNotTicketHolder
+		}
+		if item.ListedForResale {
+			return ErrTicketAlreadyListed
+		}
+		if item.Status != orderitem.TicketStatusPaid {
+			return ErrTicketNotResellable
+		}
+
+		// A ticket with an unpaid upgrade would change category under the buyer
+		var pendingUpgrades int64
+		if err := tx.Model(&order.Order{}).
+			Where("upgrade_order_item_id = ? AND payment_status = ?", item.ID, order.PaymentStatusUnpaid).
+			Count(&pendingUpgrades).Error; err != nil {
+			return err
+		}
+		if pendingUpgrades > 0 {
+			return ErrTicketNotResellable
+		}
+
+		var sched schedule.Schedule
+		if err := tx.Where("id = ?", holderOrder.ScheduleID).First(&sched).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrScheduleNotFound
+			}
+			return err
+		}
+		if !sched.Date.IsZero() && sched.Date.Before(time.Now().Truncate(24*time.Hour)) {
+			return ErrSchedulePassed
+		}
+
+		// Face value is the current price of the category the ticket is in
+		var category ticketcategory.TicketCategory
+		if err := tx.Where("id = ?", item.CategoryID).First(&category).Error; err != nil {
+			return err
+		}
+		maxPrice := resale.MaxPrice(category.Price, config.AppConfig.Resale.MaxMarkupPercent)
+		if req.Price > maxPrice {
+			return &PriceCapError{FaceValue: category.Price, MaxPrice: maxPrice}
+		}
+
+		fee := resale.PlatformFee(req.Price, config.AppConfig.Resale.PlatformFeePercent)
+		listing = &resale.ResaleListing{
+			OrderItemID:      item.ID,
+			SellerID:         userID,
+			TicketCategoryID: item.CategoryID,
+			ScheduleID:       holderOrder.ScheduleID,
+			FaceValue:        category.Price,
+			Price:            req.Price,
+			PlatformFee:      fee,
+			SellerAmount:     req.Price - fee,
+			Status:           resale.ListingStatusActive,
+		}
+		if err := tx.Create(listing).Error; err != nil {
+			return err
+		}
+
+		item.ListedForResale = true
+		return tx.Save(&item).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.FindListingByID(listing.ID)
+	if err != nil {
+		return nil, err
+	}
+	return created.ToResaleListingResponse(), nil
+}
+
+// CancelListing withdraws an active listing and unlocks the ticket for check-in.
+// An empty sellerID cancels as admin.
+func (s *Service) CancelListing(id, sellerID string) (*resale.ResaleListingResponse, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var listing resale.ResaleListing
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&listing).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrListingNotFound
+			}
+			return err
+		}
+		if sellerID != "" && listing.SellerID != sellerID {
+			return ErrNotListingSeller
+		}
+		// Reserved listings wait for the buyer's payment to settle or expire
+		if listing.Status != resale.ListingStatusActive {
+			return ErrListingNotActive
+		}
+
+		now := time.Now()
+		listing.Status = resale.ListingStatusCanceled
+		listing.CanceledAt = &now
+		if err := tx.Save(&listing).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&orderitem.OrderItem{}).
+			Where("id = ?", listing.OrderItemID).
+			Update("listed_for_resale", false).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := s.repo.FindListingByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return listing.ToResaleListingResponse(), nil
+}
+
+// Purchase reserves a listing for the buyer by creating its resale order
+func (s *Service) Purchase(id string, req *resale.PurchaseListingRequest, userID string, idempotencyKey string) (*order.OrderResponse, error) {
+	if s.orderCreator == nil {
+		return nil, ErrOrderCreatorUnavailable
+	}
+	return s.orderCreator.CreateResaleOrder(id, req, userID, idempotencyKey)
+}
+
+// GetListing returns a listing; buyers only see the public view
+func (s *Service) GetListing(id string, admin bool) (*resale.ResaleListingResponse, error) {
+	listing, err := s.repo.FindListingByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	if admin {
+		return listing.ToResaleListingResponse(), nil
+	}
+	return listing.ToPublicResponse(), nil
+}
+
+// Browse lists active listings on the marketplace, cheapest first
+func (s *Service) Browse(req *resale.ListListingsRequest) ([]*resale.ResaleListingResponse, *response.PaginationMeta, error) {
+	page, perPage := pagination(req.Page, req.PerPage)
+
+	filters := map[string]interface{}{
+		"status": resale.ListingStatusActive,
+	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
+	if req.TicketCategoryID != "" {
+		filters["ticket_category_id"] = req.TicketCategoryID
+	}
+
+	listings, total, err := s.repo.ListListings(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*resale.ResaleListingResponse, len(listings))
+	for i, l := range listings {
+		responses[i] = l.ToPublicResponse()
+	}
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// List lists listings with any status (admin)
+func (s *Service) List(scope organization.Scope, req *resale.ListListingsRequest) ([]*resale.ResaleListingResponse, *response.PaginationMeta, error) {
+	page, perPage := pagination(req.Page, req.PerPage)
+
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.ScheduleID != "" {
+		filters["schedule_id"] = req.ScheduleID
+	}
+	if req.TicketCategoryID != "" {
+		filters["ticket_category_id"] = req.TicketCategoryID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	if req.SellerID != "" {
+		filters["seller_id"] = req.SellerID
+	}
+
+	listings, total, err := s.repo.ListListings(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*resale.ResaleListingResponse, len(listings))
+	for i, l := range listings {
+		responses[i] = l.ToResaleListingResponse()
+	}
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// GetMyListings returns all listings of a seller
+func (s *Service) GetMyListings(sellerID string) ([]*resale.ResaleListingResponse, error) {
+	listings, err := s.repo.FindListingsBySellerID(sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*resale.ResaleListingResponse, len(listings))
+	for i, l := range listings {
+		responses[i] = l.ToResaleListingResponse()
+	}
+	return responses, nil
+}
+
+// GetMyPayouts returns all payouts credited to a seller
+func (s *Service) GetMyPayouts(sellerID string) ([]*resale.SellerPayoutResponse, error) {
+	payouts, err := s.repo.FindPayoutsBySellerID(sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*resale.SellerPayoutResponse, len(payouts))
+	for i, p := range payouts {
+		responses[i] = p.ToSellerPayoutResponse()
+	}
+	return responses, nil
+}
+
+// ListPayouts lists seller payouts (admin)
+func (s *Service) ListPayouts(scope organization.Scope, req *resale.ListPayoutsRequest) ([]*resale.SellerPayoutResponse, *response.PaginationMeta, error) {
+	page, perPage := pagination(req.Page, req.PerPage)
+
+	payouts, total, err := s.repo.ListPayouts(page, perPage, payoutFilters(scope, req))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*resale.SellerPayoutResponse, len(payouts))
+	for i, p := range payouts {
+		responses[i] = p.ToSellerPayoutResponse()
+	}
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// GetPayoutSummary returns gross sales, platform fees and outstanding seller payouts
+func (s *Service) GetPayoutSummary(scope organization.Scope, req *resale.ListPayoutsRequest) (*resale.PayoutSummary, error) {
+	return s.repo.GetPayoutSummary(payoutFilters(scope, req))
+}
+
+// MarkPayoutPaid records that a seller payout was transferred
+func (s *Service) MarkPayoutPaid(id string, req *resale.MarkPayoutPaidRequest, paidBy string) (*resale.SellerPayoutResponse, error) {
+	payout, err := s.repo.FindPayoutByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayoutNotFound
+		}
+		return nil, err
+	}
+	if payout.Status == resale.PayoutStatusPaid {
+		return nil, ErrPayoutAlreadyPaid
+	}
+
+	now := time.Now()
+	payout.Status = resale.PayoutStatusPaid
+	payout.Reference = req.Reference
+	payout.PaidAt = &now
+	payout.PaidBy = &paidBy
+	if err := s.repo.UpdatePayout(payout); err != nil {
+		return nil, err
+	}
+	return payout.ToSellerPayoutResponse(), nil
+}
+
+// pagination applies the default page size and limits
+func pagination(reqPage, reqPerPage int) (int, int) {
+	page := 1
+	perPage := 20
+	if reqPage > 0 {
+		page = reqPage
+	}
+	if reqPerPage > 0 && reqPerPage <= 100 {
+		perPage = reqPerPage
+	}
+	return page, perPage
+}
+
+// payoutFilters builds repository filters from payout query parameters
+func payoutFilters(scope organization.Scope, req *resale.ListPayoutsRequest) map[string]interface{} {
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	if req.SellerID != "" {
+		filters["seller_id"] = req.SellerID
+	}
+	return filters
+}
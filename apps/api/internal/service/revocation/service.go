@@ -0,0 +1,71 @@
+package revocation
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many updates a slow subscriber may fall behind before updates are dropped
+const subscriberBuffer = 64
+
+// Event is a change in whether a ticket may enter, pushed to connected gate devices
+type Event struct {
+	OrderItemID string
+	QRCode      string
+	Revoked     bool // true when the ticket was put on hold, false when the hold was released
+	Reason      string
+	OccurredAt  time.Time
+}
+
+// Service fans ticket revocation updates out to the gate devices streaming from this instance.
+// Updates are best effort: devices still get the authoritative answer when they scan.
+type Service struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]chan Event
+}
+
+func NewService() *Service {
+	return &Service{
+		subscribers: make(map[int]chan Event),
+	}
+}
+
+// Subscribe registers a listener; call the returned function to unsubscribe
+func (s *Service) Subscribe() (<-chan Event, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	ch := make(chan Event, subscriberBuffer)
+	s.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish sends an update to every subscriber without blocking on slow ones
+func (s *Service) Publish(event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("[Revocation] Subscriber %d is behind, dropped update for ticket %s", id, event.OrderItemID)
+		}
+	}
+}
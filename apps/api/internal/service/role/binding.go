@@ -0,0 +1,177 @@
+package role
+
+import (
+	"errors"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleBindingNotFound       = errors.New("role binding not found")
+	ErrRoleBindingExists         = errors.New("role already bound to the user within the event")
+	ErrRoleBindingUserNotFound   = errors.New("user not found")
+	ErrRoleBindingEventNotFound  = errors.New("event not found")
+	ErrRoleBindingOrganization   = errors.New("user is not staff of the organization hosting the event")
+	ErrSuperAdminRoleNotBindable = errors.New("super admin role cannot be bound to an event")
+)
+
+// ListBindings returns the bindings of a role to users within events, newest first
+func (s *Service) ListBindings(scope organization.Scope, roleID string, req *role.ListRoleBindingsRequest) ([]role.RoleBindingResponse, *PaginationResult, error) {
+	if _, err := s.roleRepo.FindByID(roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrRoleNotFound
+		}
+		return nil, nil, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := req.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	filters := map[string]interface{}{
+		"scope":    scope,
+		"role_id":  roleID,
+		"user_id":  req.UserID,
+		"event_id": req.EventID,
+	}
+	bindings, total, err := s.roleBindingRepo.List(page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]role.RoleBindingResponse, len(bindings))
+	for i, b := range bindings {
+		responses[i] = *b.ToRoleBindingResponse()
+	}
+
+	pagination := &PaginationResult{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      int(total),
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	}
+
+	return responses, pagination, nil
+}
+
+// CreateBinding binds a role to a user within an event
+func (s *Service) CreateBinding(roleID string, req *role.CreateRoleBindingRequest, createdBy string) (*role.RoleBindingResponse, error) {
+	r, err := s.roleRepo.FindByID(roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if r.Code == organization.SuperAdminRole {
+		return nil, ErrSuperAdminRoleNotBindable
+	}
+
+	u, err := s.userRepo.FindByID(req.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleBindingUserNotFound
+		}
+		return nil, err
+	}
+	e, err := s.eventRepo.FindByID(req.EventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleBindingEventNotFound
+		}
+		return nil, err
+	}
+	// Only staff of the organization hosting the event can be bound within it
+	if u.OrganizationID == nil || e.OrganizationID == nil || *u.OrganizationID != *e.OrganizationID {
+		return nil, ErrRoleBindingOrganization
+	}
+
+	_, err = s.roleBindingRepo.FindByUserRoleAndEvent(req.UserID, roleID, req.EventID)
+	if err == nil {
+		return nil, ErrRoleBindingExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	b := &role.RoleBinding{
+		UserID:  req.UserID,
+		RoleID:  roleID,
+		EventID: req.EventID,
+	}
+	if createdBy != "" {
+		b.CreatedBy = &createdBy
+	}
+	if err := s.roleBindingRepo.Create(b); err != nil {
+		return nil, err
+	}
+
+	// Reload
+	created, err := s.roleBindingRepo.FindByID(b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return created.ToRoleBindingResponse(), nil
+}
+
+// DeleteBinding removes a binding of the role
+func (s *Service) DeleteBinding(roleID, bindingID string) error {
+	b, err := s.roleBindingRepo.FindByID(bindingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoleBindingNotFound
+		}
+		return err
+	}
+	if b.RoleID != roleID {
+		return ErrRoleBindingNotFound
+	}
+	return s.roleBindingRepo.Delete(bindingID)
+}
+
+// HasEventPermission reports whether a role bound to the user within the event has the
+// permission. Bindings are read on every call, so removing one takes effect immediately.
+func (s *Service) HasEventPermission(userID, eventID, permissionCode string) (bool, error) {
+	if eventID == "" {
+		return false, nil
+	}
+	return s.hasBoundPermission(userID, eventID, permissionCode)
+}
+
+// HasAnyEventPermission reports whether a role bound to the user within any event has the
+// permission
+func (s *Service) HasAnyEventPermission(userID, permissionCode string) (bool, error) {
+	return s.hasBoundPermission(userID, "", permissionCode)
+}
+
+func (s *Service) hasBoundPermission(userID, eventID, permissionCode string) (bool, error) {
+	roleIDs, err := s.roleBindingRepo.ListRoleIDs(userID, eventID)
+	if err != nil {
+		return false, err
+	}
+	for _, roleID := range roleIDs {
+		granted, err := s.permissionCache.HasPermission(roleID, permissionCode)
+		if err != nil {
+			return false, err
+		}
+		if granted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EventOf returns the ID of the event a context resource belongs to
+func (s *Service) EventOf(resource role.ContextResource, id string) (string, error) {
+	return s.roleBindingRepo.FindEvent(resource, id)
+}
@@ -4,8 +4,12 @@ import (
 	"errors"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
+	eventrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/event"
 	permissionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/permission"
 	rolerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	rolebindingrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role_binding"
+	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user"
+	permissioncacheservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/permission_cache"
 	"gorm.io/gorm"
 )
 
@@ -16,14 +20,22 @@ var (
 )
 
 type Service struct {
-	roleRepo       rolerepo.Repository
-	permissionRepo permissionrepo.Repository
+	roleRepo        rolerepo.Repository
+	permissionRepo  permissionrepo.Repository
+	roleBindingRepo rolebindingrepo.Repository
+	userRepo        userrepo.Repository
+	eventRepo       eventrepo.Repository
+	permissionCache *permissioncacheservice.Service
 }
 
-func NewService(roleRepo rolerepo.Repository, permissionRepo permissionrepo.Repository) *Service {
+func NewService(roleRepo rolerepo.Repository, permissionRepo permissionrepo.Repository, roleBindingRepo rolebindingrepo.Repository, userRepo userrepo.Repository, eventRepo eventrepo.Repository, permissionCache *permissioncacheservice.Service) *Service {
 	return &Service{
-		roleRepo:       roleRepo,
-		permissionRepo: permissionRepo,
+		roleRepo:        roleRepo,
+		permissionRepo:  permissionRepo,
+		roleBindingRepo: roleBindingRepo,
+		userRepo:        userRepo,
+		eventRepo:       eventRepo,
+		permissionCache: permissionCache,
 	}
 }
 
@@ -107,6 +119,7 @@ func (s *Service) GetByIDWithPermissions(id string) (*role.RoleWithPermissionsRe
 		Description:  r.Description,
 		IsAdmin:      r.IsAdmin,
 		CanLoginAdmin: r.CanLoginAdmin,
+		RequireTwoFactor: r.RequireTwoFactor,
 		Permissions:  permissionResponses,
 		CreatedAt:    r.CreatedAt,
 		UpdatedAt:    r.UpdatedAt,
@@ -135,6 +148,11 @@ func (s *Service) Create(req *role.CreateRoleRequest) (*role.RoleResponse, error
 		canLoginAdmin = *req.CanLoginAdmin
 	}
 
+	requireTwoFactor := false
+	if req.RequireTwoFactor != nil {
+		requireTwoFactor = *req.RequireTwoFactor
+	}
+
 	// Create role
 	r := &role.Role{
 		Code:         req.Code,
@@ -142,6 +160,7 @@ func (s *Service) Create(req *role.CreateRoleRequest) (*role.RoleResponse, error
 		Description:  req.Description,
 		IsAdmin:      isAdmin,
 		CanLoginAdmin: canLoginAdmin,
+		RequireTwoFactor: requireTwoFactor,
 	}
 
 	if err := s.roleRepo.Create(r); err != nil {
@@ -181,6 +200,9 @@ func (s *Service) Update(id string, req *role.UpdateRoleRequest) (*role.RoleResp
 	if req.CanLoginAdmin != nil {
 		r.CanLoginAdmin = *req.CanLoginAdmin
 	}
+	if req.RequireTwoFactor != nil {
+		r.RequireTwoFactor = *req.RequireTwoFactor
+	}
 
 	if err := s.roleRepo.Update(r); err != nil {
 		return nil, err
@@ -206,7 +228,16 @@ func (s *Service) Delete(id string) error {
 		return err
 	}
 
-	return s.roleRepo.Delete(id)
+	if err := s.roleRepo.Delete(id); err != nil {
+		return err
+	}
+	// Bindings of a deleted role grant nothing, drop them with it
+	if err := s.roleBindingRepo.DeleteByRole(id); err != nil {
+		return err
+	}
+
+	s.permissionCache.Invalidate(id)
+	return nil
 }
 
 // AssignPermissions assigns permissions to a role
@@ -231,6 +262,9 @@ func (s *Service) AssignPermissions(roleID string, permissionIDs []string) error
 		}
 	}
 
+	// Cached permissions are dropped even when the change fails halfway
+	defer s.permissionCache.Invalidate(roleID)
+
 	// Remove all existing permissions
 	existingPermissions, err := s.roleRepo.GetPermissions(roleID)
 	if err != nil {
@@ -250,7 +284,8 @@ func (s *Service) AssignPermissions(roleID string, permissionIDs []string) error
 		}
 	}
 
-	return nil
+	// Access tokens carrying the previous version are detected as stale
+	return s.roleRepo.IncrementPermissionsVersion(roleID)
 }
 
 // PaginationResult represents pagination result
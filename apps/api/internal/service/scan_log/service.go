@@ -0,0 +1,217 @@
+package scanlog
+
+import (
+	"log"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/checkin"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	scanlog "github.com/gilabs/webapp-ticket-konser/api/internal/domain/scan_log"
+	scanlogrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/scan_log"
+	fraudservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/fraud"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// resultCodeInternalError is stored when a scan failed without a result code
+const resultCodeInternalError = "INTERNAL_ERROR"
+
+type Service struct {
+	repo         scanlogrepo.Repository
+	fraudService *fraudservice.Service
+}
+
+func NewService(repo scanlogrepo.Repository, fraudService *fraudservice.Service) *Service {
+	return &Service{
+		repo:         repo,
+		fraudService: fraudService,
+	}
+}
+
+// Source identifies who submitted a scan and from where
+type Source struct {
+	StaffID   string
+	DeviceID  string
+	IPAddress string
+	UserAgent string
+}
+
+// SourceFromContext reads the scan source of an HTTP request
+func SourceFromContext(c *gin.Context) Source {
+	source := Source{
+		StaffID:   c.GetString("user_id"),
+		DeviceID:  c.GetHeader(scanlog.DeviceIDHeader),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	// Registered devices are identified by their token; the free-form header is the fallback
+	if deviceID := c.GetString("device_id"); deviceID != "" {
+		source.DeviceID = deviceID
+	}
+	return source
+}
+
+// RecordScan appends an entry or exit scan attempt; failures to write the log never fail the scan
+func (s *Service) RecordScan(c *gin.Context, action scanlog.ScanAction, rawCode string, gateID *string, startedAt time.Time, result *checkin.CheckInResultResponse, scanErr error) {
+	s.RecordScanFrom(SourceFromContext(c), action, rawCode, gateID, startedAt, result, scanErr)
+}
+
+// RecordScanFrom appends an entry or exit scan attempt submitted outside the HTTP API
+func (s *Service) RecordScanFrom(source Source, action scanlog.ScanAction, rawCode string, gateID *string, startedAt time.Time, result *checkin.CheckInResultResponse, scanErr error) {
+	entry := newEntry(source, action, rawCode, gateID, startedAt)
+
+	switch {
+	case result != nil:
+		entry.Success = result.Success
+		entry.ResultCode = result.ErrorCode
+		entry.Message = result.Message
+		if result.CheckIn != nil {
+			entry.OrderItemID = &result.CheckIn.OrderItemID
+			entry.ScheduleID = result.CheckIn.ScheduleID
+		}
+		if result.Scan != nil {
+			entry.OrderItemID = &result.Scan.OrderItemID
+			entry.ScheduleID = &result.Scan.ScheduleID
+		}
+	case scanErr != nil:
+		entry.Message = scanErr.Error()
+	}
+	if entry.Success {
+		entry.ResultCode = scanlog.ResultCodeSuccess
+	} else if entry.ResultCode == "" {
+		entry.ResultCode = resultCodeInternalError
+	}
+
+	s.create(entry)
+}
+
+// RecordValidation appends a QR validation attempt
+func (s *Service) RecordValidation(c *gin.Context, rawCode string, startedAt time.Time, result *checkin.ValidateQRCodeResponse, scanErr error) {
+	s.RecordValidationFrom(SourceFromContext(c), rawCode, startedAt, result, scanErr)
+}
+
+// RecordValidationFrom appends a QR validation attempt submitted outside the HTTP API
+func (s *Service) RecordValidationFrom(source Source, rawCode string, startedAt time.Time, result *checkin.ValidateQRCodeResponse, scanErr error) {
+	entry := newEntry(source, scanlog.ScanActionValidate, rawCode, nil, startedAt)
+
+	switch {
+	case result != nil:
+		entry.Success = result.Valid
+		entry.ResultCode = result.ErrorCode
+		entry.Message = result.Message
+		if result.OrderItemID != "" {
+			entry.OrderItemID = &result.OrderItemID
+		}
+		if result.ScheduleID != "" {
+			entry.ScheduleID = &result.ScheduleID
+		}
+	case scanErr != nil:
+		entry.Message = scanErr.Error()
+	}
+	if entry.Success {
+		entry.ResultCode = scanlog.ResultCodeSuccess
+	} else if entry.ResultCode == "" {
+		entry.ResultCode = resultCodeInternalError
+	}
+
+	s.create(entry)
+}
+
+// newEntry builds a scan log entry with the source metadata filled in
+func newEntry(source Source, action scanlog.ScanAction, rawCode string, gateID *string, startedAt time.Time) *scanlog.ScanLog {
+	entry := &scanlog.ScanLog{
+		Action:    action,
+		RawCode:   rawCode,
+		GateID:    gateID,
+		DeviceID:  source.DeviceID,
+		LatencyMs: time.Since(startedAt).Milliseconds(),
+		IPAddress: source.IPAddress,
+		UserAgent: source.UserAgent,
+		ScannedAt: startedAt,
+	}
+	if source.StaffID != "" {
+		entry.StaffID = &source.StaffID
+	}
+	if len(entry.DeviceID) > 100 {
+		entry.DeviceID = entry.DeviceID[:100]
+	}
+	return entry
+}
+
+// create stores the entry and runs the fraud rules over it
+func (s *Service) create(entry *scanlog.ScanLog) {
+	if err := s.repo.Create(entry); err != nil {
+		log.Printf("[ScanLog] Failed to record %s scan attempt (%s): %v", entry.Action, entry.ResultCode, err)
+		return
+	}
+	if s.fraudService != nil {
+		s.fraudService.Evaluate(entry)
+	}
+}
+
+// List lists scan attempts with pagination and filters
+func (s *Service) List(scope organization.Scope, req *scanlog.ListScanLogsRequest) ([]*scanlog.ScanLogResponse, *response.PaginationMeta, error) {
+	page := 1
+	perPage := 20
+
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	logs, total, err := s.repo.List(page, perPage, buildFilters(scope, req))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*scanlog.ScanLogResponse, len(logs))
+	for i, l := range logs {
+		responses[i] = l.ToScanLogResponse()
+	}
+
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// Export exports scan attempts to CSV format
+func (s *Service) Export(scope organization.Scope, req *scanlog.ListScanLogsRequest) ([][]string, error) {
+	return s.repo.Export(buildFilters(scope, req))
+}
+
+func buildFilters(scope organization.Scope, req *scanlog.ListScanLogsRequest) map[string]interface{} {
+	filters := map[string]interface{}{
+		"scope": scope,
+	}
+	if req.Action != "" {
+		filters["action"] = req.Action
+	}
+	if req.GateID != "" {
+		filters["gate_id"] = req.GateID
+	}
+	if req.DeviceID != "" {
+		filters["device_id"] = req.DeviceID
+	}
+	if req.StaffID != "" {
+		filters["staff_id"] = req.StaffID
+	}
+	if req.OrderItemID != "" {
+		filters["order_item_id"] = req.OrderItemID
+	}
+	if req.RawCode != "" {
+		filters["raw_code"] = req.RawCode
+	}
+	if req.ResultCode != "" {
+		filters["result_code"] = req.ResultCode
+	}
+	if req.Success != nil {
+		filters["success"] = *req.Success
+	}
+	if req.StartDate != nil {
+		filters["start_date"] = req.StartDate
+	}
+	if req.EndDate != nil {
+		filters["end_date"] = req.EndDate
+	}
+	return filters
+}
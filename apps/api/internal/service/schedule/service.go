@@ -3,6 +3,7 @@ package schedule
 import (
 	"errors"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/schedule"
 	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/schedule"
 	"gorm.io/gorm"
@@ -142,9 +143,9 @@ func (s *Service) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-// List lists all schedules
-func (s *Service) List() ([]*schedule.ScheduleResponse, error) {
-	schedules, err := s.repo.List()
+// List lists the schedules within the organization scope
+func (s *Service) List(scope organization.Scope) ([]*schedule.ScheduleResponse, error) {
+	schedules, err := s.repo.List(scope)
 	if err != nil {
 		return nil, err
 	}
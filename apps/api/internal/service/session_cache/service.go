@@ -0,0 +1,145 @@
+package sessioncache
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	redisint "github.com/gilabs/webapp-ticket-konser/api/internal/integration/redis"
+	sessionrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/session"
+)
+
+const (
+	redisTimeout = 2 * time.Second
+	// maxEntries bounds the cache; expired entries are dropped once it is reached
+	maxEntries = 100000
+)
+
+// entry is the cached state of one session
+type entry struct {
+	active   bool
+	loadedAt time.Time
+}
+
+// Service caches whether each login session is active, so validating an access token doesn't
+// query the database on every request. Revocations are published over Redis when it is enabled so
+// every replica drops its copy; the TTL bounds how long a replica that missed one accepts tokens
+// of a revoked session.
+type Service struct {
+	sessionRepo sessionrepo.Repository
+	ttl         time.Duration
+
+	mu         sync.RWMutex
+	entries    map[string]*entry
+	generation uint64 // Bumped on every invalidation, so a load racing one is not stored
+}
+
+func NewService(sessionRepo sessionrepo.Repository, ttl time.Duration) *Service {
+	return &Service{
+		sessionRepo: sessionRepo,
+		ttl:         ttl,
+		entries:     make(map[string]*entry),
+	}
+}
+
+// IsSessionActive reports whether a session has not been revoked or expired.
+// It is the jwt.SessionChecker used to reject access tokens of revoked sessions.
+func (s *Service) IsSessionActive(sessionID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	e, ok := s.entries[sessionID]
+	generation := s.generation
+	s.mu.RUnlock()
+	if ok && now.Sub(e.loadedAt) < s.ttl {
+		return e.active, nil
+	}
+
+	active, err := s.sessionRepo.IsActive(sessionID, now)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	if s.generation == generation {
+		if len(s.entries) >= maxEntries {
+			s.pruneLocked(now)
+		}
+		s.entries[sessionID] = &entry{active: active, loadedAt: now}
+	}
+	s.mu.Unlock()
+	return active, nil
+}
+
+// Invalidate drops the cached state of the sessions on every replica
+func (s *Service) Invalidate(sessionIDs ...string) {
+	for _, sessionID := range sessionIDs {
+		s.invalidateLocal(sessionID)
+		s.publish(sessionID)
+	}
+}
+
+// Listen applies the invalidations published by other replicas until ctx is done. It returns
+// immediately when Redis is disabled.
+func (s *Service) Listen(ctx context.Context) {
+	if redisint.Client == nil {
+		return
+	}
+
+	pubsub := redisint.Client.Subscribe(ctx, channel())
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			s.invalidateLocal(msg.Payload)
+		}
+	}
+}
+
+func (s *Service) invalidateLocal(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	delete(s.entries, sessionID)
+}
+
+// pruneLocked drops expired entries, and every entry if that frees no room
+func (s *Service) pruneLocked(now time.Time) {
+	for sessionID, e := range s.entries {
+		if now.Sub(e.loadedAt) >= s.ttl {
+			delete(s.entries, sessionID)
+		}
+	}
+	if len(s.entries) >= maxEntries {
+		s.entries = make(map[string]*entry)
+	}
+}
+
+func (s *Service) publish(sessionID string) {
+	if redisint.Client == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := redisint.Client.Publish(ctx, channel(), sessionID).Err(); err != nil {
+		log.Printf("[SessionCache] Failed to publish invalidation of session %s: %v", sessionID, err)
+	}
+}
+
+func channel() string {
+	prefix := "ticketing_api"
+	if config.AppConfig != nil && config.AppConfig.Redis.Prefix != "" {
+		prefix = config.AppConfig.Redis.Prefix
+	}
+	return redisint.Key(prefix, "session_cache", "invalidate")
+}
@@ -0,0 +1,237 @@
+package signingkey
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/config"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	signingkeyrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/signing_key"
+	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSigningKeysDisabled = errors.New("asymmetric signing keys are disabled (JWT_SIGNING_ALGORITHM=HS256)")
+)
+
+// Service manages the JWT signing keys and keeps the JWT manager's keys in sync with the database
+type Service struct {
+	repo         signingkeyrepo.Repository
+	jwtManager   *jwt.JWTManager
+	auditService *auditservice.Service
+}
+
+func NewService(repo signingkeyrepo.Repository, jwtManager *jwt.JWTManager, auditService *auditservice.Service) *Service {
+	return &Service{
+		repo:         repo,
+		jwtManager:   jwtManager,
+		auditService: auditService,
+	}
+}
+
+// Init creates the first signing key when there is none yet and loads the keys into the JWT manager
+func (s *Service) Init() error {
+	s.jwtManager.SetAcceptLegacyHMAC(config.AppConfig.JWT.AcceptLegacyHS256)
+
+	if algorithm := config.AppConfig.JWT.SigningAlgorithm; algorithm != "HS256" {
+		if _, err := s.repo.FindActive(); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			key, err := newSigningKey(algorithm, time.Now())
+			if err != nil {
+				return err
+			}
+			if _, err := s.repo.CreateIfNone(key); err != nil {
+				return err
+			}
+		}
+	}
+	return s.Load()
+}
+
+// Load installs the active key as signing key and every key still in its grace period as
+// verification key. Keys stay loaded for verification with HS256 signing, so switching back
+// does not invalidate tokens signed with them.
+func (s *Service) Load() error {
+	keys, err := s.repo.ListVerifiable(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var signingKey *jwt.SigningKey
+	verificationKeys := make([]*jwt.VerificationKey, 0, len(keys))
+	for _, key := range keys {
+		publicKey, err := jwt.DecodePublicKey(key.PublicKey)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", key.ID, err)
+		}
+		verificationKeys = append(verificationKeys, &jwt.VerificationKey{
+			ID:        key.ID,
+			Algorithm: key.Algorithm,
+			PublicKey: publicKey,
+		})
+
+		if key.RetiredAt != nil || signingKey != nil || config.AppConfig.JWT.SigningAlgorithm == "HS256" {
+			continue
+		}
+		privateKey, err := openPrivateKey(key.PrivateKeyEncrypted)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", key.ID, err)
+		}
+		signingKey = &jwt.SigningKey{
+			ID:         key.ID,
+			Algorithm:  key.Algorithm,
+			PrivateKey: privateKey,
+		}
+	}
+
+	s.jwtManager.SetKeys(signingKey, verificationKeys)
+	return nil
+}
+
+// Rotate replaces the signing key with a new one. The old key keeps verifying tokens for the grace
+// period; a grace period of 0 invalidates the tokens it signed at once, e.g. after a leak.
+func (s *Service) Rotate(req *auth.RotateSigningKeyRequest) (*auth.SigningKeyResponse, error) {
+	cfg := config.AppConfig.JWT
+	if cfg.SigningAlgorithm == "HS256" {
+		return nil, ErrSigningKeysDisabled
+	}
+
+	algorithm := cfg.SigningAlgorithm
+	if req.Algorithm != "" {
+		algorithm = req.Algorithm
+	}
+	graceHours := cfg.RotationGraceHours
+	if req.GraceHours != nil {
+		graceHours = *req.GraceHours
+	}
+
+	now := time.Now()
+	key, err := newSigningKey(algorithm, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Rotate(key, now, now.Add(time.Duration(graceHours)*time.Hour)); err != nil {
+		return nil, err
+	}
+	if err := s.Load(); err != nil {
+		return nil, err
+	}
+	return key.ToSigningKeyResponse(now), nil
+}
+
+func (s *Service) RotateWithAudit(c *gin.Context, req *auth.RotateSigningKeyRequest) (*auth.SigningKeyResponse, error) {
+	resp, err := s.Rotate(req)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "SIGNING_KEY_ROTATE", "signing_key", resp.ID, nil, resp)
+	}
+	return resp, err
+}
+
+// List lists all signing keys, newest first
+func (s *Service) List() ([]*auth.SigningKeyResponse, error) {
+	keys, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*auth.SigningKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = key.ToSigningKeyResponse(now)
+	}
+	return responses, nil
+}
+
+// JWKS returns the public keys tokens are currently accepted from
+func (s *Service) JWKS() jwt.JWKS {
+	return s.jwtManager.JWKS()
+}
+
+func newSigningKey(algorithm string, now time.Time) (*auth.SigningKey, error) {
+	privateKey, err := jwt.GenerateKey(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	privatePEM, err := jwt.EncodePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealPrivateKey(privatePEM)
+	if err != nil {
+		return nil, err
+	}
+	publicPEM, err := jwt.EncodePublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.SigningKey{
+		Algorithm:           algorithm,
+		PrivateKeyEncrypted: sealed,
+		PublicKey:           publicPEM,
+		ActivatedAt:         now,
+	}, nil
+}
+
+// encryptionKey is the AES-256 key signing keys are encrypted with
+func encryptionKey() []byte {
+	key := config.AppConfig.JWT.KeyEncryptionKey
+	if key == "" {
+		key = "jwt-keys:" + config.AppConfig.JWT.SecretKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// sealPrivateKey encrypts a PEM private key with AES-GCM
+func sealPrivateKey(privatePEM string) (string, error) {
+	block, err := aes.NewCipher(encryptionKey())
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(privatePEM), nil)), nil
+}
+
+// openPrivateKey decrypts and parses a private key sealed by sealPrivateKey
+func openPrivateKey(sealed string) (crypto.Signer, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(encryptionKey())
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("sealed signing key is too short")
+	}
+	privatePEM, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.DecodePrivateKey(string(privatePEM))
+}
@@ -3,22 +3,27 @@ package ticketcategory
 import (
 	"errors"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/ticket_category"
+	schedulerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/schedule"
 	ticketcategoryrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/ticket_category"
 	"gorm.io/gorm"
 )
 
 var (
 	ErrTicketCategoryNotFound = errors.New("ticket category not found")
+	ErrInvalidPassSchedule    = errors.New("pass schedules must belong to the category's event")
 )
 
 type Service struct {
-	repo ticketcategoryrepo.Repository
+	repo         ticketcategoryrepo.Repository
+	scheduleRepo schedulerepo.Repository
 }
 
-func NewService(repo ticketcategoryrepo.Repository) *Service {
+func NewService(repo ticketcategoryrepo.Repository, scheduleRepo schedulerepo.Repository) *Service {
 	return &Service{
-		repo: repo,
+		repo:         repo,
+		scheduleRepo: scheduleRepo,
 	}
 }
 
@@ -56,6 +61,20 @@ func (s *Service) Create(req *ticketcategory.CreateTicketCategoryRequest) (*tick
 		Price:        req.Price,
 		Quota:        req.Quota,
 		LimitPerUser: req.LimitPerUser,
+		ReentryPolicy: req.ReentryPolicy,
+		MaxReentries: req.MaxReentries,
+	}
+	if tc.ReentryPolicy == "" {
+		tc.ReentryPolicy = ticketcategory.ReentryPolicyNone
+	}
+
+	// Multi-day pass: admits once to each listed schedule
+	scheduleIDs, err := s.validatePassSchedules(req.EventID, req.ScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, scheduleID := range scheduleIDs {
+		tc.PassSchedules = append(tc.PassSchedules, ticketcategory.PassSchedule{ScheduleID: scheduleID})
 	}
 
 	if err := s.repo.Create(tc); err != nil {
@@ -94,11 +113,31 @@ func (s *Service) Update(id string, req *ticketcategory.UpdateTicketCategoryRequ
 	if req.LimitPerUser != nil {
 		tc.LimitPerUser = *req.LimitPerUser
 	}
+	if req.ReentryPolicy != nil {
+		tc.ReentryPolicy = *req.ReentryPolicy
+	}
+	if req.MaxReentries != nil {
+		tc.MaxReentries = *req.MaxReentries
+	}
+
+	var scheduleIDs []string
+	if req.ScheduleIDs != nil {
+		scheduleIDs, err = s.validatePassSchedules(tc.EventID, *req.ScheduleIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repo.Update(tc); err != nil {
 		return nil, err
 	}
 
+	if req.ScheduleIDs != nil {
+		if err := s.repo.ReplacePassSchedules(tc.ID, scheduleIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	// Reload
 	updatedCategory, err := s.repo.FindByID(tc.ID)
 	if err != nil {
@@ -108,6 +147,31 @@ func (s *Service) Update(id string, req *ticketcategory.UpdateTicketCategoryRequ
 	return updatedCategory.ToTicketCategoryResponse(), nil
 }
 
+// validatePassSchedules deduplicates pass schedule IDs and checks they belong to the event
+func (s *Service) validatePassSchedules(eventID string, scheduleIDs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+	for _, scheduleID := range scheduleIDs {
+		if seen[scheduleID] {
+			continue
+		}
+		seen[scheduleID] = true
+
+		sched, err := s.scheduleRepo.FindByID(scheduleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrInvalidPassSchedule
+			}
+			return nil, err
+		}
+		if sched.EventID != eventID {
+			return nil, ErrInvalidPassSchedule
+		}
+		result = append(result, scheduleID)
+	}
+	return result, nil
+}
+
 // Delete deletes a ticket category
 func (s *Service) Delete(id string) error {
 	_, err := s.repo.FindByID(id)
@@ -121,9 +185,9 @@ func (s *Service) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-// List lists all ticket categories
-func (s *Service) List() ([]*ticketcategory.TicketCategoryResponse, error) {
-	categories, err := s.repo.List()
+// List lists the ticket categories within the organization scope
+func (s *Service) List(scope organization.Scope) ([]*ticketcategory.TicketCategoryResponse, error) {
+	categories, err := s.repo.List(scope)
 	if err != nil {
 		return nil, err
 	}
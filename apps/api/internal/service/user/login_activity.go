@@ -0,0 +1,111 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/auth"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
+	"github.com/gilabs/webapp-ticket-konser/api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// suspiciousActivityLimit caps each list of the suspicious login activity review
+const suspiciousActivityLimit = 100
+
+// Unlock lifts a user's login lockout and clears their failed login count
+func (s *Service) Unlock(id string) (*user.UserResponse, error) {
+	if _, err := s.userRepo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.userRepo.Unlock(id); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *Service) UnlockWithAudit(c *gin.Context, id string) (*user.UserResponse, error) {
+	oldUser, _ := s.GetByID(id)
+	resp, err := s.Unlock(id)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(c, "USER_UNLOCK", "user", id, oldUser, resp)
+	}
+	return resp, err
+}
+
+// ListLoginHistory lists a user's login attempts, newest first
+func (s *Service) ListLoginHistory(id string, req *auth.ListLoginHistoryRequest) ([]*auth.LoginAttemptResponse, *response.PaginationMeta, error) {
+	if _, err := s.userRepo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrUserNotFound
+		}
+		return nil, nil, err
+	}
+
+	page := 1
+	perPage := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PerPage > 0 && req.PerPage <= 100 {
+		perPage = req.PerPage
+	}
+
+	filters := make(map[string]interface{})
+	if req.Result != "" {
+		filters["success"] = req.Result == "success"
+	}
+
+	attempts, total, err := s.loginAttemptRepo.ListByUser(id, page, perPage, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*auth.LoginAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		responses[i] = attempt.ToLoginAttemptResponse()
+	}
+	return responses, response.NewPaginationMeta(page, perPage, int(total)), nil
+}
+
+// GetSuspiciousLoginActivity lists IP addresses and accounts with many failed logins in the
+// look-back window, and accounts that are currently locked, within the organization scope
+func (s *Service) GetSuspiciousLoginActivity(scope organization.Scope, req *auth.SuspiciousLoginActivityRequest) (*auth.SuspiciousLoginActivityResponse, error) {
+	hours := 24
+	if req.Hours > 0 {
+		hours = req.Hours
+	}
+	minFailures := 5
+	if req.MinFailures > 0 {
+		minFailures = req.MinFailures
+	}
+
+	now := time.Now()
+	since := now.Add(-time.Duration(hours) * time.Hour)
+
+	ips, err := s.loginAttemptRepo.ListSuspiciousIPs(scope, since, minFailures, suspiciousActivityLimit)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.loginAttemptRepo.ListSuspiciousAccounts(scope, since, minFailures, suspiciousActivityLimit, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		if account.LockedUntil != nil && !now.Before(*account.LockedUntil) {
+			account.LockedUntil = nil
+		}
+	}
+
+	return &auth.SuspiciousLoginActivityResponse{
+		Since:    since,
+		IPs:      ips,
+		Accounts: accounts,
+	}, nil
+}
@@ -3,9 +3,14 @@ package user
 import (
 	"errors"
 	"net/url"
+	"time"
 
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/user"
-	"github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
+	loginattemptrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/login_attempt"
+	organizationrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/organization"
+	rolerepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/role"
 	userrepo "github.com/gilabs/webapp-ticket-konser/api/internal/repository/interfaces/user"
 	auditservice "github.com/gilabs/webapp-ticket-konser/api/internal/service/audit"
 	"github.com/gin-gonic/gin"
@@ -14,22 +19,26 @@ import (
 )
 
 type Service struct {
-	userRepo     userrepo.Repository
-	roleRepo     role.Repository
-	auditService *auditservice.Service
+	userRepo         userrepo.Repository
+	loginAttemptRepo loginattemptrepo.Repository
+	roleRepo         rolerepo.Repository
+	organizationRepo organizationrepo.Repository
+	auditService     *auditservice.Service
 }
 
-func NewService(userRepo userrepo.Repository, roleRepo role.Repository, auditService *auditservice.Service) *Service {
+func NewService(userRepo userrepo.Repository, loginAttemptRepo loginattemptrepo.Repository, roleRepo rolerepo.Repository, organizationRepo organizationrepo.Repository, auditService *auditservice.Service) *Service {
 	return &Service{
-		userRepo:     userRepo,
-		roleRepo:     roleRepo,
-		auditService: auditService,
+		userRepo:         userRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		roleRepo:         roleRepo,
+		organizationRepo: organizationRepo,
+		auditService:     auditService,
 	}
 }
 
-// List returns a list of users with pagination
-func (s *Service) List(req *user.ListUsersRequest) ([]user.UserResponse, *PaginationResult, error) {
-	users, total, err := s.userRepo.List(req)
+// List returns a list of the users within the organization scope with pagination
+func (s *Service) List(scope organization.Scope, req *user.ListUsersRequest) ([]user.UserResponse, *PaginationResult, error) {
+	users, total, err := s.userRepo.List(scope, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,10 +82,11 @@ func (s *Service) GetByID(id string) (*user.UserResponse, error) {
 	return u.ToUserResponse(), nil
 }
 
-// Create creates a new user
-func (s *Service) Create(req *user.CreateUserRequest) (*user.UserResponse, error) {
+// Create creates a new user. Staff join the caller's organization, or the one a super admin
+// chooses.
+func (s *Service) Create(scope organization.Scope, req *user.CreateUserRequest) (*user.UserResponse, error) {
 	// Check if role exists
-	_, err := s.roleRepo.FindByID(req.RoleID)
+	r, err := s.roleRepo.FindByID(req.RoleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrRoleNotFound
@@ -84,6 +94,18 @@ func (s *Service) Create(req *user.CreateUserRequest) (*user.UserResponse, error
 		return nil, err
 	}
 
+	organizationID := scope.OrganizationID
+	if req.OrganizationID != "" && req.OrganizationID != organizationID {
+		if !scope.SuperAdmin {
+			return nil, ErrOrganizationForbidden
+		}
+		organizationID = req.OrganizationID
+	}
+	organizationIDPtr, err := s.userOrganization(scope, r, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if email already exists
 	_, err = s.userRepo.FindByEmail(req.Email)
 	if err == nil {
@@ -108,14 +130,23 @@ func (s *Service) Create(req *user.CreateUserRequest) (*user.UserResponse, error
 	// Generate avatar URL using dicebear lorelei
 	avatarURL := "https://api.dicebear.com/7.x/lorelei/svg?seed=" + url.QueryEscape(req.Email)
 
+	// Accounts provisioned by an admin do not go through email verification
+	verifiedAt := time.Now()
+
 	// Create user
 	u := &user.User{
-		Email:     req.Email,
-		Password:  string(hashedPassword),
-		Name:      req.Name,
-		AvatarURL: avatarURL,
-		RoleID:    req.RoleID,
-		Status:    status,
+		Email:           req.Email,
+		Password:        string(hashedPassword),
+		Name:            req.Name,
+		AvatarURL:       avatarURL,
+		RoleID:          req.RoleID,
+		OrganizationID:  organizationIDPtr,
+		Status:          status,
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	if err := s.userRepo.Create(u); err != nil {
+		return nil, err
 	}
 
 	// Reload with role
@@ -127,16 +158,16 @@ func (s *Service) Create(req *user.CreateUserRequest) (*user.UserResponse, error
 	return createdUser.ToUserResponse(), nil
 }
 
-func (s *Service) CreateWithAudit(c *gin.Context, req *user.CreateUserRequest) (*user.UserResponse, error) {
-	resp, err := s.Create(req)
+func (s *Service) CreateWithAudit(c *gin.Context, scope organization.Scope, req *user.CreateUserRequest) (*user.UserResponse, error) {
+	resp, err := s.Create(scope, req)
 	if err == nil && s.auditService != nil {
 		s.auditService.Log(c, "USER_CREATE", "user", resp.ID, nil, resp)
 	}
 	return resp, err
 }
 
-// Update updates a user
-func (s *Service) Update(id string, req *user.UpdateUserRequest) (*user.UserResponse, error) {
+// Update updates a user; only super admins move staff to another organization
+func (s *Service) Update(scope organization.Scope, id string, req *user.UpdateUserRequest) (*user.UserResponse, error) {
 	// Find user
 	u, err := s.userRepo.FindByID(id)
 	if err != nil {
@@ -164,15 +195,28 @@ func (s *Service) Update(id string, req *user.UpdateUserRequest) (*user.UserResp
 	}
 
 	if req.RoleID != "" {
+		u.RoleID = req.RoleID
+	}
+
+	organizationID := u.OrganizationIDValue()
+	if req.OrganizationID != nil && *req.OrganizationID != organizationID {
+		if !scope.SuperAdmin {
+			return nil, ErrOrganizationForbidden
+		}
+		organizationID = *req.OrganizationID
+	}
+	if req.RoleID != "" || req.OrganizationID != nil {
 		// Check if role exists
-		_, err := s.roleRepo.FindByID(req.RoleID)
+		r, err := s.roleRepo.FindByID(u.RoleID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, ErrRoleNotFound
 			}
 			return nil, err
 		}
-		u.RoleID = req.RoleID
+		if u.OrganizationID, err = s.userOrganization(scope, r, organizationID); err != nil {
+			return nil, err
+		}
 	}
 
 	if req.Status != "" {
@@ -192,9 +236,9 @@ func (s *Service) Update(id string, req *user.UpdateUserRequest) (*user.UserResp
 	return updatedUser.ToUserResponse(), nil
 }
 
-func (s *Service) UpdateWithAudit(c *gin.Context, id string, req *user.UpdateUserRequest) (*user.UserResponse, error) {
+func (s *Service) UpdateWithAudit(c *gin.Context, scope organization.Scope, id string, req *user.UpdateUserRequest) (*user.UserResponse, error) {
 	oldUser, _ := s.GetByID(id)
-	resp, err := s.Update(id, req)
+	resp, err := s.Update(scope, id, req)
 	if err == nil && s.auditService != nil {
 		s.auditService.Log(c, "USER_UPDATE", "user", id, oldUser, resp)
 	}
@@ -232,8 +276,42 @@ type PaginationResult struct {
 	TotalPages int `json:"total_pages"`
 }
 
+// userOrganization returns the organization a user with the role belongs to: none for super
+// admins and buyers, and an active organization for staff. Only super admins assign the super
+// admin role.
+func (s *Service) userOrganization(scope organization.Scope, r *role.Role, organizationID string) (*string, error) {
+	if r.Code == organization.SuperAdminRole {
+		if !scope.SuperAdmin {
+			return nil, ErrSuperAdminRoleForbidden
+		}
+		return nil, nil
+	}
+	if !r.CanLoginAdmin {
+		return nil, nil
+	}
+	if organizationID == "" {
+		return nil, organization.ErrOrganizationRequired
+	}
+
+	o, err := s.organizationRepo.FindByID(organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	if o.Status != organization.OrganizationStatusActive {
+		return nil, ErrOrganizationInactive
+	}
+	return &o.ID, nil
+}
+
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("user already exists")
-	ErrRoleNotFound      = errors.New("role not found")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrUserAlreadyExists       = errors.New("user already exists")
+	ErrRoleNotFound            = errors.New("role not found")
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrOrganizationInactive    = errors.New("organization is inactive")
+	ErrOrganizationForbidden   = errors.New("only super admins choose the organization of a user")
+	ErrSuperAdminRoleForbidden = errors.New("only super admins assign the super admin role")
 )
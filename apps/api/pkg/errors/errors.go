@@ -79,6 +79,14 @@ var ErrorCodeMap = map[string]ErrorInfo{
 		HTTPStatus: http.StatusUnauthorized,
 		Message:    "Account is disabled",
 	},
+	"ACCOUNT_LOCKED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Account is temporarily locked after too many failed logins",
+	},
+	"LOGIN_THROTTLED": {
+		HTTPStatus: http.StatusTooManyRequests,
+		Message:    "Too many failed logins. Please try again later",
+	},
 	"USER_NOT_FOUND": {
 		HTTPStatus: http.StatusNotFound,
 		Message:    "User not found",
@@ -87,6 +95,138 @@ var ErrorCodeMap = map[string]ErrorInfo{
 		HTTPStatus: http.StatusUnauthorized,
 		Message:    "Invalid refresh token",
 	},
+	"REFRESH_TOKEN_EXPIRED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Refresh token has expired",
+	},
+	"REFRESH_TOKEN_REUSED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Refresh token was already used; the session has been revoked",
+	},
+	"SESSION_REVOKED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Session has been revoked",
+	},
+	"TOKEN_PERMISSIONS_STALE": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Role permissions changed since the token was issued; please refresh the token",
+	},
+	"TWO_FACTOR_CHALLENGE_INVALID": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Two-factor challenge is invalid or expired; please log in again",
+	},
+	"TWO_FACTOR_INVALID_CODE": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Invalid two-factor code",
+	},
+	"TWO_FACTOR_ALREADY_ENABLED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Two-factor authentication is already enabled",
+	},
+	"TWO_FACTOR_NOT_ENABLED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Two-factor authentication is not enabled",
+	},
+	"TWO_FACTOR_NOT_SET_UP": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Two-factor authentication has not been set up",
+	},
+	"TWO_FACTOR_REQUIRED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Two-factor authentication is required for this role",
+	},
+	"SIGNING_KEYS_DISABLED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Asymmetric signing keys are disabled (JWT_SIGNING_ALGORITHM=HS256)",
+	},
+	"OIDC_PROVIDER_NOT_FOUND": {
+		HTTPStatus: http.StatusNotFound,
+		Message:    "Login provider is not configured",
+	},
+	"OIDC_PROVIDER_UNAVAILABLE": {
+		HTTPStatus: http.StatusBadGateway,
+		Message:    "Login provider could not be reached. Please try again later",
+	},
+	"OIDC_STATE_INVALID": {
+		HTTPStatus: http.StatusBadRequest,
+		Message:    "Login attempt is invalid or expired; please start again",
+	},
+	"OIDC_LOGIN_FAILED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Login with the provider failed",
+	},
+	"OIDC_EMAIL_NOT_VERIFIED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "The provider has not verified your email address",
+	},
+	"OIDC_ROLE_NOT_ALLOWED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Social login is only available for buyer accounts",
+	},
+	"API_KEY_INVALID": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Invalid API key",
+	},
+	"API_KEY_EXPIRED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "API key has expired",
+	},
+	"API_KEY_REVOKED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "API key has been revoked",
+	},
+	"API_KEY_NOT_ALLOWED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "This endpoint cannot be used with an API key",
+	},
+	"API_KEY_ALREADY_REVOKED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "API key is already revoked",
+	},
+	"API_KEY_PERMISSION_NOT_GRANTED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "API keys can only be granted permissions both the owner and the creator have",
+	},
+	"API_KEY_OWNER_INACTIVE": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "API key owner account is inactive",
+	},
+	"API_KEY_EXPIRY_INVALID": {
+		HTTPStatus: http.StatusBadRequest,
+		Message:    "API key expiry must be in the future and within the maximum validity",
+	},
+	"ORGANIZATION_REQUIRED": {
+		HTTPStatus: http.StatusBadRequest,
+		Message:    "An organization is required for this request",
+	},
+	"ORGANIZATION_INACTIVE": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Organization is inactive",
+	},
+	"ORGANIZATION_CODE_EXISTS": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Organization code already exists",
+	},
+	"ROLE_BINDING_EXISTS": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Role is already bound to the user within the event",
+	},
+	"ROLE_BINDING_ORGANIZATION_MISMATCH": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "User is not staff of the organization hosting the event",
+	},
+	"USER_TOKEN_INVALID": {
+		HTTPStatus: http.StatusBadRequest,
+		Message:    "Link is invalid, expired or has already been used",
+	},
+	"EMAIL_NOT_VERIFIED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Email address has not been verified",
+	},
+	"EMAIL_ALREADY_VERIFIED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Email address is already verified",
+	},
 	"FORBIDDEN": {
 		HTTPStatus: http.StatusForbidden,
 		Message:    "You do not have permission to access this resource",
@@ -129,6 +269,98 @@ var ErrorCodeMap = map[string]ErrorInfo{
 		HTTPStatus: http.StatusUnprocessableEntity,
 		Message:    "Insufficient remaining seats",
 	},
+	"INVALID_PASS_SCHEDULE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Schedule is not part of this pass",
+	},
+	"QUOTA_ALLOCATION_NOT_FOUND": {
+		HTTPStatus: http.StatusNotFound,
+		Message:    "Quota allocation not found",
+	},
+	"QUOTA_ALLOCATION_FORBIDDEN": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "You are not authorized to use this quota allocation",
+	},
+	"QUOTA_ALLOCATION_NOT_ACTIVE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Quota allocation has been released or has expired",
+	},
+	"INSUFFICIENT_ALLOCATION": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Insufficient seats remaining in quota allocation",
+	},
+	"PRESALE_CODE_REQUIRED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "A presale access code is required for this ticket category",
+	},
+	"PRESALE_CODE_INVALID": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Presale access code is invalid for this ticket category",
+	},
+	"PRESALE_CODE_FORBIDDEN": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Presale access code is bound to another user",
+	},
+	"PRESALE_CODE_EXHAUSTED": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Presale access code has no uses left",
+	},
+	"BALLOT_ONLY": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "This ticket category is sold by ballot",
+	},
+	"BALLOT_CLAIM_INVALID": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ballot claim is invalid for this order",
+	},
+	"BALLOT_CLAIM_EXPIRED": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ballot claim has expired",
+	},
+	"BALLOT_ENTRY_CLOSED": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ballot is not accepting entries",
+	},
+	"BALLOT_NOT_OPEN": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Ballot is not open",
+	},
+	"TICKET_NOT_UPGRADABLE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ticket cannot be upgraded in its current status",
+	},
+	"UPGRADE_INVALID_TARGET": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Target category is not a valid upgrade for this ticket",
+	},
+	"UPGRADE_PENDING": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Ticket already has an upgrade awaiting payment",
+	},
+	"RESALE_LISTING_NOT_AVAILABLE": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Resale listing is no longer available",
+	},
+	"RESALE_PRICE_ABOVE_CAP": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Listing price exceeds the resale price cap",
+	},
+	"RESALE_OWN_LISTING": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Cannot buy your own resale listing",
+	},
+	"TICKET_NOT_RESELLABLE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ticket cannot be listed for resale in its current status",
+	},
+	"TICKET_ALREADY_LISTED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Ticket is already listed for resale",
+	},
+	"PAYOUT_ALREADY_PAID": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Seller payout is already paid",
+	},
 	"PAYMENT_ALREADY_PROCESSED": {
 		HTTPStatus: http.StatusConflict,
 		Message:    "Payment has already been processed",
@@ -183,6 +415,90 @@ var ErrorCodeMap = map[string]ErrorInfo{
 		HTTPStatus: http.StatusForbidden,
 		Message:    "Staff is not assigned to this gate",
 	},
+	"GATE_STAFF_OFF_SHIFT": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Staff is not on shift at this gate",
+	},
+	"GATE_SHIFT_OVERLAP": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Staff already has a shift in this period",
+	},
+	"GATE_SHIFT_NOT_ACTIVE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Gate shift is not active",
+	},
+	"GATE_SHIFT_STARTED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Gate shift has already started",
+	},
+	"GATE_SHIFT_ALREADY_HANDED_OVER": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Gate shift already handed over",
+	},
+	"GATE_SHIFT_INVALID_HANDOVER": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Gate shift cannot be handed over to the same staff member",
+	},
+	"GATE_SHIFT_INVALID_STAFF": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Shift staff must be an active user of the gate's organization",
+	},
+	"GATE_SHIFT_INVALID_SCHEDULE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Shift schedule must belong to an event of the gate's organization",
+	},
+	"ALREADY_INSIDE": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Ticket is already inside",
+	},
+	"REENTRY_NOT_ALLOWED": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Re-entry not allowed for this ticket",
+	},
+	"NOT_INSIDE": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Ticket is not inside",
+	},
+	"TICKET_BLOCKED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Ticket is blocked pending fraud review",
+	},
+	"FRAUD_ALERT_ALREADY_REVIEWED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Fraud alert already reviewed",
+	},
+	"FRAUD_ALERT_NO_TICKET": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Fraud alert is not linked to a ticket",
+	},
+	"DEVICE_NOT_REGISTERED": {
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Scanner device is not registered",
+	},
+	"DEVICE_REVOKED": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Scanner device has been revoked",
+	},
+	"DEVICE_GATE_MISMATCH": {
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Scanner device is not enrolled at this gate",
+	},
+	"CHECK_IN_ALREADY_VOIDED": {
+		HTTPStatus: http.StatusConflict,
+		Message:    "Check-in already voided",
+	},
+	"WRONG_SCHEDULE": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Ticket is not valid for this schedule",
+	},
+	"TOO_EARLY": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Check-in is not open yet",
+	},
+	"SCHEDULE_ENDED": {
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Schedule has already ended",
+	},
 
 	// System Errors
 	"INTERNAL_SERVER_ERROR": {
@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,20 +12,58 @@ import (
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("token has expired")
+	ErrRevokedToken = errors.New("token session has been revoked")
+	ErrStaleToken   = errors.New("token permissions are outdated")
 )
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
-	RoleID string `json:"role_id"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	RoleID    string `json:"role_id"`
+	SessionID string `json:"sid,omitempty"` // Login session the token was issued for
+	// Organization of staff users; empty for buyers and super admins
+	OrganizationID string `json:"org,omitempty"`
+	// Permissions version of the role when the token was issued, see SetPermissionsVersionSource
+	PermissionsVersion int64 `json:"pv,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// RefreshClaims are the claims of a refresh token. The token ID (jti) identifies the current
+// token of the session; it changes on every rotation.
+type RefreshClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// SessionChecker reports whether a login session is still active, so access tokens of
+// revoked sessions are rejected before they expire
+type SessionChecker interface {
+	IsSessionActive(sessionID string) (bool, error)
+}
+
+// PermissionsVersionSource provides the permissions version of a role, which changes whenever
+// the role's permissions do
+type PermissionsVersionSource interface {
+	PermissionsVersion(roleID string) (int64, error)
+}
+
+// JWTManager issues and validates tokens. Without a signing key it signs with the HMAC secret;
+// once SetKeys installs one, tokens are signed with it and carry its kid, and are verified against
+// the matching verification key. Tokens without a kid are then only accepted while legacy HMAC
+// verification is on.
 type JWTManager struct {
 	secretKey       string
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	sessionChecker  SessionChecker
+	versionSource   PermissionsVersionSource
+
+	mu               sync.RWMutex
+	signingKey       *SigningKey
+	verificationKeys []*VerificationKey // In the order given to SetKeys, for the JWKS
+	keysByID         map[string]*VerificationKey
+	acceptLegacyHMAC bool
 }
 
 // AccessTokenTTL returns the access token TTL
@@ -39,19 +78,125 @@ func (m *JWTManager) RefreshTokenTTL() time.Duration {
 
 func NewJWTManager(secretKey string, accessTokenTTL, refreshTokenTTL time.Duration) *JWTManager {
 	return &JWTManager{
-		secretKey:       secretKey,
-		accessTokenTTL:  accessTokenTTL,
-		refreshTokenTTL: refreshTokenTTL,
+		secretKey:        secretKey,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+		acceptLegacyHMAC: true,
+	}
+}
+
+// SetKeys replaces the signing key and the verification keys; the signing key's public key should
+// be among the verification keys. A nil signing key falls back to HMAC signing.
+func (m *JWTManager) SetKeys(signingKey *SigningKey, verificationKeys []*VerificationKey) {
+	keysByID := make(map[string]*VerificationKey, len(verificationKeys))
+	for _, key := range verificationKeys {
+		keysByID[key.ID] = key
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signingKey = signingKey
+	m.verificationKeys = verificationKeys
+	m.keysByID = keysByID
+}
+
+// SetAcceptLegacyHMAC sets whether HMAC tokens without a kid are still accepted once a signing key
+// is installed, so tokens issued before the switch stay valid until they expire
+func (m *JWTManager) SetAcceptLegacyHMAC(accept bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.acceptLegacyHMAC = accept
+}
+
+// JWKS returns the verification keys as a JSON Web Key Set
+func (m *JWTManager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(m.verificationKeys))}
+	for _, key := range m.verificationKeys {
+		jwk, err := key.ToJWK()
+		if err != nil {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// sign signs the claims with the signing key, or with the HMAC secret when there is none
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	m.mu.RLock()
+	signingKey := m.signingKey
+	m.mu.RUnlock()
+
+	if signingKey == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(m.secretKey))
+	}
+
+	method := jwt.GetSigningMethod(signingKey.Algorithm)
+	if method == nil {
+		return "", ErrUnsupportedAlgorithm
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = signingKey.ID
+	return token.SignedString(signingKey.PrivateKey)
+}
+
+// keyFunc picks the key to verify a token with from its kid and algorithm
+func (m *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		if m.signingKey != nil && !m.acceptLegacyHMAC {
+			return nil, ErrInvalidToken
+		}
+		return []byte(m.secretKey), nil
+	}
+
+	key, ok := m.keysByID[kid]
+	if !ok || token.Method.Alg() != key.Algorithm || !matchesAlgorithm(key.PublicKey, key.Algorithm) {
+		return nil, ErrInvalidToken
 	}
+	return key.PublicKey, nil
+}
+
+// SetSessionChecker makes ValidateToken reject access tokens whose session is no longer active
+func (m *JWTManager) SetSessionChecker(checker SessionChecker) {
+	m.sessionChecker = checker
+}
+
+// SetPermissionsVersionSource makes access tokens carry the permissions version of their role, and
+// ValidateToken reject tokens issued before the role's permissions last changed
+func (m *JWTManager) SetPermissionsVersionSource(source PermissionsVersionSource) {
+	m.versionSource = source
 }
 
 // GenerateAccessToken generates a new access token
-func (m *JWTManager) GenerateAccessToken(userID, email, role, roleID string) (string, error) {
+func (m *JWTManager) GenerateAccessToken(userID, email, role, roleID, organizationID, sessionID string) (string, error) {
+	var permissionsVersion int64
+	if m.versionSource != nil && roleID != "" {
+		version, err := m.versionSource.PermissionsVersion(roleID)
+		if err != nil {
+			return "", err
+		}
+		permissionsVersion = version
+	}
+
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RoleID: roleID,
+		UserID:             userID,
+		Email:              email,
+		Role:               role,
+		RoleID:             roleID,
+		OrganizationID:     organizationID,
+		SessionID:          sessionID,
+		PermissionsVersion: permissionsVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -60,32 +205,28 @@ func (m *JWTManager) GenerateAccessToken(userID, email, role, roleID string) (st
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	return m.sign(claims)
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (m *JWTManager) GenerateRefreshToken(userID string) (string, error) {
-	claims := &jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshTokenTTL)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		NotBefore: jwt.NewNumericDate(time.Now()),
-		ID:        uuid.New().String(),
-		Subject:   userID,
+// GenerateRefreshToken generates a new refresh token for a session; tokenID becomes the jti
+func (m *JWTManager) GenerateRefreshToken(userID, sessionID, tokenID string) (string, error) {
+	claims := &RefreshClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			Subject:   userID,
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	return m.sign(claims)
 }
 
 // ValidateToken validates and parses a token
 func (m *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(m.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -99,29 +240,45 @@ func (m *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	return claims, nil
-}
+	// Tokens issued before sessions existed carry no session ID and stay valid until they expire
+	if m.sessionChecker != nil && claims.SessionID != "" {
+		active, err := m.sessionChecker.IsSessionActive(claims.SessionID)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		if !active {
+			return nil, ErrRevokedToken
+		}
+	}
 
-// ValidateRefreshToken validates a refresh token
-func (m *JWTManager) ValidateRefreshToken(tokenString string) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+	if m.versionSource != nil && claims.RoleID != "" {
+		version, err := m.versionSource.PermissionsVersion(claims.RoleID)
+		if err != nil {
 			return nil, ErrInvalidToken
 		}
-		return []byte(m.secretKey), nil
-	})
+		if claims.PermissionsVersion < version {
+			return nil, ErrStaleToken
+		}
+	}
+
+	return claims, nil
+}
+
+// ValidateRefreshToken validates a refresh token and returns its claims
+func (m *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, m.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return "", ErrExpiredToken
+			return nil, ErrExpiredToken
 		}
-		return "", ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
-	if !ok || !token.Valid {
-		return "", ErrInvalidToken
+	claims, ok := token.Claims.(*RefreshClaims)
+	if !ok || !token.Valid || claims.SessionID == "" || claims.ID == "" {
+		return nil, ErrInvalidToken
 	}
 
-	return claims.Subject, nil
+	return claims, nil
 }
@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+)
+
+// Asymmetric signing algorithms
+const (
+	AlgorithmEdDSA = "EdDSA" // Ed25519
+	AlgorithmRS256 = "RS256" // RSA 2048 with SHA-256
+)
+
+var ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+// SigningKey is the private key new tokens are signed with; its ID is the kid header
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// VerificationKey is a public key tokens are accepted from
+type VerificationKey struct {
+	ID        string
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"` // OKP
+	X         string `json:"x,omitempty"`   // OKP
+	N         string `json:"n,omitempty"`   // RSA
+	E         string `json:"e,omitempty"`   // RSA
+}
+
+// JWKS is a JSON Web Key Set, as served at /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GenerateKey generates a new private key for the algorithm
+func GenerateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case AlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	case AlgorithmRS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// EncodePrivateKey encodes a private key as PKCS#8 PEM
+func EncodePrivateKey(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// DecodePrivateKey decodes a PKCS#8 PEM private key
+func DecodePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid private key PEM")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return signer, nil
+}
+
+// EncodePublicKey encodes a public key as PKIX PEM
+func EncodePublicKey(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// DecodePublicKey decodes a PKIX PEM public key
+func DecodePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("invalid public key PEM")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// ToJWK converts the verification key to a JWK
+func (k *VerificationKey) ToJWK() (JWK, error) {
+	jwk := JWK{KeyID: k.ID, Algorithm: k.Algorithm, Use: "sig"}
+	switch pub := k.PublicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	default:
+		return JWK{}, ErrUnsupportedAlgorithm
+	}
+	return jwk, nil
+}
+
+// matchesAlgorithm reports whether a public key can verify tokens of the algorithm
+func matchesAlgorithm(key crypto.PublicKey, algorithm string) bool {
+	switch key.(type) {
+	case ed25519.PublicKey:
+		return algorithm == AlgorithmEdDSA
+	case *rsa.PublicKey:
+		return algorithm == AlgorithmRS256
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"log"
+	"time"
 
 	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
 	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/role"
@@ -15,7 +16,14 @@ import (
 // This function uses upsert logic: creates user if not exists, skips if exists
 func Seed() error {
 	// Get roles
-	var adminRole, staffTicketRole, guestRole role.Role
+	var superAdminRole, adminRole, staffTicketRole, guestRole role.Role
+	if err := database.DB.Where("code = ?", "super_admin").First(&superAdminRole).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("[Auth Seeder] Error: Super admin role not found. Please seed roles first.")
+			return err
+		}
+		return err
+	}
 	if err := database.DB.Where("code = ?", "admin").First(&adminRole).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Println("[Auth Seeder] Error: Admin role not found. Please seed roles first.")
@@ -44,30 +52,45 @@ func Seed() error {
 		return err
 	}
 
+	// Seeded accounts are treated as verified so they can order right away
+	verifiedAt := time.Now()
+
 	users := []user.User{
 		{
-			Email:     "admin@example.com",
-			Password:  string(hashedPassword),
-			Name:      "Admin User",
-			AvatarURL: "https://api.dicebear.com/7.x/lorelei/svg?seed=admin@example.com",
-			RoleID:    adminRole.ID,
-			Status:    "active",
+			Email:           "superadmin@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Super Admin User",
+			AvatarURL:       "https://api.dicebear.com/7.x/lorelei/svg?seed=superadmin@example.com",
+			RoleID:          superAdminRole.ID,
+			Status:          "active",
+			EmailVerifiedAt: &verifiedAt,
+		},
+		{
+			Email:           "admin@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Admin User",
+			AvatarURL:       "https://api.dicebear.com/7.x/lorelei/svg?seed=admin@example.com",
+			RoleID:          adminRole.ID,
+			Status:          "active",
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:     "staff@example.com",
-			Password:  string(hashedPassword),
-			Name:      "Staff Ticket User",
-			AvatarURL: "https://api.dicebear.com/7.x/lorelei/svg?seed=staff@example.com",
-			RoleID:    staffTicketRole.ID,
-			Status:    "active",
+			Email:           "staff@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Staff Ticket User",
+			AvatarURL:       "https://api.dicebear.com/7.x/lorelei/svg?seed=staff@example.com",
+			RoleID:          staffTicketRole.ID,
+			Status:          "active",
+			EmailVerifiedAt: &verifiedAt,
 		},
 		{
-			Email:     "guest@example.com",
-			Password:  string(hashedPassword),
-			Name:      "Guest User",
-			AvatarURL: "https://api.dicebear.com/7.x/lorelei/svg?seed=guest@example.com",
-			RoleID:    guestRole.ID,
-			Status:    "active",
+			Email:           "guest@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Guest User",
+			AvatarURL:       "https://api.dicebear.com/7.x/lorelei/svg?seed=guest@example.com",
+			RoleID:          guestRole.ID,
+			Status:          "active",
+			EmailVerifiedAt: &verifiedAt,
 		},
 	}
 
@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gilabs/webapp-ticket-konser/api/internal/database"
+	"github.com/gilabs/webapp-ticket-konser/api/internal/domain/organization"
+	"gorm.io/gorm"
+)
+
+// Seed seeds the default organization
+// This function uses upsert logic: creates the organization if not exists, skips if exists
+func Seed() error {
+	var existing organization.Organization
+	err := database.DB.Where("code = ?", organization.DefaultCode).First(&existing).Error
+	if err == nil {
+		log.Printf("[Organization Seeder] Organization %s already exists, skipping...", organization.DefaultCode)
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	o := organization.Organization{
+		Code:        organization.DefaultCode,
+		Name:        "Default Organization",
+		Description: "Organization owning the events, gates and staff created before multi-tenancy",
+		Status:      organization.OrganizationStatusActive,
+	}
+	if err := database.DB.Create(&o).Error; err != nil {
+		return err
+	}
+	log.Printf("[Organization Seeder] Created organization: %s (code: %s)", o.Name, o.Code)
+	return nil
+}
+
+// AssignUnowned assigns events, gates and staff without an organization to the default organization.
+// Buyers (roles without admin access) and super admins are not bound to an organization.
+func AssignUnowned() error {
+	var defaultOrganization organization.Organization
+	if err := database.DB.Where("code = ?", organization.DefaultCode).First(&defaultOrganization).Error; err != nil {
+		log.Printf("[Organization Seeder] Default organization not found: %v", err)
+		return err
+	}
+
+	statements := []struct {
+		table string
+		sql   string
+	}{
+		{"events", "UPDATE events SET organization_id = ? WHERE organization_id IS NULL"},
+		{"gates", "UPDATE gates SET organization_id = ? WHERE organization_id IS NULL"},
+		{"users", `UPDATE users SET organization_id = ? WHERE organization_id IS NULL
+			AND role_id IN (SELECT id FROM roles WHERE can_login_admin = true AND code <> '` + organization.SuperAdminRole + `')`},
+	}
+	for _, stmt := range statements {
+		result := database.DB.Exec(stmt.sql, defaultOrganization.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("[Organization Seeder] Assigned %d %s to organization %s", result.RowsAffected, stmt.table, defaultOrganization.Code)
+		}
+	}
+	return nil
+}
@@ -65,6 +65,7 @@ func Seed() error {
 		{Code: "role.update", Name: "Update Role", Resource: "role", Action: "update"},
 		{Code: "role.delete", Name: "Delete Role", Resource: "role", Action: "delete"},
 		{Code: "role.assign_permissions", Name: "Assign Permissions to Role", Resource: "role", Action: "assign_permissions"},
+		{Code: "role.bind", Name: "Bind Role to Users within Events", Resource: "role", Action: "bind"},
 
 		// Permission permissions
 		{Code: "permission.read", Name: "Read Permission", Resource: "permission", Action: "read"},
@@ -75,6 +76,15 @@ func Seed() error {
 		// Check-in permissions
 		{Code: "checkin.read", Name: "Read Check-in", Resource: "checkin", Action: "read"},
 		{Code: "checkin.create", Name: "Create Check-in", Resource: "checkin", Action: "create"},
+		{Code: "checkin.void", Name: "Void Check-in", Resource: "checkin", Action: "void"},
+
+		// Scan log permissions
+		{Code: "scan_log.read", Name: "Read Scan Log", Resource: "scan_log", Action: "read"},
+		{Code: "scan_log.export", Name: "Export Scan Log", Resource: "scan_log", Action: "export"},
+
+		// Fraud alert permissions
+		{Code: "fraud.read", Name: "Read Fraud Alert", Resource: "fraud", Action: "read"},
+		{Code: "fraud.review", Name: "Review Fraud Alert", Resource: "fraud", Action: "review"},
 
 		// Attendee permissions
 		{Code: "attendee.read", Name: "Read Attendee", Resource: "attendee", Action: "read"},
@@ -92,12 +102,47 @@ func Seed() error {
 		{Code: "merchandise.update", Name: "Update Merchandise", Resource: "merchandise", Action: "update"},
 		{Code: "merchandise.delete", Name: "Delete Merchandise", Resource: "merchandise", Action: "delete"},
 
+		// Quota allocation permissions
+		{Code: "quota_allocation.create", Name: "Create Quota Allocation", Resource: "quota_allocation", Action: "create"},
+		{Code: "quota_allocation.read", Name: "Read Quota Allocation", Resource: "quota_allocation", Action: "read"},
+		{Code: "quota_allocation.update", Name: "Update Quota Allocation", Resource: "quota_allocation", Action: "update"},
+
+		// Presale permissions
+		{Code: "presale.create", Name: "Create Presale Batch", Resource: "presale", Action: "create"},
+		{Code: "presale.read", Name: "Read Presale Batch", Resource: "presale", Action: "read"},
+		{Code: "presale.update", Name: "Update Presale Batch", Resource: "presale", Action: "update"},
+
+		// Ballot permissions
+		{Code: "ballot.create", Name: "Create Ballot", Resource: "ballot", Action: "create"},
+		{Code: "ballot.read", Name: "Read Ballot", Resource: "ballot", Action: "read"},
+		{Code: "ballot.update", Name: "Update Ballot", Resource: "ballot", Action: "update"},
+		{Code: "ballot.draw", Name: "Draw Ballot", Resource: "ballot", Action: "draw"},
+
+		// Resale marketplace permissions
+		{Code: "resale.read", Name: "Read Resale Listing", Resource: "resale", Action: "read"},
+		{Code: "resale.update", Name: "Update Resale Listing", Resource: "resale", Action: "update"},
+		{Code: "resale.payout", Name: "Mark Resale Payout Paid", Resource: "resale", Action: "payout"},
+
 		// Settings permissions
 		{Code: "settings.read", Name: "Read Settings", Resource: "settings", Action: "read"},
 		{Code: "settings.update", Name: "Update Settings", Resource: "settings", Action: "update"},
 
 		// Dashboard permissions
 		{Code: "dashboard.read", Name: "Read Dashboard", Resource: "dashboard", Action: "read"},
+
+		// JWT signing key permissions
+		{Code: "signing_key.read", Name: "Read Signing Keys", Resource: "signing_key", Action: "read"},
+		{Code: "signing_key.rotate", Name: "Rotate Signing Key", Resource: "signing_key", Action: "rotate"},
+
+		// API key permissions
+		{Code: "api_key.read", Name: "Read API Keys", Resource: "api_key", Action: "read"},
+		{Code: "api_key.create", Name: "Create API Key", Resource: "api_key", Action: "create"},
+		{Code: "api_key.revoke", Name: "Revoke API Key", Resource: "api_key", Action: "revoke"},
+
+		// Organization permissions (creating and updating also requires the super admin role)
+		{Code: "organization.read", Name: "Read Organizations", Resource: "organization", Action: "read"},
+		{Code: "organization.create", Name: "Create Organization", Resource: "organization", Action: "create"},
+		{Code: "organization.update", Name: "Update Organization", Resource: "organization", Action: "update"},
 	}
 
 	createdCount := 0
@@ -13,6 +13,13 @@ import (
 // This function uses upsert logic: creates role if not exists, skips if exists
 func Seed() error {
 	roles := []role.Role{
+		{
+			Code:          "super_admin",
+			Name:          "Super Admin",
+			Description:   "Platform administrator managing every organization",
+			IsAdmin:       true,
+			CanLoginAdmin: true,
+		},
 		{
 			Code:          "admin",
 			Name:          "Admin",
@@ -10,8 +10,11 @@ import (
 
 // Seed seeds role-permission relationships
 func Seed() error {
-	// 1. Assign all permissions to Admin
-	if err := assignAllToAdmin(); err != nil {
+	// 1. Assign all permissions to Super Admin and Admin
+	if err := assignAll("super_admin"); err != nil {
+		return err
+	}
+	if err := assignAll("admin"); err != nil {
 		return err
 	}
 
@@ -24,10 +27,10 @@ func Seed() error {
 	return nil
 }
 
-func assignAllToAdmin() error {
+func assignAll(roleCode string) error {
 	var adminRole role.Role
-	if err := database.DB.Where("code = ?", "admin").First(&adminRole).Error; err != nil {
-		log.Printf("[Role Permission Seeder] Admin role not found: %v", err)
+	if err := database.DB.Where("code = ?", roleCode).First(&adminRole).Error; err != nil {
+		log.Printf("[Role Permission Seeder] Role %s not found: %v", roleCode, err)
 		return err
 	}
 
@@ -57,7 +60,7 @@ func assignAllToAdmin() error {
 			skippedCount++
 		}
 	}
-	log.Printf("[Role Permission Seeder] Admin role: Assigned %d new permissions, Skipped %d existing permissions", assignedCount, skippedCount)
+	log.Printf("[Role Permission Seeder] Role %s: Assigned %d new permissions, Skipped %d existing permissions", roleCode, assignedCount, skippedCount)
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	menuseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/menu"
 	merchandiseseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/merchandise"
 	orderitemseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/order_item"
+	organizationseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/organization"
 	// orderseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/order" // Disabled for testing
 	permissionseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/permission"
 	roleseeder "github.com/gilabs/webapp-ticket-konser/api/seeders/role"
@@ -30,6 +31,9 @@ import (
 // 7. Ticket Categories (depends on: events) - requires events to exist
 // 8. Schedules (depends on: events) - requires events to exist
 //
+// The default organization is seeded first; events, gates and staff still without an organization
+// are assigned to it at the end.
+//
 // Note: All seeders use upsert logic (create if not exists, skip if exists)
 // This allows safe re-running of seeders without duplicating data
 func SeedAll() error {
@@ -37,6 +41,13 @@ func SeedAll() error {
 	log.Println("Starting database seeding...")
 	log.Println("=========================================")
 
+	// Seed the default organization (owns the seeded events, gates and staff)
+	log.Println("\n[Organization] Seeding default organization...")
+	if err := organizationseeder.Seed(); err != nil {
+		log.Printf("❌ Error seeding organizations: %v", err)
+		return err
+	}
+
 	// Step 1: Seed roles first (required for users and role_permissions)
 	log.Println("\n[Step 1/11] Seeding roles...")
 	if err := roleseeder.Seed(); err != nil {
@@ -122,6 +133,13 @@ func SeedAll() error {
 		return err
 	}
 
+	// Assign events, gates and staff without an organization to the default organization
+	log.Println("\n[Organization] Assigning unowned events, gates and staff...")
+	if err := organizationseeder.AssignUnowned(); err != nil {
+		log.Printf("❌ Error assigning unowned data to the default organization: %v", err)
+		return err
+	}
+
 	log.Println("\n=========================================")
 	log.Println("✅ All seeders completed successfully!")
 	log.Println("=========================================")